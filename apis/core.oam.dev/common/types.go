@@ -165,6 +165,8 @@ const (
 	ApplicationUnhealthy ApplicationPhase = "unhealthy"
 	// ApplicationDeleting means application is being deleted
 	ApplicationDeleting ApplicationPhase = "deleting"
+	// ApplicationRendered means the app is rendered in the render-only dry-run mode without being applied
+	ApplicationRendered ApplicationPhase = "rendered"
 )
 
 // ApplicationComponentStatus record the health status of App component
@@ -209,6 +211,56 @@ type Revision struct {
 	RevisionHash string `json:"revisionHash,omitempty"`
 }
 
+// DefinitionRevisionDiff describes what changed in a definition between two of its revisions.
+type DefinitionRevisionDiff struct {
+	// From is the name of the previous revision, empty for the first revision.
+	// +optional
+	From string `json:"from,omitempty"`
+	// To is the name of the new revision.
+	To string `json:"to"`
+	// ParametersAdded lists the paths of the parameters added in the new revision.
+	// +optional
+	ParametersAdded []string `json:"parametersAdded,omitempty"`
+	// ParametersRemoved lists the paths of the parameters removed in the new revision.
+	// +optional
+	ParametersRemoved []string `json:"parametersRemoved,omitempty"`
+	// ParametersChanged lists the paths of the parameters whose type, default or constraints changed.
+	// +optional
+	ParametersChanged []string `json:"parametersChanged,omitempty"`
+	// ChangedSections lists the changed top-level fields of the template, e.g. template.output,
+	// and of the spec, e.g. spec.workload.
+	// +optional
+	ChangedSections []string `json:"changedSections,omitempty"`
+}
+
+// DefinitionSchema is the OpenAPI V3 JSON schema of the parameters of a definition.
+type DefinitionSchema struct {
+	// Format is how the schema is encoded, e.g. gzip+base64, or empty if it's plain JSON.
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Data is the encoded schema.
+	Data string `json:"data"`
+}
+
+// DefinitionUsage summarizes the Applications referencing a definition.
+type DefinitionUsage struct {
+	// Applications is the number of Applications referencing the definition.
+	Applications int `json:"applications"`
+	// Samples lists some of the Applications referencing the definition.
+	// +optional
+	Samples []DefinitionUsageRef `json:"samples,omitempty"`
+}
+
+// DefinitionUsageRef is an Application referencing a definition.
+type DefinitionUsageRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Revision is the definition revision pinned by the Application, e.g. v1,
+	// or empty if the Application uses the latest revision.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
+
 // AppliedApplicationPolicy records minimal status information about an Application-scoped policy.
 // This covers both global (auto-discovered) and explicit (spec-referenced) policies.
 // Full details (transforms, labels, annotations, etc.) are stored in the ConfigMap referenced
@@ -287,6 +339,11 @@ type AppStatus struct {
 	// +optional
 	ApplicationPoliciesConfigMap string `json:"applicationPoliciesConfigMap,omitempty"`
 
+	// DryRunConfigMap references the ConfigMap containing the manifests rendered in the render-only dry-run mode
+	// Format: "application-dryrun-{namespace}-{name}"
+	// +optional
+	DryRunConfigMap string `json:"dryRunConfigMap,omitempty"`
+
 	// PolicyStatus records the status of policy
 	// Deprecated This field is only used by EnvBinding Policy which is deprecated.
 	PolicyStatus []PolicyStatus `json:"policy,omitempty"`
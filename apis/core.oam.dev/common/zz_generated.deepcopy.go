@@ -318,6 +318,91 @@ func (in *DefinitionReference) DeepCopy() *DefinitionReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefinitionRevisionDiff) DeepCopyInto(out *DefinitionRevisionDiff) {
+	*out = *in
+	if in.ParametersAdded != nil {
+		in, out := &in.ParametersAdded, &out.ParametersAdded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParametersRemoved != nil {
+		in, out := &in.ParametersRemoved, &out.ParametersRemoved
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParametersChanged != nil {
+		in, out := &in.ParametersChanged, &out.ParametersChanged
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedSections != nil {
+		in, out := &in.ChangedSections, &out.ChangedSections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefinitionRevisionDiff.
+func (in *DefinitionRevisionDiff) DeepCopy() *DefinitionRevisionDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(DefinitionRevisionDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefinitionSchema) DeepCopyInto(out *DefinitionSchema) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefinitionSchema.
+func (in *DefinitionSchema) DeepCopy() *DefinitionSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DefinitionSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefinitionUsage) DeepCopyInto(out *DefinitionUsage) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]DefinitionUsageRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefinitionUsage.
+func (in *DefinitionUsage) DeepCopy() *DefinitionUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DefinitionUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefinitionUsageRef) DeepCopyInto(out *DefinitionUsageRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefinitionUsageRef.
+func (in *DefinitionUsageRef) DeepCopy() *DefinitionUsageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DefinitionUsageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAMObjectReference) DeepCopyInto(out *OAMObjectReference) {
 	*out = *in
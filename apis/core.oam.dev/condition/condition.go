@@ -42,6 +42,27 @@ const (
 	// TypeSynced resources are believed to be in sync with the
 	// Kubernetes resources that manage their lifecycle.
 	TypeSynced ConditionType = "Synced"
+
+	// TypeTemplateValid definitions have a CUE template that compiles.
+	TypeTemplateValid ConditionType = "TemplateValid"
+
+	// TypePaused resources are not reconciled until they are resumed.
+	TypePaused ConditionType = "Paused"
+
+	// TypeDependenciesResolved definitions have all the definitions they require.
+	TypeDependenciesResolved ConditionType = "DependenciesResolved"
+
+	// TypeDeprecated definitions should not be used by new applications.
+	TypeDeprecated ConditionType = "Deprecated"
+
+	// TypeVisibility definitions are usable by the applications of some namespaces.
+	TypeVisibility ConditionType = "Visibility"
+
+	// TypeRevisionReconciled definitions have a DefinitionRevision of their current spec.
+	TypeRevisionReconciled ConditionType = "RevisionReconciled"
+
+	// TypeSchemaStored definitions have the schema of their parameter stored.
+	TypeSchemaStored ConditionType = "SchemaStored"
 )
 
 // A ConditionReason represents the reason a resource is in a condition.
@@ -62,6 +83,50 @@ const (
 	ReasonReconcileError   ConditionReason = "ReconcileError"
 )
 
+// Reasons a definition template is or is not valid.
+const (
+	ReasonTemplateCompiled     ConditionReason = "TemplateCompiled"
+	ReasonTemplateCompileError ConditionReason = "TemplateCompileError"
+	ReasonTemplateUnverified   ConditionReason = "TemplateUnverified"
+)
+
+// Reasons a resource is or is not paused.
+const (
+	ReasonReconcilePaused  ConditionReason = "ReconcilePaused"
+	ReasonReconcileResumed ConditionReason = "ReconcileResumed"
+)
+
+// Reasons the dependencies of a definition are or are not resolved.
+const (
+	ReasonDependenciesResolved   ConditionReason = "DependenciesResolved"
+	ReasonDependenciesUnresolved ConditionReason = "DependenciesUnresolved"
+)
+
+// Reasons a definition is or is not deprecated.
+const (
+	ReasonDefinitionDeprecated ConditionReason = "DefinitionDeprecated"
+	ReasonDefinitionSupported  ConditionReason = "DefinitionSupported"
+)
+
+// Reasons a definition is visible to some namespaces.
+const (
+	ReasonVisibleToAllNamespaces      ConditionReason = "VisibleToAllNamespaces"
+	ReasonVisibleToSelectedNamespaces ConditionReason = "VisibleToSelectedNamespaces"
+	ReasonInvalidVisibility           ConditionReason = "InvalidVisibility"
+)
+
+// Reasons the revision of a definition is or is not reconciled.
+const (
+	ReasonRevisionReconciled     ConditionReason = "RevisionReconciled"
+	ReasonRevisionReconcileError ConditionReason = "RevisionReconcileError"
+)
+
+// Reasons the schema of a definition is or is not stored.
+const (
+	ReasonSchemaStored     ConditionReason = "SchemaStored"
+	ReasonSchemaStoreError ConditionReason = "SchemaStoreError"
+)
+
 // A Condition that may apply to a resource.
 type Condition struct {
 	// Type of this condition. At most one of each condition type may apply to
@@ -82,6 +147,11 @@ type Condition struct {
 	// one status to another, if any.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the .metadata.generation of the resource the
+	// condition was last set for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // Equal returns true if the condition is identical to the supplied condition,
@@ -90,7 +160,8 @@ func (c Condition) Equal(other Condition) bool {
 	return c.Type == other.Type &&
 		c.Status == other.Status &&
 		c.Reason == other.Reason &&
-		c.Message == other.Message
+		c.Message == other.Message &&
+		c.ObservedGeneration == other.ObservedGeneration
 }
 
 // WithMessage returns a condition by adding the provided message to existing
@@ -100,6 +171,13 @@ func (c Condition) WithMessage(msg string) Condition {
 	return c
 }
 
+// WithObservedGeneration returns a condition by setting the generation of the
+// resource the condition is set for.
+func (c Condition) WithObservedGeneration(generation int64) Condition {
+	c.ObservedGeneration = generation
+	return c
+}
+
 // NOTE(negz): Conditions are implemented as a slice rather than a map to comply
 // with Kubernetes API conventions. Ideally we'd comply by using a map that
 // marshalled to a JSON array, but doing so confuses the CRD schema generator.
@@ -260,6 +338,191 @@ func ReconcileError(err error) Condition {
 	}
 }
 
+// TemplateValid returns a condition indicating that the CUE template of a
+// definition compiles.
+func TemplateValid() Condition {
+	return Condition{
+		Type:               TypeTemplateValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplateCompiled,
+	}
+}
+
+// TemplateInvalid returns a condition indicating that the CUE template of a
+// definition fails to compile.
+func TemplateInvalid(err error) Condition {
+	return Condition{
+		Type:               TypeTemplateValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplateCompileError,
+		Message:            err.Error(),
+	}
+}
+
+// TemplateUnverified returns a condition indicating that the spec of a
+// definition is unsigned or does not match its signature or checksum.
+func TemplateUnverified(err error) Condition {
+	return Condition{
+		Type:               TypeTemplateValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplateUnverified,
+		Message:            err.Error(),
+	}
+}
+
+// Paused returns a condition indicating that the reconciliation of the
+// resource is paused.
+func Paused() Condition {
+	return Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcilePaused,
+	}
+}
+
+// Resumed returns a condition indicating that the reconciliation of the
+// resource is resumed after it was paused.
+func Resumed() Condition {
+	return Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcileResumed,
+	}
+}
+
+// DependenciesResolved returns a condition indicating that all the definitions
+// required by a definition exist.
+func DependenciesResolved() Condition {
+	return Condition{
+		Type:               TypeDependenciesResolved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesResolved,
+	}
+}
+
+// DependenciesUnresolved returns a condition indicating that some definitions
+// required by a definition are invalid or missing.
+func DependenciesUnresolved(err error) Condition {
+	return Condition{
+		Type:               TypeDependenciesResolved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesUnresolved,
+		Message:            err.Error(),
+	}
+}
+
+// Deprecated returns a condition indicating that a definition is deprecated,
+// with a message for its users.
+func Deprecated(message string) Condition {
+	return Condition{
+		Type:               TypeDeprecated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDefinitionDeprecated,
+		Message:            message,
+	}
+}
+
+// NotDeprecated returns a condition indicating that a definition is no longer
+// deprecated.
+func NotDeprecated() Condition {
+	return Condition{
+		Type:               TypeDeprecated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDefinitionSupported,
+	}
+}
+
+// VisibleToAllNamespaces returns a condition indicating that a definition is
+// usable by the applications of all namespaces.
+func VisibleToAllNamespaces() Condition {
+	return Condition{
+		Type:               TypeVisibility,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVisibleToAllNamespaces,
+	}
+}
+
+// VisibleToSelectedNamespaces returns a condition indicating that a definition is
+// only usable by the applications of the namespaces described by the message.
+func VisibleToSelectedNamespaces(message string) Condition {
+	return Condition{
+		Type:               TypeVisibility,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVisibleToSelectedNamespaces,
+		Message:            message,
+	}
+}
+
+// InvalidVisibility returns a condition indicating that the visibility of a
+// definition is invalid, it is then only usable in its own namespace.
+func InvalidVisibility(err error) Condition {
+	return Condition{
+		Type:               TypeVisibility,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidVisibility,
+		Message:            err.Error(),
+	}
+}
+
+// RevisionReconciled returns a condition indicating that the DefinitionRevision
+// of the current spec of a definition exists.
+func RevisionReconciled(revision string) Condition {
+	return Condition{
+		Type:               TypeRevisionReconciled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRevisionReconciled,
+		Message:            revision,
+	}
+}
+
+// RevisionReconcileError returns a condition indicating that the
+// DefinitionRevision of the current spec of a definition cannot be created.
+func RevisionReconcileError(err error) Condition {
+	return Condition{
+		Type:               TypeRevisionReconciled,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRevisionReconcileError,
+		Message:            err.Error(),
+	}
+}
+
+// SchemaStored returns a condition indicating that the schema of the parameter
+// of a definition is stored.
+func SchemaStored() Condition {
+	return Condition{
+		Type:               TypeSchemaStored,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSchemaStored,
+	}
+}
+
+// SchemaStoreError returns a condition indicating that the schema of the
+// parameter of a definition cannot be stored.
+func SchemaStoreError(err error) Condition {
+	return Condition{
+		Type:               TypeSchemaStored,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSchemaStoreError,
+		Message:            err.Error(),
+	}
+}
+
 // ReadyCondition generate ready condition for conditionType
 func ReadyCondition(tpy string) Condition {
 	return Condition{
@@ -57,6 +57,11 @@ func TestConditionEqual(t *testing.T) {
 			b:    Condition{Message: "uncool"},
 			want: false,
 		},
+		"DifferentObservedGeneration": {
+			a:    SchemaStored().WithObservedGeneration(1),
+			b:    SchemaStored().WithObservedGeneration(2),
+			want: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+)
+
+// DefinitionSourceSpec defines where the definitions are synced from
+type DefinitionSourceSpec struct {
+	// OCI syncs the definitions from an OCI artifact.
+	// Exclusive to "git"
+	// +optional
+	OCI *OCIDefinitionSource `json:"oci,omitempty"`
+
+	// Git syncs the definitions from a Git repository.
+	// Exclusive to "oci"
+	// +optional
+	Git *GitDefinitionSource `json:"git,omitempty"`
+
+	// Path is the directory of the definitions in the source, the root directory by default.
+	// Both YAML manifests and CUE definitions are synced.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Interval is the interval to check the source for updates, 10m by default.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Prune deletes the definitions synced before which are no longer in the source.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+
+	// Suspend stops syncing the definitions.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SecretRef is the name of the secret in the namespace of the DefinitionSource holding the
+	// credentials of the source, with the keys username and password.
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// OCIDefinitionSource is an OCI artifact holding definitions
+type OCIDefinitionSource struct {
+	// Repository is the OCI repository of the artifact, e.g. ghcr.io/my-org/definitions
+	Repository string `json:"repository"`
+	// Tag of the artifact, latest by default.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Digest of the artifact, it overrides the tag.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Insecure allows pulling the artifact through plain HTTP.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// GitDefinitionSource is a Git reference holding definitions
+type GitDefinitionSource struct {
+	// URL of the Git repository
+	URL string `json:"url"`
+	// Branch to sync, the default branch of the repository by default.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+	// Tag to sync, it overrides the branch.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Commit to sync, it overrides the branch and the tag.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+}
+
+// DefinitionSourceStatus is the status of DefinitionSource
+type DefinitionSourceStatus struct {
+	condition.ConditionedStatus `json:",inline"`
+
+	// ObservedGeneration is the generation of the spec last synced
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Revision of the source last synced, the digest of the OCI artifact or the Git commit
+	Revision string `json:"revision,omitempty"`
+
+	// LastSyncTime is the time of the last successful sync
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Definitions are the definitions synced from the source
+	Definitions []SyncedDefinition `json:"definitions,omitempty"`
+}
+
+// SyncedDefinition is a definition synced from a DefinitionSource
+type SyncedDefinition struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// DefinitionSource syncs definitions from an OCI artifact or a Git repository
+// +kubebuilder:resource:scope=Namespaced,categories={oam},shortName=defsrc
+// +kubebuilder:printcolumn:name="REVISION",type=string,JSONPath=".status.revision"
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DefinitionSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DefinitionSourceSpec   `json:"spec,omitempty"`
+	Status DefinitionSourceStatus `json:"status,omitempty"`
+}
+
+// SetConditions set condition for DefinitionSource
+func (s *DefinitionSource) SetConditions(c ...condition.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition gets condition from DefinitionSource
+func (s *DefinitionSource) GetCondition(conditionType condition.ConditionType) condition.Condition {
+	return s.Status.GetCondition(conditionType)
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DefinitionSourceList contains a list of DefinitionSource
+type DefinitionSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DefinitionSource `json:"items"`
+}
@@ -55,8 +55,15 @@ var (
 	WorkflowGroupVersionKind = SchemeGroupVersion.WithKind(WorkflowKind)
 )
 
+// DefinitionSource meta
+var (
+	DefinitionSourceKind             = "DefinitionSource"
+	DefinitionSourceGroupVersionKind = SchemeGroupVersion.WithKind(DefinitionSourceKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Policy{}, &PolicyList{})
+	SchemeBuilder.Register(&DefinitionSource{}, &DefinitionSourceList{})
 	SchemeBuilder.Register(&wfTypesv1alpha1.Workflow{}, &wfTypesv1alpha1.WorkflowList{})
 	_ = SchemeBuilder.AddToScheme(k8sscheme.Scheme)
 }
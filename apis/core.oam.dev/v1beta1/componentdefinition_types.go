@@ -66,9 +66,22 @@ type ComponentDefinitionStatus struct {
 	condition.ConditionedStatus `json:",inline"`
 	// ConfigMapRef refer to a ConfigMap which contains OpenAPI V3 JSON schema of Component parameters.
 	ConfigMapRef string `json:"configMapRef,omitempty"`
+	// Schema contains the OpenAPI V3 JSON schema of the parameters if it's stored in the status instead of a ConfigMap.
+	// +optional
+	Schema *common.DefinitionSchema `json:"schema,omitempty"`
 	// LatestRevision of the component definition
 	// +optional
 	LatestRevision *common.Revision `json:"latestRevision,omitempty"`
+	// LatestRevisionDiff describes what changed in the latest revision
+	// +optional
+	LatestRevisionDiff *common.DefinitionRevisionDiff `json:"latestRevisionDiff,omitempty"`
+	// Usage summarizes the Applications referencing the definition
+	// +optional
+	Usage *common.DefinitionUsage `json:"usage,omitempty"`
+	// ObservedGeneration is the generation of the definition last processed by the controller,
+	// successfully or not. The Ready condition tells whether it was processed successfully.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -465,11 +465,26 @@ func (in *ComponentDefinitionSpec) DeepCopy() *ComponentDefinitionSpec {
 func (in *ComponentDefinitionStatus) DeepCopyInto(out *ComponentDefinitionStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(common.DefinitionSchema)
+		**out = **in
+	}
 	if in.LatestRevision != nil {
 		in, out := &in.LatestRevision, &out.LatestRevision
 		*out = new(common.Revision)
 		**out = **in
 	}
+	if in.LatestRevisionDiff != nil {
+		in, out := &in.LatestRevisionDiff, &out.LatestRevisionDiff
+		*out = new(common.DefinitionRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(common.DefinitionUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionStatus.
@@ -681,11 +696,26 @@ func (in *PolicyDefinitionSpec) DeepCopy() *PolicyDefinitionSpec {
 func (in *PolicyDefinitionStatus) DeepCopyInto(out *PolicyDefinitionStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(common.DefinitionSchema)
+		**out = **in
+	}
 	if in.LatestRevision != nil {
 		in, out := &in.LatestRevision, &out.LatestRevision
 		*out = new(common.Revision)
 		**out = **in
 	}
+	if in.LatestRevisionDiff != nil {
+		in, out := &in.LatestRevisionDiff, &out.LatestRevisionDiff
+		*out = new(common.DefinitionRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(common.DefinitionUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyDefinitionStatus.
@@ -914,11 +944,26 @@ func (in *TraitDefinitionSpec) DeepCopy() *TraitDefinitionSpec {
 func (in *TraitDefinitionStatus) DeepCopyInto(out *TraitDefinitionStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(common.DefinitionSchema)
+		**out = **in
+	}
 	if in.LatestRevision != nil {
 		in, out := &in.LatestRevision, &out.LatestRevision
 		*out = new(common.Revision)
 		**out = **in
 	}
+	if in.LatestRevisionDiff != nil {
+		in, out := &in.LatestRevisionDiff, &out.LatestRevisionDiff
+		*out = new(common.DefinitionRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(common.DefinitionUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraitDefinitionStatus.
@@ -1042,11 +1087,26 @@ func (in *WorkflowStepDefinitionSpec) DeepCopy() *WorkflowStepDefinitionSpec {
 func (in *WorkflowStepDefinitionStatus) DeepCopyInto(out *WorkflowStepDefinitionStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(common.DefinitionSchema)
+		**out = **in
+	}
 	if in.LatestRevision != nil {
 		in, out := &in.LatestRevision, &out.LatestRevision
 		*out = new(common.Revision)
 		**out = **in
 	}
+	if in.LatestRevisionDiff != nil {
+		in, out := &in.LatestRevisionDiff, &out.LatestRevisionDiff
+		*out = new(common.DefinitionRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(common.DefinitionUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStepDefinitionStatus.
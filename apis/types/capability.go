@@ -51,6 +51,9 @@ const (
 // CapabilityConfigMapNamePrefix is the prefix for capability ConfigMap name
 const CapabilityConfigMapNamePrefix = "schema-"
 
+// SchemaFormatGzipBase64 is the format of a schema compressed by gzip and encoded in base64
+const SchemaFormatGzipBase64 = "gzip+base64"
+
 const (
 	// OpenapiV3JSONSchema is the key to store OpenAPI v3 JSON schema in ConfigMap
 	OpenapiV3JSONSchema string = "openapi-v3-json-schema"
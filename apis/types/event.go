@@ -31,6 +31,7 @@ const (
 	ReasonFailedApply     = "FailedApply"
 	ReasonFailedStateKeep = "FailedStateKeep"
 	ReasonFailedGC        = "FailedGC"
+	ReasonFailedRender    = "FailedRender"
 )
 
 // event message for Application
@@ -41,4 +42,5 @@ const (
 	MessageRevisioned       = "Revisioned successfully"
 	MessageWorkflowFinished = "Workflow finished"
 	MessageDeployed         = "Deployed successfully"
+	MessageRenderedDryRun   = "Rendered successfully in the dry-run mode, the manifests are not applied"
 )
@@ -56,6 +56,9 @@ const (
 	AnnoDefinitionIcon = "definition.oam.dev/icon"
 	// AnnoDefinitionAppliedWorkloads is the annotation which describe what is the workloads used for in a TraitDefinition Object
 	AnnoDefinitionAppliedWorkloads = "definition.oam.dev/appliedWorkloads"
+	// AnnoDefinitionSchemaFormat is the annotation which describe how the schema is encoded in a schema ConfigMap,
+	// the schema is stored as plain JSON if it's absent
+	AnnoDefinitionSchemaFormat = "definition.oam.dev/schema-format"
 	// LabelDefinition is the label for definition
 	LabelDefinition = "definition.oam.dev"
 	// LabelDefinitionName is the label for definition name
@@ -17,9 +17,12 @@ limitations under the License.
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 
 	oamcontroller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+	"github.com/oam-dev/kubevela/pkg/precheck"
 )
 
 // ControllerConfig wraps the oamcontroller.Args configuration.
@@ -36,12 +39,25 @@ func NewControllerConfig() *ControllerConfig {
 	return &ControllerConfig{
 		Args: oamcontroller.Args{
 			RevisionLimit:                                50,
-			AppRevisionLimit:                             10,
+			AppRevisionLimit:                             precheck.DefaultAppRevisionLimit,
 			DefRevisionLimit:                             20,
 			AutoGenWorkloadDefinition:                    true,
 			ConcurrentReconciles:                         4,
 			IgnoreAppWithoutControllerRequirement:        false,
 			IgnoreDefinitionWithoutControllerRequirement: false,
+			DefinitionDeletionProtection:                 false,
+			// the same as the default rate limiter of the controllers
+			DefinitionRateLimiter: oamcontroller.RateLimiterArgs{
+				BaseDelay: 5 * time.Millisecond,
+				MaxDelay:  1000 * time.Second,
+				QPS:       10,
+				Burst:     100,
+			},
+			// the same as the event aggregator of client-go
+			DefinitionEvents: oamcontroller.EventAggregatorArgs{
+				Interval: 10 * time.Minute,
+				Burst:    10,
+			},
 		},
 	}
 }
@@ -54,8 +70,15 @@ func (c *ControllerConfig) AddFlags(fs *pflag.FlagSet) {
 		"RevisionLimit is the maximum number of revisions that will be maintained. The default value is 50.")
 	fs.IntVar(&c.AppRevisionLimit, "application-revision-limit", c.AppRevisionLimit,
 		"application-revision-limit is the maximum number of application useless revisions that will be maintained, if the useless revisions exceed this number, older ones will be GCed first.The default value is 10.")
+	fs.DurationVar(&c.AppRevisionMaxAge, "application-revision-max-age", c.AppRevisionMaxAge,
+		"application-revision-max-age is the maximum age of application revisions, older ones will be GCed unless they are still referenced. 0 disables the age based pruning. "+
+			"It can be overridden per application with the 'app.oam.dev/revision-max-age' annotation.")
+	fs.Int64Var(&c.AppRevisionMaxTotalSize, "application-revision-max-total-size", c.AppRevisionMaxTotalSize,
+		"application-revision-max-total-size is the maximum total size in bytes of the application revisions of an application, the oldest ones will be GCed first unless they are still referenced. 0 disables the size based pruning. "+
+			"It can be overridden per application with the 'app.oam.dev/revision-max-total-size' annotation.")
 	fs.IntVar(&c.DefRevisionLimit, "definition-revision-limit", c.DefRevisionLimit,
-		"definition-revision-limit is the maximum number of component/trait definition useless revisions that will be maintained, if the useless revisions exceed this number, older ones will be GCed first.The default value is 20.")
+		"definition-revision-limit is the maximum number of component/trait definition useless revisions that will be maintained, if the useless revisions exceed this number, older ones will be GCed first.The default value is 20. "+
+			"It can be overridden per definition with the 'definition.oam.dev/revision-history-limit' annotation.")
 	fs.BoolVar(&c.AutoGenWorkloadDefinition, "autogen-workload-definition", c.AutoGenWorkloadDefinition,
 		"Automatic generated workloadDefinition which componentDefinition refers to.")
 	fs.IntVar(&c.ConcurrentReconciles, "concurrent-reconciles", c.ConcurrentReconciles,
@@ -64,4 +87,24 @@ func (c *ControllerConfig) AddFlags(fs *pflag.FlagSet) {
 		"If true, application controller will not process the app without 'app.oam.dev/controller-version-require' annotation")
 	fs.BoolVar(&c.IgnoreDefinitionWithoutControllerRequirement, "ignore-definition-without-controller-version", c.IgnoreDefinitionWithoutControllerRequirement,
 		"If true, trait/component/workflowstep definition controller will not process the definition without 'definition.oam.dev/controller-version-require' annotation")
+	fs.BoolVar(&c.DefinitionDeletionProtection, "definition-deletion-protection", c.DefinitionDeletionProtection,
+		"If true, the definition controllers hold the deletion of definitions still referenced by applications until the references are removed or the 'definition.oam.dev/force-delete' annotation is set")
+	fs.DurationVar(&c.DefinitionRateLimiter.BaseDelay, "definition-rate-limiter-base-delay", c.DefinitionRateLimiter.BaseDelay,
+		"The backoff of the first retry of a failed definition in the workqueue of each definition controller.")
+	fs.DurationVar(&c.DefinitionRateLimiter.MaxDelay, "definition-rate-limiter-max-delay", c.DefinitionRateLimiter.MaxDelay,
+		"The maximum backoff of a failed definition in the workqueue of each definition controller.")
+	fs.Float64Var(&c.DefinitionRateLimiter.QPS, "definition-rate-limiter-qps", c.DefinitionRateLimiter.QPS,
+		"The overall rate of definitions processed by each definition controller, in definitions per second.")
+	fs.IntVar(&c.DefinitionRateLimiter.Burst, "definition-rate-limiter-burst", c.DefinitionRateLimiter.Burst,
+		"The bucket size of the overall rate of definitions processed by each definition controller.")
+	fs.DurationVar(&c.DefinitionEvents.Interval, "definition-event-aggregation-interval", c.DefinitionEvents.Interval,
+		"The window identical events of a definition are counted in by each definition controller. 0 disables the deduplication of the events.")
+	fs.IntVar(&c.DefinitionEvents.Burst, "definition-event-aggregation-burst", c.DefinitionEvents.Burst,
+		"The number of identical events of a definition recorded within the aggregation interval, the others are counted and reported with the next identical event.")
+	fs.StringVar(&c.DefinitionShardLabel, "definition-shard-label", c.DefinitionShardLabel,
+		"The label selector of the definitions handled by the definition controllers, e.g. 'definition.oam.dev/shard=tenant-a'. "+
+			"It splits the definitions across several controllers. All definitions are handled if empty.")
+	fs.StringVar(&c.DefinitionSignaturePublicKeys, "definition-signature-public-keys", c.DefinitionSignaturePublicKeys,
+		"The path of a PEM file with the public keys trusted to sign the specs of definitions. "+
+			"If set, the webhooks reject and the definition controllers do not process the definitions which are not signed by one of the keys.")
 }
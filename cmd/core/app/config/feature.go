@@ -17,8 +17,15 @@ limitations under the License.
 package config
 
 import (
+	"maps"
+	"strings"
+	"time"
+
 	"github.com/spf13/pflag"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/oam-dev/kubevela/pkg/features"
 )
 
 // FeatureConfig contains feature gate configuration.
@@ -26,15 +33,107 @@ import (
 type FeatureConfig struct {
 	// Note: The actual configuration is managed by the utilfeature package
 	// This is a wrapper to maintain consistency with our config pattern
+
+	// ConfigMap is the ConfigMap in the runtime namespace feature gates are reloaded from at runtime.
+	ConfigMap string
+	// Reloadable lists the feature gates that can be changed through ConfigMap.
+	Reloadable []string
+	// ReloadInterval is the period the ConfigMap is read at.
+	ReloadInterval time.Duration
+	// StatusConfigMap is the ConfigMap in the runtime namespace the feature gate status is mirrored to.
+	StatusConfigMap string
+	// StrictValidation fails the startup on feature gate dependency violations instead of logging them.
+	StrictValidation bool
+	// Explicit holds the feature gates set through --feature-gates.
+	Explicit map[featuregate.Feature]bool
 }
 
 // NewFeatureConfig creates a new FeatureConfig with defaults.
 func NewFeatureConfig() *FeatureConfig {
-	return &FeatureConfig{}
+	return &FeatureConfig{
+		ConfigMap:        "",
+		Reloadable:       reloadableFeatures(),
+		ReloadInterval:   time.Minute,
+		StatusConfigMap:  "vela-core-feature-gates",
+		StrictValidation: false,
+		Explicit:         map[featuregate.Feature]bool{},
+	}
+}
+
+// reloadableFeatures returns the names of all the feature gates that can be
+// changed at runtime.
+func reloadableFeatures() []string {
+	names := make([]string, 0, len(features.ReloadableFeatures))
+	for _, f := range features.ReloadableFeatures {
+		names = append(names, string(f))
+	}
+	return names
+}
+
+// DependencyValidator returns a validator of the registered feature gate
+// dependencies that checks the lifecycle of the gates set through --feature-gates.
+func (c *FeatureConfig) DependencyValidator() *features.DependencyValidator {
+	v := features.NewDependencyValidator()
+	v.Explicit = c.Explicit
+	return v
+}
+
+// Validate checks that only runtime-safe feature gates are reloadable.
+func (c *FeatureConfig) Validate() error {
+	return features.ValidateReloadable(c.Reloadable)
 }
 
 // AddFlags registers feature gate configuration flags.
 // Delegates to the Kubernetes feature gate system.
 func (c *FeatureConfig) AddFlags(fs *pflag.FlagSet) {
-	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
+	gates := pflag.NewFlagSet("feature-gates", pflag.ContinueOnError)
+	utilfeature.DefaultMutableFeatureGate.AddFlag(gates)
+	gates.VisitAll(func(f *pflag.Flag) {
+		f.Value = &explicitFeatureGates{Value: f.Value, explicit: c.Explicit}
+		fs.AddFlag(f)
+	})
+	fs.StringVar(&c.ConfigMap,
+		"feature-gates-configmap",
+		c.ConfigMap,
+		"Name of a ConfigMap in the runtime namespace whose 'featureGates' key (e.g. A=true,B=false) overrides the "+
+			"--feature-gates-reloadable gates at runtime. Changes violating feature gate dependencies are rejected. "+
+			"Empty disables the reload.")
+	fs.StringSliceVar(&c.Reloadable,
+		"feature-gates-reloadable",
+		c.Reloadable,
+		"Names of the feature gates that can be changed at runtime through --feature-gates-configmap. Only gates read on "+
+			"every use are accepted: "+strings.Join(reloadableFeatures(), ", ")+".")
+	fs.DurationVar(&c.ReloadInterval,
+		"feature-gates-reload-interval",
+		c.ReloadInterval,
+		"Interval the feature gate ConfigMap is read at.")
+	fs.StringVar(&c.StatusConfigMap,
+		"feature-gates-status-configmap",
+		c.StatusConfigMap,
+		"Name of the ConfigMap in the runtime namespace the enabled feature gates and dependency validation results are "+
+			"mirrored to, as also served at /featuregates on the metrics server. Empty disables the mirror.")
+	fs.BoolVar(&c.StrictValidation,
+		"feature-gates-strict",
+		c.StrictValidation,
+		"If true, startup fails when the feature gates violate their dependencies or conflicts, or enable a removed gate. "+
+			"Otherwise the violations are only logged.")
+}
+
+// explicitFeatureGates records the gates set through the --feature-gates flag
+// before passing them on to the feature gate.
+type explicitFeatureGates struct {
+	pflag.Value
+	explicit map[featuregate.Feature]bool
+}
+
+func (f *explicitFeatureGates) Set(value string) error {
+	if err := f.Value.Set(value); err != nil {
+		return err
+	}
+	gates, err := features.ParseGates(value)
+	if err != nil {
+		return err
+	}
+	maps.Copy(f.explicit, gates)
+	return nil
 }
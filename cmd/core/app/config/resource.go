@@ -20,17 +20,23 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/oam-dev/kubevela/pkg/resourcekeeper"
+	"github.com/oam-dev/kubevela/pkg/resourcetracker"
 )
 
 // ResourceConfig contains resource management configuration.
 type ResourceConfig struct {
-	MaxDispatchConcurrent int
+	MaxDispatchConcurrent               int
+	MaxAppComponentDispatchConcurrent   int
+	MaxGlobalResourceDispatchConcurrent int
+	RecompressExisting                  bool
+	RecompressionQPS                    float64
 }
 
 // NewResourceConfig creates a new ResourceConfig with defaults.
 func NewResourceConfig() *ResourceConfig {
 	return &ResourceConfig{
 		MaxDispatchConcurrent: 10,
+		RecompressionQPS:      resourcetracker.DefaultRecompressionQPS,
 	}
 }
 
@@ -40,6 +46,22 @@ func (c *ResourceConfig) AddFlags(fs *pflag.FlagSet) {
 		"max-dispatch-concurrent",
 		c.MaxDispatchConcurrent,
 		"Set the max dispatch concurrent number, default is 10")
+	fs.IntVar(&c.MaxAppComponentDispatchConcurrent,
+		"max-app-component-dispatch-concurrent",
+		c.MaxAppComponentDispatchConcurrent,
+		"Set the max number of components dispatched in parallel per application, 0 means unlimited")
+	fs.IntVar(&c.MaxGlobalResourceDispatchConcurrent,
+		"max-global-resource-dispatch-concurrent",
+		c.MaxGlobalResourceDispatchConcurrent,
+		"Set the max number of resources dispatched in parallel across all applications, 0 means unlimited")
+	fs.BoolVar(&c.RecompressExisting,
+		"recompress-existing-resources",
+		c.RecompressExisting,
+		"Rewrite the existing ResourceTrackers and ApplicationRevisions in the background into the compression format enabled by the feature gates")
+	fs.Float64Var(&c.RecompressionQPS,
+		"recompression-qps",
+		c.RecompressionQPS,
+		"Set the max number of objects rewritten per second by the background recompression")
 }
 
 // SyncToResourceGlobals syncs the parsed configuration values to resource package global variables.
@@ -52,4 +74,6 @@ func (c *ResourceConfig) AddFlags(fs *pflag.FlagSet) {
 // The flow is: CLI flags -> ResourceConfig struct fields -> resourcekeeper globals (via this method)
 func (c *ResourceConfig) SyncToResourceGlobals() {
 	resourcekeeper.MaxDispatchConcurrent = c.MaxDispatchConcurrent
+	resourcekeeper.MaxAppComponentDispatchConcurrent = c.MaxAppComponentDispatchConcurrent
+	resourcekeeper.MaxGlobalResourceDispatchConcurrent = c.MaxGlobalResourceDispatchConcurrent
 }
@@ -20,6 +20,7 @@ import (
 	cliflag "k8s.io/component-base/cli/flag"
 
 	"github.com/oam-dev/kubevela/cmd/core/app/config"
+	"github.com/oam-dev/kubevela/pkg/precheck"
 )
 
 // CoreOptions contains everything necessary to create and run vela-core
@@ -44,6 +45,7 @@ type CoreOptions struct {
 	Profiling     *config.ProfilingConfig
 	KLog          *config.KLogConfig
 	Controller    *config.ControllerConfig
+	Precheck      *precheck.Config
 }
 
 // NewCoreOptions creates a new NewVelaCoreOptions object with default parameters
@@ -68,6 +70,7 @@ func NewCoreOptions() *CoreOptions {
 	profiling := config.NewProfilingConfig()
 	klog := config.NewKLogConfig(observability)
 	controller := config.NewControllerConfig()
+	precheckConfig := precheck.NewConfig()
 
 	s := &CoreOptions{
 		// Config modules
@@ -90,6 +93,7 @@ func NewCoreOptions() *CoreOptions {
 		Profiling:     profiling,
 		KLog:          klog,
 		Controller:    controller,
+		Precheck:      precheckConfig,
 	}
 
 	return s
@@ -113,6 +117,7 @@ func (s *CoreOptions) Flags() cliflag.NamedFlagSets {
 	s.Resource.AddFlags(fss.FlagSet("resource"))
 	s.Workflow.AddFlags(fss.FlagSet("workflow"))
 	s.Controller.AddFlags(fss.FlagSet("controller"))
+	s.Precheck.AddFlags(fss.FlagSet("precheck"))
 
 	// External package configurations (now wrapped in config modules)
 	s.Client.AddFlags(fss.FlagSet("client"))
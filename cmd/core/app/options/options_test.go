@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/component-base/featuregate"
 
 	commonconfig "github.com/oam-dev/kubevela/pkg/controller/common"
 	"github.com/oam-dev/kubevela/pkg/oam"
@@ -79,11 +80,22 @@ func TestNewCoreOptions_DefaultValues(t *testing.T) {
 	// Test Controller defaults
 	assert.Equal(t, 50, opt.Controller.RevisionLimit)
 	assert.Equal(t, 10, opt.Controller.AppRevisionLimit)
+	assert.Equal(t, time.Duration(0), opt.Controller.AppRevisionMaxAge)
+	assert.Equal(t, int64(0), opt.Controller.AppRevisionMaxTotalSize)
 	assert.Equal(t, 20, opt.Controller.DefRevisionLimit)
 	assert.Equal(t, true, opt.Controller.AutoGenWorkloadDefinition)
 	assert.Equal(t, 4, opt.Controller.ConcurrentReconciles)
 	assert.Equal(t, false, opt.Controller.IgnoreAppWithoutControllerRequirement)
 	assert.Equal(t, false, opt.Controller.IgnoreDefinitionWithoutControllerRequirement)
+	assert.Equal(t, false, opt.Controller.DefinitionDeletionProtection)
+	assert.Equal(t, 5*time.Millisecond, opt.Controller.DefinitionRateLimiter.BaseDelay)
+	assert.Equal(t, 1000*time.Second, opt.Controller.DefinitionRateLimiter.MaxDelay)
+	assert.Equal(t, float64(10), opt.Controller.DefinitionRateLimiter.QPS)
+	assert.Equal(t, 100, opt.Controller.DefinitionRateLimiter.Burst)
+	assert.Equal(t, 10*time.Minute, opt.Controller.DefinitionEvents.Interval)
+	assert.Equal(t, 10, opt.Controller.DefinitionEvents.Burst)
+	assert.Equal(t, "", opt.Controller.DefinitionShardLabel)
+	assert.Equal(t, "", opt.Controller.DefinitionSignaturePublicKeys)
 
 	// Test Workflow defaults
 	assert.Equal(t, 60, opt.Workflow.MaxWaitBackoffTime)
@@ -92,6 +104,14 @@ func TestNewCoreOptions_DefaultValues(t *testing.T) {
 
 	// Test Resource defaults
 	assert.Equal(t, 10, opt.Resource.MaxDispatchConcurrent)
+	assert.Equal(t, 0, opt.Resource.MaxAppComponentDispatchConcurrent)
+	assert.Equal(t, 0, opt.Resource.MaxGlobalResourceDispatchConcurrent)
+	assert.Equal(t, false, opt.Resource.RecompressExisting)
+	assert.Equal(t, float64(5), opt.Resource.RecompressionQPS)
+
+	// Test Precheck defaults of the hooks listing the stored objects
+	assert.Equal(t, 0, opt.Precheck.SizeAdvisory.Sample)
+	assert.Equal(t, false, opt.Precheck.CRD.DetectCompressionDowngrade)
 
 	// Ensure all config modules are initialized
 	assert.NotNil(t, opt.Admission)
@@ -102,6 +122,7 @@ func TestNewCoreOptions_DefaultValues(t *testing.T) {
 	assert.NotNil(t, opt.Profiling)
 	assert.NotNil(t, opt.KLog)
 	assert.NotNil(t, opt.Controller)
+	assert.NotNil(t, opt.Precheck)
 }
 
 func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
@@ -150,17 +171,84 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 		// Controller flags
 		"--revision-limit=100",
 		"--application-revision-limit=20",
+		"--application-revision-max-age=720h",
+		"--application-revision-max-total-size=10485760",
 		"--definition-revision-limit=30",
 		"--autogen-workload-definition=false",
 		"--concurrent-reconciles=8",
 		"--ignore-app-without-controller-version=true",
 		"--ignore-definition-without-controller-version=true",
+		"--definition-deletion-protection=true",
+		"--definition-rate-limiter-base-delay=1s",
+		"--definition-rate-limiter-max-delay=5m",
+		"--definition-rate-limiter-qps=2.5",
+		"--definition-rate-limiter-burst=20",
+		"--definition-event-aggregation-interval=1m",
+		"--definition-event-aggregation-burst=3",
+		"--definition-shard-label=definition.oam.dev/shard=tenant-a",
+		"--definition-signature-public-keys=/etc/vela/definition-keys.pem",
 		// Workflow flags
 		"--max-workflow-wait-backoff-time=30",
 		"--max-workflow-failed-backoff-time=150",
 		"--max-workflow-step-error-retry-times=5",
 		// Resource flags
 		"--max-dispatch-concurrent=5",
+		// Precheck flags
+		"--precheck-crds=-traitdefinitions.core.oam.dev,widgets.example.com=spec.size",
+		"--precheck-crds-configmap=vela-precheck",
+		"--precheck-auto-upgrade-crds=true",
+		"--precheck-crd-schema-drift=true",
+		"--precheck-conversion-webhooks=true",
+		"--precheck-definition-round-trip=true",
+		"--precheck-field-pruning=true",
+		"--precheck-migrate-stored-versions=true",
+		"--precheck-crd-validation-rules=true",
+		"--prestart-hook-timeout=30s",
+		"--prestart-hook-timeouts=CRDValidation=2m",
+		"--prestart-hooks-deadline=5m",
+		"--prestart-hook-skip=CRDValidation",
+		"--prestart-hook-severity=CRDValidation=Warn",
+		"--prestart-hook-results-configmap=precheck-results",
+		"--prestart-hook-failure-report=/var/run/vela/precheck.json",
+		"--prestart-hook-termination-message-path=/tmp/termination-log",
+		"--prestart-hook-revalidate=CRDValidation,Other",
+		"--prestart-hook-revalidate-interval=10m",
+		"--poststart-version-lease=vela-core-version",
+		"--prestart-hook-readiness-gate=true",
+		"--prestart-hook-retry-interval=1m",
+		"--precheck-webhook-service=vela-webhook",
+		"--precheck-webhook-cert-min-validity=48h",
+		"--precheck-cluster-probe-timeout=10s",
+		"--precheck-cluster-gateway-cert-min-validity=72h",
+		"--precheck-rbac=false",
+		"--precheck-min-kubernetes-version=v1.28.0",
+		"--precheck-max-kubernetes-minor-skew=1",
+		"--precheck-required-apis=apps.kruise.io/v1alpha1",
+		"--precheck-namespace-labels=team=platform",
+		"--precheck-quota-warn-ratio=0.8",
+		"--precheck-orphan-gc=true",
+		"--precheck-size-advisory-sample=3",
+		"--precheck-size-advisory-threshold=1Mi",
+		"--precheck-crd-best-practices=true",
+		"--precheck-scale-traits=scaler,hpa",
+		"--precheck-crd-version-compatibility=true",
+		"--precheck-crd-version-skew-blocking=true",
+		"--precheck-compression-downgrade=true",
+		"--precheck-compression-downgrade-blocking=true",
+		"--precheck-dry-run-components=20",
+		"--precheck-crd-concurrency=8",
+		"--precheck-crd-qps=5",
+		"--precheck-object-ttl=30m",
+		"--precheck-test-namespace=vela-precheck",
+		"--precheck-test-name-prefix=precheck-",
+		"--precheck-round-trips-on-leader=true",
+		"--precheck-fingerprint-configmap=precheck-fingerprint",
+		"--feature-gates-configmap=vela-feature-gates",
+		"--feature-gates-reloadable=PreDispatchDryRun",
+		"--feature-gates-reload-interval=30s",
+		"--feature-gates-status-configmap=feature-status",
+		"--feature-gates-strict=true",
+		"--feature-gates=ApplyOnce=false",
 	}
 
 	err := fs.Parse(args)
@@ -212,11 +300,22 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 	// Verify Controller flags
 	assert.Equal(t, 100, opt.Controller.RevisionLimit)
 	assert.Equal(t, 20, opt.Controller.AppRevisionLimit)
+	assert.Equal(t, 720*time.Hour, opt.Controller.AppRevisionMaxAge)
+	assert.Equal(t, int64(10485760), opt.Controller.AppRevisionMaxTotalSize)
 	assert.Equal(t, 30, opt.Controller.DefRevisionLimit)
 	assert.Equal(t, false, opt.Controller.AutoGenWorkloadDefinition)
 	assert.Equal(t, 8, opt.Controller.ConcurrentReconciles)
 	assert.Equal(t, true, opt.Controller.IgnoreAppWithoutControllerRequirement)
 	assert.Equal(t, true, opt.Controller.IgnoreDefinitionWithoutControllerRequirement)
+	assert.Equal(t, true, opt.Controller.DefinitionDeletionProtection)
+	assert.Equal(t, time.Second, opt.Controller.DefinitionRateLimiter.BaseDelay)
+	assert.Equal(t, 5*time.Minute, opt.Controller.DefinitionRateLimiter.MaxDelay)
+	assert.Equal(t, 2.5, opt.Controller.DefinitionRateLimiter.QPS)
+	assert.Equal(t, 20, opt.Controller.DefinitionRateLimiter.Burst)
+	assert.Equal(t, time.Minute, opt.Controller.DefinitionEvents.Interval)
+	assert.Equal(t, 3, opt.Controller.DefinitionEvents.Burst)
+	assert.Equal(t, "definition.oam.dev/shard=tenant-a", opt.Controller.DefinitionShardLabel)
+	assert.Equal(t, "/etc/vela/definition-keys.pem", opt.Controller.DefinitionSignaturePublicKeys)
 
 	// Verify Workflow flags
 	assert.Equal(t, 30, opt.Workflow.MaxWaitBackoffTime)
@@ -225,6 +324,65 @@ func TestCoreOptions_FlagsCompleteSet(t *testing.T) {
 
 	// Verify Resource flags
 	assert.Equal(t, 5, opt.Resource.MaxDispatchConcurrent)
+
+	// Verify Precheck flags
+	assert.Equal(t, []string{"-traitdefinitions.core.oam.dev", "widgets.example.com=spec.size"}, opt.Precheck.CRD.CRDs)
+	assert.Equal(t, "vela-precheck", opt.Precheck.CRD.ConfigMap)
+	assert.Equal(t, true, opt.Precheck.CRD.AutoUpgrade)
+	assert.Equal(t, true, opt.Precheck.CRD.DetectSchemaDrift)
+	assert.Equal(t, true, opt.Precheck.CRD.CheckConversionWebhooks)
+	assert.Equal(t, true, opt.Precheck.CRD.DefinitionRoundTrip)
+	assert.Equal(t, true, opt.Precheck.CRD.VerifyFieldPruning)
+	assert.Equal(t, true, opt.Precheck.CRD.MigrateStoredVersions)
+	assert.Equal(t, true, opt.Precheck.CRD.CheckValidationRules)
+	assert.Equal(t, 30*time.Second, opt.Precheck.Runner.Timeout)
+	assert.Equal(t, map[string]string{"CRDValidation": "2m"}, opt.Precheck.Runner.Timeouts)
+	assert.Equal(t, 5*time.Minute, opt.Precheck.Runner.Deadline)
+	assert.Equal(t, []string{"CRDValidation"}, opt.Precheck.Runner.Skip)
+	assert.Equal(t, map[string]string{"CRDValidation": "Warn"}, opt.Precheck.Runner.Severities)
+	assert.Equal(t, "precheck-results", opt.Precheck.Runner.ResultsConfigMap)
+	assert.Equal(t, "/var/run/vela/precheck.json", opt.Precheck.Runner.FailureReport)
+	assert.Equal(t, "/tmp/termination-log", opt.Precheck.Runner.TerminationMessagePath)
+	assert.Equal(t, []string{"CRDValidation", "Other"}, opt.Precheck.Runner.RevalidateHooks)
+	assert.Equal(t, 10*time.Minute, opt.Precheck.Runner.RevalidateInterval)
+	assert.Equal(t, "vela-core-version", opt.Precheck.VersionLease.Name)
+	assert.Equal(t, true, opt.Precheck.Runner.ReadinessGate)
+	assert.Equal(t, time.Minute, opt.Precheck.Runner.RetryInterval)
+	assert.Equal(t, "vela-webhook", opt.Precheck.Webhook.Service)
+	assert.Equal(t, 48*time.Hour, opt.Precheck.Webhook.CertMinValidity)
+	assert.Equal(t, 10*time.Second, opt.Precheck.ClusterGateway.ProbeTimeout)
+	assert.Equal(t, 72*time.Hour, opt.Precheck.ClusterGateway.CertMinValidity)
+	assert.Equal(t, false, opt.Precheck.RBAC.Enabled)
+	assert.Equal(t, "v1.28.0", opt.Precheck.APIAvailability.MinVersion)
+	assert.Equal(t, 1, opt.Precheck.APIAvailability.MaxMinorSkew)
+	assert.Equal(t, []string{"apps.kruise.io/v1alpha1"}, opt.Precheck.APIAvailability.RequiredAPIs)
+	assert.Equal(t, map[string]string{"team": "platform"}, opt.Precheck.Namespace.Labels)
+	assert.Equal(t, 0.8, opt.Precheck.Namespace.QuotaWarnRatio)
+	assert.Equal(t, true, opt.Precheck.OrphanDetection.GarbageCollect)
+	assert.Equal(t, 3, opt.Precheck.SizeAdvisory.Sample)
+	assert.Equal(t, "1Mi", opt.Precheck.SizeAdvisory.Threshold)
+	assert.Equal(t, true, opt.Precheck.CRD.EnforceBestPractices)
+	assert.Equal(t, []string{"scaler", "hpa"}, opt.Precheck.CRD.ScaleTraits)
+	assert.Equal(t, true, opt.Precheck.CRD.CheckVersionCompatibility)
+	assert.Equal(t, true, opt.Precheck.CRD.BlockVersionSkew)
+	assert.Equal(t, true, opt.Precheck.CRD.DetectCompressionDowngrade)
+	assert.Equal(t, true, opt.Precheck.CRD.BlockCompressionDowngrade)
+	assert.Equal(t, 20, opt.Precheck.CRD.DryRunComponents)
+	assert.Equal(t, 8, opt.Precheck.CRD.Concurrency)
+	assert.Equal(t, float32(5), opt.Precheck.CRD.QPS)
+	assert.Equal(t, 30*time.Minute, opt.Precheck.CRD.TestObjectTTL)
+	assert.Equal(t, "vela-precheck", opt.Precheck.CRD.TestNamespace)
+	assert.Equal(t, "precheck-", opt.Precheck.CRD.TestNamePrefix)
+	assert.Equal(t, true, opt.Precheck.CRD.RoundTripsOnLeader)
+	assert.Equal(t, "precheck-fingerprint", opt.Precheck.CRD.FingerprintConfigMap)
+
+	// Verify Feature flags
+	assert.Equal(t, "vela-feature-gates", opt.Feature.ConfigMap)
+	assert.Equal(t, []string{"PreDispatchDryRun"}, opt.Feature.Reloadable)
+	assert.Equal(t, 30*time.Second, opt.Feature.ReloadInterval)
+	assert.Equal(t, "feature-status", opt.Feature.StatusConfigMap)
+	assert.Equal(t, true, opt.Feature.StrictValidation)
+	assert.Equal(t, map[featuregate.Feature]bool{"ApplyOnce": false}, opt.Feature.Explicit)
 }
 
 func TestCuexOptions_SyncToGlobals(t *testing.T) {
@@ -374,10 +532,14 @@ func TestApplicationOptions_SyncToGlobals(t *testing.T) {
 func TestResourceOptions_SyncToGlobals(t *testing.T) {
 	// Store original value
 	origDispatch := resourcekeeper.MaxDispatchConcurrent
+	origAppComponent := resourcekeeper.MaxAppComponentDispatchConcurrent
+	origGlobalResource := resourcekeeper.MaxGlobalResourceDispatchConcurrent
 
 	// Restore after test
 	defer func() {
 		resourcekeeper.MaxDispatchConcurrent = origDispatch
+		resourcekeeper.MaxAppComponentDispatchConcurrent = origAppComponent
+		resourcekeeper.MaxGlobalResourceDispatchConcurrent = origGlobalResource
 	}()
 
 	opts := NewCoreOptions()
@@ -385,6 +547,8 @@ func TestResourceOptions_SyncToGlobals(t *testing.T) {
 
 	args := []string{
 		"--max-dispatch-concurrent=25",
+		"--max-app-component-dispatch-concurrent=4",
+		"--max-global-resource-dispatch-concurrent=200",
 	}
 
 	err := fss.FlagSet("resource").Parse(args)
@@ -392,10 +556,14 @@ func TestResourceOptions_SyncToGlobals(t *testing.T) {
 
 	// Verify struct field is updated
 	assert.Equal(t, 25, opts.Resource.MaxDispatchConcurrent)
+	assert.Equal(t, 4, opts.Resource.MaxAppComponentDispatchConcurrent)
+	assert.Equal(t, 200, opts.Resource.MaxGlobalResourceDispatchConcurrent)
 
 	// After sync, global should be updated
 	opts.Resource.SyncToResourceGlobals()
 	assert.Equal(t, 25, resourcekeeper.MaxDispatchConcurrent)
+	assert.Equal(t, 4, resourcekeeper.MaxAppComponentDispatchConcurrent)
+	assert.Equal(t, 200, resourcekeeper.MaxGlobalResourceDispatchConcurrent)
 }
 
 func TestCoreOptions_InvalidValues(t *testing.T) {
@@ -528,6 +696,7 @@ func TestCoreOptions_FlagSetsOrganization(t *testing.T) {
 		"resource",
 		"workflow",
 		"controller",
+		"precheck",
 		"client",
 		"reconcile",
 		"sharding",
@@ -761,6 +930,7 @@ func TestCoreOptions_AllConfigModulesHaveFlags(t *testing.T) {
 		"performance":   {"perf-enabled"},
 		"workflow":      {"max-workflow-wait-backoff-time"},
 		"resource":      {"max-dispatch-concurrent"},
+		"precheck":      {"precheck-crds", "precheck-crds-configmap"},
 	}
 
 	for setName, expectedFlags := range configsWithExpectedFlags {
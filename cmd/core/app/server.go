@@ -21,19 +21,26 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"time"
 
 	velaclient "github.com/kubevela/pkg/controller/client"
 	"github.com/kubevela/pkg/controller/sharding"
 	"github.com/kubevela/pkg/meta"
+	"github.com/kubevela/pkg/util/k8s"
 	"github.com/kubevela/pkg/util/profiling"
+	"github.com/kubevela/pkg/util/singleton"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/rest"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,8 +55,6 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
 	"github.com/oam-dev/kubevela/cmd/core/app/config"
-	"github.com/oam-dev/kubevela/cmd/core/app/hooks"
-	"github.com/oam-dev/kubevela/cmd/core/app/hooks/crdvalidation"
 	"github.com/oam-dev/kubevela/cmd/core/app/options"
 	"github.com/oam-dev/kubevela/pkg/auth"
 	"github.com/oam-dev/kubevela/pkg/cache"
@@ -57,10 +62,14 @@ import (
 	oamv1beta1 "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/application"
 	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/versionlease"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/monitor/watcher"
 	"github.com/oam-dev/kubevela/pkg/multicluster"
 	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/precheck"
+	"github.com/oam-dev/kubevela/pkg/resourcetracker"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
 	"github.com/oam-dev/kubevela/pkg/utils/util"
 	oamwebhook "github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev"
@@ -148,6 +157,14 @@ func run(ctx context.Context, coreOptions *options.CoreOptions) error {
 
 	// Configure feature gates
 	klog.V(2).InfoS("Configuring feature gates")
+	if err := coreOptions.Feature.Validate(); err != nil {
+		klog.ErrorS(err, "Invalid reloadable feature gates")
+		return err
+	}
+	if err := validateFeatureGates(coreOptions.Feature); err != nil {
+		klog.ErrorS(err, "Invalid feature gate configuration")
+		return err
+	}
 	configureFeatureGates(coreOptions)
 
 	// Create controller manager
@@ -306,6 +323,24 @@ func setupMultiCluster(ctx context.Context, kubeConfig *rest.Config, multiCluste
 	return nil
 }
 
+// validateFeatureGates checks the enabled feature gates against the registered
+// feature dependencies and the lifecycle of the explicitly set gates. Violations
+// are only logged, unless strict validation is set, in which case the violations
+// not declared as warnings fail the startup.
+func validateFeatureGates(featureConfig *config.FeatureConfig) error {
+	strict := featureConfig.StrictValidation
+	violations := featureConfig.DependencyValidator().Validate(utilfeature.DefaultMutableFeatureGate.Enabled)
+	for _, v := range violations {
+		if v.Warn || !strict {
+			klog.InfoS("Feature gate dependency not satisfied", "reason", v.Error())
+		}
+	}
+	if !strict {
+		return nil
+	}
+	return features.Err(violations)
+}
+
 // configureFeatureGates sets up feature-dependent configurations
 func configureFeatureGates(coreOptions *options.CoreOptions) {
 	if utilfeature.DefaultMutableFeatureGate.Enabled(features.ApplyOnce) {
@@ -326,6 +361,9 @@ func buildManagerOptions(ctx context.Context, coreOptions *options.CoreOptions)
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress: coreOptions.Observability.MetricsAddr,
+			ExtraHandlers: map[string]http.Handler{
+				features.StatusPath: features.StatusHandler(utilfeature.DefaultMutableFeatureGate, coreOptions.Feature.DependencyValidator()),
+			},
 		},
 		LeaderElection:          coreOptions.Server.EnableLeaderElection,
 		LeaderElectionNamespace: coreOptions.Server.LeaderElectionNamespace,
@@ -459,7 +497,10 @@ func prepareRun(ctx context.Context, manager manager.Manager, coreOptions *optio
 		klog.InfoS("Webhook enabled, registering OAM webhooks",
 			"port", coreOptions.Webhook.WebhookPort,
 			"certDir", coreOptions.Webhook.CertDir)
-		oamwebhook.Register(manager, coreOptions.Controller.Args)
+		if err := oamwebhook.Register(manager, coreOptions.Controller.Args); err != nil {
+			klog.ErrorS(err, "Unable to register the OAM webhooks")
+			return err
+		}
 		klog.V(2).InfoS("Waiting for webhook secret volume",
 			"timeout", waitSecretTimeout,
 			"checkInterval", waitSecretInterval)
@@ -484,20 +525,160 @@ func prepareRun(ctx context.Context, manager manager.Manager, coreOptions *optio
 	}
 
 	klog.InfoS("Starting vela controller manager with pre-start validation")
-	for _, hook := range []hooks.PreStartHook{crdvalidation.NewHook()} {
-		hookName := hook.Name()
-		klog.InfoS("Running pre-start hook", "hook", hookName)
-		if err := hook.Run(ctx); err != nil {
-			klog.ErrorS(err, "Failed to run pre-start hook", "hook", hookName)
-			return fmt.Errorf("failed to run hook %s: %w", hookName, err)
+	preStartHooks, err := precheck.PreStartHooks(singleton.KubeClient.Get(), manager.GetConfig(), precheck.Options{
+		Precheck:             coreOptions.Precheck,
+		Namespace:            k8s.GetRuntimeNamespace(),
+		AppRevisionLimit:     coreOptions.Controller.AppRevisionLimit,
+		UseWebhook:           coreOptions.Webhook.UseWebhook,
+		WebhookCertDir:       coreOptions.Webhook.CertDir,
+		EnableClusterGateway: coreOptions.MultiCluster.EnableClusterGateway,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Invalid pre-start hook configuration")
+		return err
+	}
+	preStartHooks, leaderHooks := hooks.SplitLeaderOnly(preStartHooks)
+	runner, err := precheck.NewRunner(preStartHooks, coreOptions.Precheck)
+	if err != nil {
+		klog.ErrorS(err, "Invalid pre-start hook configuration")
+		return err
+	}
+	resultsConfigMap := coreOptions.Precheck.Runner.ResultsConfigMap
+	publishResults := func(ctx context.Context, results []hooks.Result) {
+		if resultsConfigMap == "" {
+			return
+		}
+		if err := hooks.PublishResults(ctx, singleton.KubeClient.Get(), k8s.GetRuntimeNamespace(), resultsConfigMap, results); err != nil {
+			klog.ErrorS(err, "Failed to publish pre-start hook results", "configMap", resultsConfigMap)
 		}
-		klog.InfoS("Pre-start hook completed successfully", "hook", hookName)
+	}
+	gate := hooks.NewReadinessGate()
+	hookErr := runner.Run(ctx)
+	publishResults(ctx, runner.Results())
+	gate.Update(runner.Results())
+	if hookErr != nil && coreOptions.Precheck.Runner.ReadinessGate {
+		klog.ErrorS(hookErr, "Pre-start hooks failed, waiting for them to pass")
+		hookErr = hooks.WaitUntilReady(ctx, runner, gate, coreOptions.Server.HealthAddr, coreOptions.Precheck.Runner.RetryInterval, publishResults)
+	}
+	if hookErr != nil {
+		writeHookFailure(coreOptions.Precheck, runner.Results())
+		return hookErr
 	}
 	klog.InfoS("All pre-start validation hooks completed successfully")
+	if err := manager.AddReadyzCheck("prestart-hooks", gate.Check); err != nil {
+		klog.ErrorS(err, "Failed to add pre-start hook readiness check")
+		return err
+	}
+
+	postStartHooks := leaderHooks
+	if name := coreOptions.Precheck.VersionLease.Name; name != "" {
+		postStartHooks = append(postStartHooks, versionlease.NewHook(singleton.KubeClient.Get(), k8s.GetRuntimeNamespace(), name))
+	}
+	if len(postStartHooks) > 0 {
+		postStart := &hooks.PostStartRunner{PostStartHooks: postStartHooks, Cache: manager.GetCache()}
+		postStart.HookTimeout = runner.HookTimeout
+		postStart.Timeouts = runner.Timeouts
+		postStart.Skip = runner.Skip
+		postStart.Severities = runner.Severities
+		if err := manager.Add(postStart); err != nil {
+			klog.ErrorS(err, "Failed to register post-start hooks")
+			return err
+		}
+	}
+
+	if interval := coreOptions.Precheck.Runner.RevalidateInterval; interval > 0 {
+		var revalidated []hooks.PreStartHook
+		for _, hook := range runner.Hooks {
+			if slices.Contains(coreOptions.Precheck.Runner.RevalidateHooks, hook.Name()) {
+				revalidated = append(revalidated, hook)
+			}
+		}
+		revalidateRunner := &hooks.Runner{
+			Hooks:       revalidated,
+			HookTimeout: runner.HookTimeout,
+			Timeouts:    runner.Timeouts,
+			Deadline:    runner.Deadline,
+			Skip:        runner.Skip,
+			Severities:  runner.Severities,
+		}
+		revalidator := hooks.NewRevalidator(revalidateRunner, interval, runner.Results())
+		revalidator.OnResults = func(ctx context.Context, results []hooks.Result) {
+			publishResults(ctx, results)
+			gate.Update(results)
+		}
+		if resultsConfigMap != "" {
+			revalidator.Recorder = manager.GetEventRecorderFor(types.VelaCoreName)
+			revalidator.Object = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: k8s.GetRuntimeNamespace(), Name: resultsConfigMap}}
+		}
+		if err := manager.Add(revalidator); err != nil {
+			klog.ErrorS(err, "Failed to register pre-start hook revalidation")
+			return err
+		}
+	}
+
+	if coreOptions.Resource.RecompressExisting {
+		recompressor := &resourcetracker.Recompressor{
+			Reader: manager.GetAPIReader(),
+			Client: manager.GetClient(),
+			QPS:    coreOptions.Resource.RecompressionQPS,
+		}
+		if err := manager.Add(recompressor); err != nil {
+			klog.ErrorS(err, "Failed to register the background recompression")
+			return err
+		}
+	}
+
+	publishFeatureStatus := func(ctx context.Context) {
+		name := coreOptions.Feature.StatusConfigMap
+		if name == "" {
+			return
+		}
+		status := features.CurrentStatus(utilfeature.DefaultMutableFeatureGate, coreOptions.Feature.DependencyValidator(), time.Now())
+		if err := features.PublishStatus(ctx, singleton.KubeClient.Get(), k8s.GetRuntimeNamespace(), name, status); err != nil {
+			klog.ErrorS(err, "Failed to publish feature gate status", "configMap", name)
+		}
+	}
+	publishFeatureStatus(ctx)
+	if name := coreOptions.Feature.ConfigMap; name != "" {
+		reloader := &features.Reloader{
+			Client:    manager.GetAPIReader(),
+			Namespace: k8s.GetRuntimeNamespace(),
+			Name:      name,
+			Gate:      utilfeature.DefaultMutableFeatureGate,
+			Validator: coreOptions.Feature.DependencyValidator(),
+			Interval:  coreOptions.Feature.ReloadInterval,
+			Recorder:  manager.GetEventRecorderFor(types.VelaCoreName),
+			OnChange:  publishFeatureStatus,
+		}
+		for _, gate := range coreOptions.Feature.Reloadable {
+			reloader.Mutable = append(reloader.Mutable, featuregate.Feature(gate))
+		}
+		if err := manager.Add(reloader); err != nil {
+			klog.ErrorS(err, "Failed to register feature gate reloader")
+			return err
+		}
+	}
 
 	return nil
 }
 
+// writeHookFailure writes the results of the pre-start hooks that blocked the
+// startup to the failure report and the termination message, so that installers
+// and operators can react to the failed checks. Failing to write them is logged
+// but does not change the exit error.
+func writeHookFailure(precheckConfig *precheck.Config, results []hooks.Result) {
+	if path := precheckConfig.Runner.FailureReport; path != "" {
+		if err := hooks.WriteFailureReport(path, results); err != nil {
+			klog.ErrorS(err, "Failed to write pre-start hook failure report", "path", path)
+		}
+	}
+	if path := precheckConfig.Runner.TerminationMessagePath; path != "" {
+		if err := hooks.WriteTerminationMessage(path, results); err != nil {
+			klog.ErrorS(err, "Failed to write pre-start hook termination message", "path", path)
+		}
+	}
+}
+
 // registerHealthChecks is used to create readiness&liveness probes
 func registerHealthChecks(manager ctrl.Manager) error {
 	klog.InfoS("Registering readiness and health checks")
@@ -262,6 +262,29 @@ var _ = Describe("Server Tests", func() {
 		})
 	})
 
+	Describe("validateFeatureGates", func() {
+		AfterEach(func() {
+			Expect(feature.DefaultMutableFeatureGate.Set("EnableGlobalPolicies=false,GzipResourceTracker=false,ZstdResourceTracker=false")).To(Succeed())
+		})
+
+		It("should only warn when a required feature gate is disabled by default", func() {
+			Expect(feature.DefaultMutableFeatureGate.Set("EnableGlobalPolicies=true")).To(Succeed())
+			Expect(validateFeatureGates(&config.FeatureConfig{})).To(Succeed())
+		})
+
+		It("should fail when a required feature gate is disabled in strict mode", func() {
+			Expect(feature.DefaultMutableFeatureGate.Set("EnableGlobalPolicies=true")).To(Succeed())
+			err := validateFeatureGates(&config.FeatureConfig{StrictValidation: true})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("EnableGlobalPolicies requires EnableApplicationScopedPolicies"))
+		})
+
+		It("should only warn about conflicts the controller resolves in strict mode", func() {
+			Expect(feature.DefaultMutableFeatureGate.Set("GzipResourceTracker=true,ZstdResourceTracker=true")).To(Succeed())
+			Expect(validateFeatureGates(&config.FeatureConfig{StrictValidation: true})).To(Succeed())
+		})
+	})
+
 	Describe("configureFeatureGates", func() {
 		var coreOpts *options.CoreOptions
 		var originalPeriod time.Duration
@@ -478,6 +501,7 @@ var _ = Describe("Server Tests", func() {
 
 				// Verify metrics configuration
 				Expect(managerOpts.Metrics.BindAddress).To(Equal(":8080"))
+				Expect(managerOpts.Metrics.ExtraHandlers).To(HaveKey("/featuregates"))
 
 				// Verify health probe configuration
 				Expect(managerOpts.HealthProbeBindAddress).To(Equal(":8081"))
@@ -74,6 +74,14 @@ func NewApplicationParser(cli client.Client) *Parser {
 	}
 }
 
+// NewApplicationParserWithTemplateCache create appfile parser loading the templates of definitions through the cache
+func NewApplicationParserWithTemplateCache(cli client.Client, cache *TemplateCache) *Parser {
+	return &Parser{
+		client:     cli,
+		tmplLoader: cache.LoadTemplate,
+	}
+}
+
 // NewDryRunApplicationParser create an appfile parser for DryRun
 func NewDryRunApplicationParser(cli client.Client, defs []*unstructured.Unstructured) *Parser {
 	return &Parser{
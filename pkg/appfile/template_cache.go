@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appfile
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	oamutil "github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// templateCacheKey identifies a template by the definition and the revision it is resolved from. The templates are
+// looked up with the namespaces of the application, since a definition in the namespace of the application shadows
+// the system one, and stored with the namespace of the definition they are resolved from.
+type templateCacheKey struct {
+	capType    types.CapType
+	namespace  string
+	xNamespace string
+	name       string
+	// revision is the name of the DefinitionRevision, empty for the latest definition
+	revision string
+}
+
+// TemplateCache caches the templates loaded from the definitions in the cluster, so that the applications using
+// the same definitions share them across reconciles instead of resolving the definitions again. It saves the
+// lookups of the definitions, with the fallback from the namespace of the application to the system namespace,
+// and of their DefinitionRevisions, and the extraction of the templates from them. The CUE templates are still
+// compiled with the parameters and the context of each component at every render, since neither the compiled
+// cue.Value nor the parsed syntax of a template can be shared by concurrent renders: the unification of a value and
+// the resolution of the identifiers of a syntax tree both write to them. The templates of a definition
+// are dropped when one of its DefinitionRevisions or the definition itself changes, see InvalidateDefinition.
+type TemplateCache struct {
+	mu      sync.RWMutex
+	entries map[templateCacheKey]*Template
+	// resolved maps the keys the templates are looked up with to the keys of the entries, so that the templates of
+	// the system definitions are stored once for the applications of all namespaces.
+	resolved map[templateCacheKey]templateCacheKey
+	// epoch counts the calls of InvalidateAll and epochs the calls of InvalidateDefinition per definition name. The
+	// templates loaded while their definition was invalidated are not stored, as they may have been loaded from
+	// the definition before the change.
+	epoch  uint64
+	epochs map[string]uint64
+}
+
+// NewTemplateCache creates an empty TemplateCache
+func NewTemplateCache() *TemplateCache {
+	return &TemplateCache{
+		entries:  make(map[templateCacheKey]*Template),
+		resolved: make(map[templateCacheKey]templateCacheKey),
+		epochs:   make(map[string]uint64),
+	}
+}
+
+// LoadTemplate loads the template like LoadTemplate, from the cache if the definition was loaded before. The
+// types resolving to a revision selected at runtime, i.e. `@revision` types of auto-updated applications, and the
+// WorkloadDefinitions, which have no revision, are not cached. The returned templates are copies and may be
+// modified by the caller.
+func (c *TemplateCache) LoadTemplate(ctx context.Context, cli client.Client, capName string, capType types.CapType, annotations map[string]string) (*Template, error) {
+	key, cacheable := c.keyOf(ctx, capName, capType, annotations)
+	var epoch uint64
+	if cacheable {
+		c.mu.RLock()
+		tmpl, hit := c.entries[c.resolved[key]]
+		epoch = c.epochOf(key.name)
+		c.mu.RUnlock()
+		if hit {
+			return copyTemplate(tmpl), nil
+		}
+	}
+	tmpl, err := LoadTemplate(ctx, cli, capName, capType, annotations)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable && tmpl.WorkloadDefinition == nil {
+		entryKey := key
+		if namespace := definitionNamespace(tmpl); namespace != "" {
+			entryKey = templateCacheKey{capType: capType, namespace: namespace, name: key.name, revision: key.revision}
+		}
+		c.mu.Lock()
+		if c.epochOf(key.name) == epoch {
+			if _, found := c.entries[entryKey]; !found {
+				c.entries[entryKey] = copyTemplate(tmpl)
+			}
+			c.resolved[key] = entryKey
+		}
+		c.mu.Unlock()
+	}
+	return tmpl, nil
+}
+
+// epochOf returns the number of invalidations of the definitions with the given name. It must be called with the
+// lock held.
+func (c *TemplateCache) epochOf(name string) uint64 {
+	return c.epoch + c.epochs[name]
+}
+
+func (c *TemplateCache) keyOf(ctx context.Context, capName string, capType types.CapType, annotations map[string]string) (templateCacheKey, bool) {
+	key := templateCacheKey{
+		capType:    capType,
+		namespace:  oamutil.GetDefinitionNamespaceWithCtx(ctx),
+		xNamespace: oamutil.GetXDefinitionNamespaceWithCtx(ctx),
+		name:       capName,
+	}
+	if !strings.Contains(capName, "@") {
+		return key, true
+	}
+	if annotations[oam.AnnotationAutoUpdate] == "true" {
+		return key, false
+	}
+	revision, err := oamutil.ConvertDefinitionRevName(capName)
+	if err != nil {
+		return key, false
+	}
+	key.name, key.revision = strings.Split(capName, "@")[0], revision
+	return key, true
+}
+
+// InvalidateDefinition drops the templates of the definitions with the given name, in all namespaces and of all
+// revisions.
+func (c *TemplateCache) InvalidateDefinition(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epochs[name]++
+	removed := 0
+	for key := range c.entries {
+		if key.name == name {
+			delete(c.entries, key)
+			removed++
+		}
+	}
+	for key := range c.resolved {
+		if key.name == name {
+			delete(c.resolved, key)
+		}
+	}
+	if removed > 0 {
+		klog.V(4).InfoS("Invalidated cached definition templates", "definition", name, "count", removed)
+	}
+}
+
+// InvalidateAll clears the cache
+func (c *TemplateCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	c.entries = make(map[templateCacheKey]*Template)
+	c.resolved = make(map[templateCacheKey]templateCacheKey)
+}
+
+// definitionNamespace returns the namespace of the definition the template is resolved from
+func definitionNamespace(tmpl *Template) string {
+	switch {
+	case tmpl.ComponentDefinition != nil:
+		return tmpl.ComponentDefinition.Namespace
+	case tmpl.TraitDefinition != nil:
+		return tmpl.TraitDefinition.Namespace
+	case tmpl.PolicyDefinition != nil:
+		return tmpl.PolicyDefinition.Namespace
+	case tmpl.WorkflowStepDefinition != nil:
+		return tmpl.WorkflowStepDefinition.Namespace
+	}
+	return ""
+}
+
+// copyTemplate returns a copy of the template not sharing the definitions with it
+func copyTemplate(tmpl *Template) *Template {
+	copied := *tmpl
+	copied.Terraform = tmpl.Terraform.DeepCopy()
+	copied.ComponentDefinition = tmpl.ComponentDefinition.DeepCopy()
+	copied.WorkloadDefinition = tmpl.WorkloadDefinition.DeepCopy()
+	copied.TraitDefinition = tmpl.TraitDefinition.DeepCopy()
+	copied.PolicyDefinition = tmpl.PolicyDefinition.DeepCopy()
+	copied.WorkflowStepDefinition = tmpl.WorkflowStepDefinition.DeepCopy()
+	return &copied
+}
+
+// Size returns the number of cached templates
+func (c *TemplateCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
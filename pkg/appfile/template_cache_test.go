@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appfile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	oamutil "github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+func TestTemplateCache(t *testing.T) {
+	newCompDef := func(template string) v1beta1.ComponentDefinition {
+		return v1beta1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: oam.SystemDefinitionNamespace},
+			Spec: v1beta1.ComponentDefinitionSpec{
+				Schematic: &common.Schematic{CUE: &common.CUE{Template: template}},
+			},
+		}
+	}
+	latest := newCompDef("output: {kind: \"Deployment\"}")
+	gets := map[string]int{}
+	cli := &test.MockClient{
+		MockGet: func(_ context.Context, key ktypes.NamespacedName, obj client.Object) error {
+			gets[key.Name]++
+			switch o := obj.(type) {
+			case *v1beta1.ComponentDefinition:
+				*o = *latest.DeepCopy()
+			case *v1beta1.DefinitionRevision:
+				*o = v1beta1.DefinitionRevision{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: oam.SystemDefinitionNamespace},
+					Spec: v1beta1.DefinitionRevisionSpec{
+						DefinitionType:      common.ComponentType,
+						ComponentDefinition: newCompDef("output: {kind: \"StatefulSet\"}"),
+					},
+				}
+			}
+			return nil
+		},
+	}
+	cache := NewTemplateCache()
+	ctx := oamutil.SetNamespaceInCtx(context.Background(), "default")
+
+	// the latest definition is resolved once
+	tmpl, err := cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, "output: {kind: \"Deployment\"}", tmpl.TemplateStr)
+	cached, err := cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, tmpl.TemplateStr, cached.TemplateStr)
+	require.Equal(t, 1, gets["worker"])
+
+	// the cached definitions are not shared with the callers
+	cached.ComponentDefinition.Spec.Schematic.CUE.Template = "modified"
+	cached, err = cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, "output: {kind: \"Deployment\"}", cached.ComponentDefinition.Spec.Schematic.CUE.Template)
+
+	// the revisions are cached apart from the latest definition
+	tmpl, err = cache.LoadTemplate(ctx, cli, "worker@v1", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, "output: {kind: \"StatefulSet\"}", tmpl.TemplateStr)
+	_, err = cache.LoadTemplate(ctx, cli, "worker@v1", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, gets["worker-v1"])
+	require.Equal(t, 2, cache.Size())
+
+	// the applications of other namespaces may resolve another definition, the system definition is stored once
+	_, err = cache.LoadTemplate(oamutil.SetNamespaceInCtx(context.Background(), "team"), cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, gets["worker"])
+	require.Equal(t, 2, cache.Size())
+	_, err = cache.LoadTemplate(oamutil.SetNamespaceInCtx(context.Background(), "team"), cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, gets["worker"])
+
+	// the changes of the definition are loaded after the invalidation
+	latest = newCompDef("output: {kind: \"Job\"}")
+	cache.InvalidateDefinition("worker")
+	require.Equal(t, 0, cache.Size())
+	tmpl, err = cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, "output: {kind: \"Job\"}", tmpl.TemplateStr)
+	require.Equal(t, 3, gets["worker"])
+
+	// the revisions of auto-updated applications are resolved at each load
+	_, cacheable := cache.keyOf(ctx, "worker@v1", types.TypeComponentDefinition, map[string]string{oam.AnnotationAutoUpdate: "true"})
+	require.False(t, cacheable)
+
+	cache.InvalidateAll()
+	require.Equal(t, 0, cache.Size())
+}
+
+func TestTemplateCacheDiscardsInvalidatedLoads(t *testing.T) {
+	cache := NewTemplateCache()
+	invalidate := func() {}
+	cli := &test.MockClient{
+		MockGet: func(_ context.Context, _ ktypes.NamespacedName, obj client.Object) error {
+			def, ok := obj.(*v1beta1.ComponentDefinition)
+			require.True(t, ok)
+			*def = v1beta1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: oam.SystemDefinitionNamespace},
+				Spec: v1beta1.ComponentDefinitionSpec{
+					Schematic: &common.Schematic{CUE: &common.CUE{Template: "output: {kind: \"Deployment\"}"}},
+				},
+			}
+			// the definition changes after it was read by the load
+			invalidate()
+			return nil
+		},
+	}
+	ctx := oamutil.SetNamespaceInCtx(context.Background(), "default")
+
+	invalidate = func() { cache.InvalidateDefinition("worker") }
+	_, err := cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, cache.Size())
+
+	invalidate = cache.InvalidateAll
+	_, err = cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, cache.Size())
+
+	// the invalidations of other definitions do not discard the load
+	invalidate = func() { cache.InvalidateDefinition("scaler") }
+	_, err = cache.LoadTemplate(ctx, cli, "worker", types.TypeComponentDefinition, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, cache.Size())
+}
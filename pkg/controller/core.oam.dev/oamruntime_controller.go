@@ -16,6 +16,14 @@ limitations under the License.
 
 package core_oam_dev
 
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
 // Args args used by controller
 type Args struct {
 
@@ -27,6 +35,13 @@ type Args struct {
 	// The default value is 10.
 	AppRevisionLimit int
 
+	// AppRevisionMaxAge is the maximum age of application revisions that will be maintained, 0 means no limit.
+	AppRevisionMaxAge time.Duration
+
+	// AppRevisionMaxTotalSize is the maximum total size in bytes of the application revisions of an application
+	// that will be maintained, 0 means no limit.
+	AppRevisionMaxTotalSize int64
+
 	// DefRevisionLimit is the maximum number of component/trait definition revisions that will be maintained.
 	// The default value is 20.
 	DefRevisionLimit int
@@ -42,4 +57,58 @@ type Args struct {
 
 	// IgnoreDefinitionWithoutControllerRequirement indicates that trait/component/workflowstep definition controller will not process the definition without 'definition.oam.dev/controller-version-require' annotation.
 	IgnoreDefinitionWithoutControllerRequirement bool
+
+	// DefinitionDeletionProtection indicates that the definition controllers hold the deletion of definitions
+	// with a finalizer until no Application references them anymore.
+	DefinitionDeletionProtection bool
+
+	// DefinitionRateLimiter configures the workqueue rate limiter of each definition controller.
+	DefinitionRateLimiter RateLimiterArgs
+
+	// DefinitionEvents configures the deduplication of the events recorded by each definition controller.
+	DefinitionEvents EventAggregatorArgs
+
+	// DefinitionShardLabel is the label selector of the definitions handled by the definition controllers,
+	// so that the definitions can be sharded across several controllers. All definitions are handled if empty.
+	DefinitionShardLabel string
+
+	// DefinitionSignaturePublicKeys is the path of a PEM file with the public keys trusted to sign the
+	// specs of definitions. When set, the webhooks reject the definitions without a valid signature and
+	// the definition controllers do not create their revisions.
+	DefinitionSignaturePublicKeys string
+}
+
+// RateLimiterArgs configures a workqueue rate limiter, which combines a per-item exponential
+// failure backoff and an overall token bucket, like the default rate limiter of the controllers.
+type RateLimiterArgs struct {
+	// BaseDelay is the backoff of the first retry of a failed item.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum backoff of a failed item.
+	MaxDelay time.Duration
+	// QPS is the overall rate of the items, in items per second.
+	QPS float64
+	// Burst is the bucket size of the overall rate.
+	Burst int
+}
+
+// NewRateLimiter returns a new rate limiter. Each controller must have its own rate limiter.
+// Empty args return nil, which makes the controller use its default rate limiter.
+func (a RateLimiterArgs) NewRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	if a == (RateLimiterArgs{}) {
+		return nil
+	}
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](a.BaseDelay, a.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(a.QPS), a.Burst)},
+	)
+}
+
+// EventAggregatorArgs configures the deduplication of the events recorded by a controller, like the
+// event aggregator of client-go: identical events of an object past Burst within Interval are not
+// recorded, their count is reported with the first identical event after Interval.
+type EventAggregatorArgs struct {
+	// Interval is the window identical events are counted in.
+	Interval time.Duration
+	// Burst is the number of identical events of an object recorded within Interval.
+	Burst int
 }
@@ -30,6 +30,7 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
 )
 
@@ -322,6 +323,51 @@ var _ = Describe("Test Global Policy Cache", func() {
 		Expect(applicationPolicyCache.Size()).Should(Equal(0))
 	})
 
+	It("Test cache invalidation on policy definition changes", func() {
+		newApp := func(name string) *v1beta1.Application {
+			return &v1beta1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: v1beta1.ApplicationSpec{
+					Components: []common.ApplicationComponent{{Name: name, Type: "webservice"}},
+				},
+			}
+		}
+		auditApp, otherApp := newApp("audit-app"), newApp("other-app")
+		Expect(applicationPolicyCache.Set(auditApp, []RenderedPolicyResult{
+			{PolicyName: "audit", PolicyType: "audit", PolicyNamespace: oam.SystemDefinitionNamespace, Enabled: true},
+		})).Should(Succeed())
+		Expect(applicationPolicyCache.Set(otherApp, []RenderedPolicyResult{
+			{PolicyName: "other", PolicyType: "other", PolicyNamespace: oam.SystemDefinitionNamespace, Enabled: true},
+		})).Should(Succeed())
+
+		audit := &v1beta1.PolicyDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: oam.SystemDefinitionNamespace},
+			Spec:       v1beta1.PolicyDefinitionSpec{Scope: v1beta1.ApplicationScope, Global: true},
+		}
+		policyScopeIndex.AddOrUpdate(audit)
+		defer policyScopeIndex.Delete(audit.Name, audit.Namespace)
+
+		// an update of a global policy only drops the applications which rendered it
+		updated := audit.DeepCopy()
+		updated.Spec.Priority = 10
+		(&Reconciler{}).handlePolicyDefinitionChange(ctx, updated)
+		_, hit, err := applicationPolicyCache.Get(auditApp)
+		Expect(err).Should(BeNil())
+		Expect(hit).Should(BeFalse())
+		_, hit, err = applicationPolicyCache.Get(otherApp)
+		Expect(err).Should(BeNil())
+		Expect(hit).Should(BeTrue())
+
+		// a new global policy applies to all the applications
+		added := &v1beta1.PolicyDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "added", Namespace: oam.SystemDefinitionNamespace},
+			Spec:       v1beta1.PolicyDefinitionSpec{Scope: v1beta1.ApplicationScope, Global: true},
+		}
+		defer policyScopeIndex.Delete(added.Name, added.Namespace)
+		(&Reconciler{}).handlePolicyDefinitionChange(ctx, added)
+		Expect(applicationPolicyCache.Size()).Should(Equal(0))
+	})
+
 	It("Test cache cleanup stale entries", func() {
 		app := &v1beta1.Application{
 			ObjectMeta: metav1.ObjectMeta{
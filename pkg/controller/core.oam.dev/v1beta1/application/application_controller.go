@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -57,6 +58,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/auth"
 	common2 "github.com/oam-dev/kubevela/pkg/controller/common"
 	core "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
 	"github.com/oam-dev/kubevela/pkg/features"
 	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
 	"github.com/oam-dev/kubevela/pkg/oam"
@@ -84,6 +86,9 @@ const (
 var (
 	// EnableResourceTrackerDeleteOnlyTrigger optimize ResourceTracker mutate event trigger by only receiving deleting events
 	EnableResourceTrackerDeleteOnlyTrigger = true
+
+	// definitionTemplateCache is shared by the reconciles of all applications if DefinitionTemplateCache is enabled
+	definitionTemplateCache = appfile.NewTemplateCache()
 )
 
 // Reconciler reconciles an Application object
@@ -95,10 +100,15 @@ type Reconciler struct {
 }
 
 type options struct {
-	appRevisionLimit     int
-	concurrentReconciles int
-	ignoreAppNoCtrlReq   bool
-	controllerVersion    string
+	appRevisionLimit        int
+	appRevisionMaxAge       time.Duration
+	appRevisionMaxTotalSize int64
+	concurrentReconciles    int
+	ignoreAppNoCtrlReq      bool
+	controllerVersion       string
+	// cacheDefinitionTemplates captures DefinitionTemplateCache at setup, the invalidation of the cache is only
+	// watched if it is enabled
+	cacheDefinitionTemplates bool
 }
 
 // +kubebuilder:rbac:groups=core.oam.dev,resources=applications,verbs=get;list;watch;create;update;patch;delete
@@ -142,6 +152,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	logCtx.AddTag("publish_version", app.GetAnnotations()[oam.AnnotationPublishVersion])
 
 	appParser := appfile.NewApplicationParser(r.Client)
+	if r.cacheDefinitionTemplates {
+		appParser = appfile.NewApplicationParserWithTemplateCache(r.Client, definitionTemplateCache)
+	}
 	handler, err := NewAppHandler(logCtx, r, app)
 	if err != nil {
 		return r.endWithNegativeCondition(logCtx, app, condition.ReconcileError(err), common.ApplicationStarting)
@@ -182,9 +195,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedParse, err))
 		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition("Parsed", err), common.ApplicationRendering)
 	}
+	if err := r.checkDefinitionVisibility(logCtx, app, appFile); err != nil {
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedParse, err))
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition("Parsed", err), common.ApplicationRendering)
+	}
 	app.Status.SetConditions(condition.ReadyCondition("Parsed"))
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonParsed, velatypes.MessageParsed))
 
+	if isRenderOnlyDryRun(app) {
+		return r.renderOnly(logCtx, app, appFile)
+	}
+	if err := r.leaveDryRun(logCtx, app); err != nil {
+		logCtx.Error(err, "Failed to delete the dry-run ConfigMap")
+		return r.endWithNegativeCondition(logCtx, app, condition.ReconcileError(err), common.ApplicationRendering)
+	}
+
 	if err := handler.PrepareCurrentAppRevision(logCtx, appFile); err != nil {
 		logCtx.Error(err, "Failed to prepare app revision")
 		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedRevision, err))
@@ -337,6 +362,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	opts := []resourcekeeper.GCOption{
 		resourcekeeper.AppRevisionLimitGCOption(r.appRevisionLimit),
+		resourcekeeper.AppRevisionMaxAgeGCOption(r.appRevisionMaxAge),
+		resourcekeeper.AppRevisionMaxTotalSizeGCOption(r.appRevisionMaxTotalSize),
 	}
 	if DisableAllApplicationRevision {
 		opts = append(opts, resourcekeeper.DisableApplicationRevisionGCOption{})
@@ -390,6 +417,8 @@ func (r *Reconciler) gcResourceTrackers(logCtx monitorContext.Context, handler *
 
 	options := []resourcekeeper.GCOption{
 		resourcekeeper.AppRevisionLimitGCOption(r.appRevisionLimit),
+		resourcekeeper.AppRevisionMaxAgeGCOption(r.appRevisionMaxAge),
+		resourcekeeper.AppRevisionMaxTotalSizeGCOption(r.appRevisionMaxTotalSize),
 	}
 	if DisableAllApplicationRevision {
 		options = append(options, resourcekeeper.DisableApplicationRevisionGCOption{})
@@ -516,6 +545,7 @@ func (r *Reconciler) handleFinalizers(ctx monitorContext.Context, app *v1beta1.A
 				meta.RemoveFinalizer(app, oam.FinalizerResourceTracker)
 				meta.RemoveFinalizer(app, oam.FinalizerOrphanResource)
 				applicationPolicyCache.InvalidateApplication(app.Namespace, app.Name)
+				resourcetracker.DeleteSizeMetrics(app)
 				return r.result(errors.Wrap(r.Client.Update(ctx, app), errUpdateApplicationFinalizer)).end(true)
 			}
 			if wfContext.EnableInMemoryContext {
@@ -612,6 +642,63 @@ func (r *Reconciler) emitPolicyEvents(app *v1beta1.Application) {
 	}
 }
 
+// checkDefinitionVisibility re-checks the visibility of the definitions used by the application, as the
+// visible-namespaces annotation of a definition can be changed after the application is admitted. Appfiles
+// rendered from the ApplicationRevision of a publish version are not checked, since they do not depend on the
+// current definitions.
+func (r *Reconciler) checkDefinitionVisibility(ctx context.Context, app *v1beta1.Application, af *appfile.Appfile) error {
+	if !feature.DefaultMutableFeatureGate.Enabled(features.DefinitionVisibility) || af.AppRevision != nil {
+		return nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.Namespace}, namespace); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		namespace.Name = app.Namespace
+	}
+
+	type definitionType struct{ kind, typ string }
+	var types []definitionType
+	for _, comp := range app.Spec.Components {
+		types = append(types, definitionType{v1beta1.ComponentDefinitionKind, comp.Type})
+		for _, trait := range comp.Traits {
+			types = append(types, definitionType{v1beta1.TraitDefinitionKind, trait.Type})
+		}
+	}
+	for _, policy := range app.Spec.Policies {
+		types = append(types, definitionType{v1beta1.PolicyDefinitionKind, policy.Type})
+	}
+	if app.Spec.Workflow != nil {
+		for _, step := range app.Spec.Workflow.Steps {
+			types = append(types, definitionType{v1beta1.WorkflowStepDefinitionKind, step.Type})
+			for _, subStep := range step.SubSteps {
+				types = append(types, definitionType{v1beta1.WorkflowStepDefinitionKind, subStep.Type})
+			}
+		}
+	}
+
+	var reasons []string
+	checked := map[definitionType]bool{}
+	for _, t := range types {
+		if checked[t] {
+			continue
+		}
+		checked[t] = true
+		reason, err := coredef.CheckDefinitionVisibility(ctx, r.Client, t.kind, t.typ, namespace)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) > 0 {
+		return errors.New(strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
 func (r *Reconciler) doWorkflowFinish(logCtx monitorContext.Context, app *v1beta1.Application, handler *AppHandler, state workflowv1alpha1.WorkflowRunPhase) {
 	logCtx = logCtx.Fork("do-workflow-finish", monitorContext.DurationMetric(func(v float64) {
 		metrics.AppReconcileStageDurationHistogram.WithLabelValues("do-workflow-finish").Observe(v)
@@ -660,7 +747,7 @@ func isHealthy(services []common.ApplicationComponentStatus) bool {
 
 // SetupWithManager install to manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		Watches(
 			&v1beta1.ResourceTracker{},
 			ctrlHandler.EnqueueRequestsFromMapFunc(findObjectForResourceTracker)).
@@ -725,9 +812,13 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&v1beta1.PolicyDefinition{},
 			ctrlHandler.EnqueueRequestsFromMapFunc(r.handlePolicyDefinitionChange),
-		).
-		For(&v1beta1.Application{}).
-		Complete(r)
+		)
+	if r.cacheDefinitionTemplates {
+		for _, obj := range []client.Object{&v1beta1.DefinitionRevision{}, &v1beta1.ComponentDefinition{}, &v1beta1.TraitDefinition{}, &v1beta1.WorkflowStepDefinition{}} {
+			builder = builder.Watches(obj, ctrlHandler.EnqueueRequestsFromMapFunc(handleDefinitionTemplateChange))
+		}
+	}
+	return builder.For(&v1beta1.Application{}).Complete(r)
 }
 
 // Setup adds a controller that reconciles App.
@@ -791,6 +882,8 @@ func filterManagedFieldChangesUpdate(e ctrlEvent.UpdateEvent) bool {
 
 func (r *Reconciler) handlePolicyDefinitionChange(ctx context.Context, obj client.Object) []reconcile.Request {
 	policy := obj.(*v1beta1.PolicyDefinition)
+	indexed := policyScopeIndex.GetFromNamespace(policy.Name, policy.Namespace)
+	wasGlobal := indexed != nil && indexed.Global && indexed.Scope == v1beta1.ApplicationScope
 
 	// Update the in-memory index so the next reconcile sees the new/updated policy.
 	// Without this, newly created global PolicyDefinitions are invisible until the
@@ -801,18 +894,57 @@ func (r *Reconciler) handlePolicyDefinitionChange(ctx context.Context, obj clien
 		policyScopeIndex.AddOrUpdate(policy)
 	}
 
-	// Invalidate rendered policy cache when global policies change
-	if policy.Namespace == oam.SystemDefinitionNamespace {
-		// vela-system policy affects all namespaces - invalidate entire cache
+	// A policy that becomes global applies to Applications which never rendered it, any other change only affects
+	// the Applications which rendered the policy.
+	isGlobal := policy.GetDeletionTimestamp() == nil && policy.Spec.Global && policy.Spec.Scope == v1beta1.ApplicationScope
+	switch {
+	case isGlobal && !wasGlobal && policy.Namespace == oam.SystemDefinitionNamespace:
 		applicationPolicyCache.InvalidateAll()
-	} else {
-		// Namespace-specific policy - invalidate for that namespace
+	case isGlobal && !wasGlobal:
 		applicationPolicyCache.InvalidateForNamespace(policy.Namespace)
+	default:
+		applicationPolicyCache.InvalidateForPolicy(policy.Name)
 	}
+	definitionTemplateCache.InvalidateDefinition(policy.Name)
 
 	return []reconcile.Request{}
 }
 
+// handleDefinitionTemplateChange drops the cached templates of the definition whose revisions or spec changed. The
+// definitions are watched besides their revisions since reverting a definition to the spec of an existing revision
+// creates no DefinitionRevision.
+func handleDefinitionTemplateChange(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetName()
+	if defRev, ok := obj.(*v1beta1.DefinitionRevision); ok {
+		name = revisionDefinitionName(defRev)
+		if name == "" {
+			definitionTemplateCache.InvalidateAll()
+			return nil
+		}
+	}
+	definitionTemplateCache.InvalidateDefinition(name)
+	return nil
+}
+
+// revisionDefinitionName returns the name of the definition of the DefinitionRevision, from its label or, for the
+// revisions missing it, from the definition embedded in the revision.
+func revisionDefinitionName(defRev *v1beta1.DefinitionRevision) string {
+	if name := defRev.GetLabels()[oamutil.DefinitionKindToNameLabel[defRev.Spec.DefinitionType]]; name != "" {
+		return name
+	}
+	switch defRev.Spec.DefinitionType {
+	case common.ComponentType:
+		return defRev.Spec.ComponentDefinition.Name
+	case common.TraitType:
+		return defRev.Spec.TraitDefinition.Name
+	case common.PolicyType:
+		return defRev.Spec.PolicyDefinition.Name
+	case common.WorkflowStepType:
+		return defRev.Spec.WorkflowStepDefinition.Name
+	}
+	return ""
+}
+
 func findObjectForResourceTracker(_ context.Context, rt client.Object) []reconcile.Request {
 	if EnableResourceTrackerDeleteOnlyTrigger && rt.GetDeletionTimestamp() == nil {
 		return nil
@@ -839,10 +971,13 @@ func timeReconcile(app *v1beta1.Application) func() {
 
 func parseOptions(args core.Args) options {
 	return options{
-		appRevisionLimit:     args.AppRevisionLimit,
-		concurrentReconciles: args.ConcurrentReconciles,
-		ignoreAppNoCtrlReq:   args.IgnoreAppWithoutControllerRequirement,
-		controllerVersion:    version.VelaVersion,
+		appRevisionLimit:         args.AppRevisionLimit,
+		appRevisionMaxAge:        args.AppRevisionMaxAge,
+		appRevisionMaxTotalSize:  args.AppRevisionMaxTotalSize,
+		concurrentReconciles:     args.ConcurrentReconciles,
+		ignoreAppNoCtrlReq:       args.IgnoreAppWithoutControllerRequirement,
+		controllerVersion:        version.VelaVersion,
+		cacheDefinitionTemplates: feature.DefaultMutableFeatureGate.Enabled(features.DefinitionTemplateCache),
 	}
 }
 
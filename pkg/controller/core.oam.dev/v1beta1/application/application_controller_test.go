@@ -4837,3 +4837,23 @@ func Test_setVelaVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_revisionDefinitionName(t *testing.T) {
+	labeled := &v1beta1.DefinitionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "scaler-v1", Labels: map[string]string{oam.LabelTraitDefinitionName: "scaler"}},
+		Spec:       v1beta1.DefinitionRevisionSpec{DefinitionType: common.TraitType},
+	}
+	if name := revisionDefinitionName(labeled); name != "scaler" {
+		t.Errorf("revisionDefinitionName() = %q, want %q", name, "scaler")
+	}
+	unlabeled := &v1beta1.DefinitionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "webservice-v1"},
+		Spec: v1beta1.DefinitionRevisionSpec{
+			DefinitionType:      common.ComponentType,
+			ComponentDefinition: v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "webservice"}},
+		},
+	}
+	if name := revisionDefinitionName(unlabeled); name != "webservice" {
+		t.Errorf("revisionDefinitionName() = %q, want %q", name, "webservice")
+	}
+}
@@ -180,7 +180,22 @@ func (c *ApplicationPolicyCache) InvalidateForNamespace(namespace string) {
 	}
 }
 
-// InvalidateAll clears the entire cache. Call when global (vela-system) policies change.
+// InvalidateForPolicy removes the cache entries of the Applications whose rendered results include a policy of the
+// given definition, either applied as a global policy or referenced by type in the Application.
+func (c *ApplicationPolicyCache) InvalidateForPolicy(policyDefName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		for _, result := range entry.renderedResults {
+			if result.PolicyType == policyDefName {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// InvalidateAll clears the entire cache. Call when a new global (vela-system) policy applies to all Applications.
 func (c *ApplicationPolicyCache) InvalidateAll() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -56,6 +56,8 @@ type AppHandler struct {
 	currentAppRev  *v1beta1.ApplicationRevision
 	latestAppRev   *v1beta1.ApplicationRevision
 	resourceKeeper resourcekeeper.ResourceKeeper
+	// componentLimiter bounds the components of the application dispatched in parallel
+	componentLimiter *resourcekeeper.ConcurrencyLimiter
 
 	isNewRevision  bool
 	currentRevHash string
@@ -90,6 +92,7 @@ func NewAppHandler(ctx context.Context, r *Reconciler, app *v1beta1.Application)
 		Client:                      r.Client,
 		app:                         app,
 		resourceKeeper:              resourceHandler,
+		componentLimiter:            resourcekeeper.NewConcurrencyLimiter(resourcekeeper.DispatchScopeComponent, resourcekeeper.MaxAppComponentDispatchConcurrent),
 		applicationScopedPolicyDefs: make(map[string]*v1beta1.PolicyDefinition),
 	}, nil
 }
@@ -305,6 +305,11 @@ func (h *AppHandler) checkComponentHealth(appParser *appfile.Parser, af *appfile
 
 func (h *AppHandler) applyComponentFunc(appParser *appfile.Parser, af *appfile.Appfile) oamprovidertypes.ComponentApply {
 	return func(baseCtx context.Context, comp common.ApplicationComponent, patcher *cue.Value, clusterName string, overrideNamespace string) (*unstructured.Unstructured, []*unstructured.Unstructured, bool, error) {
+		release, err := h.componentLimiter.Acquire(baseCtx)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		defer release()
 		t := time.Now()
 		appRev := h.currentAppRev
 		defer func() { metrics.ApplyComponentTimeHistogram.WithLabelValues("-").Observe(time.Since(t).Seconds()) }()
@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	monitorContext "github.com/kubevela/pkg/monitor/context"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/controller/utils"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const (
+	// DryRunModeRender renders the application without applying the manifests
+	DryRunModeRender = "render"
+	// DryRunCondition is the condition type of the render-only dry-run
+	DryRunCondition = "DryRun"
+	// DryRunReasonManifestsTooLarge is the reason of the DryRun condition when the rendered manifests cannot
+	// be stored in a ConfigMap
+	DryRunReasonManifestsTooLarge = "ManifestsTooLarge"
+
+	// maxDryRunConfigMapSize is the maximum total size of the data of a ConfigMap accepted by the API server
+	maxDryRunConfigMapSize = 1 << 20
+	// dryRunRedactedValue replaces the values of the rendered Secrets in the dry-run ConfigMap
+	dryRunRedactedValue = "<redacted>"
+)
+
+// isRenderOnlyDryRun checks if the application is annotated to be rendered without being applied
+func isRenderOnlyDryRun(app *v1beta1.Application) bool {
+	return app.GetAnnotations()[oam.AnnotationDryRun] == DryRunModeRender
+}
+
+// renderOnly renders the components and traits of the application with the live definitions and records
+// the manifests in a ConfigMap. No ApplicationRevision is created and no workflow runs, so the resources
+// already applied by the application are left untouched.
+func (r *Reconciler) renderOnly(logCtx monitorContext.Context, app *v1beta1.Application, appFile *appfile.Appfile) (ctrl.Result, error) {
+	manifests, err := appFile.GenerateComponentManifests()
+	if err != nil {
+		logCtx.Error(err, "Failed to render the application in the dry-run mode")
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedRender, err))
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(DryRunCondition, err), common.ApplicationRendering)
+	}
+	data, err := dryRunConfigMapData(manifests)
+	if err != nil {
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(DryRunCondition, err), common.ApplicationRendering)
+	}
+	if size := dryRunConfigMapSize(data); size > maxDryRunConfigMapSize {
+		err := fmt.Errorf("the rendered manifests take %d bytes, more than the %d bytes a ConfigMap can hold", size, maxDryRunConfigMapSize)
+		logCtx.Info("Cannot store the rendered manifests", "reason", err.Error())
+		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedRender, err))
+		if err := r.deleteDryRunConfigMap(logCtx, app); err != nil {
+			return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(DryRunCondition, err), common.ApplicationRendering)
+		}
+		app.Status.SetConditions(condition.Condition{
+			Type:               DryRunCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             DryRunReasonManifestsTooLarge,
+			Message:            err.Error(),
+		})
+		if err := r.patchStatus(logCtx, app, common.ApplicationRendering); err != nil {
+			return r.result(errors.WithMessage(err, "cannot update application status")).ret()
+		}
+		return r.result(nil).ret()
+	}
+	if err := createOrUpdateDryRunConfigMap(logCtx, r.Client, app, data); err != nil {
+		logCtx.Error(err, "Failed to store the rendered manifests")
+		return r.endWithNegativeCondition(logCtx, app, condition.ErrorCondition(DryRunCondition, err), common.ApplicationRendering)
+	}
+	app.Status.DryRunConfigMap = dryRunConfigMapName(app.Namespace, app.Name)
+	app.Status.SetConditions(condition.ReadyCondition(DryRunCondition))
+	r.Recorder.Event(app, event.Normal(velatypes.ReasonRendered, velatypes.MessageRenderedDryRun))
+	logCtx.Info("Successfully rendered the application in the dry-run mode", "configMap", app.Status.DryRunConfigMap)
+	if err := r.patchStatus(logCtx, app, common.ApplicationRendered); err != nil {
+		return r.result(errors.WithMessage(err, "cannot update application status")).ret()
+	}
+	return r.result(nil).ret()
+}
+
+// dryRunConfigMapData returns the rendered manifests of each component as a multi-document YAML keyed by
+// the component name. The values of rendered Secrets are redacted, as the ConfigMap is readable by anyone
+// allowed to read ConfigMaps in the namespace of the application.
+func dryRunConfigMapData(manifests []*velatypes.ComponentManifest) (map[string]string, error) {
+	data := map[string]string{}
+	for _, m := range manifests {
+		var objs []*unstructured.Unstructured
+		if m.ComponentOutput != nil {
+			objs = append(objs, m.ComponentOutput)
+		}
+		objs = append(objs, m.ComponentOutputsAndTraits...)
+		var docs []string
+		for _, obj := range objs {
+			if obj == nil {
+				continue
+			}
+			bs, err := yaml.Marshal(redactSecretData(obj).Object)
+			if err != nil {
+				return nil, fmt.Errorf("cannot marshal the manifests of component %s: %w", m.Name, err)
+			}
+			docs = append(docs, string(bs))
+		}
+		data[m.Name+".yaml"] = strings.Join(docs, "---\n")
+	}
+	return data, nil
+}
+
+// redactSecretData returns a copy of the Secret with the values of its data and stringData replaced, keeping
+// the keys. Other objects are returned as they are.
+func redactSecretData(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj.GetAPIVersion() != "v1" || obj.GetKind() != "Secret" {
+		return obj
+	}
+	redacted := obj.DeepCopy()
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := redacted.Object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range values {
+			values[key] = dryRunRedactedValue
+		}
+	}
+	return redacted
+}
+
+// dryRunConfigMapSize returns the size of the ConfigMap data as counted by the API server.
+func dryRunConfigMapSize(data map[string]string) int {
+	size := 0
+	for key, value := range data {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// dryRunConfigMapName returns the ConfigMap name for the rendered manifests, capped at 253 chars.
+func dryRunConfigMapName(namespace, appName string) string {
+	const prefix = "application-dryrun-"
+	const maxLen = 253
+	name := prefix + namespace + "-" + appName
+	if len(name) <= maxLen {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(namespace+"/"+appName)))[:8]
+	available := maxLen - len(prefix) - 1 - 8
+	// the truncated name must not end with a separator, which would make an invalid name with the hash
+	truncated := strings.TrimRight((namespace + "-" + appName)[:available], ".-")
+	return prefix + truncated + "-" + hash
+}
+
+// createOrUpdateDryRunConfigMap stores the rendered manifests in the dry-run ConfigMap of the application. The
+// ConfigMap is left untouched when the hash of the manifests is unchanged, so that reconciling an unchanged
+// application does not rewrite it. An existing ConfigMap of the same name which is not controlled by the
+// application is never taken over.
+func createOrUpdateDryRunConfigMap(ctx context.Context, cli client.Client, app *v1beta1.Application, data map[string]string) error {
+	hash, err := utils.ComputeSpecHash(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the hash of the rendered manifests")
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dryRunConfigMapName(app.Namespace, app.Name),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         v1beta1.SchemeGroupVersion.String(),
+					Kind:               v1beta1.ApplicationKind,
+					Name:               app.Name,
+					UID:                app.UID,
+					Controller:         ptrBool(true),
+					BlockOwnerDeletion: ptrBool(true),
+				},
+			},
+		},
+		Data: data,
+	}
+	meta.AddLabels(cm, map[string]string{
+		oam.LabelAppName:      app.Name,
+		oam.LabelAppNamespace: app.Namespace,
+		oam.LabelAppUID:       string(app.UID),
+	})
+	meta.AddAnnotations(cm, map[string]string{
+		oam.AnnotationDryRunManifestsHash: hash,
+		oam.AnnotationLastAppliedTime:     time.Now().Format(time.RFC3339),
+	})
+	err = cli.Create(ctx, cm)
+	if err == nil || !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "failed to create the dry-run ConfigMap")
+	}
+	existing := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(cm), existing); err != nil {
+		return errors.Wrap(err, "failed to get the dry-run ConfigMap")
+	}
+	if !metav1.IsControlledBy(existing, app) {
+		return fmt.Errorf("ConfigMap %s already exists and is not controlled by the application", existing.Name)
+	}
+	if existing.GetAnnotations()[oam.AnnotationDryRunManifestsHash] == hash {
+		return nil
+	}
+	existing.Data = data
+	existing.OwnerReferences = cm.OwnerReferences
+	meta.AddLabels(existing, cm.GetLabels())
+	meta.AddAnnotations(existing, cm.GetAnnotations())
+	return errors.Wrap(cli.Update(ctx, existing), "failed to update the dry-run ConfigMap")
+}
+
+// leaveDryRun deletes the dry-run ConfigMap and the DryRun condition of an application which is no longer
+// rendered in the dry-run mode.
+func (r *Reconciler) leaveDryRun(ctx context.Context, app *v1beta1.Application) error {
+	if err := r.deleteDryRunConfigMap(ctx, app); err != nil {
+		return err
+	}
+	app.Status.Conditions = slices.DeleteFunc(app.Status.Conditions, func(c condition.Condition) bool {
+		return c.Type == DryRunCondition
+	})
+	return nil
+}
+
+// deleteDryRunConfigMap deletes the ConfigMap recorded by a previous render-only dry-run of the application,
+// once the application leaves the dry-run mode or its manifests can no longer be stored.
+func (r *Reconciler) deleteDryRunConfigMap(ctx context.Context, app *v1beta1.Application) error {
+	if app.Status.DryRunConfigMap == "" {
+		return nil
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: app.Status.DryRunConfigMap, Namespace: app.Namespace}}
+	if err := r.Client.Delete(ctx, cm); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to delete the dry-run ConfigMap")
+	}
+	app.Status.DryRunConfigMap = ""
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+func TestIsRenderOnlyDryRun(t *testing.T) {
+	app := &v1beta1.Application{}
+	require.False(t, isRenderOnlyDryRun(app))
+	app.SetAnnotations(map[string]string{oam.AnnotationDryRun: "server"})
+	require.False(t, isRenderOnlyDryRun(app))
+	app.SetAnnotations(map[string]string{oam.AnnotationDryRun: DryRunModeRender})
+	require.True(t, isRenderOnlyDryRun(app))
+}
+
+func TestDryRunConfigMapData(t *testing.T) {
+	deploy := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"}}
+	service := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "Service"}}
+	data, err := dryRunConfigMapData([]*velatypes.ComponentManifest{
+		{Name: "backend", ComponentOutput: deploy, ComponentOutputsAndTraits: []*unstructured.Unstructured{service}},
+		{Name: "empty"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: apps/v1\nkind: Deployment\n---\napiVersion: v1\nkind: Service\n", data["backend.yaml"])
+	require.Equal(t, "", data["empty.yaml"])
+}
+
+func TestDryRunConfigMapDataRedactsSecrets(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+		"stringData": map[string]interface{}{"token": "secret"},
+	}}
+	data, err := dryRunConfigMapData([]*velatypes.ComponentManifest{{Name: "db", ComponentOutput: secret}})
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: v1\ndata:\n  password: <redacted>\nkind: Secret\nstringData:\n  token: <redacted>\n", data["db.yaml"])
+	require.Equal(t, "c2VjcmV0", secret.Object["data"].(map[string]interface{})["password"])
+}
+
+func TestDryRunConfigMapName(t *testing.T) {
+	require.Equal(t, "application-dryrun-default-app", dryRunConfigMapName("default", "app"))
+	name := dryRunConfigMapName("default", strings.Repeat("a", 300))
+	require.Len(t, name, 253)
+	require.NotEqual(t, name, dryRunConfigMapName("default", strings.Repeat("a", 301)))
+
+	// the name is not truncated right after a separator
+	name = dryRunConfigMapName("default", strings.Repeat("a", 216)+"-"+strings.Repeat("b", 100))
+	require.LessOrEqual(t, len(name), 253)
+	require.NotContains(t, name, "--")
+	require.Regexp(t, `^application-dryrun-default-a+-[0-9a-f]{8}$`, name)
+}
+
+func TestCreateOrUpdateDryRunConfigMap(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid")}}
+	key := client.ObjectKey{Name: dryRunConfigMapName(app.Namespace, app.Name), Namespace: app.Namespace}
+
+	require.NoError(t, createOrUpdateDryRunConfigMap(ctx, cli, app, map[string]string{"backend.yaml": "v1"}))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, key, cm))
+	require.Equal(t, map[string]string{"backend.yaml": "v1"}, cm.Data)
+	require.Equal(t, "app", cm.Labels[oam.LabelAppName])
+	require.Equal(t, types.UID("uid"), cm.OwnerReferences[0].UID)
+
+	require.NoError(t, createOrUpdateDryRunConfigMap(ctx, cli, app, map[string]string{"frontend.yaml": "v2"}))
+	require.NoError(t, cli.Get(ctx, key, cm))
+	require.Equal(t, map[string]string{"frontend.yaml": "v2"}, cm.Data)
+
+	// unchanged manifests are not written again
+	resourceVersion := cm.ResourceVersion
+	require.NoError(t, createOrUpdateDryRunConfigMap(ctx, cli, app, map[string]string{"frontend.yaml": "v2"}))
+	require.NoError(t, cli.Get(ctx, key, cm))
+	require.Equal(t, resourceVersion, cm.ResourceVersion)
+
+	// a ConfigMap of the same name not controlled by the application is not taken over
+	other := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: types.UID("other-uid")}}
+	foreign := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: dryRunConfigMapName(other.Namespace, other.Name), Namespace: other.Namespace},
+		Data: map[string]string{"user": "data"}}
+	require.NoError(t, cli.Create(ctx, foreign))
+	require.ErrorContains(t, createOrUpdateDryRunConfigMap(ctx, cli, other, map[string]string{"backend.yaml": "v1"}), "not controlled by the application")
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(foreign), foreign))
+	require.Equal(t, map[string]string{"user": "data"}, foreign.Data)
+	require.Empty(t, foreign.OwnerReferences)
+}
+
+func TestDryRunConfigMapSize(t *testing.T) {
+	require.Equal(t, 0, dryRunConfigMapSize(nil))
+	require.Equal(t, len("backend.yaml")+2, dryRunConfigMapSize(map[string]string{"backend.yaml": "v1"}))
+}
+
+func TestDeleteDryRunConfigMap(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	r := &Reconciler{Client: cli}
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid")}}
+	key := client.ObjectKey{Name: dryRunConfigMapName(app.Namespace, app.Name), Namespace: app.Namespace}
+
+	require.NoError(t, createOrUpdateDryRunConfigMap(ctx, cli, app, map[string]string{"backend.yaml": "v1"}))
+	app.Status.DryRunConfigMap = key.Name
+	require.NoError(t, r.deleteDryRunConfigMap(ctx, app))
+	require.Empty(t, app.Status.DryRunConfigMap)
+	require.True(t, kerrors.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{})))
+
+	// deleting again is a no-op
+	require.NoError(t, r.deleteDryRunConfigMap(ctx, app))
+}
+
+func TestLeaveDryRun(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	r := &Reconciler{Client: cli}
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid")}}
+	key := client.ObjectKey{Name: dryRunConfigMapName(app.Namespace, app.Name), Namespace: app.Namespace}
+
+	require.NoError(t, createOrUpdateDryRunConfigMap(ctx, cli, app, map[string]string{"backend.yaml": "v1"}))
+	app.Status.DryRunConfigMap = key.Name
+	app.Status.SetConditions(condition.ReadyCondition(DryRunCondition), condition.ReadyCondition("Parsed"))
+	require.NoError(t, r.leaveDryRun(ctx, app))
+	require.Empty(t, app.Status.DryRunConfigMap)
+	require.True(t, kerrors.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{})))
+	require.Len(t, app.Status.Conditions, 1)
+	require.Equal(t, condition.ConditionType("Parsed"), app.Status.Conditions[0].Type)
+}
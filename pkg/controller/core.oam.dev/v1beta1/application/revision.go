@@ -52,6 +52,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/resourcetracker"
 )
 
 type contextKey int
@@ -529,7 +530,11 @@ func (h *AppHandler) FinalizeAndApplyAppRevision(ctx context.Context) error {
 	gotAppRev := &v1beta1.ApplicationRevision{}
 	if err := h.Get(ctx, client.ObjectKey{Name: appRev.Name, Namespace: appRev.Namespace}, gotAppRev); err != nil {
 		if apierrors.IsNotFound(err) {
-			return h.Create(ctx, appRev)
+			if err := h.Create(ctx, appRev); err != nil {
+				return err
+			}
+			resourcetracker.RecordSizeMetrics(appRev)
+			return nil
 		}
 		return err
 	}
@@ -548,7 +553,11 @@ func (h *AppHandler) FinalizeAndApplyAppRevision(ctx context.Context) error {
 		appRev.Spec.Compression.SetType(compression.Zstd)
 	}
 
-	return h.Update(ctx, appRev)
+	if err := h.Update(ctx, appRev); err != nil {
+		return err
+	}
+	resourcetracker.RecordSizeMetrics(appRev)
+	return nil
 }
 
 // UpdateAppLatestRevisionStatus only call to update app's latest revision status after applying manifests successfully
@@ -20,24 +20,23 @@ package componentdefinition
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	ctrlrec "github.com/kubevela/pkg/controller/reconciler"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/util/retry"
-	"k8s.io/klog/v2"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
-	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
 	"github.com/oam-dev/kubevela/pkg/controller/utils"
-	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/version"
 )
 
@@ -54,101 +53,120 @@ type options struct {
 	concurrentReconciles int
 	ignoreDefNoCtrlReq   bool
 	controllerVersion    string
+	deletionProtection   bool
+	rateLimiter          workqueue.TypedRateLimiter[reconcile.Request]
+	eventAggregator      oamctrl.EventAggregatorArgs
+	shardSelector        labels.Selector
+	verifier             *signature.Verifier
 }
 
 // Reconcile is the main logic for ComponentDefinition controller
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx, cancel := ctrlrec.NewReconcileContext(ctx)
-	defer cancel()
-
-	klog.InfoS("Reconcile componentDefinition", "componentDefinition", klog.KRef(req.Namespace, req.Name))
-
-	var componentDefinition v1beta1.ComponentDefinition
-	if err := r.Get(ctx, req.NamespacedName, &componentDefinition); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	if !coredef.MatchControllerRequirement(&componentDefinition, r.controllerVersion, r.ignoreDefNoCtrlReq) {
-		klog.InfoS("skip definition: not match the controller requirement of definition", "componentDefinition", klog.KObj(&componentDefinition))
-		return ctrl.Result{}, nil
-	}
-
-	defRev, result, err := coredef.ReconcileDefinitionRevision(ctx, r.Client, r.record, &componentDefinition, r.defRevLimit, func(revision *common.Revision) error {
-		componentDefinition.Status.LatestRevision = revision
-		return r.UpdateStatus(ctx, &componentDefinition)
-	})
-	if result != nil {
-		return *result, err
-	}
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+	return r.definitionReconciler().Reconcile(ctx, req)
+}
 
-	def := utils.NewCapabilityComponentDef(&componentDefinition)
-	// Store the parameter of componentDefinition to configMap
-	cmName, err := def.StoreOpenAPISchema(ctx, r.Client, req.Namespace, req.Name, defRev.Name)
-	if err != nil {
-		klog.InfoS("Could not store capability in ConfigMap", "err", err)
-		r.record.Event(&(componentDefinition), event.Warning("Could not store capability in ConfigMap", err))
-		return ctrl.Result{}, util.PatchCondition(ctx, r, &(componentDefinition),
-			condition.ReconcileError(fmt.Errorf(util.ErrStoreCapabilityInConfigMap, def.Name, err)))
+func (r *Reconciler) definitionReconciler() *coredef.DefinitionReconciler[*v1beta1.ComponentDefinition] {
+	return &coredef.DefinitionReconciler[*v1beta1.ComponentDefinition]{
+		Client:         r.Client,
+		Record:         r.record,
+		DefinitionKind: definitionKind,
+		DefinitionReconcilerOptions: coredef.DefinitionReconcilerOptions{
+			DefRevLimit:        r.defRevLimit,
+			IgnoreDefNoCtrlReq: r.ignoreDefNoCtrlReq,
+			ControllerVersion:  r.controllerVersion,
+			DeletionProtection: r.deletionProtection,
+			ShardSelector:      r.shardSelector,
+			Verifier:           r.verifier,
+		},
 	}
-	if componentDefinition.Status.ConfigMapRef != cmName {
-		componentDefinition.Status.ConfigMapRef = cmName
-		// Override the conditions, which maybe include the error info.
-		componentDefinition.Status.Conditions = []condition.Condition{condition.ReconcileSuccess()}
-
-		if err := r.UpdateStatus(ctx, &componentDefinition); err != nil {
-			klog.InfoS("Could not update componentDefinition Status", "err", err)
-			r.record.Event(&componentDefinition, event.Warning("cannot update ComponentDefinition Status", err))
-			return ctrl.Result{}, util.PatchCondition(ctx, r, &componentDefinition,
-				condition.ReconcileError(fmt.Errorf(util.ErrUpdateComponentDefinition, componentDefinition.Name, err)))
-		}
-		klog.InfoS("Successfully updated the status.configMapRef of the ComponentDefinition", "componentDefinition",
-			klog.KRef(req.Namespace, req.Name), "status.configMapRef", cmName)
-	}
-	return ctrl.Result{}, nil
 }
 
-// UpdateStatus updates v1beta1.ComponentDefinition's Status with retry.RetryOnConflict
-func (r *Reconciler) UpdateStatus(ctx context.Context, def *v1beta1.ComponentDefinition, opts ...client.SubResourceUpdateOption) error {
-	status := def.DeepCopy().Status
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
-		if err = r.Get(ctx, client.ObjectKey{Namespace: def.Namespace, Name: def.Name}, def); err != nil {
-			return
+var definitionKind = coredef.DefinitionKind[*v1beta1.ComponentDefinition]{
+	Kind: v1beta1.ComponentDefinitionKind,
+	New: func() *v1beta1.ComponentDefinition {
+		return &v1beta1.ComponentDefinition{}
+	},
+	GetStatus: func(def *v1beta1.ComponentDefinition) coredef.DefinitionStatus {
+		status := def.Status.DeepCopy()
+		return coredef.DefinitionStatus{
+			ConditionedStatus:  status.ConditionedStatus,
+			ConfigMapRef:       status.ConfigMapRef,
+			Schema:             status.Schema,
+			LatestRevision:     status.LatestRevision,
+			LatestRevisionDiff: status.LatestRevisionDiff,
+			Usage:              status.Usage,
+			ObservedGeneration: status.ObservedGeneration,
 		}
-		def.Status = status
-		return r.Status().Update(ctx, def, opts...)
-	})
+	},
+	SetStatus: func(def *v1beta1.ComponentDefinition, status coredef.DefinitionStatus) {
+		def.Status.ConditionedStatus = status.ConditionedStatus
+		def.Status.ConfigMapRef = status.ConfigMapRef
+		def.Status.Schema = status.Schema
+		def.Status.LatestRevision = status.LatestRevision
+		def.Status.LatestRevisionDiff = status.LatestRevisionDiff
+		def.Status.Usage = status.Usage
+		def.Status.ObservedGeneration = status.ObservedGeneration
+	},
+	Capability: func(def *v1beta1.ComponentDefinition) coredef.DefinitionCapability {
+		capability := utils.NewCapabilityComponentDef(def)
+		return &capability
+	},
 }
 
 // SetupWithManager will setup with event recorder
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.record = event.NewAPIRecorder(mgr.GetEventRecorderFor("ComponentDefinition")).
-		WithAnnotations("controller", "ComponentDefinition")
-	return ctrl.NewControllerManagedBy(mgr).
+	r.record = coredef.NewEventRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor("ComponentDefinition")).
+		WithAnnotations("controller", "ComponentDefinition"), r.eventAggregator)
+	err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.concurrentReconciles,
+			RateLimiter:             r.rateLimiter,
 		}).
-		For(&v1beta1.ComponentDefinition{}).
+		For(&v1beta1.ComponentDefinition{}, builder.WithPredicates(coredef.ShardPredicate(r.shardSelector))).
 		Complete(r)
+	if err != nil {
+		return err
+	}
+	usage := &coredef.DefinitionUsageReconciler[*v1beta1.ComponentDefinition]{
+		Client:         r.Client,
+		DefinitionKind: definitionKind,
+		ShardSelector:  r.shardSelector,
+	}
+	return usage.SetupWithManager(mgr, common.ComponentType, controller.Options{MaxConcurrentReconciles: r.concurrentReconciles})
 }
 
 // Setup adds a controller that reconciles ComponentDefinition.
 func Setup(mgr ctrl.Manager, args oamctrl.Args) error {
+	opts, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
 	r := Reconciler{
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
-		options: parseOptions(args),
+		options: opts,
 	}
 	return r.SetupWithManager(mgr)
 }
 
-func parseOptions(args oamctrl.Args) options {
+func parseOptions(args oamctrl.Args) (options, error) {
+	shardSelector, err := coredef.ParseShardSelector(args.DefinitionShardLabel)
+	if err != nil {
+		return options{}, err
+	}
+	verifier, err := signature.LoadVerifier(args.DefinitionSignaturePublicKeys)
+	if err != nil {
+		return options{}, err
+	}
 	return options{
 		defRevLimit:          args.DefRevisionLimit,
 		concurrentReconciles: args.ConcurrentReconciles,
 		ignoreDefNoCtrlReq:   args.IgnoreDefinitionWithoutControllerRequirement,
 		controllerVersion:    version.VelaVersion,
-	}
+		deletionProtection:   args.DefinitionDeletionProtection,
+		rateLimiter:          args.DefinitionRateLimiter.NewRateLimiter(),
+		eventAggregator:      args.DefinitionEvents,
+		shardSelector:        shardSelector,
+		verifier:             verifier,
+	}, nil
 }
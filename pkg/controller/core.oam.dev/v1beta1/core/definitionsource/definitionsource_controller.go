@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definitionsource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	ctrlrec "github.com/kubevela/pkg/controller/reconciler"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+	"github.com/oam-dev/kubevela/pkg/definition/validation"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// defaultSyncInterval is the interval to check a DefinitionSource for updates if not specified
+const defaultSyncInterval = 10 * time.Minute
+
+// Reconciler reconciles a DefinitionSource object
+type Reconciler struct {
+	client.Client
+	record               event.Recorder
+	fetcher              Fetcher
+	concurrentReconciles int
+}
+
+// Reconcile syncs the definitions of the DefinitionSource: it applies the definitions of the
+// current revision of the source and prunes the definitions no longer in the source.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := ctrlrec.NewReconcileContext(ctx)
+	defer cancel()
+
+	klog.InfoS("Reconcile definitionSource", "definitionSource", klog.KRef(req.Namespace, req.Name))
+
+	src := &v1alpha1.DefinitionSource{}
+	if err := r.Get(ctx, req.NamespacedName, src); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if src.Spec.Suspend {
+		klog.InfoS("skip definitionSource: suspended", "definitionSource", klog.KObj(src))
+		return ctrl.Result{}, nil
+	}
+
+	artifact, err := r.fetcher.Fetch(ctx, r.Client, src)
+	if err != nil {
+		return r.endWithError(ctx, src, "Could not fetch the source", err)
+	}
+	defs, err := ParseDefinitions(artifact.Files, src.Spec.Path)
+	if err != nil {
+		return r.endWithError(ctx, src, "Could not parse the definitions", err)
+	}
+	// An empty source, e.g. a wrong path or a broken artifact, would prune all the definitions synced before
+	if src.Spec.Prune && len(defs) == 0 {
+		return r.endWithError(ctx, src, "Refused to prune the definitions",
+			fmt.Errorf("revision %s has no definitions under path %q, refusing to prune the definitions synced from the source",
+				artifact.Revision, src.Spec.Path))
+	}
+	synced := make([]v1alpha1.SyncedDefinition, 0, len(defs))
+	for _, def := range defs {
+		if err := r.applyDefinition(ctx, src, artifact.Revision, def); err != nil {
+			return r.endWithError(ctx, src, "Could not apply the definitions", err)
+		}
+		synced = append(synced, v1alpha1.SyncedDefinition{Kind: def.GetKind(), Name: def.GetName()})
+	}
+	if src.Spec.Prune {
+		if err := r.pruneDefinitions(ctx, src, synced); err != nil {
+			return r.endWithError(ctx, src, "Could not prune the definitions", err)
+		}
+	}
+
+	if src.Status.Revision != artifact.Revision {
+		klog.InfoS("Synced the definitions of the definitionSource", "definitionSource", klog.KObj(src),
+			"revision", artifact.Revision, "definitions", len(synced))
+		r.record.Event(src, event.Normal("Synced",
+			fmt.Sprintf("Synced %d definitions from revision %s", len(synced), artifact.Revision)))
+	}
+	now := metav1.Now()
+	src.Status.Revision = artifact.Revision
+	src.Status.LastSyncTime = &now
+	src.Status.Definitions = synced
+	src.Status.ObservedGeneration = src.Generation
+	src.Status.SetConditions(condition.ReconcileSuccess())
+	if err := r.Status().Update(ctx, src); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: syncInterval(src)}, nil
+}
+
+func (r *Reconciler) endWithError(ctx context.Context, src *v1alpha1.DefinitionSource, reason string, err error) (ctrl.Result, error) {
+	klog.ErrorS(err, reason, "definitionSource", klog.KObj(src))
+	r.record.Event(src, event.Warning(event.Reason(reason), err))
+	// The source is checked again at the next sync interval
+	return ctrl.Result{RequeueAfter: syncInterval(src)}, util.PatchCondition(ctx, r, src, condition.ReconcileError(err))
+}
+
+// applyDefinition creates or updates the definition in the namespace of the source. Definitions
+// created by users or by other sources are not overridden.
+func (r *Reconciler) applyDefinition(ctx context.Context, src *v1alpha1.DefinitionSource, revision string, def *unstructured.Unstructured) error {
+	def.SetNamespace(src.Namespace)
+	labels := def.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[oam.LabelDefinitionSource] = src.Name
+	def.SetLabels(labels)
+	annotations := def.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[oam.AnnotationDefinitionSourceRevision] = revision
+	def.SetAnnotations(annotations)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(def.GroupVersionKind())
+	if err := r.Get(ctx, client.ObjectKeyFromObject(def), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.InfoS("Create the definition synced from the definitionSource", "kind", def.GetKind(),
+			"definition", klog.KObj(def), "definitionSource", klog.KObj(src))
+		return r.Create(ctx, def)
+	}
+	if owner := existing.GetLabels()[oam.LabelDefinitionSource]; owner != src.Name {
+		return fmt.Errorf("%s %s already exists and is not synced from this source", def.GetKind(), def.GetName())
+	}
+	if apiequality.Semantic.DeepEqual(existing.Object["spec"], def.Object["spec"]) &&
+		apiequality.Semantic.DeepEqual(existing.GetLabels(), def.GetLabels()) &&
+		apiequality.Semantic.DeepEqual(existing.GetAnnotations(), def.GetAnnotations()) {
+		return nil
+	}
+	def.SetResourceVersion(existing.GetResourceVersion())
+	// Keep the finalizers, e.g. the deletion protection of the definition controllers
+	def.SetFinalizers(existing.GetFinalizers())
+	klog.InfoS("Update the definition synced from the definitionSource", "kind", def.GetKind(),
+		"definition", klog.KObj(def), "definitionSource", klog.KObj(src))
+	return r.Update(ctx, def)
+}
+
+// pruneDefinitions deletes the definitions synced from the source before which are no longer in the source
+func (r *Reconciler) pruneDefinitions(ctx context.Context, src *v1alpha1.DefinitionSource, synced []v1alpha1.SyncedDefinition) error {
+	keep := map[v1alpha1.SyncedDefinition]bool{}
+	for _, def := range synced {
+		keep[def] = true
+	}
+	for _, kind := range validation.DefinitionKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := r.List(ctx, list, client.InNamespace(src.Namespace),
+			client.MatchingLabels{oam.LabelDefinitionSource: src.Name}); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			def := &list.Items[i]
+			if keep[v1alpha1.SyncedDefinition{Kind: kind, Name: def.GetName()}] {
+				continue
+			}
+			klog.InfoS("Prune the definition no longer in the definitionSource", "kind", kind,
+				"definition", klog.KObj(def), "definitionSource", klog.KObj(src))
+			if err := r.Delete(ctx, def); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func syncInterval(src *v1alpha1.DefinitionSource) time.Duration {
+	if src.Spec.Interval != nil && src.Spec.Interval.Duration > 0 {
+		return src.Spec.Interval.Duration
+	}
+	return defaultSyncInterval
+}
+
+// SetupWithManager will setup with event recorder
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.record = event.NewAPIRecorder(mgr.GetEventRecorderFor("DefinitionSource")).
+		WithAnnotations("controller", "DefinitionSource")
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.concurrentReconciles,
+		}).
+		For(&v1alpha1.DefinitionSource{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Setup adds a controller that reconciles DefinitionSource if the DefinitionSourceController feature is enabled.
+func Setup(mgr ctrl.Manager, args oamctrl.Args) error {
+	if !utilfeature.DefaultMutableFeatureGate.Enabled(features.DefinitionSourceController) {
+		return nil
+	}
+	r := Reconciler{
+		Client:               mgr.GetClient(),
+		fetcher:              NewFetcher(),
+		concurrentReconciles: args.ConcurrentReconciles,
+	}
+	return r.SetupWithManager(mgr)
+}
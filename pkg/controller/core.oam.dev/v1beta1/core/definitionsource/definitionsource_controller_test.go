@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definitionsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+type fakeFetcher struct {
+	artifact *Artifact
+	err      error
+}
+
+func (f *fakeFetcher) Fetch(context.Context, client.Reader, *v1alpha1.DefinitionSource) (*Artifact, error) {
+	return f.artifact, f.err
+}
+
+const hpaYAML = `apiVersion: core.oam.dev/v1beta1
+kind: TraitDefinition
+metadata:
+  name: hpa
+spec:
+  schematic:
+    cue:
+      template: |
+        parameter: max: *10 | int
+`
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	src := &v1alpha1.DefinitionSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "catalog"},
+		Spec: v1alpha1.DefinitionSourceSpec{
+			OCI:   &v1alpha1.OCIDefinitionSource{Repository: "ghcr.io/my-org/definitions"},
+			Prune: true,
+		},
+	}
+	manual := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "manual"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(src, manual).
+		WithStatusSubresource(src).Build()
+	fetcher := &fakeFetcher{artifact: &Artifact{Revision: "sha256:1", Files: map[string][]byte{
+		"scaler.yaml": []byte(scalerYAML),
+		"hpa.yaml":    []byte(hpaYAML),
+	}}}
+	r := &Reconciler{Client: cli, record: event.NewNopRecorder(), fetcher: fetcher}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(src)}
+
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, defaultSyncInterval, result.RequeueAfter)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(src), src))
+	require.Equal(t, "sha256:1", src.Status.Revision)
+	require.Equal(t, []v1alpha1.SyncedDefinition{{Kind: "TraitDefinition", Name: "hpa"}, {Kind: "TraitDefinition", Name: "scaler"}},
+		src.Status.Definitions)
+	require.Equal(t, corev1.ConditionTrue, src.Status.GetCondition(condition.TypeSynced).Status)
+	scaler := &v1beta1.TraitDefinition{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: oam.SystemDefinitionNamespace, Name: "scaler"}, scaler))
+	require.Equal(t, "catalog", scaler.Labels[oam.LabelDefinitionSource])
+	require.Equal(t, "sha256:1", scaler.Annotations[oam.AnnotationDefinitionSourceRevision])
+
+	// hpa is removed from the source and pruned, the definition not synced from the source is kept
+	fetcher.artifact = &Artifact{Revision: "sha256:2", Files: map[string][]byte{"scaler.yaml": []byte(scalerYAML)}}
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(scaler), scaler))
+	require.Equal(t, "sha256:2", scaler.Annotations[oam.AnnotationDefinitionSourceRevision])
+	err = cli.Get(ctx, client.ObjectKey{Namespace: oam.SystemDefinitionNamespace, Name: "hpa"}, &v1beta1.TraitDefinition{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(manual), manual))
+
+	// definitions not synced from the source are not overridden
+	fetcher.artifact.Files["manual.yaml"] = []byte(`apiVersion: core.oam.dev/v1beta1
+kind: TraitDefinition
+metadata:
+  name: manual
+`)
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(src), src))
+	require.Equal(t, "TraitDefinition manual already exists and is not synced from this source",
+		src.Status.GetCondition(condition.TypeSynced).Message)
+	delete(fetcher.artifact.Files, "manual.yaml")
+
+	// a source resolving to no definitions does not prune the synced definitions
+	fetcher.artifact = &Artifact{Revision: "sha256:3", Files: map[string][]byte{"README.md": []byte("# definitions")}}
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(scaler), scaler))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(src), src))
+	cond := src.Status.GetCondition(condition.TypeSynced)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Contains(t, cond.Message, "revision sha256:3 has no definitions")
+	require.Equal(t, "sha256:2", src.Status.Revision)
+
+	fetcher.err = errors.New("registry unavailable")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(src), src))
+	cond = src.Status.GetCondition(condition.TypeSynced)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, "registry unavailable", cond.Message)
+	require.Equal(t, "sha256:2", src.Status.Revision)
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definitionsource
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
+)
+
+// Artifact is the content of a DefinitionSource at a revision
+type Artifact struct {
+	// Revision is the digest of the OCI artifact or the Git commit
+	Revision string
+	// Files are the contents of the files in the artifact by their slash separated path
+	Files map[string][]byte
+}
+
+// Fetcher fetches the artifact of a DefinitionSource
+type Fetcher interface {
+	Fetch(ctx context.Context, cli client.Reader, src *v1alpha1.DefinitionSource) (*Artifact, error)
+}
+
+// NewFetcher returns a Fetcher pulling OCI artifacts and cloning Git repositories
+func NewFetcher() Fetcher {
+	return &sourceFetcher{}
+}
+
+type sourceFetcher struct{}
+
+type credentials struct {
+	username string
+	password string
+}
+
+// Fetch fetches the artifact from the OCI registry or the Git repository of the DefinitionSource
+func (f *sourceFetcher) Fetch(ctx context.Context, cli client.Reader, src *v1alpha1.DefinitionSource) (*Artifact, error) {
+	creds, err := getCredentials(ctx, cli, src)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case src.Spec.OCI != nil && src.Spec.Git != nil:
+		return nil, errors.New("oci and git are exclusive")
+	case src.Spec.OCI != nil:
+		return fetchOCI(ctx, src.Spec.OCI, creds)
+	case src.Spec.Git != nil:
+		return fetchGit(ctx, src.Spec.Git, creds)
+	default:
+		return nil, errors.New("either oci or git must be set")
+	}
+}
+
+func getCredentials(ctx context.Context, cli client.Reader, src *v1alpha1.DefinitionSource) (*credentials, error) {
+	if src.Spec.SecretRef == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: src.Namespace, Name: src.Spec.SecretRef}, secret); err != nil {
+		return nil, fmt.Errorf("cannot get the credentials of the source: %w", err)
+	}
+	return &credentials{username: string(secret.Data["username"]), password: string(secret.Data["password"])}, nil
+}
+
+func fetchOCI(ctx context.Context, source *v1alpha1.OCIDefinitionSource, creds *credentials) (*Artifact, error) {
+	var opts []name.Option
+	if source.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref := source.Repository + ":latest"
+	switch {
+	case source.Digest != "":
+		ref = source.Repository + "@" + source.Digest
+	case source.Tag != "":
+		ref = source.Repository + ":" + source.Tag
+	}
+	reference, err := name.ParseReference(ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %s: %w", ref, err)
+	}
+	auth := authn.Anonymous
+	if creds != nil {
+		auth = &authn.Basic{Username: creds.username, Password: creds.password}
+	}
+	img, err := remote.Image(reference, remote.WithContext(ctx), remote.WithAuth(auth))
+	if err != nil {
+		return nil, fmt.Errorf("cannot pull %s: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the digest of %s: %w", ref, err)
+	}
+	layers := mutate.Extract(img)
+	defer func() { _ = layers.Close() }()
+	files, err := readTar(layers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract %s: %w", ref, err)
+	}
+	return &Artifact{Revision: digest.String(), Files: files}, nil
+}
+
+var (
+	// maxFileSize bounds the size of each file of an artifact
+	maxFileSize int64 = 1 << 20
+	// maxArtifactSize bounds the size of all the files of an artifact together
+	maxArtifactSize int64 = 32 << 20
+	// maxArchiveSize bounds the size of the archive of an OCI artifact, including
+	// the headers and the entries that are not read
+	maxArchiveSize int64 = 64 << 20
+	// maxRepositorySize bounds the size of the objects and the checkout of a Git
+	// repository written to disk
+	maxRepositorySize int64 = 256 << 20
+	// gitFetchTimeout bounds the clone of a Git repository
+	gitFetchTimeout = 5 * time.Minute
+)
+
+// artifactFiles collects the files of an artifact within maxFileSize and maxArtifactSize
+type artifactFiles struct {
+	files map[string][]byte
+	size  int64
+}
+
+func newArtifactFiles() *artifactFiles {
+	return &artifactFiles{files: map[string][]byte{}}
+}
+
+// add reads the file from r, failing once the file or the artifact exceeds its limit
+func (a *artifactFiles) add(name string, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > maxFileSize {
+		return fmt.Errorf("file %s exceeds the limit of %d bytes", name, maxFileSize)
+	}
+	if a.size += int64(len(data)); a.size > maxArtifactSize {
+		return fmt.Errorf("the artifact exceeds the limit of %d bytes", maxArtifactSize)
+	}
+	a.files[name] = data
+	return nil
+}
+
+func readTar(r io.Reader) (map[string][]byte, error) {
+	files := newArtifactFiles()
+	tr := tar.NewReader(io.LimitReader(r, maxArchiveSize))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files.files, nil
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("the archive is truncated or exceeds the limit of %d bytes", maxArchiveSize)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := files.add(strings.TrimPrefix(path.Clean("/"+header.Name), "/"), tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func fetchGit(ctx context.Context, source *v1alpha1.GitDefinitionSource, creds *credentials) (*Artifact, error) {
+	dir, err := os.MkdirTemp("", "definition-source-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithTimeout(ctx, gitFetchTimeout)
+	defer cancel()
+	worktree := newBoundedFS(osfs.New(dir), maxRepositorySize)
+	dot, err := worktree.Chroot(git.GitDirName)
+	if err != nil {
+		return nil, err
+	}
+	storage := filesystem.NewStorage(dot, cache.NewObjectLRUDefault())
+	var auth *githttp.BasicAuth
+	if creds != nil {
+		auth = &githttp.BasicAuth{Username: creds.username, Password: creds.password}
+	}
+
+	var repo *git.Repository
+	if source.Commit != "" {
+		repo, err = fetchGitCommit(ctx, storage, worktree, source, auth)
+	} else {
+		opts := &git.CloneOptions{URL: source.URL, Depth: 1, SingleBranch: true, Tags: git.NoTags}
+		if auth != nil {
+			opts.Auth = auth
+		}
+		switch {
+		case source.Tag != "":
+			opts.ReferenceName = plumbing.NewTagReferenceName(source.Tag)
+		case source.Branch != "":
+			opts.ReferenceName = plumbing.NewBranchReferenceName(source.Branch)
+		}
+		repo, err = git.CloneContext(ctx, storage, worktree, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot clone %s: %w", source.URL, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	files, err := readDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Artifact{Revision: head.Hash().String(), Files: files}, nil
+}
+
+// fetchGitCommit fetches the pinned commit alone and checks it out. Servers which do not allow fetching a commit
+// by its hash, and abbreviated hashes, fall back to the history of the branch of the source.
+func fetchGitCommit(ctx context.Context, storage *filesystem.Storage, worktree billy.Filesystem, source *v1alpha1.GitDefinitionSource, auth *githttp.BasicAuth) (*git.Repository, error) {
+	repo, err := git.Init(storage, worktree)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{source.URL}}); err != nil {
+		return nil, err
+	}
+	opts := &git.FetchOptions{RemoteName: git.DefaultRemoteName, Depth: 1, Tags: git.NoTags}
+	if auth != nil {
+		opts.Auth = auth
+	}
+	pinned := config.RefSpec(source.Commit + ":refs/heads/pinned")
+	if pinned.IsExactSHA1() {
+		opts.RefSpecs = []config.RefSpec{pinned}
+		err = repo.FetchContext(ctx, opts)
+	}
+	if !pinned.IsExactSHA1() || errors.Is(err, git.ErrExactSHA1NotSupported) {
+		branch := "*"
+		if source.Branch != "" {
+			branch = source.Branch
+		}
+		opts.Depth = 0
+		opts.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, git.DefaultRemoteName, branch))}
+		err = repo.FetchContext(ctx, opts)
+	}
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(source.Commit))
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve commit %s: %w", source.Commit, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: *hash}); err != nil {
+		return nil, fmt.Errorf("cannot checkout commit %s: %w", source.Commit, err)
+	}
+	return repo, nil
+}
+
+// boundedFS fails the writes once the files written through it, and the file systems chrooted from it, exceed
+// the limit, so that cloning a huge repository cannot fill the disk of the controller.
+type boundedFS struct {
+	billy.Filesystem
+	written *atomic.Int64
+	limit   int64
+}
+
+func newBoundedFS(fs billy.Filesystem, limit int64) *boundedFS {
+	return &boundedFS{Filesystem: fs, written: &atomic.Int64{}, limit: limit}
+}
+
+func (b *boundedFS) Create(filename string) (billy.File, error) {
+	return b.wrap(b.Filesystem.Create(filename))
+}
+
+func (b *boundedFS) OpenFile(filename string, flag int, perm os.FileMode) (billy.File, error) {
+	return b.wrap(b.Filesystem.OpenFile(filename, flag, perm))
+}
+
+func (b *boundedFS) TempFile(dir, prefix string) (billy.File, error) {
+	return b.wrap(b.Filesystem.TempFile(dir, prefix))
+}
+
+func (b *boundedFS) Chroot(path string) (billy.Filesystem, error) {
+	fs, err := b.Filesystem.Chroot(path)
+	if err != nil {
+		return nil, err
+	}
+	return &boundedFS{Filesystem: fs, written: b.written, limit: b.limit}, nil
+}
+
+func (b *boundedFS) wrap(file billy.File, err error) (billy.File, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &boundedFile{File: file, fs: b}, nil
+}
+
+type boundedFile struct {
+	billy.File
+	fs *boundedFS
+}
+
+func (f *boundedFile) Write(p []byte) (int, error) {
+	if f.fs.written.Add(int64(len(p))) > f.fs.limit {
+		return 0, fmt.Errorf("the repository exceeds the limit of %d bytes", f.fs.limit)
+	}
+	return f.File.Write(p)
+}
+
+func readDir(dir string) (map[string][]byte, error) {
+	files := newArtifactFiles()
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(filepath.Clean(p))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		return files.add(filepath.ToSlash(rel), f)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files.files, nil
+}
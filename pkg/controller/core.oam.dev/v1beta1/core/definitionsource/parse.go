@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definitionsource
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/pkg/definition/validation"
+)
+
+// ParseDefinitions parses the definitions in the YAML manifests and the CUE files under the directory.
+// Manifests of other kinds are ignored.
+func ParseDefinitions(files map[string][]byte, dir string) ([]*unstructured.Unstructured, error) {
+	dir = strings.Trim(path.Clean("/"+dir), "/")
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		if dir == "" || strings.HasPrefix(p, dir+"/") {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var defs []*unstructured.Unstructured
+	seen := map[string]string{}
+	for _, p := range paths {
+		var parsed []*unstructured.Unstructured
+		var err error
+		switch path.Ext(p) {
+		case ".yaml", ".yml":
+			parsed, err = validation.ParseYAML(files[p])
+		case ".cue":
+			parsed, err = validation.ParseCUE(files[p])
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", p, err)
+		}
+		for _, def := range parsed {
+			key := def.GetKind() + "/" + def.GetName()
+			if previous, found := seen[key]; found {
+				return nil, fmt.Errorf("%s is defined in both %s and %s", key, previous, p)
+			}
+			seen[key] = p
+			defs = append(defs, def)
+		}
+	}
+	return defs, nil
+}
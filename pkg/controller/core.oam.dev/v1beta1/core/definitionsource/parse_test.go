@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definitionsource
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/stretchr/testify/require"
+)
+
+const scalerYAML = `apiVersion: core.oam.dev/v1beta1
+kind: TraitDefinition
+metadata:
+  name: scaler
+spec:
+  schematic:
+    cue:
+      template: |
+        parameter: replicas: *1 | int
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-definition
+`
+
+const gatewayCUE = `gateway: {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Expose the component"
+	attributes: appliesToWorkloads: ["deployments.apps"]
+}
+template: {
+	parameter: domain: string
+}
+`
+
+func TestParseDefinitions(t *testing.T) {
+	files := map[string][]byte{
+		"definitions/scaler.yaml":  []byte(scalerYAML),
+		"definitions/gateway.cue":  []byte(gatewayCUE),
+		"definitions/README.md":    []byte("# definitions"),
+		"examples/app.yaml":        []byte("apiVersion: core.oam.dev/v1beta1\nkind: Application\nmetadata:\n  name: app\n"),
+		"definitions/empty.yml":    []byte("---\n"),
+		"other/scaler-source.yaml": []byte(scalerYAML),
+	}
+	defs, err := ParseDefinitions(files, "./definitions/")
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	require.Equal(t, "TraitDefinition", defs[0].GetKind())
+	require.Equal(t, "gateway", defs[0].GetName())
+	require.Equal(t, "TraitDefinition", defs[1].GetKind())
+	require.Equal(t, "scaler", defs[1].GetName())
+
+	// the same definition is found in two files from the root directory
+	_, err = ParseDefinitions(files, "")
+	require.ErrorContains(t, err, "TraitDefinition/scaler is defined in both")
+
+	_, err = ParseDefinitions(map[string][]byte{"broken.cue": []byte("abc:]{xa}")}, "")
+	require.ErrorContains(t, err, "cannot parse broken.cue")
+}
+
+func TestReadTar(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./definitions/", Typeflag: tar.TypeDir, Mode: 0755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./definitions/scaler.yaml", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(scalerYAML))}))
+	_, err := tw.Write([]byte(scalerYAML))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	files, err := readTar(buf)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"definitions/scaler.yaml": []byte(scalerYAML)}, files)
+}
+
+func TestReadArtifactLimits(t *testing.T) {
+	fileSize, artifactSize := maxFileSize, maxArtifactSize
+	defer func() { maxFileSize, maxArtifactSize = fileSize, artifactSize }()
+	maxFileSize, maxArtifactSize = 8, 12
+
+	writeTar := func(files ...string) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for i, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: fmt.Sprintf("def-%d.cue", i), Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return buf
+	}
+	_, err := readTar(writeTar("123456789"))
+	require.ErrorContains(t, err, "file def-0.cue exceeds the limit of 8 bytes")
+	_, err = readTar(writeTar("1234567", "1234567"))
+	require.ErrorContains(t, err, "the artifact exceeds the limit of 12 bytes")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.cue"), []byte("1234567"), 0600))
+	files, err := readDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"a.cue": []byte("1234567")}, files)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.cue"), []byte("1234567"), 0600))
+	_, err = readDir(dir)
+	require.ErrorContains(t, err, "the artifact exceeds the limit of 12 bytes")
+}
+
+func TestBoundedFS(t *testing.T) {
+	fs := newBoundedFS(osfs.New(t.TempDir()), 12)
+	f, err := fs.Create("a")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("1234567"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// the file systems chrooted from it share the limit
+	dot, err := fs.Chroot(".git")
+	require.NoError(t, err)
+	tmp, err := dot.TempFile("", "pack-")
+	require.NoError(t, err)
+	defer func() { _ = tmp.Close() }()
+	_, err = tmp.Write([]byte("1234567"))
+	require.ErrorContains(t, err, "the repository exceeds the limit of 12 bytes")
+}
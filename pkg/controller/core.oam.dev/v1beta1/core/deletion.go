@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// ValidateDefinitionDeletion returns an error if Applications still reference the definition,
+// unless the definition carries the force-delete annotation.
+func ValidateDefinitionDeletion(ctx context.Context, cli client.Reader, def client.Object) error {
+	if def.GetAnnotations()[oam.AnnotationForceDelete] == "true" {
+		return nil
+	}
+	usage, err := ComputeDefinitionUsage(ctx, cli, def)
+	if err != nil {
+		return err
+	}
+	if usage.Applications == 0 {
+		return nil
+	}
+	defType, _ := definitionTypeOf(def)
+	apps := make([]string, 0, len(usage.Samples))
+	for _, ref := range usage.Samples {
+		apps = append(apps, ref.Namespace+"/"+ref.Name)
+	}
+	return fmt.Errorf("%sDefinition %s is still referenced by %d application(s), e.g. %s; remove the references or set the %s=\"true\" annotation to delete it anyway",
+		defType, def.GetName(), usage.Applications, strings.Join(apps, ", "), oam.AnnotationForceDelete)
+}
+
+// ReconcileDeletionProtection keeps the in-use finalizer on the definition while protection is enabled
+// and removes it once a deleted definition is not referenced anymore. It returns true if the definition
+// is being deleted, in which case nothing else should be reconciled.
+func ReconcileDeletionProtection(ctx context.Context, cli client.Client, record event.Recorder, def client.Object, enabled bool) (bool, error) {
+	if def.GetDeletionTimestamp() == nil {
+		if enabled && controllerutil.AddFinalizer(def, oam.FinalizerDefinitionInUse) {
+			return false, cli.Update(ctx, def)
+		}
+		return false, nil
+	}
+	if !controllerutil.ContainsFinalizer(def, oam.FinalizerDefinitionInUse) {
+		return true, nil
+	}
+	if enabled {
+		if err := ValidateDefinitionDeletion(ctx, cli, def); err != nil {
+			klog.InfoS("Hold the deletion of the definition", "definition", klog.KObj(def), "reason", err.Error())
+			record.Event(def, event.Warning("Deletion is blocked", err))
+			// the definition is enqueued again when the referencing applications change
+			return true, nil
+		}
+	}
+	controllerutil.RemoveFinalizer(def, oam.FinalizerDefinitionInUse)
+	return true, cli.Update(ctx, def)
+}
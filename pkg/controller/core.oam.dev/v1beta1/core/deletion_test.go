@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestValidateDefinitionDeletion(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).
+		WithObjects(usageTestApp("default", "app", "worker", "scaler")).Build()
+	ctx := context.Background()
+
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	err := ValidateDefinitionDeletion(ctx, cli, trait)
+	require.ErrorContains(t, err, "TraitDefinition scaler is still referenced by 1 application(s), e.g. default/app")
+
+	trait.SetAnnotations(map[string]string{oam.AnnotationForceDelete: "true"})
+	require.NoError(t, ValidateDefinitionDeletion(ctx, cli, trait))
+
+	comp := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "webservice"}}
+	require.NoError(t, ValidateDefinitionDeletion(ctx, cli, comp))
+}
+
+func TestReconcileDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	app := usageTestApp("default", "app", "worker")
+	comp := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "worker"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(app, comp).Build()
+
+	deleting, err := ReconcileDeletionProtection(ctx, cli, event.NewNopRecorder(), comp, true)
+	require.NoError(t, err)
+	require.False(t, deleting)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.Contains(t, comp.Finalizers, oam.FinalizerDefinitionInUse)
+
+	// the deletion is held while the application references the definition
+	require.NoError(t, cli.Delete(ctx, comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	deleting, err = ReconcileDeletionProtection(ctx, cli, event.NewNopRecorder(), comp, true)
+	require.NoError(t, err)
+	require.True(t, deleting)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+
+	require.NoError(t, cli.Delete(ctx, app))
+	deleting, err = ReconcileDeletionProtection(ctx, cli, event.NewNopRecorder(), comp, true)
+	require.NoError(t, err)
+	require.True(t, deleting)
+	require.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(comp), comp)))
+}
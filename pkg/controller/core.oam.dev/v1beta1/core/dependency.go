@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// DefinitionDependency is a definition required by another definition
+type DefinitionDependency struct {
+	Type common.DefinitionType
+	Name string
+}
+
+// String returns the dependency in the format of the requires annotation
+func (d DefinitionDependency) String() string {
+	return string(d.Type) + "/" + d.Name
+}
+
+// ParseDefinitionDependencies parses the dependencies declared by the requires annotation of the definition.
+func ParseDefinitionDependencies(def client.Object) ([]DefinitionDependency, error) {
+	var deps []DefinitionDependency
+	for _, item := range strings.Split(def.GetAnnotations()[oam.AnnotationDefinitionRequires], ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		typ, name, found := strings.Cut(item, "/")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid dependency %q: expect <type>/<name>", item)
+		}
+		dep := DefinitionDependency{Type: common.DefinitionType(typ), Name: name}
+		if _, err := newDefinitionOfType(dep.Type); err != nil {
+			return nil, fmt.Errorf("invalid dependency %q: %w", item, err)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// ResolveDefinitionDependencies checks that the definitions required by the definition exist, either
+// in the namespace of the definition or in the system definition namespace. It returns an error listing
+// the missing definitions.
+func ResolveDefinitionDependencies(ctx context.Context, cli client.Reader, def client.Object) error {
+	deps, err := ParseDefinitionDependencies(def)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, dep := range deps {
+		found, err := definitionExists(ctx, cli, dep, def.GetNamespace())
+		if err != nil {
+			return fmt.Errorf("cannot get the required definition %s: %w", dep, err)
+		}
+		if !found {
+			missing = append(missing, dep.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required definitions: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ReconcileDependenciesCondition resolves the dependencies of the definition and patches its
+// DependenciesResolved condition if it changed. It returns false if some dependencies are unresolved.
+// Definitions which never declared dependencies are left untouched.
+func ReconcileDependenciesCondition(ctx context.Context, cli client.Client, record event.Recorder, def util.ConditionedObject) (bool, error) {
+	if _, declared := def.GetAnnotations()[oam.AnnotationDefinitionRequires]; !declared &&
+		def.GetCondition(condition.TypeDependenciesResolved).Status == corev1.ConditionUnknown {
+		return true, nil
+	}
+	resolveErr := ResolveDefinitionDependencies(ctx, cli, def)
+	cond := condition.DependenciesResolved()
+	if resolveErr != nil {
+		var statusErr apierrors.APIStatus
+		if errors.As(resolveErr, &statusErr) {
+			return false, resolveErr
+		}
+		cond = condition.DependenciesUnresolved(resolveErr)
+	}
+	if !util.IsConditionChanged([]condition.Condition{cond}, def) {
+		return resolveErr == nil, nil
+	}
+	if resolveErr != nil {
+		klog.InfoS("The dependencies of the definition are unresolved", "definition", klog.KObj(def), "err", resolveErr)
+		record.Event(def, event.Warning("Dependencies are unresolved", resolveErr))
+	}
+	return resolveErr == nil, util.PatchCondition(ctx, cli, def, cond)
+}
+
+func definitionExists(ctx context.Context, cli client.Reader, dep DefinitionDependency, namespace string) (bool, error) {
+	namespaces := []string{namespace}
+	if namespace != oam.SystemDefinitionNamespace {
+		namespaces = append(namespaces, oam.SystemDefinitionNamespace)
+	}
+	for _, ns := range namespaces {
+		obj, err := newDefinitionOfType(dep.Type)
+		if err != nil {
+			return false, err
+		}
+		err = cli.Get(ctx, types.NamespacedName{Namespace: ns, Name: dep.Name}, obj)
+		if err == nil {
+			return true, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func newDefinitionOfType(defType common.DefinitionType) (client.Object, error) {
+	switch defType {
+	case common.ComponentType:
+		return &v1beta1.ComponentDefinition{}, nil
+	case common.TraitType:
+		return &v1beta1.TraitDefinition{}, nil
+	case common.PolicyType:
+		return &v1beta1.PolicyDefinition{}, nil
+	case common.WorkflowStepType:
+		return &v1beta1.WorkflowStepDefinition{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported definition type %q", defType)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestParseDefinitionDependencies(t *testing.T) {
+	trait := &v1beta1.TraitDefinition{}
+	deps, err := ParseDefinitionDependencies(trait)
+	require.NoError(t, err)
+	require.Empty(t, deps)
+
+	trait.SetAnnotations(map[string]string{oam.AnnotationDefinitionRequires: "Component/webservice, Trait/gateway,"})
+	deps, err = ParseDefinitionDependencies(trait)
+	require.NoError(t, err)
+	require.Equal(t, []DefinitionDependency{
+		{Type: common.ComponentType, Name: "webservice"},
+		{Type: common.TraitType, Name: "gateway"},
+	}, deps)
+
+	for _, requires := range []string{"webservice", "Component/", "Workload/deployment"} {
+		trait.SetAnnotations(map[string]string{oam.AnnotationDefinitionRequires: requires})
+		_, err = ParseDefinitionDependencies(trait)
+		require.Error(t, err, requires)
+	}
+}
+
+func TestReconcileDependenciesCondition(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hpa"}}
+	webservice := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "webservice"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait, webservice).
+		WithStatusSubresource(trait).Build()
+
+	// definitions without dependencies have no condition
+	resolved, err := ReconcileDependenciesCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.True(t, resolved)
+	require.Empty(t, trait.Status.Conditions)
+
+	trait.SetAnnotations(map[string]string{oam.AnnotationDefinitionRequires: "Component/webservice,Trait/gateway"})
+	require.NoError(t, cli.Update(ctx, trait))
+	resolved, err = ReconcileDependenciesCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.False(t, resolved)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	cond := trait.Status.GetCondition(condition.TypeDependenciesResolved)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, "missing required definitions: Trait/gateway", cond.Message)
+
+	gateway := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"}}
+	require.NoError(t, cli.Create(ctx, gateway))
+	resolved, err = ReconcileDependenciesCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.True(t, resolved)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, corev1.ConditionTrue, trait.Status.GetCondition(condition.TypeDependenciesResolved).Status)
+	require.Equal(t, "Component/webservice,Trait/gateway", trait.GetAnnotations()[oam.AnnotationDefinitionRequires])
+
+	// the namespace of the definition does not provide definitions to the system namespace
+	system := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "system",
+		Annotations: map[string]string{oam.AnnotationDefinitionRequires: "Trait/gateway"}}}
+	require.Error(t, ResolveDefinitionDependencies(ctx, cli, system))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// defaultDeprecationMessage is the message of the definitions deprecated without a deprecation message
+const defaultDeprecationMessage = "the definition is deprecated"
+
+// IsDefinitionDeprecated returns true and the deprecation message if the definition carries the deprecated annotation.
+func IsDefinitionDeprecated(def client.Object) (bool, string) {
+	annotations := def.GetAnnotations()
+	if annotations[oam.AnnotationDefinitionDeprecated] != "true" {
+		return false, ""
+	}
+	if msg := annotations[oam.AnnotationDefinitionDeprecationMessage]; msg != "" {
+		return true, msg
+	}
+	return true, defaultDeprecationMessage
+}
+
+// ReconcileDeprecatedCondition patches the Deprecated condition of the definition from its deprecation
+// annotations, which is read by the application webhook and the CLI. A definition that was deprecated
+// before gets a not deprecated condition, others are left untouched.
+func ReconcileDeprecatedCondition(ctx context.Context, cli client.StatusClient, record event.Recorder, def util.ConditionedObject) error {
+	deprecated, msg := IsDefinitionDeprecated(def)
+	cond := condition.Deprecated(msg)
+	if !deprecated {
+		if def.GetCondition(condition.TypeDeprecated).Status != corev1.ConditionTrue {
+			return nil
+		}
+		cond = condition.NotDeprecated()
+	}
+	if !util.IsConditionChanged([]condition.Condition{cond}, def) {
+		return nil
+	}
+	if deprecated {
+		klog.InfoS("The definition is deprecated", "definition", klog.KObj(def), "message", msg)
+		record.Event(def, event.Normal("Deprecated", msg))
+	}
+	return util.PatchCondition(ctx, cli, def, cond)
+}
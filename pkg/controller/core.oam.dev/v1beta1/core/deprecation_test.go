@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestReconcileDeprecatedCondition(t *testing.T) {
+	ctx := context.Background()
+	comp := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "worker"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(comp).
+		WithStatusSubresource(comp).Build()
+
+	// definitions never deprecated have no condition
+	require.NoError(t, ReconcileDeprecatedCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.Empty(t, comp.Status.Conditions)
+
+	comp.SetAnnotations(map[string]string{oam.AnnotationDefinitionDeprecated: "true"})
+	require.NoError(t, ReconcileDeprecatedCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	cond := comp.Status.GetCondition(condition.TypeDeprecated)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Equal(t, defaultDeprecationMessage, cond.Message)
+
+	comp.SetAnnotations(map[string]string{
+		oam.AnnotationDefinitionDeprecated:         "true",
+		oam.AnnotationDefinitionDeprecationMessage: "use webservice instead",
+	})
+	require.NoError(t, ReconcileDeprecatedCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.Equal(t, "use webservice instead", comp.Status.GetCondition(condition.TypeDeprecated).Message)
+
+	comp.SetAnnotations(nil)
+	require.NoError(t, ReconcileDeprecatedCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	cond = comp.Status.GetCondition(condition.TypeDeprecated)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonDefinitionSupported, cond.Reason)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+)
+
+// NewEventRecorder wraps record to drop the identical events of an object past
+// args.Burst within args.Interval, so that a definition failing on every retry
+// does not flood the events. The number of dropped events is appended to the
+// message of the first identical event after args.Interval. Empty args return
+// record unchanged.
+func NewEventRecorder(record event.Recorder, args oamctrl.EventAggregatorArgs) event.Recorder {
+	if args.Interval <= 0 || args.Burst <= 0 {
+		return record
+	}
+	return &aggregatingRecorder{record: record, events: &eventAggregator{
+		args:    args,
+		now:     time.Now,
+		entries: map[eventKey]*eventEntry{},
+	}}
+}
+
+// aggregatingRecorder records the events let through by its aggregator, which
+// is shared with the recorders returned by WithAnnotations.
+type aggregatingRecorder struct {
+	record event.Recorder
+	events *eventAggregator
+}
+
+// Event records e unless too many identical events of obj were recorded.
+func (r *aggregatingRecorder) Event(obj runtime.Object, e event.Event) {
+	if e, ok := r.events.admit(obj, e); ok {
+		r.record.Event(obj, e)
+	}
+}
+
+// WithAnnotations returns a recorder adding the annotations to the events,
+// which shares the aggregation of the events with r.
+func (r *aggregatingRecorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return &aggregatingRecorder{record: r.record.WithAnnotations(keysAndValues...), events: r.events}
+}
+
+// eventKey identifies the identical events of an object.
+type eventKey struct {
+	object  string
+	typ     event.Type
+	reason  event.Reason
+	message string
+}
+
+// eventEntry counts the identical events of an object since start.
+type eventEntry struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// eventAggregator counts the identical events of each object within the
+// interval of its args.
+type eventAggregator struct {
+	args      oamctrl.EventAggregatorArgs
+	now       func() time.Time
+	mu        sync.Mutex
+	entries   map[eventKey]*eventEntry
+	lastPrune time.Time
+}
+
+// admit counts e and returns whether it is recorded, with the number of
+// identical events dropped in the previous interval appended to its message.
+func (a *eventAggregator) admit(obj runtime.Object, e event.Event) (event.Event, bool) {
+	key := eventKey{object: objectKey(obj), typ: e.Type, reason: e.Reason, message: e.Message}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	a.prune(now)
+
+	entry, found := a.entries[key]
+	if found && now.Sub(entry.start) < a.args.Interval {
+		entry.count++
+		if entry.count > a.args.Burst {
+			entry.dropped++
+			return e, false
+		}
+		return e, true
+	}
+	if found && entry.dropped > 0 {
+		e.Message = fmt.Sprintf("%s (%d identical events dropped in the last %s)", e.Message, entry.dropped, a.args.Interval)
+	}
+	a.entries[key] = &eventEntry{start: now, count: 1}
+	return e, true
+}
+
+// prune forgets the entries whose interval expired, at most once per
+// interval. The events they dropped are not reported if the event does not
+// occur again before the entries are pruned.
+func (a *eventAggregator) prune(now time.Time) {
+	if now.Sub(a.lastPrune) < a.args.Interval {
+		return
+	}
+	a.lastPrune = now
+	for key, entry := range a.entries {
+		if now.Sub(entry.start) >= 2*a.args.Interval {
+			delete(a.entries, key)
+		}
+	}
+}
+
+// objectKey identifies the object of an event.
+func objectKey(obj runtime.Object) string {
+	o, err := meta.Accessor(obj)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	if uid := o.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return o.GetNamespace() + "/" + o.GetName()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+)
+
+type recordedEvents struct {
+	events      *[]event.Event
+	annotations []string
+}
+
+func (r recordedEvents) Event(_ runtime.Object, e event.Event) {
+	*r.events = append(*r.events, e)
+}
+
+func (r recordedEvents) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return recordedEvents{events: r.events, annotations: append(r.annotations, keysAndValues...)}
+}
+
+func TestEventRecorder(t *testing.T) {
+	var events []event.Event
+	base := recordedEvents{events: &events}
+	require.Equal(t, base, NewEventRecorder(base, oamctrl.EventAggregatorArgs{}))
+
+	now := time.Now()
+	record := NewEventRecorder(base, oamctrl.EventAggregatorArgs{Interval: time.Minute, Burst: 2})
+	record.(*aggregatingRecorder).events.now = func() time.Time { return now }
+	annotated := record.WithAnnotations("controller", "TraitDefinition")
+
+	scaler := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler", UID: "1"}}
+	gateway := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway", UID: "2"}}
+	failure := event.Warning("Could not store capability in ConfigMap", errors.New("conflict"))
+	for i := 0; i < 5; i++ {
+		annotated.Event(scaler, failure)
+	}
+	record.Event(gateway, failure)
+	record.Event(scaler, event.Normal("DefinitionRevisionCreated", "scaler-v2"))
+	require.Len(t, events, 4)
+
+	now = now.Add(time.Minute)
+	record.Event(scaler, failure)
+	require.Len(t, events, 5)
+	require.Equal(t, "conflict (3 identical events dropped in the last 1m0s)", events[4].Message)
+	record.Event(scaler, failure)
+	require.Len(t, events, 6)
+	require.Equal(t, "conflict", events[5].Message)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// IsDefinitionPaused returns true if the definition carries the paused annotation.
+func IsDefinitionPaused(def client.Object) bool {
+	return def.GetAnnotations()[oam.AnnotationDefinitionPaused] == "true"
+}
+
+// ReconcilePausedCondition patches the Paused condition of the definition. A definition that was paused
+// before gets a resumed condition, others are left untouched. It returns true if the definition is paused,
+// in which case nothing else should be reconciled.
+func ReconcilePausedCondition(ctx context.Context, cli client.StatusClient, record event.Recorder, def util.ConditionedObject) (bool, error) {
+	paused := IsDefinitionPaused(def)
+	cond := condition.Paused()
+	if !paused {
+		if def.GetCondition(condition.TypePaused).Status != corev1.ConditionTrue {
+			return false, nil
+		}
+		cond = condition.Resumed()
+	}
+	if !util.IsConditionChanged([]condition.Condition{cond}, def) {
+		return paused, nil
+	}
+	if paused {
+		klog.InfoS("The reconciliation of the definition is paused", "definition", klog.KObj(def))
+		record.Event(def, event.Normal("Paused", "The reconciliation is paused by the "+oam.AnnotationDefinitionPaused+" annotation"))
+	} else {
+		klog.InfoS("The reconciliation of the definition is resumed", "definition", klog.KObj(def))
+		record.Event(def, event.Normal("Resumed", "The reconciliation is resumed"))
+	}
+	return paused, util.PatchCondition(ctx, cli, def, cond)
+}
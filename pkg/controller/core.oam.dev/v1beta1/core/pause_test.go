@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestReconcilePausedCondition(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+
+	// definitions never paused have no condition
+	paused, err := ReconcilePausedCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.False(t, paused)
+	require.Empty(t, trait.Status.Conditions)
+
+	trait.SetAnnotations(map[string]string{oam.AnnotationDefinitionPaused: "true"})
+	paused, err = ReconcilePausedCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.True(t, paused)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, corev1.ConditionTrue, trait.Status.GetCondition(condition.TypePaused).Status)
+
+	trait.SetAnnotations(nil)
+	paused, err = ReconcilePausedCondition(ctx, cli, event.NewNopRecorder(), trait)
+	require.NoError(t, err)
+	require.False(t, paused)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	cond := trait.Status.GetCondition(condition.TypePaused)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonReconcileResumed, cond.Reason)
+}
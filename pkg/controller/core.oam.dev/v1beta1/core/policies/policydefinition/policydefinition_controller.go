@@ -20,24 +20,23 @@ package policydefinition
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	ctrlrec "github.com/kubevela/pkg/controller/reconciler"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/util/retry"
-	"k8s.io/klog/v2"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
-	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
 	"github.com/oam-dev/kubevela/pkg/controller/utils"
-	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/version"
 )
 
@@ -50,97 +49,98 @@ type Reconciler struct {
 	concurrentReconciles int
 	ignoreDefNoCtrlReq   bool
 	controllerVersion    string
+	deletionProtection   bool
+	rateLimiter          workqueue.TypedRateLimiter[reconcile.Request]
+	eventAggregator      oamctrl.EventAggregatorArgs
+	shardSelector        labels.Selector
+	verifier             *signature.Verifier
 }
 
 // Reconcile is the main logic for PolicyDefinition controller
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.definitionReconciler().Reconcile(ctx, req)
+}
 
-	ctx, cancel := ctrlrec.NewReconcileContext(ctx)
-	defer cancel()
-
-	definitionName := req.NamespacedName.Name
-	klog.InfoS("Reconciling PolicyDefinition...", "Name", definitionName, "Namespace", req.Namespace)
-	var policyDefinition v1beta1.PolicyDefinition
-	if err := r.Get(ctx, req.NamespacedName, &policyDefinition); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	// this is a placeholder for finalizer here in the future
-	if policyDefinition.DeletionTimestamp != nil {
-		return ctrl.Result{}, nil
-	}
-
-	if !coredef.MatchControllerRequirement(&policyDefinition, r.controllerVersion, r.ignoreDefNoCtrlReq) {
-		klog.InfoS("skip definition: not match the controller requirement of definition", "policyDefinition", klog.KObj(&policyDefinition))
-		return ctrl.Result{}, nil
-	}
-
-	defRev, result, err := coredef.ReconcileDefinitionRevision(ctx, r.Client, r.record, &policyDefinition, r.defRevLimit, func(revision *common.Revision) error {
-		policyDefinition.Status.LatestRevision = revision
-		return r.UpdateStatus(ctx, &policyDefinition)
-	})
-	if result != nil {
-		return *result, err
-	}
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	def := utils.NewCapabilityPolicyDef(&policyDefinition)
-	def.Name = req.NamespacedName.Name
-	// Store the parameter of policyDefinition to configMap
-	cmName, err := def.StoreOpenAPISchema(ctx, r.Client, req.Namespace, req.Name, defRev.Name)
-	if err != nil {
-		klog.InfoS("Could not capability in ConfigMap", "err", err)
-		r.record.Event(&(policyDefinition), event.Warning("Could not store capability in ConfigMap", err))
-		return ctrl.Result{}, util.PatchCondition(ctx, r, &(policyDefinition),
-			condition.ReconcileError(fmt.Errorf(util.ErrStoreCapabilityInConfigMap, def.Name, err)))
-	}
-
-	if policyDefinition.Status.ConfigMapRef != cmName {
-		policyDefinition.Status.ConfigMapRef = cmName
-		// Override the conditions, which maybe include the error info.
-		policyDefinition.Status.Conditions = []condition.Condition{condition.ReconcileSuccess()}
-
-		if err := r.UpdateStatus(ctx, &policyDefinition); err != nil {
-			klog.InfoS("Could not update policyDefinition Details", "err", err)
-			r.record.Event(&policyDefinition, event.Warning("cannot update PolicyDefinition Details", err))
-			return ctrl.Result{}, util.PatchCondition(ctx, r, &policyDefinition,
-				condition.ReconcileError(fmt.Errorf(util.ErrUpdatePolicyDefinition, policyDefinition.Name, err)))
-		}
-		klog.InfoS("Successfully updated the status.configMapRef of the PolicyDefinition", "policyDefinition",
-			klog.KRef(req.Namespace, req.Name), "status.configMapRef", cmName)
+func (r *Reconciler) definitionReconciler() *coredef.DefinitionReconciler[*v1beta1.PolicyDefinition] {
+	return &coredef.DefinitionReconciler[*v1beta1.PolicyDefinition]{
+		Client:         r.Client,
+		Record:         r.record,
+		DefinitionKind: definitionKind,
+		DefinitionReconcilerOptions: coredef.DefinitionReconcilerOptions{
+			DefRevLimit:        r.defRevLimit,
+			IgnoreDefNoCtrlReq: r.ignoreDefNoCtrlReq,
+			ControllerVersion:  r.controllerVersion,
+			DeletionProtection: r.deletionProtection,
+			ShardSelector:      r.shardSelector,
+			Verifier:           r.verifier,
+		},
 	}
-
-	return ctrl.Result{}, nil
 }
 
-// UpdateStatus updates v1beta1.PolicyDefinition's Status with retry.RetryOnConflict
-func (r *Reconciler) UpdateStatus(ctx context.Context, def *v1beta1.PolicyDefinition, opts ...client.SubResourceUpdateOption) error {
-	status := def.DeepCopy().Status
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
-		if err = r.Get(ctx, client.ObjectKey{Namespace: def.Namespace, Name: def.Name}, def); err != nil {
-			return
+var definitionKind = coredef.DefinitionKind[*v1beta1.PolicyDefinition]{
+	Kind: v1beta1.PolicyDefinitionKind,
+	New: func() *v1beta1.PolicyDefinition {
+		return &v1beta1.PolicyDefinition{}
+	},
+	GetStatus: func(def *v1beta1.PolicyDefinition) coredef.DefinitionStatus {
+		status := def.Status.DeepCopy()
+		return coredef.DefinitionStatus{
+			ConditionedStatus:  status.ConditionedStatus,
+			ConfigMapRef:       status.ConfigMapRef,
+			Schema:             status.Schema,
+			LatestRevision:     status.LatestRevision,
+			LatestRevisionDiff: status.LatestRevisionDiff,
+			Usage:              status.Usage,
+			ObservedGeneration: status.ObservedGeneration,
 		}
-		def.Status = status
-		return r.Status().Update(ctx, def, opts...)
-	})
+	},
+	SetStatus: func(def *v1beta1.PolicyDefinition, status coredef.DefinitionStatus) {
+		def.Status.ConditionedStatus = status.ConditionedStatus
+		def.Status.ConfigMapRef = status.ConfigMapRef
+		def.Status.Schema = status.Schema
+		def.Status.LatestRevision = status.LatestRevision
+		def.Status.LatestRevisionDiff = status.LatestRevisionDiff
+		def.Status.Usage = status.Usage
+		def.Status.ObservedGeneration = status.ObservedGeneration
+	},
+	Capability: func(def *v1beta1.PolicyDefinition) coredef.DefinitionCapability {
+		capability := utils.NewCapabilityPolicyDef(def)
+		return &capability
+	},
 }
 
 // SetupWithManager will setup with event recorder
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.record = event.NewAPIRecorder(mgr.GetEventRecorderFor("PolicyDefinition")).
-		WithAnnotations("controller", "PolicyDefinition")
-	return ctrl.NewControllerManagedBy(mgr).
+	r.record = coredef.NewEventRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor("PolicyDefinition")).
+		WithAnnotations("controller", "PolicyDefinition"), r.eventAggregator)
+	err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.concurrentReconciles,
+			RateLimiter:             r.rateLimiter,
 		}).
-		For(&v1beta1.PolicyDefinition{}).
+		For(&v1beta1.PolicyDefinition{}, builder.WithPredicates(coredef.ShardPredicate(r.shardSelector))).
 		Complete(r)
+	if err != nil {
+		return err
+	}
+	usage := &coredef.DefinitionUsageReconciler[*v1beta1.PolicyDefinition]{
+		Client:         r.Client,
+		DefinitionKind: definitionKind,
+		ShardSelector:  r.shardSelector,
+	}
+	return usage.SetupWithManager(mgr, common.PolicyType, controller.Options{MaxConcurrentReconciles: r.concurrentReconciles})
 }
 
 // Setup adds a controller that reconciles PolicyDefinition.
 func Setup(mgr ctrl.Manager, args oamctrl.Args) error {
+	shardSelector, err := coredef.ParseShardSelector(args.DefinitionShardLabel)
+	if err != nil {
+		return err
+	}
+	verifier, err := signature.LoadVerifier(args.DefinitionSignaturePublicKeys)
+	if err != nil {
+		return err
+	}
 	r := Reconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -148,6 +148,11 @@ func Setup(mgr ctrl.Manager, args oamctrl.Args) error {
 		concurrentReconciles: args.ConcurrentReconciles,
 		ignoreDefNoCtrlReq:   args.IgnoreDefinitionWithoutControllerRequirement,
 		controllerVersion:    version.VelaVersion,
+		deletionProtection:   args.DefinitionDeletionProtection,
+		rateLimiter:          args.DefinitionRateLimiter.NewRateLimiter(),
+		eventAggregator:      args.DefinitionEvents,
+		shardSelector:        shardSelector,
+		verifier:             verifier,
 	}
 	return r.SetupWithManager(mgr)
 }
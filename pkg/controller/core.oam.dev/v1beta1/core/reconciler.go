@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	ctrlrec "github.com/kubevela/pkg/controller/reconciler"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// dependencyRecheckInterval is the interval to check again the unresolved dependencies of a definition
+const dependencyRecheckInterval = time.Minute
+
+// DefinitionStatus is the status shared by all kinds of definitions. Each kind
+// copies the fields of its own status from and to a DefinitionStatus through
+// the GetStatus and SetStatus of its DefinitionKind.
+type DefinitionStatus struct {
+	condition.ConditionedStatus `json:",inline"`
+	ConfigMapRef                string                         `json:"configMapRef,omitempty"`
+	Schema                      *common.DefinitionSchema       `json:"schema,omitempty"`
+	LatestRevision              *common.Revision               `json:"latestRevision,omitempty"`
+	LatestRevisionDiff          *common.DefinitionRevisionDiff `json:"latestRevisionDiff,omitempty"`
+	Usage                       *common.DefinitionUsage        `json:"usage,omitempty"`
+	ObservedGeneration          int64                          `json:"observedGeneration,omitempty"`
+}
+
+func (s *DefinitionStatus) deepCopy() DefinitionStatus {
+	out := *s
+	s.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	out.Schema = s.Schema.DeepCopy()
+	out.LatestRevision = s.LatestRevision.DeepCopy()
+	out.LatestRevisionDiff = s.LatestRevisionDiff.DeepCopy()
+	out.Usage = s.Usage.DeepCopy()
+	return out
+}
+
+// DefinitionCapability generates and stores the OpenAPI schema of a definition.
+type DefinitionCapability interface {
+	StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name, revName string) (string, error)
+	GenerateDefinitionSchema(ctx context.Context, k8sClient client.Client, name string) (*common.DefinitionSchema, error)
+}
+
+// DefinitionKind provides the kind-specific hooks of a DefinitionReconciler.
+type DefinitionKind[T util.ConditionedObject] struct {
+	// Kind of the definition, e.g. TraitDefinition
+	Kind string
+	// New returns an empty definition
+	New func() T
+	// GetStatus returns a deep copy of the status of the definition
+	GetStatus func(def T) DefinitionStatus
+	// SetStatus sets the status of the definition
+	SetStatus func(def T, status DefinitionStatus)
+	// Capability returns the capability of the definition to store its schema
+	Capability func(def T) DefinitionCapability
+}
+
+// DefinitionReconcilerOptions are the options of a DefinitionReconciler.
+type DefinitionReconcilerOptions struct {
+	DefRevLimit        int
+	IgnoreDefNoCtrlReq bool
+	ControllerVersion  string
+	DeletionProtection bool
+	// ShardSelector selects the definitions handled by the controller, nil selects all definitions
+	ShardSelector labels.Selector
+	// Verifier verifies the signatures and the checksums of the definitions before their revisions are created
+	Verifier *signature.Verifier
+}
+
+// DefinitionReconciler reconciles a kind of definition. It generates the
+// revisions and the schema of the definition and keeps its status up to date,
+// except for status.usage which is kept by the DefinitionUsageReconciler.
+//
+// Once a generation of the definition is processed, status.observedGeneration
+// is set to it and the Ready condition tells the outcome: True once its
+// revision and schema are stored, False with the message of the failed phase
+// otherwise. A change is still being processed while status.observedGeneration
+// is behind metadata.generation, e.g. when the definition is paused.
+type DefinitionReconciler[T util.ConditionedObject] struct {
+	client.Client
+	Record event.Recorder
+	DefinitionKind[T]
+	DefinitionReconcilerOptions
+}
+
+// Reconcile is the main logic of the definition controllers
+func (r *DefinitionReconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := ctrlrec.NewReconcileContext(ctx)
+	defer cancel()
+	defer func(begin time.Time) {
+		metrics.DefinitionReconcileTimeHistogram.WithLabelValues(r.Kind, req.Name).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	logKey := strings.ToLower(r.Kind[:1]) + r.Kind[1:]
+	klog.InfoS("Reconcile "+logKey, logKey, klog.KRef(req.Namespace, req.Name))
+
+	def := r.New()
+	if err := r.Get(ctx, req.NamespacedName, def); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The shard labels of the definition may have changed since it was enqueued
+	if !InShard(r.ShardSelector, def) {
+		klog.InfoS("skip definition: not in the shard of the controller", logKey, klog.KObj(def))
+		return ctrl.Result{}, nil
+	}
+	deleting, err := ReconcileDeletionProtection(ctx, r.Client, r.Record, def, r.DeletionProtection)
+	if deleting || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The restored spec is reconciled with the update event of the rollback
+	if rolledBack, err := ReconcileRollback(ctx, r.Client, r.Record, def, r.GetStatus(def).LatestRevision); rolledBack || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused definition stages its edits without creating new revisions
+	if paused, err := ReconcilePausedCondition(ctx, r, r.Record, def); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := ReconcileDeprecatedCondition(ctx, r, r.Record, def); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := ReconcileVisibilityCondition(ctx, r, r.Record, def); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !MatchControllerRequirement(def, r.ControllerVersion, r.IgnoreDefNoCtrlReq) {
+		klog.InfoS("skip definition: not match the controller requirement of definition", logKey, klog.KObj(def))
+		return ctrl.Result{}, nil
+	}
+
+	// Definitions admitted without the webhooks, e.g. while they were unavailable, are verified again
+	if unverified, err := ReconcileSignatureCondition(ctx, r, r.Record, def, r.Verifier); err != nil {
+		return ctrl.Result{}, err
+	} else if unverified {
+		return ctrl.Result{}, r.notReady(ctx, def, condition.TypeTemplateValid)
+	}
+
+	defRev, result, err := ReconcileDefinitionRevision(ctx, r.Client, r.Record, def, r.DefRevLimit, func(revision *common.Revision, diff *common.DefinitionRevisionDiff) error {
+		metrics.DefinitionRevisionCreatedCounter.WithLabelValues(r.Kind, req.Name).Inc()
+		status := r.GetStatus(def)
+		status.LatestRevision = revision
+		status.LatestRevisionDiff = diff
+		r.SetStatus(def, status)
+		return r.UpdateStatus(ctx, def)
+	})
+	if result != nil {
+		if err == nil {
+			err = r.notReady(ctx, def, condition.TypeRevisionReconciled)
+		}
+		return *result, err
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	revisionReconciled := condition.RevisionReconciled(defRev.Name).WithObservedGeneration(def.GetGeneration())
+	if util.IsConditionChanged([]condition.Condition{revisionReconciled}, def) {
+		if err := util.PatchCondition(ctx, r, def, revisionReconciled); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Unresolved dependencies are reported in the DependenciesResolved condition, the definition is
+	// still reconciled and checked again later since the required definitions are not watched
+	resolved, err := ReconcileDependenciesCondition(ctx, r.Client, r.Record, def)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// An invalid template is reported in the TemplateValid condition, its schema cannot be generated
+	if err := ReconcileTemplateCondition(ctx, r, r.Record, def); err != nil {
+		if err := util.PatchCondition(ctx, r, def, condition.SchemaStoreError(err).WithObservedGeneration(def.GetGeneration())); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.notReady(ctx, def, condition.TypeTemplateValid)
+	}
+
+	capability := r.Capability(def)
+	var cmName string
+	var schema *common.DefinitionSchema
+	if utilfeature.DefaultMutableFeatureGate.Enabled(features.StoreDefinitionSchemaInStatus) {
+		// Store the parameter of the definition in the status
+		schema, err = capability.GenerateDefinitionSchema(ctx, r.Client, req.Name)
+	} else {
+		// Store the parameter of the definition to configMap
+		cmName, err = capability.StoreOpenAPISchema(ctx, r.Client, req.Namespace, req.Name, defRev.Name)
+	}
+	if err != nil {
+		metrics.DefinitionSchemaStoreFailuresCounter.WithLabelValues(r.Kind, req.Name).Inc()
+		klog.InfoS("Could not store capability in ConfigMap", "err", err)
+		r.Record.Event(def, event.Warning("Could not store capability in ConfigMap", err))
+		if err := util.PatchCondition(ctx, r, def,
+			condition.SchemaStoreError(fmt.Errorf(util.ErrStoreCapabilityInConfigMap, def.GetName(), err)).
+				WithObservedGeneration(def.GetGeneration())); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.notReady(ctx, def, condition.TypeSchemaStored)
+	}
+
+	// Override the SchemaStored and Ready conditions, which maybe include the error info.
+	processed := []condition.Condition{
+		condition.SchemaStored().WithObservedGeneration(def.GetGeneration()),
+		condition.Available().WithObservedGeneration(def.GetGeneration()),
+	}
+	status := r.GetStatus(def)
+	if status.ConfigMapRef != cmName || !apiequality.Semantic.DeepEqual(status.Schema, schema) ||
+		util.IsConditionChanged(processed, def) || status.ObservedGeneration != def.GetGeneration() ||
+		hasCondition(status.ConditionedStatus, condition.TypeSynced) {
+		status.ConfigMapRef = cmName
+		status.Schema = schema
+		status.ObservedGeneration = def.GetGeneration()
+		status.SetConditions(processed...)
+		// The Synced condition set by former releases is replaced by the conditions of each phase
+		status.Conditions = removeCondition(status.Conditions, condition.TypeSynced)
+		r.SetStatus(def, status)
+		if err := r.UpdateStatus(ctx, def); err != nil {
+			klog.ErrorS(err, "Could not update "+r.Kind+" Status", logKey, klog.KRef(req.Namespace, req.Name))
+			r.Record.Event(def, event.Warning(event.Reason("Could not update "+r.Kind+" Status"), err))
+			if err := util.PatchCondition(ctx, r, def,
+				condition.SchemaStoreError(fmt.Errorf("cannot update %s %s: %w", r.Kind, def.GetName(), err)).
+					WithObservedGeneration(def.GetGeneration())); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, r.notReady(ctx, def, condition.TypeSchemaStored)
+		}
+		klog.InfoS("Successfully updated the status.configMapRef of the "+r.Kind, logKey,
+			klog.KRef(req.Namespace, req.Name), "status.configMapRef", cmName)
+	}
+
+	if !resolved {
+		return ctrl.Result{RequeueAfter: dependencyRecheckInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// notReady sets the Ready condition of the definition to False with the message
+// of the condition of the failed phase, and marks the generation of the
+// definition as observed, so that the failure of a change is not mistaken for
+// its processing being in progress.
+func (r *DefinitionReconciler[T]) notReady(ctx context.Context, def T, phase condition.ConditionType) error {
+	failed := def.GetCondition(phase)
+	ready := condition.Unavailable().WithMessage(fmt.Sprintf("%s: %s", phase, failed.Message)).
+		WithObservedGeneration(def.GetGeneration())
+	status := r.GetStatus(def)
+	if status.ObservedGeneration == def.GetGeneration() && !util.IsConditionChanged([]condition.Condition{ready}, def) {
+		return nil
+	}
+	patch := client.MergeFrom(def.DeepCopyObject().(client.Object))
+	status.SetConditions(ready)
+	status.ObservedGeneration = def.GetGeneration()
+	r.SetStatus(def, status)
+	return r.Status().Patch(ctx, def, patch, client.FieldOwner(def.GetUID()))
+}
+
+// hasCondition returns whether status has a condition of the type.
+func hasCondition(status condition.ConditionedStatus, ct condition.ConditionType) bool {
+	return slices.ContainsFunc(status.Conditions, func(c condition.Condition) bool { return c.Type == ct })
+}
+
+// removeCondition returns conds without the condition of the type.
+func removeCondition(conds []condition.Condition, ct condition.ConditionType) []condition.Condition {
+	return slices.DeleteFunc(conds, func(c condition.Condition) bool { return c.Type == ct })
+}
+
+// UpdateStatus updates the status of the definition with retry.RetryOnConflict
+func (r *DefinitionReconciler[T]) UpdateStatus(ctx context.Context, def T, opts ...client.SubResourceUpdateOption) error {
+	status := r.GetStatus(def)
+	attempts := 0
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if attempts++; attempts > 1 {
+			metrics.DefinitionStatusConflictRetriesCounter.WithLabelValues(r.Kind, def.GetName()).Inc()
+		}
+		if err = r.Get(ctx, client.ObjectKeyFromObject(def), def); err != nil {
+			return
+		}
+		// the status is copied since def is decoded into by the next Get on conflicts
+		r.SetStatus(def, status.deepCopy())
+		return r.Status().Update(ctx, def, opts...)
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/controller/utils"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var testTraitDefinitionKind = DefinitionKind[*v1beta1.TraitDefinition]{
+	Kind: v1beta1.TraitDefinitionKind,
+	New: func() *v1beta1.TraitDefinition {
+		return &v1beta1.TraitDefinition{}
+	},
+	GetStatus: func(def *v1beta1.TraitDefinition) DefinitionStatus {
+		status := def.Status.DeepCopy()
+		return DefinitionStatus{
+			ConditionedStatus:  status.ConditionedStatus,
+			ConfigMapRef:       status.ConfigMapRef,
+			Schema:             status.Schema,
+			LatestRevision:     status.LatestRevision,
+			LatestRevisionDiff: status.LatestRevisionDiff,
+			Usage:              status.Usage,
+			ObservedGeneration: status.ObservedGeneration,
+		}
+	},
+	SetStatus: func(def *v1beta1.TraitDefinition, status DefinitionStatus) {
+		def.Status.ConditionedStatus = status.ConditionedStatus
+		def.Status.ConfigMapRef = status.ConfigMapRef
+		def.Status.Schema = status.Schema
+		def.Status.LatestRevision = status.LatestRevision
+		def.Status.LatestRevisionDiff = status.LatestRevisionDiff
+		def.Status.Usage = status.Usage
+		def.Status.ObservedGeneration = status.ObservedGeneration
+	},
+	Capability: func(def *v1beta1.TraitDefinition) DefinitionCapability {
+		capability := utils.NewCapabilityTraitDef(def)
+		return &capability
+	},
+}
+
+func TestDefinitionReconciler(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	trait.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: `
+patch: spec: replicas: parameter.replicas
+parameter: replicas: *1 | int
+`}}
+	// The Synced condition of former releases is replaced by the conditions of each phase
+	trait.Status.SetConditions(condition.ReconcileError(errors.New("cannot store capability")))
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+	r := &DefinitionReconciler[*v1beta1.TraitDefinition]{
+		Client:                      cli,
+		Record:                      event.NewNopRecorder(),
+		DefinitionKind:              testTraitDefinitionKind,
+		DefinitionReconcilerOptions: DefinitionReconcilerOptions{DefRevLimit: 10},
+	}
+
+	revisionsCreated := metrics.DefinitionRevisionCreatedCounter.WithLabelValues(v1beta1.TraitDefinitionKind, "scaler")
+	before := testutil.ToFloat64(revisionsCreated)
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trait)})
+	require.NoError(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(revisionsCreated))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, "scaler-v1", trait.Status.LatestRevision.Name)
+	require.Equal(t, "trait-schema-scaler", trait.Status.ConfigMapRef)
+	require.Equal(t, trait.Generation, trait.Status.ObservedGeneration)
+	for _, ct := range []condition.ConditionType{condition.TypeRevisionReconciled, condition.TypeSchemaStored, condition.TypeTemplateValid, condition.TypeReady} {
+		cond := trait.Status.GetCondition(ct)
+		require.Equal(t, corev1.ConditionTrue, cond.Status, ct)
+		require.Equal(t, trait.Generation, cond.ObservedGeneration, ct)
+		require.False(t, cond.LastTransitionTime.IsZero(), ct)
+	}
+	require.Equal(t, "scaler-v1", trait.Status.GetCondition(condition.TypeRevisionReconciled).Message)
+	require.Equal(t, corev1.ConditionUnknown, trait.Status.GetCondition(condition.TypeSynced).Status)
+	// the usage is kept by the DefinitionUsageReconciler
+	require.Nil(t, trait.Status.Usage)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trait-schema-scaler"}, cm))
+	defRev := &v1beta1.DefinitionRevision{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "scaler-v1"}, defRev))
+}
+
+func TestDefinitionReconcilerReportsFailingPhase(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler", Generation: 3}}
+	trait.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: `parameter: replicas: int & "one"`}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+	r := &DefinitionReconciler[*v1beta1.TraitDefinition]{
+		Client:                      cli,
+		Record:                      event.NewNopRecorder(),
+		DefinitionKind:              testTraitDefinitionKind,
+		DefinitionReconcilerOptions: DefinitionReconcilerOptions{DefRevLimit: 10},
+	}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trait)})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, corev1.ConditionTrue, trait.Status.GetCondition(condition.TypeRevisionReconciled).Status)
+	require.Equal(t, corev1.ConditionFalse, trait.Status.GetCondition(condition.TypeTemplateValid).Status)
+	schemaStored := trait.Status.GetCondition(condition.TypeSchemaStored)
+	require.Equal(t, corev1.ConditionFalse, schemaStored.Status)
+	require.Equal(t, condition.ReasonSchemaStoreError, schemaStored.Reason)
+	require.Equal(t, trait.Generation, schemaStored.ObservedGeneration)
+	require.Equal(t, int64(3), trait.Status.ObservedGeneration)
+	ready := trait.Status.GetCondition(condition.TypeReady)
+	require.Equal(t, corev1.ConditionFalse, ready.Status)
+	require.Contains(t, ready.Message, "TemplateValid: line 1, column")
+}
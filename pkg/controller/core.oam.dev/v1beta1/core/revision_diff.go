@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// DiffDefinitionRevisions computes what changed in the definition between the old and the new revision.
+// A nil old revision diffs the new revision against an empty definition.
+func DiffDefinitionRevisions(old, new *v1beta1.DefinitionRevision) (*common.DefinitionRevisionDiff, error) {
+	diff := &common.DefinitionRevisionDiff{To: new.Name}
+	var oldSpec interface{}
+	var oldSchematic *common.Schematic
+	if old != nil {
+		diff.From = old.Name
+		oldSpec, oldSchematic = revisionSpec(old)
+	}
+	newSpec, newSchematic := revisionSpec(new)
+
+	specSections, err := diffSpecs(oldSpec, newSpec)
+	if err != nil {
+		return nil, err
+	}
+	oldParams, oldSections, err := parseTemplate(templateOf(oldSchematic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the template of revision %s: %w", diff.From, err)
+	}
+	newParams, newSections, err := parseTemplate(templateOf(newSchematic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the template of revision %s: %w", diff.To, err)
+	}
+	diff.ChangedSections = append(specSections, diffValues(oldSections, newSections, "template.")...)
+	sort.Strings(diff.ChangedSections)
+
+	for path, value := range newParams {
+		oldValue, found := oldParams[path]
+		switch {
+		case !found:
+			diff.ParametersAdded = append(diff.ParametersAdded, path)
+		case oldValue != value:
+			diff.ParametersChanged = append(diff.ParametersChanged, path)
+		}
+	}
+	for path := range oldParams {
+		if _, found := newParams[path]; !found {
+			diff.ParametersRemoved = append(diff.ParametersRemoved, path)
+		}
+	}
+	sort.Strings(diff.ParametersAdded)
+	sort.Strings(diff.ParametersRemoved)
+	sort.Strings(diff.ParametersChanged)
+	return diff, nil
+}
+
+// FormatDefinitionRevisionDiff renders the diff in a single line suitable for events.
+func FormatDefinitionRevisionDiff(diff *common.DefinitionRevisionDiff) string {
+	var parts []string
+	add := func(title string, items []string) {
+		if len(items) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", title, strings.Join(items, ", ")))
+		}
+	}
+	add("parameters added", diff.ParametersAdded)
+	add("parameters removed", diff.ParametersRemoved)
+	add("parameters changed", diff.ParametersChanged)
+	add("changed", diff.ChangedSections)
+	if len(parts) == 0 {
+		parts = append(parts, "no semantic change")
+	}
+	from := diff.From
+	if from == "" {
+		from = "none"
+	}
+	return fmt.Sprintf("revision %s (from %s): %s", diff.To, from, strings.Join(parts, "; "))
+}
+
+func revisionSpec(defRev *v1beta1.DefinitionRevision) (interface{}, *common.Schematic) {
+	switch defRev.Spec.DefinitionType {
+	case common.ComponentType:
+		return defRev.Spec.ComponentDefinition.Spec, defRev.Spec.ComponentDefinition.Spec.Schematic
+	case common.TraitType:
+		return defRev.Spec.TraitDefinition.Spec, defRev.Spec.TraitDefinition.Spec.Schematic
+	case common.PolicyType:
+		return defRev.Spec.PolicyDefinition.Spec, defRev.Spec.PolicyDefinition.Spec.Schematic
+	case common.WorkflowStepType:
+		return defRev.Spec.WorkflowStepDefinition.Spec, defRev.Spec.WorkflowStepDefinition.Spec.Schematic
+	}
+	return nil, nil
+}
+
+func templateOf(schematic *common.Schematic) string {
+	if schematic == nil || schematic.CUE == nil {
+		return ""
+	}
+	return schematic.CUE.Template
+}
+
+// diffSpecs returns the top-level spec fields that differ. The CUE template is
+// diffed separately, other schematics are compared as a whole.
+func diffSpecs(old, new interface{}) ([]string, error) {
+	oldFields, err := specFields(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := specFields(new)
+	if err != nil {
+		return nil, err
+	}
+	return diffValues(oldFields, newFields, "spec."), nil
+}
+
+func specFields(spec interface{}) (map[string]string, error) {
+	fields := map[string]string{}
+	if spec == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if schematic, ok := m["schematic"].(map[string]interface{}); ok {
+		delete(schematic, "cue")
+		if len(schematic) == 0 {
+			delete(m, "schematic")
+		}
+	}
+	for k, v := range m {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		fields[k] = string(data)
+	}
+	return fields, nil
+}
+
+func diffValues(old, new map[string]string, prefix string) []string {
+	var changed []string
+	for k, v := range new {
+		if oldValue, found := old[k]; !found || oldValue != v {
+			changed = append(changed, prefix+k)
+		}
+	}
+	for k := range old {
+		if _, found := new[k]; !found {
+			changed = append(changed, prefix+k)
+		}
+	}
+	return changed
+}
+
+// parseTemplate returns the formatted value of every parameter, keyed by its
+// dotted path, and of every top-level field of the template.
+func parseTemplate(template string) (map[string]string, map[string]string, error) {
+	params, sections := map[string]string{}, map[string]string{}
+	if template == "" {
+		return params, sections, nil
+	}
+	f, err := parser.ParseFile("-", template, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, decl := range f.Decls {
+		field, ok := decl.(*ast.Field)
+		if !ok {
+			continue
+		}
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		value, err := formatNode(field.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		sections[name] += value
+		if structLit, ok := field.Value.(*ast.StructLit); ok && name == "parameter" {
+			if err := collectParameters(structLit, "", params); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return params, sections, nil
+}
+
+func collectParameters(structLit *ast.StructLit, prefix string, params map[string]string) error {
+	for _, elt := range structLit.Elts {
+		field, ok := elt.(*ast.Field)
+		if !ok {
+			continue
+		}
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if nested, ok := field.Value.(*ast.StructLit); ok {
+			// a nested struct is kept as a parameter itself so that its
+			// optionality is diffed, its fields are diffed one by one
+			params[path] = constraintOf(field) + "struct"
+			if err := collectParameters(nested, path, params); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := formatNode(field.Value)
+		if err != nil {
+			return err
+		}
+		params[path] = constraintOf(field) + value
+	}
+	return nil
+}
+
+func formatNode(node ast.Node) (string, error) {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return "", nil
+	}
+	data, err := format.Node(node)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// constraintOf returns the marker of an optional or a required field.
+func constraintOf(field *ast.Field) string {
+	switch field.Constraint {
+	case token.OPTION, token.NOT:
+		return field.Constraint.String()
+	}
+	return ""
+}
+
+func fieldName(field *ast.Field) string {
+	switch label := field.Label.(type) {
+	case *ast.Ident:
+		return label.Name
+	case *ast.BasicLit:
+		return strings.Trim(label.Value, `"`)
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+func traitRevision(name string, appliesTo []string, template string) *v1beta1.DefinitionRevision {
+	defRev := &v1beta1.DefinitionRevision{}
+	defRev.Name = name
+	defRev.Spec.DefinitionType = common.TraitType
+	defRev.Spec.TraitDefinition.Spec.AppliesToWorkloads = appliesTo
+	defRev.Spec.TraitDefinition.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: template}}
+	return defRev
+}
+
+func TestDiffDefinitionRevisions(t *testing.T) {
+	v1 := traitRevision("scaler-v1", []string{"deployments.apps"}, `
+patch: spec: replicas: parameter.replicas
+parameter: {
+	replicas: *1 | int
+	selector?: string
+	resources: {
+		cpu: string
+	}
+}
+`)
+	v2 := traitRevision("scaler-v2", []string{"deployments.apps", "statefulsets.apps"}, `
+patch: spec: replicas: parameter.replicas
+parameter: {
+	replicas: *2 | int
+	resources: {
+		cpu:    string
+		memory: string
+	}
+	labels?: [string]: string
+}
+`)
+	diff, err := DiffDefinitionRevisions(v1, v2)
+	require.NoError(t, err)
+	require.Equal(t, &common.DefinitionRevisionDiff{
+		From:              "scaler-v1",
+		To:                "scaler-v2",
+		ParametersAdded:   []string{"labels", "resources.memory"},
+		ParametersRemoved: []string{"selector"},
+		ParametersChanged: []string{"replicas"},
+		ChangedSections:   []string{"spec.appliesToWorkloads", "template.parameter"},
+	}, diff)
+	require.Equal(t, "revision scaler-v2 (from scaler-v1): parameters added: labels, resources.memory; "+
+		"parameters removed: selector; parameters changed: replicas; changed: spec.appliesToWorkloads, template.parameter",
+		FormatDefinitionRevisionDiff(diff))
+
+	diff, err = DiffDefinitionRevisions(nil, v1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"replicas", "resources", "resources.cpu", "selector"}, diff.ParametersAdded)
+	require.Contains(t, diff.ChangedSections, "template.patch")
+
+	diff, err = DiffDefinitionRevisions(v1, traitRevision("scaler-v3", []string{"deployments.apps"}, "parameter: {"))
+	require.Error(t, err)
+	require.Nil(t, diff)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestRevisionHistoryLimit(t *testing.T) {
+	def := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	require.Equal(t, 20, RevisionHistoryLimit(def, 20))
+	for value, expected := range map[string]int{"5": 5, "0": 0, "-1": 20, "many": 20} {
+		def.SetAnnotations(map[string]string{oam.AnnotationDefinitionRevisionHistoryLimit: value})
+		require.Equal(t, expected, RevisionHistoryLimit(def, 20), value)
+	}
+}
+
+// newDefinitionRevisionsClient returns a client with the given number of revisions of the trait.
+func newDefinitionRevisionsClient(def *v1beta1.TraitDefinition, revisions int) client.Client {
+	var objs []client.Object
+	for i := 1; i <= revisions; i++ {
+		defRev := &v1beta1.DefinitionRevision{}
+		defRev.SetNamespace(def.Namespace)
+		defRev.SetName(fmt.Sprintf("%s-v%d", def.Name, i))
+		defRev.SetLabels(map[string]string{oam.LabelTraitDefinitionName: def.Name})
+		defRev.Spec.Revision = int64(i)
+		objs = append(objs, defRev)
+	}
+	return fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(objs...).Build()
+}
+
+func TestCleanUpDefinitionRevisionWithHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	def := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler",
+		Annotations: map[string]string{oam.AnnotationDefinitionRevisionHistoryLimit: "2"}}}
+	def.Status.LatestRevision = &common.Revision{Name: "scaler-v6", Revision: 6}
+	cli := newDefinitionRevisionsClient(def, 6)
+
+	require.NoError(t, CleanUpDefinitionRevision(ctx, cli, def, RevisionHistoryLimit(def, 20)))
+	defRevs := &v1beta1.DefinitionRevisionList{}
+	require.NoError(t, cli.List(ctx, defRevs))
+	var names []string
+	for _, defRev := range defRevs.Items {
+		names = append(names, defRev.Name)
+	}
+	require.ElementsMatch(t, []string{"scaler-v4", "scaler-v5", "scaler-v6"}, names)
+}
+
+func TestCleanUpDefinitionRevisionKeepsProtectedRevisions(t *testing.T) {
+	ctx := context.Background()
+	def := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	def.Status.LatestRevision = &common.Revision{Name: "scaler-v6", Revision: 6}
+	cli := newDefinitionRevisionsClient(def, 6)
+	for _, name := range []string{"scaler-v1", "scaler-v3"} {
+		defRev := &v1beta1.DefinitionRevision{}
+		require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, defRev))
+		defRev.SetAnnotations(map[string]string{oam.AnnotationDefinitionRevisionProtected: "true"})
+		require.NoError(t, cli.Update(ctx, defRev))
+	}
+
+	require.NoError(t, CleanUpDefinitionRevision(ctx, cli, def, 1))
+	defRevs := &v1beta1.DefinitionRevisionList{}
+	require.NoError(t, cli.List(ctx, defRevs))
+	var names []string
+	for _, defRev := range defRevs.Items {
+		names = append(names, defRev.Name)
+	}
+	require.ElementsMatch(t, []string{"scaler-v1", "scaler-v3", "scaler-v5", "scaler-v6"}, names)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Masterminds/semver"
@@ -371,22 +372,26 @@ func CleanUpDefinitionRevision(ctx context.Context, cli client.Client, def runti
 	if err := cli.List(ctx, defRevList, listOpts...); err != nil {
 		return err
 	}
-	needKill := len(defRevList.Items) - revisionLimit - 1
+	// the revision in use and the protected revisions are neither pruned nor counted in the limit
+	var candidates []v1beta1.DefinitionRevision
+	for _, rev := range defRevList.Items {
+		if rev.Name == usingRevision.Name || IsProtectedDefinitionRevision(&rev) {
+			continue
+		}
+		candidates = append(candidates, rev)
+	}
+	needKill := len(candidates) - revisionLimit
 	if needKill <= 0 {
 		return nil
 	}
 	klog.InfoS("cleanup old definitionRevision", "needKillNum", needKill)
 
-	sortedRevision := defRevList.Items
-	sort.Sort(historiesByRevision(sortedRevision))
+	sort.Sort(historiesByRevision(candidates))
 
-	for _, rev := range sortedRevision {
+	for _, rev := range candidates {
 		if needKill <= 0 {
 			break
 		}
-		if rev.Name == usingRevision.Name {
-			continue
-		}
 		if err := cli.Delete(ctx, rev.DeepCopy()); err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
@@ -395,6 +400,28 @@ func CleanUpDefinitionRevision(ctx context.Context, cli client.Client, def runti
 	return nil
 }
 
+// RevisionHistoryLimit returns the revision limit of the definition, i.e. the value of its
+// AnnotationDefinitionRevisionHistoryLimit annotation if valid, otherwise the given default.
+func RevisionHistoryLimit(def metav1.Object, defaultLimit int) int {
+	v, ok := def.GetAnnotations()[oam.AnnotationDefinitionRevisionHistoryLimit]
+	if !ok {
+		return defaultLimit
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 0 {
+		klog.Warningf("ignoring invalid %s annotation %q on definition %s/%s, using global default %d",
+			oam.AnnotationDefinitionRevisionHistoryLimit, v, def.GetNamespace(), def.GetName(), defaultLimit)
+		return defaultLimit
+	}
+	return limit
+}
+
+// IsProtectedDefinitionRevision returns whether the revision is exempted from pruning by the
+// AnnotationDefinitionRevisionProtected annotation.
+func IsProtectedDefinitionRevision(defRev *v1beta1.DefinitionRevision) bool {
+	return defRev.GetAnnotations()[oam.AnnotationDefinitionRevisionProtected] == "true"
+}
+
 type historiesByRevision []v1beta1.DefinitionRevision
 
 func (h historiesByRevision) Len() int      { return len(h) }
@@ -409,7 +436,7 @@ func ReconcileDefinitionRevision(ctx context.Context,
 	record event.Recorder,
 	definition util.ConditionedObject,
 	revisionLimit int,
-	updateLatestRevision func(*common.Revision) error,
+	updateLatestRevision func(*common.Revision, *common.DefinitionRevisionDiff) error,
 ) (*v1beta1.DefinitionRevision, *ctrl.Result, error) {
 
 	// generate DefinitionRevision from componentDefinition
@@ -418,39 +445,76 @@ func ReconcileDefinitionRevision(ctx context.Context,
 		klog.ErrorS(err, "Could not generate DefinitionRevision", "componentDefinition", klog.KObj(definition))
 		record.Event(definition, event.Warning("Could not generate DefinitionRevision", err))
 		return nil, &ctrl.Result{}, util.PatchCondition(ctx, cli, definition,
-			condition.ReconcileError(fmt.Errorf(util.ErrGenerateDefinitionRevision, definition.GetName(), err)))
+			condition.RevisionReconcileError(fmt.Errorf(util.ErrGenerateDefinitionRevision, definition.GetName(), err)).WithObservedGeneration(definition.GetGeneration()))
 	}
 
 	if isNewRevision {
+		diff := diffWithLatestRevision(ctx, cli, definition, defRev)
 		if err := CreateDefinitionRevision(ctx, cli, definition, defRev.DeepCopy()); err != nil {
 			klog.ErrorS(err, "Could not create DefinitionRevision")
 			record.Event(definition, event.Warning("cannot create DefinitionRevision", err))
 			return nil, &ctrl.Result{}, util.PatchCondition(ctx, cli, definition,
-				condition.ReconcileError(fmt.Errorf(util.ErrCreateDefinitionRevision, defRev.Name, err)))
+				condition.RevisionReconcileError(fmt.Errorf(util.ErrCreateDefinitionRevision, defRev.Name, err)).WithObservedGeneration(definition.GetGeneration()))
 		}
 		klog.InfoS("Successfully created definitionRevision", "definitionRevision", klog.KObj(defRev))
+		if diff != nil {
+			record.Event(definition, event.Normal("DefinitionRevisionCreated", FormatDefinitionRevisionDiff(diff)))
+		}
 
 		if err := updateLatestRevision(&common.Revision{
 			Name:         defRev.Name,
 			Revision:     defRev.Spec.Revision,
 			RevisionHash: defRev.Spec.RevisionHash,
-		}); err != nil {
+		}, diff); err != nil {
 			klog.ErrorS(err, "Could not update Definition Details")
 			record.Event(definition, event.Warning("cannot update the definition status", err))
 			return nil, &ctrl.Result{}, util.PatchCondition(ctx, cli, definition,
-				condition.ReconcileError(fmt.Errorf(util.ErrUpdateComponentDefinition, definition.GetName(), err)))
+				condition.RevisionReconcileError(fmt.Errorf(util.ErrUpdateComponentDefinition, definition.GetName(), err)).WithObservedGeneration(definition.GetGeneration()))
 		}
 		klog.InfoS("Successfully updated the status.latestRevision of the definition", "Definition", klog.KRef(definition.GetNamespace(), definition.GetName()),
 			"Name", defRev.Name, "Revision", defRev.Spec.Revision, "RevisionHash", defRev.Spec.RevisionHash)
 	}
 
-	if err = CleanUpDefinitionRevision(ctx, cli, definition, revisionLimit); err != nil {
+	if err = CleanUpDefinitionRevision(ctx, cli, definition, RevisionHistoryLimit(definition, revisionLimit)); err != nil {
 		klog.InfoS("Failed to collect garbage", "err", err)
 		record.Event(definition, event.Warning("failed to garbage collect DefinitionRevision of type ComponentDefinition", err))
 	}
 	return defRev, nil, nil
 }
 
+// diffWithLatestRevision computes the changes of the new revision against the latest revision of
+// the definition. Failing to compute them doesn't block the revision, nil is returned instead.
+func diffWithLatestRevision(ctx context.Context, cli client.Client, def client.Object, defRev *v1beta1.DefinitionRevision) *common.DefinitionRevisionDiff {
+	var latest *common.Revision
+	switch definition := def.(type) {
+	case *v1beta1.ComponentDefinition:
+		latest = definition.Status.LatestRevision
+	case *v1beta1.TraitDefinition:
+		latest = definition.Status.LatestRevision
+	case *v1beta1.PolicyDefinition:
+		latest = definition.Status.LatestRevision
+	case *v1beta1.WorkflowStepDefinition:
+		latest = definition.Status.LatestRevision
+	}
+	var old *v1beta1.DefinitionRevision
+	if latest != nil {
+		old = &v1beta1.DefinitionRevision{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: def.GetNamespace(), Name: latest.Name}, old); err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Could not get the latest definitionRevision to diff with", "definitionRevision", latest.Name)
+				return nil
+			}
+			old = nil
+		}
+	}
+	diff, err := DiffDefinitionRevisions(old, defRev)
+	if err != nil {
+		klog.ErrorS(err, "Could not diff the definitionRevision with the latest one", "definitionRevision", klog.KObj(defRev))
+		return nil
+	}
+	return diff
+}
+
 // CreateDefinitionRevision create the revision of the definition
 func CreateDefinitionRevision(ctx context.Context, cli client.Client, def util.ConditionedObject, defRev *v1beta1.DefinitionRevision) error {
 	namespace := def.GetNamespace()
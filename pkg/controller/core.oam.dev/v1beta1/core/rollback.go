@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// unknownRollbackUser is recorded for the rollbacks requested without the rollback-by annotation
+const unknownRollbackUser = "unknown"
+
+// ReconcileRollback restores the spec of the definition from the DefinitionRevision requested by its
+// rollback-to-revision annotation, then removes the rollback annotations. It records an event with the
+// user requesting the rollback and the revisions rolled back from and to. It returns true if the
+// definition is updated, in which case nothing else should be reconciled.
+func ReconcileRollback(ctx context.Context, cli client.Client, record event.Recorder, def util.ConditionedObject, latest *common.Revision) (bool, error) {
+	annotations := def.GetAnnotations()
+	target, found := annotations[oam.AnnotationDefinitionRollbackToRevision]
+	if !found {
+		return false, nil
+	}
+	user := annotations[oam.AnnotationDefinitionRollbackBy]
+	if user == "" {
+		user = unknownRollbackUser
+	}
+	revName := target
+	if _, err := strconv.ParseInt(target, 10, 64); err == nil {
+		revName = ConstructDefinitionRevisionName(def.GetName(), target)
+	}
+
+	defRev := &v1beta1.DefinitionRevision{}
+	var revAnnotations map[string]string
+	err := cli.Get(ctx, client.ObjectKey{Namespace: def.GetNamespace(), Name: revName}, defRev)
+	if err == nil {
+		revAnnotations, err = restoreDefinitionSpec(def, defRev)
+	} else if client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	if err == nil {
+		// The integrity annotations must match the restored template to pass the webhooks
+		for _, key := range []string{oam.AnnotationDefinitionSignature, oam.AnnotationDefinitionChecksum} {
+			if value, found := revAnnotations[key]; found {
+				annotations[key] = value
+			} else {
+				delete(annotations, key)
+			}
+		}
+	}
+	// A failed rollback is not retried, the annotations are removed so that it can be requested again
+	delete(annotations, oam.AnnotationDefinitionRollbackToRevision)
+	delete(annotations, oam.AnnotationDefinitionRollbackBy)
+	def.SetAnnotations(annotations)
+	if err != nil {
+		klog.ErrorS(err, "Failed to roll back the definition", "definition", klog.KObj(def), "revision", revName, "user", user)
+		record.Event(def, event.Warning("RollbackFailed", fmt.Errorf("cannot roll back to revision %s requested by %s: %w", revName, user, err)))
+		return true, cli.Update(ctx, def)
+	}
+	if err := cli.Update(ctx, def); err != nil {
+		return false, err
+	}
+	from := "none"
+	if latest != nil {
+		from = latest.Name
+	}
+	klog.InfoS("Rolled back the definition", "definition", klog.KObj(def), "from", from, "to", defRev.Name, "user", user)
+	record.Event(def, event.Normal("RolledBack", fmt.Sprintf("Rolled back from revision %s to revision %s, requested by %s", from, defRev.Name, user)))
+	return true, nil
+}
+
+// restoreDefinitionSpec restores the spec of the definition from the revision and returns the annotations
+// of the definition at the revision.
+func restoreDefinitionSpec(def client.Object, defRev *v1beta1.DefinitionRevision) (map[string]string, error) {
+	switch d := def.(type) {
+	case *v1beta1.ComponentDefinition:
+		if snapshot := defRev.Spec.ComponentDefinition; defRev.Spec.DefinitionType == common.ComponentType && snapshot.Name == d.Name {
+			d.Spec = *snapshot.Spec.DeepCopy()
+			return snapshot.Annotations, nil
+		}
+	case *v1beta1.TraitDefinition:
+		if snapshot := defRev.Spec.TraitDefinition; defRev.Spec.DefinitionType == common.TraitType && snapshot.Name == d.Name {
+			d.Spec = *snapshot.Spec.DeepCopy()
+			return snapshot.Annotations, nil
+		}
+	case *v1beta1.PolicyDefinition:
+		if snapshot := defRev.Spec.PolicyDefinition; defRev.Spec.DefinitionType == common.PolicyType && snapshot.Name == d.Name {
+			d.Spec = *snapshot.Spec.DeepCopy()
+			return snapshot.Annotations, nil
+		}
+	case *v1beta1.WorkflowStepDefinition:
+		if snapshot := defRev.Spec.WorkflowStepDefinition; defRev.Spec.DefinitionType == common.WorkflowStepType && snapshot.Name == d.Name {
+			d.Spec = *snapshot.Spec.DeepCopy()
+			return snapshot.Annotations, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported definition %T", def)
+	}
+	return nil, fmt.Errorf("%s is not a revision of %s", defRev.Name, def.GetName())
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestReconcileRollback(t *testing.T) {
+	ctx := context.Background()
+	newTrait := func(template string) *v1beta1.TraitDefinition {
+		return &v1beta1.TraitDefinition{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"},
+			Spec: v1beta1.TraitDefinitionSpec{
+				Schematic: &common.Schematic{CUE: &common.CUE{Template: template}},
+			},
+		}
+	}
+	v1 := newTrait("parameter: replicas: *1 | int")
+	v1.SetAnnotations(map[string]string{oam.AnnotationDefinitionChecksum: "sha256:v1"})
+	rev1 := &v1beta1.DefinitionRevision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler-v1"},
+		Spec:       v1beta1.DefinitionRevisionSpec{Revision: 1, DefinitionType: common.TraitType, TraitDefinition: *v1},
+	}
+	other := &v1beta1.DefinitionRevision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-v1"},
+		Spec: v1beta1.DefinitionRevisionSpec{Revision: 1, DefinitionType: common.TraitType, TraitDefinition: v1beta1.TraitDefinition{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		}},
+	}
+	trait := newTrait("parameter: replicas: *2 | int")
+	latest := &common.Revision{Name: "scaler-v2", Revision: 2}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait, rev1, other).Build()
+
+	// definitions without the rollback annotation are left untouched
+	rolledBack, err := ReconcileRollback(ctx, cli, event.NewNopRecorder(), trait, latest)
+	require.NoError(t, err)
+	require.False(t, rolledBack)
+
+	trait.SetAnnotations(map[string]string{
+		oam.AnnotationDefinitionRollbackToRevision: "1",
+		oam.AnnotationDefinitionRollbackBy:         "alice",
+		oam.AnnotationDefinitionChecksum:           "sha256:v2",
+	})
+	rolledBack, err = ReconcileRollback(ctx, cli, event.NewNopRecorder(), trait, latest)
+	require.NoError(t, err)
+	require.True(t, rolledBack)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, "parameter: replicas: *1 | int", trait.Spec.Schematic.CUE.Template)
+	require.Equal(t, map[string]string{oam.AnnotationDefinitionChecksum: "sha256:v1"}, trait.GetAnnotations())
+
+	// a failed rollback keeps the spec and removes the annotations
+	for _, target := range []string{"3", "gateway-v1"} {
+		trait.SetAnnotations(map[string]string{oam.AnnotationDefinitionRollbackToRevision: target})
+		trait.Spec.Schematic.CUE.Template = "parameter: replicas: *3 | int"
+		rolledBack, err = ReconcileRollback(ctx, cli, event.NewNopRecorder(), trait, latest)
+		require.NoError(t, err)
+		require.True(t, rolledBack)
+		require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+		require.Equal(t, "parameter: replicas: *3 | int", trait.Spec.Schematic.CUE.Template)
+		require.Empty(t, trait.GetAnnotations())
+	}
+}
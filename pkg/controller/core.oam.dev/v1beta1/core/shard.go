@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ParseShardSelector parses the label selector of the definitions handled by the
+// definition controllers of this shard. An empty selector selects all definitions.
+func ParseShardSelector(selector string) (labels.Selector, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition shard label %q: %w", selector, err)
+	}
+	return s, nil
+}
+
+// InShard checks whether the definition is handled by the shard of the selector.
+// A nil selector selects all definitions.
+func InShard(selector labels.Selector, def client.Object) bool {
+	return selector == nil || selector.Matches(labels.Set(def.GetLabels()))
+}
+
+// ShardPredicate filters the events of the definitions out of the shard of the selector.
+func ShardPredicate(selector labels.Selector) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(def client.Object) bool {
+		return InShard(selector, def)
+	})
+}
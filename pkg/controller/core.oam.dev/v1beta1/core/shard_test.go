@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+func TestShardSelector(t *testing.T) {
+	tenantA := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "scaler",
+		Labels: map[string]string{"definition.oam.dev/shard": "tenant-a"}}}
+	tenantB := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "gateway",
+		Labels: map[string]string{"definition.oam.dev/shard": "tenant-b"}}}
+	unlabeled := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "storage"}}
+
+	selector, err := ParseShardSelector("")
+	require.NoError(t, err)
+	require.True(t, InShard(selector, tenantA))
+	require.True(t, InShard(selector, unlabeled))
+	require.True(t, InShard(nil, tenantB))
+
+	selector, err = ParseShardSelector("definition.oam.dev/shard=tenant-a")
+	require.NoError(t, err)
+	require.True(t, InShard(selector, tenantA))
+	require.False(t, InShard(selector, tenantB))
+	require.False(t, InShard(selector, unlabeled))
+
+	p := ShardPredicate(selector)
+	require.True(t, p.Create(event.CreateEvent{Object: tenantA}))
+	require.False(t, p.Create(event.CreateEvent{Object: tenantB}))
+	require.False(t, p.Update(event.UpdateEvent{ObjectOld: tenantA, ObjectNew: tenantB}))
+
+	selector, err = ParseShardSelector("definition.oam.dev/shard notin (tenant-a)")
+	require.NoError(t, err)
+	require.False(t, InShard(selector, tenantA))
+	require.True(t, InShard(selector, unlabeled))
+
+	_, err = ParseShardSelector("definition.oam.dev/shard in (tenant-a")
+	require.Error(t, err)
+}
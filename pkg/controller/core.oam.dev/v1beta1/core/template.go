@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	cueErrors "cuelang.org/go/cue/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/kubevela/pkg/cue/cuex"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// contextNotFound matches the errors of references to the context, which is
+// only provided when an Application renders the template
+var contextNotFound = regexp.MustCompile(`^.+:\sreference\s"context"\snot\sfound$`)
+
+// CompileTemplate compiles the CUE template of a definition and returns all
+// the compile errors, each prefixed with its line and column in the template.
+func CompileTemplate(ctx context.Context, template string) error {
+	val, err := cuex.DefaultCompiler.Get().CompileStringWithOptions(ctx, template)
+	if err == nil {
+		err = val.Err()
+	}
+	if err == nil {
+		err = val.Validate()
+	}
+	if err == nil {
+		return nil
+	}
+	var msgs []string
+	for _, e := range cueErrors.Errors(err) {
+		msg := e.Error()
+		if contextNotFound.MatchString(msg) {
+			continue
+		}
+		if pos := e.Position(); pos.IsValid() {
+			msg = fmt.Sprintf("line %d, column %d: %s", pos.Line(), pos.Column(), msg)
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// ReconcileTemplateCondition compiles the CUE template of the definition and
+// patches its TemplateValid condition if it changed. It returns the compile
+// errors if the template is invalid. Definitions without a CUE template are
+// left untouched.
+func ReconcileTemplateCondition(ctx context.Context, cli client.StatusClient, record event.Recorder, def util.ConditionedObject) error {
+	template := definitionTemplate(def)
+	if template == "" {
+		return nil
+	}
+	compileErr := CompileTemplate(ctx, template)
+	cond := condition.TemplateValid().WithObservedGeneration(def.GetGeneration())
+	if compileErr != nil {
+		cond = condition.TemplateInvalid(compileErr).WithObservedGeneration(def.GetGeneration())
+		klog.InfoS("The template of the definition is invalid", "definition", klog.KObj(def), "err", compileErr)
+		record.Event(def, event.Warning("Template is invalid", compileErr))
+	}
+	if util.IsConditionChanged([]condition.Condition{cond}, def) {
+		if err := util.PatchCondition(ctx, cli, def, cond); err != nil {
+			return err
+		}
+	}
+	if compileErr != nil {
+		return fmt.Errorf("invalid template: %w", compileErr)
+	}
+	return nil
+}
+
+// ReconcileSignatureCondition verifies the signature and the checksum of the
+// definition with the verifier. A definition failing the verification, e.g.
+// applied while the webhooks were unavailable, gets a TemplateValid condition
+// set to False and true is returned. The returned error is only set when the
+// condition cannot be patched, so that the definition is requeued.
+func ReconcileSignatureCondition(ctx context.Context, cli client.StatusClient, record event.Recorder, def util.ConditionedObject, verifier *signature.Verifier) (bool, error) {
+	verifyErr := verifier.Verify(def)
+	if verifyErr == nil {
+		return false, nil
+	}
+	klog.InfoS("The definition failed the signature verification", "definition", klog.KObj(def), "err", verifyErr)
+	record.Event(def, event.Warning("Signature verification failed", verifyErr))
+	cond := condition.TemplateUnverified(verifyErr).WithObservedGeneration(def.GetGeneration())
+	if util.IsConditionChanged([]condition.Condition{cond}, def) {
+		if err := util.PatchCondition(ctx, cli, def, cond); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func definitionTemplate(def client.Object) string {
+	var schematic *common.Schematic
+	switch definition := def.(type) {
+	case *v1beta1.ComponentDefinition:
+		schematic = definition.Spec.Schematic
+	case *v1beta1.TraitDefinition:
+		schematic = definition.Spec.Schematic
+	case *v1beta1.PolicyDefinition:
+		schematic = definition.Spec.Schematic
+	case *v1beta1.WorkflowStepDefinition:
+		schematic = definition.Spec.Schematic
+	}
+	return templateOf(schematic)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestCompileTemplate(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, CompileTemplate(ctx, `
+patch: spec: replicas: parameter.replicas
+parameter: replicas: *1 | int
+`))
+	// references to the context are resolved when an application renders the template
+	require.NoError(t, CompileTemplate(ctx, `
+output: metadata: name: context.name
+`))
+
+	err := CompileTemplate(ctx, `
+parameter: replicas: *1 | int
+patch: spec: replicas: parameter.replicas & "one"
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 3, column")
+}
+
+func TestReconcileTemplateCondition(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	trait.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: `parameter: replicas: *1 | int`}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+
+	require.NoError(t, ReconcileTemplateCondition(ctx, cli, event.NewNopRecorder(), trait))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, corev1.ConditionTrue, trait.Status.GetCondition(condition.TypeTemplateValid).Status)
+
+	trait.Spec.Schematic.CUE.Template = `parameter: replicas: int & "one"`
+	require.Error(t, ReconcileTemplateCondition(ctx, cli, event.NewNopRecorder(), trait))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	cond := trait.Status.GetCondition(condition.TypeTemplateValid)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonTemplateCompileError, cond.Reason)
+	require.Contains(t, cond.Message, "line 1, column")
+
+	// definitions without a CUE template have no condition
+	comp := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ref-objects"}}
+	require.NoError(t, ReconcileTemplateCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.Empty(t, comp.Status.Conditions)
+}
+
+func TestReconcileSignatureCondition(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	trait.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: `parameter: replicas: *1 | int`}}
+	payload, err := signature.Payload(trait)
+	require.NoError(t, err)
+	trait.Annotations = map[string]string{oam.AnnotationDefinitionChecksum: signature.Checksum(payload)}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+	verifier, err := signature.LoadVerifier("")
+	require.NoError(t, err)
+
+	unverified, err := ReconcileSignatureCondition(ctx, cli, event.NewNopRecorder(), trait, verifier)
+	require.NoError(t, err)
+	require.False(t, unverified)
+	require.Empty(t, trait.Status.Conditions)
+
+	// the fields besides the template are covered by the checksum
+	trait.Spec.AppliesToWorkloads = []string{"deployments.apps"}
+	unverified, err = ReconcileSignatureCondition(ctx, cli, event.NewNopRecorder(), trait, verifier)
+	require.NoError(t, err)
+	require.True(t, unverified)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	cond := trait.Status.GetCondition(condition.TypeTemplateValid)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonTemplateUnverified, cond.Reason)
+	require.Contains(t, cond.Message, "does not match its checksum")
+
+	// failing to patch the condition is returned to requeue the definition
+	failing := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).WithStatusSubresource(trait).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(context.Context, client.Client, string, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+				return errors.New("conflict")
+			},
+		}).Build()
+	trait.Status.Conditions = nil
+	unverified, err = ReconcileSignatureCondition(ctx, failing, event.NewNopRecorder(), trait, verifier)
+	require.EqualError(t, err, "conflict")
+	require.True(t, unverified)
+}
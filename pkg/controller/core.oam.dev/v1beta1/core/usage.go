@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// DefinitionUsageSampleLimit bounds the number of Applications listed in the usage of a definition
+const DefinitionUsageSampleLimit = 10
+
+// ReferencedDefinitions returns the definitions of the given type referenced by the Application,
+// mapped to the revisions the Application pins, e.g. v1 for webservice@v1, or to an empty string
+// if it uses the latest revision.
+func ReferencedDefinitions(app *v1beta1.Application, defType common.DefinitionType) map[string]string {
+	refs := map[string]string{}
+	add := func(typ string) {
+		if typ == "" {
+			return
+		}
+		name, revision := typ, ""
+		if idx := strings.LastIndex(typ, "@"); idx > 0 {
+			name, revision = typ[:idx], typ[idx+1:]
+		}
+		if _, found := refs[name]; !found || revision == "" {
+			refs[name] = revision
+		}
+	}
+	switch defType {
+	case common.ComponentType:
+		for _, comp := range app.Spec.Components {
+			add(comp.Type)
+		}
+	case common.TraitType:
+		for _, comp := range app.Spec.Components {
+			for _, trait := range comp.Traits {
+				add(trait.Type)
+			}
+		}
+	case common.PolicyType:
+		for _, policy := range app.Spec.Policies {
+			add(policy.Type)
+		}
+	case common.WorkflowStepType:
+		if app.Spec.Workflow != nil {
+			for _, step := range app.Spec.Workflow.Steps {
+				add(step.Type)
+				for _, sub := range step.SubSteps {
+					add(sub.Type)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// ComputeDefinitionUsage summarizes the Applications referencing the definition. Definitions in the
+// system definition namespace are looked up in the Applications of all namespaces, the others only in
+// the Applications of their own namespace. Applications shadowing a system definition with a namespaced
+// one of the same name are counted as well, which errs on the safe side for deprecation decisions.
+func ComputeDefinitionUsage(ctx context.Context, cli client.Reader, def client.Object) (*common.DefinitionUsage, error) {
+	defType, err := definitionTypeOf(def)
+	if err != nil {
+		return nil, err
+	}
+	var listOpts []client.ListOption
+	if def.GetNamespace() != oam.SystemDefinitionNamespace {
+		listOpts = append(listOpts, client.InNamespace(def.GetNamespace()))
+	}
+	apps := &v1beta1.ApplicationList{}
+	if err := cli.List(ctx, apps, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	var refs []common.DefinitionUsageRef
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		revision, found := ReferencedDefinitions(app, defType)[def.GetName()]
+		if !found {
+			continue
+		}
+		refs = append(refs, common.DefinitionUsageRef{Namespace: app.Namespace, Name: app.Name, Revision: revision})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	usage := &common.DefinitionUsage{Applications: len(refs)}
+	if len(refs) > DefinitionUsageSampleLimit {
+		refs = refs[:DefinitionUsageSampleLimit]
+	}
+	usage.Samples = refs
+	return usage, nil
+}
+
+// EnqueueReferencedDefinitions returns a handler enqueueing the definitions of the given type referenced
+// by an Application, both in the namespace of the Application and in the system definition namespace.
+// On updates the definitions referenced before and after the update are enqueued.
+func EnqueueReferencedDefinitions(defType common.DefinitionType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		app, ok := obj.(*v1beta1.Application)
+		if !ok {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for name := range ReferencedDefinitions(app, defType) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: app.Namespace, Name: name}})
+			if app.Namespace != oam.SystemDefinitionNamespace {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: oam.SystemDefinitionNamespace, Name: name}})
+			}
+		}
+		return reqs
+	})
+}
+
+// DefinitionUsageReconciler keeps the status.usage of a kind of definition up to date. It runs apart from
+// the DefinitionReconciler, so that the changes of the Applications only recompute the usage of the
+// definitions they reference instead of reconciling their revisions and schemas.
+type DefinitionUsageReconciler[T util.ConditionedObject] struct {
+	client.Client
+	DefinitionKind[T]
+	// ShardSelector selects the definitions handled by the controller, nil selects all definitions
+	ShardSelector labels.Selector
+}
+
+// Reconcile updates the status.usage of the definition
+func (r *DefinitionUsageReconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logKey := strings.ToLower(r.Kind[:1]) + r.Kind[1:]
+	def := r.New()
+	if err := r.Get(ctx, req.NamespacedName, def); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// Definitions enqueued by the Applications are not filtered by the shard predicate
+	if !InShard(r.ShardSelector, def) {
+		return ctrl.Result{}, nil
+	}
+	usage, err := ComputeDefinitionUsage(ctx, r.Client, def)
+	if err != nil {
+		klog.ErrorS(err, "Could not compute the usage of the "+r.Kind, logKey, klog.KObj(def))
+		return ctrl.Result{}, err
+	}
+	status := r.GetStatus(def)
+	if apiequality.Semantic.DeepEqual(status.Usage, usage) {
+		return ctrl.Result{}, nil
+	}
+	patch := client.MergeFrom(def.DeepCopyObject().(client.Object))
+	status.Usage = usage
+	r.SetStatus(def, status)
+	if err := r.Status().Patch(ctx, def, patch); err != nil {
+		klog.ErrorS(err, "Could not update the status.usage of the "+r.Kind, logKey, klog.KObj(def))
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager adds a controller of the usage of the definitions of the type to the manager, named
+// after the kind of the definitions.
+func (r *DefinitionUsageReconciler[T]) SetupWithManager(mgr ctrl.Manager, defType common.DefinitionType, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(r.Kind)+"-usage").
+		WithOptions(opts).
+		For(r.New(), builder.WithPredicates(ShardPredicate(r.ShardSelector), predicate.GenerationChangedPredicate{})).
+		Watches(&v1beta1.Application{}, EnqueueReferencedDefinitions(defType),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func definitionTypeOf(def client.Object) (common.DefinitionType, error) {
+	switch def.(type) {
+	case *v1beta1.ComponentDefinition:
+		return common.ComponentType, nil
+	case *v1beta1.TraitDefinition:
+		return common.TraitType, nil
+	case *v1beta1.PolicyDefinition:
+		return common.PolicyType, nil
+	case *v1beta1.WorkflowStepDefinition:
+		return common.WorkflowStepType, nil
+	default:
+		return "", fmt.Errorf("unsupported definition %T", def)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func usageTestApp(namespace, name, compType string, traitTypes ...string) *v1beta1.Application {
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	comp := common.ApplicationComponent{Name: "comp", Type: compType}
+	for _, typ := range traitTypes {
+		comp.Traits = append(comp.Traits, common.ApplicationTrait{Type: typ})
+	}
+	app.Spec.Components = []common.ApplicationComponent{comp}
+	return app
+}
+
+func TestReferencedDefinitions(t *testing.T) {
+	app := usageTestApp("default", "app", "webservice@v2", "scaler", "labels@v1")
+	app.Spec.Policies = []v1beta1.AppPolicy{{Name: "topology", Type: "topology"}}
+	app.Spec.Workflow = &v1beta1.Workflow{Steps: []wfTypesv1alpha1.WorkflowStep{{
+		WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "group", Type: "step-group"},
+		SubSteps:         []wfTypesv1alpha1.WorkflowStepBase{{Name: "deploy", Type: "deploy"}},
+	}}}
+	require.Equal(t, map[string]string{"webservice": "v2"}, ReferencedDefinitions(app, common.ComponentType))
+	require.Equal(t, map[string]string{"scaler": "", "labels": "v1"}, ReferencedDefinitions(app, common.TraitType))
+	require.Equal(t, map[string]string{"topology": ""}, ReferencedDefinitions(app, common.PolicyType))
+	require.Equal(t, map[string]string{"step-group": "", "deploy": ""}, ReferencedDefinitions(app, common.WorkflowStepType))
+}
+
+func TestComputeDefinitionUsage(t *testing.T) {
+	objs := []client.Object{
+		usageTestApp("team-a", "b", "webservice", "scaler"),
+		usageTestApp("team-a", "a", "webservice@v1"),
+		usageTestApp("team-b", "c", "worker"),
+	}
+	for i := 0; i < DefinitionUsageSampleLimit; i++ {
+		objs = append(objs, usageTestApp("team-c", fmt.Sprintf("app-%02d", i), "worker"))
+	}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(objs...).Build()
+	ctx := context.Background()
+
+	def := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "webservice"}}
+	usage, err := ComputeDefinitionUsage(ctx, cli, def)
+	require.NoError(t, err)
+	require.Equal(t, &common.DefinitionUsage{Applications: 2, Samples: []common.DefinitionUsageRef{
+		{Namespace: "team-a", Name: "a", Revision: "v1"},
+		{Namespace: "team-a", Name: "b"},
+	}}, usage)
+
+	def = &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "worker"}}
+	usage, err = ComputeDefinitionUsage(ctx, cli, def)
+	require.NoError(t, err)
+	require.Equal(t, DefinitionUsageSampleLimit+1, usage.Applications)
+	require.Len(t, usage.Samples, DefinitionUsageSampleLimit)
+	require.Equal(t, "team-b", usage.Samples[0].Namespace)
+
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "scaler"}}
+	usage, err = ComputeDefinitionUsage(ctx, cli, trait)
+	require.NoError(t, err)
+	require.Equal(t, &common.DefinitionUsage{Applications: 0}, usage)
+}
+
+func TestDefinitionUsageReconciler(t *testing.T) {
+	ctx := context.Background()
+	trait := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scaler"}}
+	trait.Spec.Schematic = &common.Schematic{CUE: &common.CUE{Template: `
+patch: spec: replicas: parameter.replicas
+parameter: replicas: *1 | int
+`}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(trait).
+		WithStatusSubresource(trait).Build()
+	r := &DefinitionReconciler[*v1beta1.TraitDefinition]{
+		Client:                      cli,
+		Record:                      event.NewNopRecorder(),
+		DefinitionKind:              testTraitDefinitionKind,
+		DefinitionReconcilerOptions: DefinitionReconcilerOptions{DefRevLimit: 10},
+	}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trait)})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	latestRevision := trait.Status.LatestRevision
+	schema := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: trait.Status.ConfigMapRef}, schema))
+
+	// an update of an Application only updates the usage of the definitions it references
+	app := usageTestApp("default", "app", "webservice", "scaler")
+	require.NoError(t, cli.Create(ctx, app))
+	revisionsCreated := metrics.DefinitionRevisionCreatedCounter.WithLabelValues(v1beta1.TraitDefinitionKind, "scaler")
+	before := testutil.ToFloat64(revisionsCreated)
+	usageReconciler := &DefinitionUsageReconciler[*v1beta1.TraitDefinition]{Client: cli, DefinitionKind: testTraitDefinitionKind}
+	_, err = usageReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trait)})
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, &common.DefinitionUsage{Applications: 1, Samples: []common.DefinitionUsageRef{{Namespace: "default", Name: "app"}}},
+		trait.Status.Usage)
+	require.Equal(t, latestRevision, trait.Status.LatestRevision)
+	require.Equal(t, before, testutil.ToFloat64(revisionsCreated))
+	updated := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(schema), updated))
+	require.Equal(t, schema.ResourceVersion, updated.ResourceVersion)
+	revisions := &v1beta1.DefinitionRevisionList{}
+	require.NoError(t, cli.List(ctx, revisions))
+	require.Len(t, revisions.Items, 1)
+
+	// definitions out of the shard of the controller are left untouched
+	require.NoError(t, cli.Delete(ctx, app))
+	usageReconciler.ShardSelector = labels.SelectorFromSet(labels.Set{"shard": "other"})
+	_, err = usageReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trait)})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(trait), trait))
+	require.Equal(t, 1, trait.Status.Usage.Applications)
+}
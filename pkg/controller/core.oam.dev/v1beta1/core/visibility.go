@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// ParseDefinitionVisibility parses the selector of the namespaces the definition is visible to from its
+// visible-namespaces annotation. A nil selector is returned for the definitions without the annotation. The
+// annotation is only valid on the definitions in vela-system: applications resolve their definitions from
+// their own namespace and vela-system only, so the definitions of other namespaces are never found by the
+// applications of the selected namespaces.
+func ParseDefinitionVisibility(def client.Object) (labels.Selector, error) {
+	value, found := def.GetAnnotations()[oam.AnnotationDefinitionVisibleNamespaces]
+	if !found {
+		return nil, nil
+	}
+	if def.GetNamespace() != oam.SystemDefinitionNamespace {
+		return nil, fmt.Errorf("the %s annotation is only supported on the definitions in namespace %s",
+			oam.AnnotationDefinitionVisibleNamespaces, oam.SystemDefinitionNamespace)
+	}
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q: %w", oam.AnnotationDefinitionVisibleNamespaces, value, err)
+	}
+	return selector, nil
+}
+
+// IsDefinitionVisible checks whether the applications of the namespace can use the definition. A definition
+// is always visible to its own namespace. Without the visible-namespaces annotation, the definitions in
+// vela-system are visible to all namespaces, otherwise only the namespaces matching the annotation can use it.
+// The definitions of other namespaces are only visible to their own namespace.
+func IsDefinitionVisible(def client.Object, namespace *corev1.Namespace) (bool, error) {
+	if def.GetNamespace() == namespace.Name {
+		return true, nil
+	}
+	selector, err := ParseDefinitionVisibility(def)
+	if err != nil {
+		return false, err
+	}
+	if selector == nil {
+		return def.GetNamespace() == oam.SystemDefinitionNamespace, nil
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// CheckDefinitionVisibility returns the reason why the applications of the namespace cannot use the definition
+// of the kind referred by the type, or an empty string if they can. The @revision suffix of a versioned type is
+// dropped, as the visibility is set on the definition itself. Definitions which cannot be found are left to the
+// definition resolution of the application parser, as they may be built-in types or be applied after the
+// application. The namespace of the application must be set in the context, see util.SetNamespaceInCtx.
+func CheckDefinitionVisibility(ctx context.Context, cli client.Reader, kind, typ string, namespace *corev1.Namespace) (string, error) {
+	var def client.Object
+	switch kind {
+	case v1beta1.ComponentDefinitionKind:
+		def = &v1beta1.ComponentDefinition{}
+	case v1beta1.TraitDefinitionKind:
+		def = &v1beta1.TraitDefinition{}
+	case v1beta1.PolicyDefinitionKind:
+		def = &v1beta1.PolicyDefinition{}
+	case v1beta1.WorkflowStepDefinitionKind:
+		def = &v1beta1.WorkflowStepDefinition{}
+	default:
+		return "", fmt.Errorf("unsupported definition kind %s", kind)
+	}
+	name, _, _ := strings.Cut(typ, "@")
+	if err := util.GetDefinition(ctx, cli, def, name); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	visible, err := IsDefinitionVisible(def, namespace)
+	if err == nil && visible {
+		return "", nil
+	}
+	reason := fmt.Sprintf("%s %q is not visible to namespace %s", kind, typ, namespace.Name)
+	if err != nil {
+		reason = fmt.Sprintf("%s: %s", reason, err.Error())
+	}
+	return reason, nil
+}
+
+func visibilityCondition(def client.Object) condition.Condition {
+	selector, err := ParseDefinitionVisibility(def)
+	switch {
+	case err != nil:
+		return condition.InvalidVisibility(err)
+	case selector == nil && def.GetNamespace() == oam.SystemDefinitionNamespace, selector != nil && selector.Empty():
+		return condition.VisibleToAllNamespaces()
+	case selector == nil:
+		return condition.VisibleToSelectedNamespaces(fmt.Sprintf("visible to namespace %s only", def.GetNamespace()))
+	default:
+		return condition.VisibleToSelectedNamespaces(fmt.Sprintf("visible to the namespaces matching %q", selector.String()))
+	}
+}
+
+// ReconcileVisibilityCondition patches the Visibility condition of the definition from its visible-namespaces
+// annotation, the visibility itself is enforced by the application webhook and controller. The definitions which
+// never had the annotation are left untouched.
+func ReconcileVisibilityCondition(ctx context.Context, cli client.StatusClient, record event.Recorder, def util.ConditionedObject) error {
+	if _, found := def.GetAnnotations()[oam.AnnotationDefinitionVisibleNamespaces]; !found &&
+		def.GetCondition(condition.TypeVisibility).Reason == "" {
+		return nil
+	}
+	cond := visibilityCondition(def)
+	if !util.IsConditionChanged([]condition.Condition{cond}, def) {
+		return nil
+	}
+	if cond.Status == corev1.ConditionFalse {
+		klog.InfoS("The visibility of the definition is invalid", "definition", klog.KObj(def), "message", cond.Message)
+		record.Event(def, event.Warning(event.Reason(cond.Reason), errors.New(cond.Message)))
+	}
+	return util.PatchCondition(ctx, cli, def, cond)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestIsDefinitionVisible(t *testing.T) {
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}}
+	teamB := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}}
+
+	testCases := map[string]struct {
+		namespace   string
+		annotations map[string]string
+		visibleToA  bool
+		visibleToB  bool
+		expectError bool
+	}{
+		"system definitions are visible to all namespaces": {
+			namespace:  oam.SystemDefinitionNamespace,
+			visibleToA: true,
+			visibleToB: true,
+		},
+		"namespaced definitions are visible to their namespace": {
+			namespace:  "team-a",
+			visibleToA: true,
+		},
+		"system definitions restricted to selected namespaces": {
+			namespace:   oam.SystemDefinitionNamespace,
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=b"},
+			visibleToB:  true,
+		},
+		"namespaced definitions cannot be shared with other namespaces": {
+			namespace:   "team-c",
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team in (a,b)"},
+			expectError: true,
+		},
+		"invalid visibility": {
+			namespace:   oam.SystemDefinitionNamespace,
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team in (a"},
+			expectError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			def := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: tc.namespace, Annotations: tc.annotations}}
+			visible, err := IsDefinitionVisible(def, teamA)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.visibleToA, visible)
+			visible, err = IsDefinitionVisible(def, teamB)
+			require.NoError(t, err)
+			require.Equal(t, tc.visibleToB, visible)
+		})
+	}
+}
+
+func TestReconcileVisibilityCondition(t *testing.T) {
+	ctx := context.Background()
+	comp := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: oam.SystemDefinitionNamespace, Name: "worker"}}
+	team := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "worker"}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(comp, team).
+		WithStatusSubresource(comp, team).Build()
+
+	// definitions never restricted have no condition
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.Empty(t, comp.Status.Conditions)
+
+	comp.SetAnnotations(map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=b"})
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	cond := comp.Status.GetCondition(condition.TypeVisibility)
+	require.Equal(t, condition.ReasonVisibleToSelectedNamespaces, cond.Reason)
+	require.Equal(t, `visible to the namespaces matching "team=b"`, cond.Message)
+
+	comp.SetAnnotations(map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team in (b"})
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	cond = comp.Status.GetCondition(condition.TypeVisibility)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonInvalidVisibility, cond.Reason)
+
+	comp.SetAnnotations(nil)
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), comp))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	cond = comp.Status.GetCondition(condition.TypeVisibility)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Equal(t, condition.ReasonVisibleToAllNamespaces, cond.Reason)
+
+	// the definitions of other namespaces cannot be shared
+	team.SetAnnotations(map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=b"})
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), team))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(team), team))
+	cond = team.Status.GetCondition(condition.TypeVisibility)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, condition.ReasonInvalidVisibility, cond.Reason)
+	require.Contains(t, cond.Message, "only supported on the definitions in namespace vela-system")
+
+	team.SetAnnotations(nil)
+	require.NoError(t, ReconcileVisibilityCondition(ctx, cli, event.NewNopRecorder(), team))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(team), team))
+	cond = team.Status.GetCondition(condition.TypeVisibility)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Equal(t, "visible to namespace team-a only", cond.Message)
+}
+
+func TestCheckDefinitionVisibility(t *testing.T) {
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}}
+	gateway := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "gateway",
+		Namespace:   oam.SystemDefinitionNamespace,
+		Annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=b"},
+	}}
+	worker := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "team-a"}}
+	// a definition of another team selecting team-a is not resolved for the applications of team-a
+	billing := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "billing",
+		Namespace:   "team-b",
+		Annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=a"},
+	}}
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(gateway, worker, billing).Build()
+	ctx := util.SetNamespaceInCtx(context.Background(), teamA.Name)
+
+	testCases := map[string]struct {
+		kind   string
+		typ    string
+		reason string
+	}{
+		"visible definition": {
+			kind: v1beta1.ComponentDefinitionKind,
+			typ:  "worker",
+		},
+		"hidden definition": {
+			kind:   v1beta1.TraitDefinitionKind,
+			typ:    "gateway",
+			reason: `TraitDefinition "gateway" is not visible to namespace team-a`,
+		},
+		"hidden definition with revision": {
+			kind:   v1beta1.TraitDefinitionKind,
+			typ:    "gateway@v2",
+			reason: `TraitDefinition "gateway@v2" is not visible to namespace team-a`,
+		},
+		"missing definition": {
+			kind: v1beta1.ComponentDefinitionKind,
+			typ:  "webservice",
+		},
+		"definition of another namespace": {
+			kind: v1beta1.ComponentDefinitionKind,
+			typ:  "billing",
+		},
+		"builtin policy": {
+			kind: v1beta1.PolicyDefinitionKind,
+			typ:  "debug",
+		},
+		"builtin workflow step": {
+			kind: v1beta1.WorkflowStepDefinitionKind,
+			typ:  "suspend",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			reason, err := CheckDefinitionVisibility(ctx, cli, tc.kind, tc.typ, teamA)
+			require.NoError(t, err)
+			require.Equal(t, tc.reason, reason)
+		})
+	}
+}
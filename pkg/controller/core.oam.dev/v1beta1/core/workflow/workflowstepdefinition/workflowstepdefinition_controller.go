@@ -20,24 +20,23 @@ package workflowstepdefinition
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	ctrlrec "github.com/kubevela/pkg/controller/reconciler"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/util/retry"
-	"k8s.io/klog/v2"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
-	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	oamctrl "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
 	"github.com/oam-dev/kubevela/pkg/controller/utils"
-	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/version"
 )
 
@@ -54,108 +53,120 @@ type options struct {
 	concurrentReconciles int
 	ignoreDefNoCtrlReq   bool
 	controllerVersion    string
+	deletionProtection   bool
+	rateLimiter          workqueue.TypedRateLimiter[reconcile.Request]
+	eventAggregator      oamctrl.EventAggregatorArgs
+	shardSelector        labels.Selector
+	verifier             *signature.Verifier
 }
 
 // Reconcile is the main logic for WorkflowStepDefinition controller
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx, cancel := ctrlrec.NewReconcileContext(ctx)
-	defer cancel()
-
-	definitionName := req.NamespacedName.Name
-	klog.InfoS("Reconciling WorkflowStepDefinition...", "Name", definitionName, "Namespace", req.Namespace)
-
-	var wfStepDefinition v1beta1.WorkflowStepDefinition
-	if err := r.Get(ctx, req.NamespacedName, &wfStepDefinition); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	// this is a placeholder for finalizer here in the future
-	if wfStepDefinition.DeletionTimestamp != nil {
-		return ctrl.Result{}, nil
-	}
-
-	if !coredef.MatchControllerRequirement(&wfStepDefinition, r.controllerVersion, r.ignoreDefNoCtrlReq) {
-		klog.InfoS("skip definition: not match the controller requirement of definition", "workflowStepDefinition", klog.KObj(&wfStepDefinition))
-		return ctrl.Result{}, nil
-	}
-
-	defRev, result, err := coredef.ReconcileDefinitionRevision(ctx, r.Client, r.record, &wfStepDefinition, r.defRevLimit, func(revision *common.Revision) error {
-		wfStepDefinition.Status.LatestRevision = revision
-		return r.UpdateStatus(ctx, &wfStepDefinition)
-	})
-	if result != nil {
-		return *result, err
-	}
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	def := utils.NewCapabilityStepDef(&wfStepDefinition)
-	def.Name = req.NamespacedName.Name
-	// Store the parameter of stepDefinition to configMap
-	cmName, err := def.StoreOpenAPISchema(ctx, r.Client, req.Namespace, req.Name, defRev.Name)
-	if err != nil {
-		klog.InfoS("Could not store capability in ConfigMap", "err", err)
-		r.record.Event(&(wfStepDefinition), event.Warning("Could not store capability in ConfigMap", err))
-		return ctrl.Result{}, util.PatchCondition(ctx, r, &wfStepDefinition,
-			condition.ReconcileError(fmt.Errorf(util.ErrStoreCapabilityInConfigMap, wfStepDefinition.Name, err)))
-	}
+	return r.definitionReconciler().Reconcile(ctx, req)
+}
 
-	if wfStepDefinition.Status.ConfigMapRef != cmName {
-		wfStepDefinition.Status.ConfigMapRef = cmName
-		// Override the conditions, which maybe include the error info.
-		wfStepDefinition.Status.Conditions = []condition.Condition{condition.ReconcileSuccess()}
-		if err := r.UpdateStatus(ctx, &wfStepDefinition); err != nil {
-			klog.ErrorS(err, "Could not update WorkflowStepDefinition Status", "workflowStepDefinition", klog.KRef(req.Namespace, req.Name))
-			r.record.Event(&wfStepDefinition, event.Warning("Could not update WorkflowStepDefinition Status", err))
-			return ctrl.Result{}, util.PatchCondition(ctx, r, &wfStepDefinition,
-				condition.ReconcileError(fmt.Errorf(util.ErrUpdateWorkflowStepDefinition, wfStepDefinition.Name, err)))
-		}
-		klog.InfoS("Successfully updated the status.configMapRef of the WorkflowStepDefinition", "workflowStepDefinition",
-			klog.KRef(req.Namespace, req.Name), "status.configMapRef", cmName)
+func (r *Reconciler) definitionReconciler() *coredef.DefinitionReconciler[*v1beta1.WorkflowStepDefinition] {
+	return &coredef.DefinitionReconciler[*v1beta1.WorkflowStepDefinition]{
+		Client:         r.Client,
+		Record:         r.record,
+		DefinitionKind: definitionKind,
+		DefinitionReconcilerOptions: coredef.DefinitionReconcilerOptions{
+			DefRevLimit:        r.defRevLimit,
+			IgnoreDefNoCtrlReq: r.ignoreDefNoCtrlReq,
+			ControllerVersion:  r.controllerVersion,
+			DeletionProtection: r.deletionProtection,
+			ShardSelector:      r.shardSelector,
+			Verifier:           r.verifier,
+		},
 	}
-	return ctrl.Result{}, nil
 }
 
-// UpdateStatus updates v1beta1.WorkflowStepDefinition's Status with retry.RetryOnConflict
-func (r *Reconciler) UpdateStatus(ctx context.Context, def *v1beta1.WorkflowStepDefinition, opts ...client.SubResourceUpdateOption) error {
-	status := def.DeepCopy().Status
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
-		if err = r.Get(ctx, client.ObjectKey{Namespace: def.Namespace, Name: def.Name}, def); err != nil {
-			return
+var definitionKind = coredef.DefinitionKind[*v1beta1.WorkflowStepDefinition]{
+	Kind: v1beta1.WorkflowStepDefinitionKind,
+	New: func() *v1beta1.WorkflowStepDefinition {
+		return &v1beta1.WorkflowStepDefinition{}
+	},
+	GetStatus: func(def *v1beta1.WorkflowStepDefinition) coredef.DefinitionStatus {
+		status := def.Status.DeepCopy()
+		return coredef.DefinitionStatus{
+			ConditionedStatus:  status.ConditionedStatus,
+			ConfigMapRef:       status.ConfigMapRef,
+			Schema:             status.Schema,
+			LatestRevision:     status.LatestRevision,
+			LatestRevisionDiff: status.LatestRevisionDiff,
+			Usage:              status.Usage,
+			ObservedGeneration: status.ObservedGeneration,
 		}
-		def.Status = status
-		return r.Status().Update(ctx, def, opts...)
-	})
+	},
+	SetStatus: func(def *v1beta1.WorkflowStepDefinition, status coredef.DefinitionStatus) {
+		def.Status.ConditionedStatus = status.ConditionedStatus
+		def.Status.ConfigMapRef = status.ConfigMapRef
+		def.Status.Schema = status.Schema
+		def.Status.LatestRevision = status.LatestRevision
+		def.Status.LatestRevisionDiff = status.LatestRevisionDiff
+		def.Status.Usage = status.Usage
+		def.Status.ObservedGeneration = status.ObservedGeneration
+	},
+	Capability: func(def *v1beta1.WorkflowStepDefinition) coredef.DefinitionCapability {
+		capability := utils.NewCapabilityStepDef(def)
+		return &capability
+	},
 }
 
 // SetupWithManager will setup with event recorder
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.record = event.NewAPIRecorder(mgr.GetEventRecorderFor("WorkflowStepDefinition")).
-		WithAnnotations("controller", "WorkflowStepDefinition")
-	return ctrl.NewControllerManagedBy(mgr).
+	r.record = coredef.NewEventRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor("WorkflowStepDefinition")).
+		WithAnnotations("controller", "WorkflowStepDefinition"), r.eventAggregator)
+	err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.concurrentReconciles,
+			RateLimiter:             r.rateLimiter,
 		}).
-		For(&v1beta1.WorkflowStepDefinition{}).
+		For(&v1beta1.WorkflowStepDefinition{}, builder.WithPredicates(coredef.ShardPredicate(r.shardSelector))).
 		Complete(r)
+	if err != nil {
+		return err
+	}
+	usage := &coredef.DefinitionUsageReconciler[*v1beta1.WorkflowStepDefinition]{
+		Client:         r.Client,
+		DefinitionKind: definitionKind,
+		ShardSelector:  r.shardSelector,
+	}
+	return usage.SetupWithManager(mgr, common.WorkflowStepType, controller.Options{MaxConcurrentReconciles: r.concurrentReconciles})
 }
 
 // Setup adds a controller that reconciles WorkflowStepDefinition.
 func Setup(mgr ctrl.Manager, args oamctrl.Args) error {
+	opts, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
 	r := Reconciler{
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
-		options: parseOptions(args),
+		options: opts,
 	}
 	return r.SetupWithManager(mgr)
 }
 
-func parseOptions(args oamctrl.Args) options {
+func parseOptions(args oamctrl.Args) (options, error) {
+	shardSelector, err := coredef.ParseShardSelector(args.DefinitionShardLabel)
+	if err != nil {
+		return options{}, err
+	}
+	verifier, err := signature.LoadVerifier(args.DefinitionSignaturePublicKeys)
+	if err != nil {
+		return options{}, err
+	}
 	return options{
 		defRevLimit:          args.DefRevisionLimit,
 		concurrentReconciles: args.ConcurrentReconciles,
 		ignoreDefNoCtrlReq:   args.IgnoreDefinitionWithoutControllerRequirement,
 		controllerVersion:    version.VelaVersion,
-	}
+		deletionProtection:   args.DefinitionDeletionProtection,
+		rateLimiter:          args.DefinitionRateLimiter.NewRateLimiter(),
+		eventAggregator:      args.DefinitionEvents,
+		shardSelector:        shardSelector,
+		verifier:             verifier,
+	}, nil
 }
@@ -18,8 +18,12 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,6 +36,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +45,7 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
 	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/features"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
 	"github.com/oam-dev/kubevela/pkg/schema"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
@@ -356,14 +362,22 @@ func GetGitSSHPublicKey(ctx context.Context, k8sClient client.Client, gitCredent
 	return publicKey, nil
 }
 
-// StoreOpenAPISchema stores OpenAPI v3 schema in ConfigMap from WorkloadDefinition
-func (def *CapabilityComponentDefinition) StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name, revName string) (string, error) {
+// GenerateDefinitionSchema generates the OpenAPI v3 schema of the ComponentDefinition to be stored in its status
+func (def *CapabilityComponentDefinition) GenerateDefinitionSchema(ctx context.Context, k8sClient client.Client, name string) (*commontypes.DefinitionSchema, error) {
+	jsonSchema, err := def.generateOpenAPISchema(ctx, k8sClient, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefinitionSchema(jsonSchema)
+}
+
+func (def *CapabilityComponentDefinition) generateOpenAPISchema(ctx context.Context, k8sClient client.Client, name string) ([]byte, error) {
 	var jsonSchema []byte
 	var err error
 	switch def.WorkloadType {
 	case util.TerraformDef:
 		if def.Terraform == nil {
-			return "", fmt.Errorf("no Configuration is set in Terraform specification: %s", def.Name)
+			return nil, fmt.Errorf("no Configuration is set in Terraform specification: %s", def.Name)
 		}
 		configuration := def.Terraform.Configuration
 		if def.Terraform.Type == "remote" {
@@ -373,12 +387,12 @@ func (def *CapabilityComponentDefinition) StoreOpenAPISchema(ctx context.Context
 				gitCredentialsSecretReference := def.Terraform.GitCredentialsSecretReference
 				publicKey, err = GetGitSSHPublicKey(ctx, k8sClient, gitCredentialsSecretReference)
 				if err != nil {
-					return "", fmt.Errorf("issue with gitCredentialsSecretReference %s/%s: %w", gitCredentialsSecretReference.Namespace, gitCredentialsSecretReference.Name, err)
+					return nil, fmt.Errorf("issue with gitCredentialsSecretReference %s/%s: %w", gitCredentialsSecretReference.Namespace, gitCredentialsSecretReference.Name, err)
 				}
 			}
 			configuration, err = GetTerraformConfigurationFromRemote(def.Name, def.Terraform.Configuration, def.Terraform.Path, publicKey)
 			if err != nil {
-				return "", fmt.Errorf("cannot get Terraform configuration %s from remote: %w", def.Name, err)
+				return nil, fmt.Errorf("cannot get Terraform configuration %s from remote: %w", def.Name, err)
 			}
 		}
 		jsonSchema, err = GetOpenAPISchemaFromTerraformComponentDefinition(configuration)
@@ -386,7 +400,16 @@ func (def *CapabilityComponentDefinition) StoreOpenAPISchema(ctx context.Context
 		jsonSchema, err = def.GetOpenAPISchema(ctx, name)
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to generate OpenAPI v3 JSON schema for capability %s: %w", def.Name, err)
+		return nil, fmt.Errorf("failed to generate OpenAPI v3 JSON schema for capability %s: %w", def.Name, err)
+	}
+	return jsonSchema, nil
+}
+
+// StoreOpenAPISchema stores OpenAPI v3 schema in ConfigMap from WorkloadDefinition
+func (def *CapabilityComponentDefinition) StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name, revName string) (string, error) {
+	jsonSchema, err := def.generateOpenAPISchema(ctx, k8sClient, name)
+	if err != nil {
+		return "", err
 	}
 	componentDefinition := def.ComponentDefinition
 	ownerReference := []metav1.OwnerReference{{
@@ -449,6 +472,15 @@ func (def *CapabilityTraitDefinition) GetOpenAPISchema(ctx context.Context, name
 	return getOpenAPISchema(ctx, capability)
 }
 
+// GenerateDefinitionSchema generates the OpenAPI v3 schema of the TraitDefinition to be stored in its status
+func (def *CapabilityTraitDefinition) GenerateDefinitionSchema(ctx context.Context, _ client.Client, name string) (*commontypes.DefinitionSchema, error) {
+	jsonSchema, err := def.GetOpenAPISchema(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI v3 JSON schema for capability %s: %w", def.Name, err)
+	}
+	return NewDefinitionSchema(jsonSchema)
+}
+
 // StoreOpenAPISchema stores OpenAPI v3 schema from TraitDefinition in ConfigMap
 func (def *CapabilityTraitDefinition) StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name string, revName string) (string, error) {
 	jsonSchema, err := def.GetOpenAPISchema(ctx, name)
@@ -515,6 +547,15 @@ func (def *CapabilityStepDefinition) GetOpenAPISchema(ctx context.Context, name
 	return getOpenAPISchema(ctx, capability)
 }
 
+// GenerateDefinitionSchema generates the OpenAPI v3 schema of the WorkflowStepDefinition to be stored in its status
+func (def *CapabilityStepDefinition) GenerateDefinitionSchema(ctx context.Context, _ client.Client, name string) (*commontypes.DefinitionSchema, error) {
+	jsonSchema, err := def.GetOpenAPISchema(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI v3 JSON schema for capability %s: %w", def.Name, err)
+	}
+	return NewDefinitionSchema(jsonSchema)
+}
+
 // StoreOpenAPISchema stores OpenAPI v3 schema from StepDefinition in ConfigMap
 func (def *CapabilityStepDefinition) StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name string, revName string) (string, error) {
 	var jsonSchema []byte
@@ -584,6 +625,15 @@ func (def *CapabilityPolicyDefinition) GetOpenAPISchema(ctx context.Context, nam
 	return getOpenAPISchema(ctx, capability)
 }
 
+// GenerateDefinitionSchema generates the OpenAPI v3 schema of the PolicyDefinition to be stored in its status
+func (def *CapabilityPolicyDefinition) GenerateDefinitionSchema(ctx context.Context, _ client.Client, name string) (*commontypes.DefinitionSchema, error) {
+	jsonSchema, err := def.GetOpenAPISchema(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI v3 JSON schema for capability %s: %w", def.Name, err)
+	}
+	return NewDefinitionSchema(jsonSchema)
+}
+
 // StoreOpenAPISchema stores OpenAPI v3 schema from StepDefinition in ConfigMap
 func (def *CapabilityPolicyDefinition) StoreOpenAPISchema(ctx context.Context, k8sClient client.Client, namespace, name, revName string) (string, error) {
 	var jsonSchema []byte
@@ -637,8 +687,12 @@ func (def *CapabilityBaseDefinition) CreateOrUpdateConfigMap(ctx context.Context
 	definitionName, definitionType string, labels map[string]string, appliedWorkloads []string, jsonSchema []byte, ownerReferences []metav1.OwnerReference) (string, error) {
 	cmName := fmt.Sprintf("%s-%s%s", definitionType, types.CapabilityConfigMapNamePrefix, definitionName)
 	var cm v1.ConfigMap
+	schemaData, format, err := EncodeOpenAPISchema(jsonSchema, utilfeature.DefaultMutableFeatureGate.Enabled(features.CompressDefinitionSchema))
+	if err != nil {
+		return cmName, fmt.Errorf(util.ErrUpdateCapabilityInConfigMap, definitionName, err)
+	}
 	var data = map[string]string{
-		types.OpenapiV3JSONSchema: string(jsonSchema),
+		types.OpenapiV3JSONSchema: schemaData,
 	}
 	if labels == nil {
 		labels = make(map[string]string)
@@ -649,10 +703,13 @@ func (def *CapabilityBaseDefinition) CreateOrUpdateConfigMap(ctx context.Context
 	if appliedWorkloads != nil {
 		annotations[types.AnnoDefinitionAppliedWorkloads] = strings.Join(appliedWorkloads, ",")
 	}
+	if format != "" {
+		annotations[types.AnnoDefinitionSchemaFormat] = format
+	}
 
 	// No need to check the existence of namespace, if it doesn't exist, API server will return the error message
 	// before it's to be reconciled by ComponentDefinition/TraitDefinition controller.
-	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cmName}, &cm)
+	err = k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cmName}, &cm)
 	if err != nil && apierrors.IsNotFound(err) {
 		cm = v1.ConfigMap{
 			TypeMeta: metav1.TypeMeta{
@@ -686,6 +743,68 @@ func (def *CapabilityBaseDefinition) CreateOrUpdateConfigMap(ctx context.Context
 	return cmName, nil
 }
 
+// EncodeOpenAPISchema encodes the OpenAPI schema to be stored in a schema ConfigMap. If compress is set, the schema
+// is compressed by gzip and encoded in base64, and the returned format should be set as the format annotation.
+func EncodeOpenAPISchema(jsonSchema []byte, compress bool) (string, string, error) {
+	if !compress {
+		return string(jsonSchema), "", nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(jsonSchema); err != nil {
+		return "", "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), types.SchemaFormatGzipBase64, nil
+}
+
+// DecodeOpenAPISchema returns the OpenAPI schema stored in a schema ConfigMap, decompressing it according to the
+// format annotation of the ConfigMap.
+func DecodeOpenAPISchema(cm *v1.ConfigMap) ([]byte, error) {
+	jsonSchema, err := decodeOpenAPISchema(cm.Data[types.OpenapiV3JSONSchema], cm.GetAnnotations()[types.AnnoDefinitionSchemaFormat])
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema in ConfigMap %s: %w", cm.Name, err)
+	}
+	return jsonSchema, nil
+}
+
+// NewDefinitionSchema encodes the OpenAPI schema to be stored in the status of a definition, it's compressed if the
+// CompressDefinitionSchema feature is enabled.
+func NewDefinitionSchema(jsonSchema []byte) (*commontypes.DefinitionSchema, error) {
+	data, format, err := EncodeOpenAPISchema(jsonSchema, utilfeature.DefaultMutableFeatureGate.Enabled(features.CompressDefinitionSchema))
+	if err != nil {
+		return nil, err
+	}
+	return &commontypes.DefinitionSchema{Format: format, Data: data}, nil
+}
+
+// DecodeDefinitionSchema returns the OpenAPI schema stored in the status of a definition.
+func DecodeDefinitionSchema(schema *commontypes.DefinitionSchema) ([]byte, error) {
+	return decodeOpenAPISchema(schema.Data, schema.Format)
+}
+
+func decodeOpenAPISchema(data string, format string) ([]byte, error) {
+	switch format {
+	case "":
+		return []byte(data), nil
+	case types.SchemaFormatGzipBase64:
+		compressed, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, err
+		}
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = r.Close() }()
+		return io.ReadAll(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
 // getOpenAPISchema is the main function for GetDefinition API
 func getOpenAPISchema(ctx context.Context, capability types.Capability) ([]byte, error) {
 	s, err := schema.ParsePropertiesToSchema(ctx, capability.CueTemplate)
@@ -32,13 +32,17 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
 	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/features"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
 
 	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"golang.org/x/crypto/ssh/testdata"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 )
 
 const TestDir = "testdata/definition"
@@ -535,3 +539,45 @@ func TestGetGitSSHPublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeOpenAPISchema(t *testing.T) {
+	jsonSchema := []byte(`{"properties":{"replicas":{"default":1,"type":"integer"}},"type":"object"}`)
+	for _, compress := range []bool{false, true} {
+		data, format, err := EncodeOpenAPISchema(jsonSchema, compress)
+		assert.NoError(t, err)
+		cm := &corev1.ConfigMap{Data: map[string]string{types.OpenapiV3JSONSchema: data}}
+		if compress {
+			assert.Equal(t, types.SchemaFormatGzipBase64, format)
+			assert.NotEqual(t, string(jsonSchema), data)
+			cm.SetAnnotations(map[string]string{types.AnnoDefinitionSchemaFormat: format})
+		} else {
+			assert.Empty(t, format)
+		}
+		decoded, err := DecodeOpenAPISchema(cm)
+		assert.NoError(t, err)
+		assert.Equal(t, jsonSchema, decoded)
+	}
+
+	cm := &corev1.ConfigMap{Data: map[string]string{types.OpenapiV3JSONSchema: "invalid"}}
+	cm.SetAnnotations(map[string]string{types.AnnoDefinitionSchemaFormat: types.SchemaFormatGzipBase64})
+	_, err := DecodeOpenAPISchema(cm)
+	assert.Error(t, err)
+	cm.SetAnnotations(map[string]string{types.AnnoDefinitionSchemaFormat: "zstd"})
+	_, err = DecodeOpenAPISchema(cm)
+	assert.ErrorContains(t, err, "unsupported format")
+}
+
+func TestDefinitionSchema(t *testing.T) {
+	jsonSchema := []byte(`{"properties":{"image":{"type":"string"}},"required":["image"],"type":"object"}`)
+	schema, err := NewDefinitionSchema(jsonSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, &common.DefinitionSchema{Data: string(jsonSchema)}, schema)
+
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.CompressDefinitionSchema, true)
+	schema, err = NewDefinitionSchema(jsonSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, types.SchemaFormatGzipBase64, schema.Format)
+	decoded, err := DecodeDefinitionSchema(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonSchema, decoded)
+}
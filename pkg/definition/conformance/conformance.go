@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance tests definitions against sample parameters. Each sample is rendered with the
+// template of the definition, and the rendered outputs are validated against the OpenAPI schemas of
+// the cluster and the policy rules. The results can be written as a JUnit report to gate the changes
+// of the definitions in CI.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/cue/definition"
+	velaprocess "github.com/oam-dev/kubevela/pkg/cue/process"
+)
+
+const (
+	// sampleAppName is the name of the application the samples are rendered in
+	sampleAppName = "conformance"
+	// sampleNamespace is the namespace the samples are rendered in
+	sampleNamespace = "default"
+)
+
+// Sample is a set of parameters of a definition to render
+type Sample struct {
+	Name       string
+	Parameters map[string]interface{}
+}
+
+// LoadSamples loads the samples from the YAML or JSON files in the directory. Each file holds the
+// parameters of one sample, named after the file.
+func LoadSamples(dir string) ([]Sample, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var samples []Sample
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, entry.Name())))
+		if err != nil {
+			return nil, err
+		}
+		params := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &params); err != nil {
+			return nil, fmt.Errorf("invalid sample %s: %w", entry.Name(), err)
+		}
+		samples = append(samples, Sample{Name: strings.TrimSuffix(entry.Name(), ext), Parameters: params})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Name < samples[j].Name })
+	return samples, nil
+}
+
+// SchemaValidator validates the rendered objects against their schemas
+type SchemaValidator interface {
+	Validate(ctx context.Context, obj *unstructured.Unstructured) error
+}
+
+// Rule is a policy rule the rendered objects must follow
+type Rule struct {
+	Name  string
+	Check func(obj *unstructured.Unstructured) error
+}
+
+// CUERule returns a rule unifying the rendered objects with the CUE constraint, e.g.
+// `metadata: labels: team: string`. The fields of the constraint must be set in the objects.
+func CUERule(name, constraint string) (Rule, error) {
+	cuectx := cuecontext.New()
+	value := cuectx.CompileString(constraint)
+	if err := value.Err(); err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %s: %w", name, err)
+	}
+	return Rule{Name: name, Check: func(obj *unstructured.Unstructured) error {
+		unified := value.Unify(cuectx.Encode(obj.Object))
+		return unified.Validate(cue.Concrete(true))
+	}}, nil
+}
+
+// Options configure how the samples are validated
+type Options struct {
+	// Validator validates the rendered objects against the OpenAPI schemas, skipped if nil
+	Validator SchemaValidator
+	// Rules are the policy rules of the rendered objects
+	Rules []Rule
+}
+
+// Run renders each sample with the template of the definition and validates the rendered objects.
+// Only ComponentDefinitions and TraitDefinitions with a CUE template are supported, traits are rendered
+// without a workload.
+func Run(ctx context.Context, def *unstructured.Unstructured, samples []Sample, opts Options) (*Report, error) {
+	template, _, err := unstructured.NestedString(def.Object, "spec", "schematic", "cue", "template")
+	if err != nil || template == "" {
+		return nil, fmt.Errorf("%s %s has no CUE template", def.GetKind(), def.GetName())
+	}
+	var newEngine func() definition.AbstractEngine
+	switch def.GetKind() {
+	case v1beta1.ComponentDefinitionKind:
+		newEngine = func() definition.AbstractEngine { return definition.NewWorkloadAbstractEngine(def.GetName()) }
+	case v1beta1.TraitDefinitionKind:
+		newEngine = func() definition.AbstractEngine { return definition.NewTraitAbstractEngine(def.GetName()) }
+	default:
+		return nil, fmt.Errorf("unsupported definition kind %s", def.GetKind())
+	}
+
+	report := &Report{Kind: def.GetKind(), Definition: def.GetName()}
+	for _, sample := range samples {
+		begin := time.Now()
+		result := CaseResult{Sample: sample.Name}
+		objs, err := render(ctx, newEngine(), template, sample)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("render: %s", err.Error()))
+		}
+		for _, obj := range objs {
+			result.Failures = append(result.Failures, validateObject(ctx, obj, opts)...)
+		}
+		result.Outputs = len(objs)
+		result.Objects = objs
+		result.Duration = time.Since(begin)
+		report.Cases = append(report.Cases, result)
+	}
+	return report, nil
+}
+
+func render(ctx context.Context, engine definition.AbstractEngine, template string, sample Sample) ([]*unstructured.Unstructured, error) {
+	pctx := velaprocess.NewContext(velaprocess.ContextData{
+		Ctx:             ctx,
+		AppName:         sampleAppName,
+		CompName:        sample.Name,
+		Namespace:       sampleNamespace,
+		AppRevisionName: sampleAppName + "-v1",
+	})
+	if err := engine.Complete(pctx, template, sample.Parameters); err != nil {
+		return nil, err
+	}
+	base, auxiliaries := pctx.Output()
+	var objs []*unstructured.Unstructured
+	if base != nil {
+		obj, err := base.Unstructured()
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	for _, aux := range auxiliaries {
+		obj, err := aux.Ins.Unstructured()
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func validateObject(ctx context.Context, obj *unstructured.Unstructured, opts Options) []string {
+	ref := obj.GetKind() + "/" + obj.GetName()
+	var failures []string
+	if opts.Validator != nil {
+		if err := opts.Validator.Validate(ctx, obj); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: schema: %s", ref, err.Error()))
+		}
+	}
+	for _, rule := range opts.Rules {
+		if err := rule.Check(obj); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: rule %s: %s", ref, rule.Name, err.Error()))
+		}
+	}
+	return failures
+}
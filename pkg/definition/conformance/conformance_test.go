@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+const workerTemplate = `
+output: {
+	apiVersion: "apps/v1"
+	kind:       "Deployment"
+	metadata: name: context.name
+	spec: {
+		replicas: parameter.replicas
+		template: spec: containers: [{name: context.name, image: parameter.image}]
+	}
+}
+outputs: service: {
+	apiVersion: "v1"
+	kind:       "Service"
+	metadata: name: context.name
+}
+parameter: {
+	image:     string
+	replicas: *1 | int
+}
+`
+
+type replicasValidator struct{}
+
+func (replicasValidator) Validate(_ context.Context, obj *unstructured.Unstructured) error {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if found && replicas > 2 {
+		return fmt.Errorf("spec.replicas: must be at most 2")
+	}
+	return nil
+}
+
+func newWorker() *unstructured.Unstructured {
+	def := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"schematic": map[string]interface{}{"cue": map[string]interface{}{"template": workerTemplate}},
+		},
+	}}
+	def.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(v1beta1.ComponentDefinitionKind))
+	def.SetName("worker")
+	return def
+}
+
+func TestRun(t *testing.T) {
+	samples, err := LoadSamples("testdata/samples")
+	require.NoError(t, err)
+	require.Len(t, samples, 3)
+	require.Equal(t, "default", samples[0].Name)
+
+	rule, err := CUERule("named", `metadata: name: =~"^[a-z]+$"`)
+	require.NoError(t, err)
+	report, err := Run(context.Background(), newWorker(), samples, Options{Validator: replicasValidator{}, Rules: []Rule{rule}})
+	require.NoError(t, err)
+	require.Len(t, report.Cases, 3)
+
+	require.Empty(t, report.Cases[0].Failures)
+	require.Equal(t, 2, report.Cases[0].Outputs)
+	require.Len(t, report.Cases[0].Objects, 2)
+	require.Equal(t, "Deployment", report.Cases[0].Objects[0].GetKind())
+	require.Len(t, report.Cases[1].Failures, 1)
+	require.Contains(t, report.Cases[1].Failures[0], "render")
+	require.Equal(t, []string{"Deployment/replicas: schema: spec.replicas: must be at most 2"}, report.Cases[2].Failures)
+	require.Equal(t, 2, report.Failed())
+	require.True(t, errors.Is(report.Err(), ErrNotConformant))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteJUnit(buf, report))
+	require.Contains(t, buf.String(), `<testsuite name="ComponentDefinition/worker" tests="3" failures="2"`)
+	require.Contains(t, buf.String(), `<testcase name="default" classname="ComponentDefinition/worker"`)
+	require.Contains(t, buf.String(), `<failure message="1 conformance failures" type="conformance">`)
+}
+
+func TestCUERule(t *testing.T) {
+	rule, err := CUERule("team-label", `metadata: labels: team: string`)
+	require.NoError(t, err)
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetLabels(map[string]string{"team": "payments"})
+	require.NoError(t, rule.Check(obj))
+	obj.SetLabels(nil)
+	require.Error(t, rule.Check(obj))
+
+	_, err = CUERule("invalid", `metadata: {`)
+	require.Error(t, err)
+}
+
+func TestRunUnsupported(t *testing.T) {
+	def := newWorker()
+	def.SetKind(v1beta1.PolicyDefinitionKind)
+	_, err := Run(context.Background(), def, nil, Options{})
+	require.Error(t, err)
+
+	def = newWorker()
+	unstructured.RemoveNestedField(def.Object, "spec", "schematic")
+	_, err = Run(context.Background(), def, nil, Options{})
+	require.Error(t, err)
+}
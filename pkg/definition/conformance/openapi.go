@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// gvkExtension is the extension of the OpenAPI schemas listing the kinds they describe
+const gvkExtension = "x-kubernetes-group-version-kind"
+
+// NewOpenAPIValidator returns a SchemaValidator validating the objects against the OpenAPI v3 schemas
+// served by the cluster, e.g. openapi3.NewRoot(discoveryClient.OpenAPIV3()). Objects of kinds the cluster
+// does not serve are invalid.
+func NewOpenAPIValidator(root openapi3.Root) SchemaValidator {
+	return &openAPIValidator{root: root, specs: map[schema.GroupVersion]*groupVersionSpec{}}
+}
+
+type openAPIValidator struct {
+	root  openapi3.Root
+	mu    sync.Mutex
+	specs map[schema.GroupVersion]*groupVersionSpec
+}
+
+type groupVersionSpec struct {
+	// raw is the document the references of the schemas are resolved in
+	raw     map[string]interface{}
+	schemas map[string]*spec.Schema
+}
+
+// Validate validates the object against the schema of its kind
+func (v *openAPIValidator) Validate(_ context.Context, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	gvSpec, err := v.groupVersionSpec(gvk.GroupVersion())
+	if err != nil {
+		return err
+	}
+	s, found := gvSpec.schemas[gvk.Kind]
+	if !found {
+		return fmt.Errorf("the cluster does not serve %s", gvk)
+	}
+	result := validate.NewSchemaValidator(s, gvSpec.raw, "", strfmt.Default).Validate(obj.Object)
+	return result.AsError()
+}
+
+func (v *openAPIValidator) groupVersionSpec(gv schema.GroupVersion) (*groupVersionSpec, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if gvSpec, found := v.specs[gv]; found {
+		return gvSpec, nil
+	}
+	doc, err := v.root.GVSpec(gv)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the OpenAPI schemas of %s: %w", gv, err)
+	}
+	raw, err := v.root.GVSpecAsMap(gv)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the OpenAPI schemas of %s: %w", gv, err)
+	}
+	gvSpec := &groupVersionSpec{raw: raw, schemas: map[string]*spec.Schema{}}
+	if doc.Components != nil {
+		for _, s := range doc.Components.Schemas {
+			gvks, _ := s.Extensions[gvkExtension].([]interface{})
+			for _, item := range gvks {
+				m, _ := item.(map[string]interface{})
+				if m["group"] == gv.Group && m["version"] == gv.Version {
+					if kind, ok := m["kind"].(string); ok {
+						gvSpec.schemas[kind] = s
+					}
+				}
+			}
+		}
+	}
+	v.specs[gv] = gvSpec
+	return gvSpec, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrNotConformant is returned by Report.Err when some samples fail
+var ErrNotConformant = errors.New("the definition is not conformant")
+
+// Report is the result of the conformance tests of a definition
+type Report struct {
+	Kind       string
+	Definition string
+	Cases      []CaseResult
+}
+
+// CaseResult is the result of the conformance test of a sample
+type CaseResult struct {
+	Sample   string
+	Outputs  int
+	Duration time.Duration
+	Failures []string
+	// Objects are the objects rendered for the sample
+	Objects []*unstructured.Unstructured
+}
+
+// Failed returns the number of the failed samples
+func (r *Report) Failed() int {
+	failed := 0
+	for _, c := range r.Cases {
+		if len(c.Failures) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Err returns ErrNotConformant with the failures if some samples fail
+func (r *Report) Err() error {
+	var failures []string
+	for _, c := range r.Cases {
+		for _, failure := range c.Failures {
+			failures = append(failures, fmt.Sprintf("%s: %s", c.Sample, failure))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrNotConformant, strings.Join(failures, "; "))
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the reports as a JUnit XML report, with a test suite per definition and a
+// test case per sample.
+func WriteJUnit(w io.Writer, reports ...*Report) error {
+	suites := junitTestSuites{}
+	for _, r := range reports {
+		suite := junitTestSuite{Name: r.Kind + "/" + r.Definition, Tests: len(r.Cases), Failures: r.Failed()}
+		var total time.Duration
+		for _, c := range r.Cases {
+			total += c.Duration
+			tc := junitTestCase{Name: c.Sample, ClassName: suite.Name, Time: formatSeconds(c.Duration)}
+			if len(c.Failures) > 0 {
+				tc.Failure = &junitFailure{
+					Message: fmt.Sprintf("%d conformance failures", len(c.Failures)),
+					Type:    "conformance",
+					Text:    strings.Join(c.Failures, "\n"),
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suite.Time = formatSeconds(total)
+		suites.Suites = append(suites.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/parser"
+)
+
+// GenerateAST generates the complete CUE definition of the component, like
+// GenerateFullDefinition, as a CUE syntax tree. Tools post-processing the
+// generated definition, e.g. to add imports, rename fields or merge it with
+// other files, can change the tree and print it back with cue/format instead
+// of editing the generated string.
+func (g *CUEGenerator) GenerateAST(c *ComponentDefinition) (*ast.File, error) {
+	return parseDefinitionCUE(DefinitionTypeComponent, c.GetName(), g.GenerateFullDefinition(c))
+}
+
+// DefinitionAST returns the CUE definition generated for a definition of any
+// type as a CUE syntax tree, see CUEGenerator.GenerateAST.
+func DefinitionAST(def Definition) (*ast.File, error) {
+	return parseDefinitionCUE(def.DefType(), def.DefName(), def.ToCue())
+}
+
+// parseDefinitionCUE parses the CUE generated for the named definition,
+// keeping its comments.
+func parseDefinitionCUE(defType DefinitionType, name, cue string) (*ast.File, error) {
+	f, err := parser.ParseFile(name+".cue", cue, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CUE generated for %s %q: %w", defType, name, err)
+	}
+	return f, nil
+}
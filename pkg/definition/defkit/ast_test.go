@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("AST", func() {
+	topLevelLabels := func(f *ast.File) []string {
+		var labels []string
+		for _, decl := range f.Decls {
+			if field, ok := decl.(*ast.Field); ok {
+				name, _, err := ast.LabelName(field.Label)
+				Expect(err).NotTo(HaveOccurred())
+				labels = append(labels, name)
+			}
+		}
+		return labels
+	}
+
+	It("should generate the syntax tree of a component", func() {
+		comp := defkit.WebServiceScaffold()
+
+		f, err := defkit.NewCUEGenerator().GenerateAST(comp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(topLevelLabels(f)).To(ContainElements("webservice", "template"))
+		Expect(f.Imports).NotTo(BeEmpty())
+
+		// The tree can be changed and printed back
+		for _, decl := range f.Decls {
+			if field, ok := decl.(*ast.Field); ok {
+				if name, _, _ := ast.LabelName(field.Label); name == "webservice" {
+					field.Label = ast.NewString("web-service")
+				}
+			}
+		}
+		out, err := format.Node(f)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(`"web-service": {`))
+		Expect(string(out)).To(ContainSubstring("image: parameter.image"))
+	})
+
+	It("should generate the syntax tree of a definition of any type", func() {
+		f, err := defkit.DefinitionAST(defkit.LabelsTrait())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(topLevelLabels(f)).To(ContainElements("labels", "template"))
+	})
+
+	It("should report the raw CUE that does not parse", func() {
+		comp := defkit.NewComponent("broken").RawCUE("broken: {")
+		_, err := defkit.DefinitionAST(comp)
+		Expect(err).To(MatchError(ContainSubstring(`component "broken"`)))
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+// WebServiceScaffold returns a component definition for a long-running,
+// scalable service, to be used as a working baseline of custom components.
+// Its image, ports, env, cpu and memory parameters are wired to a Deployment,
+// the main output. A Service exposing the ports with expose set is generated
+// when there is one, of the type of the exposeType parameter, and an Ingress
+// routing the host of the ingress parameter to a port of the Service when the
+// parameter is set.
+//
+// The definition is named "webservice" and can be customized like any other
+// one, e.g. to add a parameter and an output on top of the scaffold:
+//
+//	ws := defkit.WebServiceScaffold()
+//	scaffold := ws.GetTemplate()
+//	ws.Params(defkit.String("serviceAccountName")).
+//		Template(func(tpl *defkit.Template) {
+//			scaffold(tpl)
+//			tpl.Outputs("monitor", ...)
+//		})
+func WebServiceScaffold() *ComponentDefinition {
+	image := String("image").Required().Description("Which image would you like to use for your service")
+	ports := List("ports").Default([]any{}).Description("Which ports do you want customer traffic sent to").
+		WithFields(
+			Int("port").Required().Description("Number of port to expose on the pod's IP address"),
+			String("name").Optional().Description("Name of the port"),
+			Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
+			Bool("expose").Default(false).Description("Specify if the port should be exposed"),
+		)
+	env := List("env").Optional().Description("Define arguments by using environment variables").
+		WithFields(
+			String("name").Required().Description("Environment variable name"),
+			String("value").Optional().Description("The value of the environment variable"),
+		)
+	cpu := String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+	exposeType := Enum("exposeType").Values("ClusterIP", "NodePort", "LoadBalancer").Default("ClusterIP").
+		Description("Specify what kind of Service you want. options: \"ClusterIP\", \"NodePort\", \"LoadBalancer\"")
+	ingress := Struct("ingress").Optional().Description("Route the traffic of a host to the service").
+		WithFields(
+			Field("host", ParamTypeString).Required().Description("Host the traffic is routed from"),
+			Field("path", ParamTypeString).Default("/").Description("Path prefix the traffic is routed from"),
+			Field("port", ParamTypeInt).Required().Description("Exposed port of the service the traffic is routed to"),
+		)
+
+	return NewComponent("webservice").
+		Description("Describes long-running, scalable, containerized services that have a stable network endpoint to receive external network traffic from customers.").
+		Workload("apps/v1", "Deployment").
+		Params(image, ports, env, cpu, memory, exposeType, ingress).
+		Template(func(tpl *Template) {
+			vela := VelaCtx()
+			containerPorts := Each(ports).Map(FieldMap{
+				"containerPort": F("port"),
+				"name":          F("name").Or(Format("port-%v", F("port"))),
+				"protocol":      F("protocol"),
+			})
+			containerEnv := Each(env).Map(FieldMap{
+				"name":  F("name"),
+				"value": Optional("value"),
+			})
+
+			tpl.Output(
+				NewResource("apps/v1", "Deployment").
+					Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+					Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+					Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+					Set("spec.template.spec.containers[0].name", vela.Name()).
+					Set("spec.template.spec.containers[0].image", image).
+					Set("spec.template.spec.containers[0].ports", containerPorts).
+					SetIf(env.IsSet(), "spec.template.spec.containers[0].env", containerEnv).
+					SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
+					SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
+					SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
+					SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory),
+			)
+
+			tpl.OutputsIf(HasExposedPorts(ports), "webserviceExpose",
+				NewResource("v1", "Service").
+					Set("metadata.name", vela.Name()).
+					Set("spec.selector[app.oam.dev/component]", vela.Name()).
+					Set("spec.type", exposeType).
+					Set("spec.ports", Each(ports).Filter(FieldEquals("expose", true)).Map(FieldMap{
+						"port":       F("port"),
+						"targetPort": F("port"),
+						"name":       F("name").Or(Format("port-%v", F("port"))),
+						"protocol":   F("protocol"),
+					})),
+			)
+			tpl.OutputsIf(ingress.IsSet(), "webserviceIngress",
+				NewResource("networking.k8s.io/v1", "Ingress").
+					Set("metadata.name", vela.Name()).
+					Set("spec.rules[0].host", ingress.Field("host")).
+					Set("spec.rules[0].http.paths[0].path", ingress.Field("path")).
+					Set("spec.rules[0].http.paths[0].pathType", Lit("Prefix")).
+					Set("spec.rules[0].http.paths[0].backend.service.name", vela.Name()).
+					Set("spec.rules[0].http.paths[0].backend.service.port.number", ingress.Field("port")),
+			)
+		})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Builtin Components", func() {
+
+	Context("WebServiceScaffold", func() {
+		It("should wire the parameters to a Deployment and conditional Service and Ingress", func() {
+			comp := defkit.WebServiceScaffold()
+			Expect(comp.GetName()).To(Equal("webservice"))
+			Expect(comp.GetWorkload().Kind()).To(Equal("Deployment"))
+
+			cue := comp.ToCue()
+			Expect(cue).To(ContainSubstring(`"port-" + strconv.FormatInt(v.port, 10)`))
+			Expect(cue).To(ContainSubstring("image: parameter.image"))
+			Expect(cue).To(ContainSubstring("for v in parameter.ports"))
+			Expect(cue).To(ContainSubstring("for v in parameter.env"))
+			Expect(cue).To(ContainSubstring("cpu: parameter.cpu"))
+			Expect(cue).To(ContainSubstring("memory: parameter.memory"))
+			Expect(cue).To(ContainSubstring("if len([for p in parameter.ports if p.expose == true { p }]) > 0"))
+			Expect(cue).To(ContainSubstring("webserviceExpose: {"))
+			Expect(cue).To(ContainSubstring("type: parameter.exposeType"))
+			Expect(cue).To(ContainSubstring("webserviceIngress: {"))
+			Expect(cue).To(ContainSubstring("host: parameter.ingress.host"))
+			Expect(cue).To(ContainSubstring(`exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"`))
+		})
+
+		It("should generate the Service only when a port is exposed", func() {
+			comp := defkit.WebServiceScaffold()
+
+			outputs := comp.RenderAll(defkit.TestContext().
+				WithName("web").
+				WithParam("image", "nginx").
+				WithParam("ports", []map[string]any{{"port": 80, "expose": false}}))
+			Expect(outputs.Primary.Get("spec.template.spec.containers[0].image")).To(Equal("nginx"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("webserviceExpose"))
+
+			outputs = comp.RenderAll(defkit.TestContext().
+				WithName("web").
+				WithParam("image", "nginx").
+				WithParam("ports", []map[string]any{{"port": 80, "expose": true}}))
+			Expect(outputs.Auxiliary).To(HaveKey("webserviceExpose"))
+			Expect(outputs.Auxiliary["webserviceExpose"].Kind()).To(Equal("Service"))
+		})
+
+		It("should be customizable from the scaffold", func() {
+			comp := defkit.WebServiceScaffold()
+			scaffold := comp.GetTemplate()
+			account := defkit.String("serviceAccountName")
+			comp.Params(account).
+				Template(func(tpl *defkit.Template) {
+					scaffold(tpl)
+					tpl.Outputs("account", defkit.NewResource("v1", "ServiceAccount").
+						Set("metadata.name", account))
+				})
+
+			cue := comp.ToCue()
+			Expect(cue).To(ContainSubstring("webserviceExpose: {"))
+			Expect(cue).To(ContainSubstring("account: {"))
+			Expect(cue).To(ContainSubstring("serviceAccountName: string"))
+		})
+	})
+})
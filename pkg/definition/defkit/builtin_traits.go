@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+// MergeStrategy is the patch strategy used by the prebuilt metadata traits
+// to merge their parameter into the workload.
+type MergeStrategy string
+
+const (
+	// MergeStrategyJSONMerge merges the keys into the existing ones, a key set
+	// to null is removed from the workload. This is the strategy of the
+	// built-in annotations and labels traits.
+	MergeStrategyJSONMerge MergeStrategy = "jsonMergePatch"
+	// MergeStrategyRetainKeys replaces the existing keys with the parameter.
+	MergeStrategyRetainKeys MergeStrategy = "retainKeys"
+	// MergeStrategyStrategic merges the keys with the default strategic merge
+	// patch, a key already set on the workload to another value conflicts.
+	MergeStrategyStrategic MergeStrategy = ""
+)
+
+// AnnotationsTrait returns a trait definition equivalent to the built-in
+// annotations trait. It adds the annotations of its parameter to the workload,
+// and to the pod template or job template the workload generates.
+// The merge strategy defaults to MergeStrategyJSONMerge.
+//
+// Example:
+//
+//	defkit.AnnotationsTrait(defkit.MergeStrategyRetainKeys).ToCue()
+func AnnotationsTrait(strategy ...MergeStrategy) *TraitDefinition {
+	return metadataTrait("annotations", "annotations",
+		"Add annotations on your workload. If it generates pod or job, add same annotations for generated pods.",
+		true, strategy)
+}
+
+// LabelsTrait returns a trait definition equivalent to the built-in labels
+// trait. It adds the labels of its parameter to the workload and to the pod
+// template the workload generates.
+// The merge strategy defaults to MergeStrategyJSONMerge.
+func LabelsTrait(strategy ...MergeStrategy) *TraitDefinition {
+	return metadataTrait("labels", "labels",
+		"Add labels on your workload. if it generates pod, add same label for generated pods.",
+		false, strategy)
+}
+
+// metadataTrait builds a trait patching the metadata field of the workload,
+// of its pod template and optionally of its job template.
+func metadataTrait(name, field, description string, jobTemplate bool, strategy []MergeStrategy) *TraitDefinition {
+	s := MergeStrategyJSONMerge
+	if len(strategy) > 0 {
+		s = strategy[0]
+	}
+	param := DynamicMap().ValueType(ParamTypeString)
+	if s == MergeStrategyJSONMerge {
+		// null removes the key with a JSON merge patch
+		param.ValueTypeUnion("string | null")
+	}
+	content := field + "Content"
+	output := ContextOutput()
+
+	return NewTrait(name).
+		Description(description).
+		AppliesTo("*").
+		PodDisruptive(true).
+		Param(param).
+		Template(func(tpl *Template) {
+			tpl.PatchStrategy(string(s))
+			tpl.AddLetBinding(content, ForEachMap())
+			patch := tpl.Patch().
+				Set("metadata."+field, LetVariable(content)).
+				If(And(output.HasPath("spec"), output.HasPath("spec.template"))).
+				Set("spec.template.metadata."+field, LetVariable(content)).
+				EndIf()
+			if !jobTemplate {
+				return
+			}
+			patch.
+				If(And(output.HasPath("spec"), output.HasPath("spec.jobTemplate"))).
+				Set("spec.jobTemplate.metadata."+field, LetVariable(content)).
+				EndIf().
+				If(And(
+					output.HasPath("spec"),
+					output.HasPath("spec.jobTemplate"),
+					output.HasPath("spec.jobTemplate.spec"),
+					output.HasPath("spec.jobTemplate.spec.template"),
+				)).
+				Set("spec.jobTemplate.spec.template.metadata."+field, LetVariable(content)).
+				EndIf()
+		})
+}
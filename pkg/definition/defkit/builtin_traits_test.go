@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Builtin Traits", func() {
+
+	Context("AnnotationsTrait", func() {
+		It("should mirror the built-in annotations trait", func() {
+			trait := defkit.AnnotationsTrait()
+			Expect(trait.GetName()).To(Equal("annotations"))
+
+			cue := trait.ToCue()
+			Expect(cue).To(ContainSubstring("// +patchStrategy=jsonMergePatch"))
+			Expect(cue).To(ContainSubstring("let annotationsContent ="))
+			Expect(cue).To(ContainSubstring("for k, v in parameter"))
+			Expect(cue).To(ContainSubstring("metadata: annotations: annotationsContent"))
+			Expect(cue).To(ContainSubstring("context.output.spec.template != _|_"))
+			Expect(cue).To(ContainSubstring("context.output.spec.jobTemplate.spec.template != _|_"))
+			Expect(cue).To(ContainSubstring("parameter: [string]: string | null"))
+		})
+
+		It("should use the given merge strategy", func() {
+			cue := defkit.AnnotationsTrait(defkit.MergeStrategyRetainKeys).ToCue()
+			Expect(cue).To(ContainSubstring("// +patchStrategy=retainKeys"))
+			Expect(cue).To(ContainSubstring("parameter: [string]: string\n"))
+			Expect(cue).NotTo(ContainSubstring("string | null"))
+		})
+	})
+
+	Context("LabelsTrait", func() {
+		It("should mirror the built-in labels trait", func() {
+			trait := defkit.LabelsTrait()
+			Expect(trait.GetName()).To(Equal("labels"))
+
+			cue := trait.ToCue()
+			Expect(cue).To(ContainSubstring("// +patchStrategy=jsonMergePatch"))
+			Expect(cue).To(ContainSubstring("let labelsContent ="))
+			Expect(cue).To(ContainSubstring("metadata: labels: labelsContent"))
+			Expect(cue).To(ContainSubstring("context.output.spec.template != _|_"))
+			Expect(cue).NotTo(ContainSubstring("jobTemplate"))
+		})
+
+		It("should omit the patch strategy for strategic merge", func() {
+			cue := defkit.LabelsTrait(defkit.MergeStrategyStrategic).ToCue()
+			Expect(cue).NotTo(ContainSubstring("+patchStrategy"))
+		})
+	})
+})
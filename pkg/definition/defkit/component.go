@@ -17,6 +17,8 @@ limitations under the License.
 package defkit
 
 import (
+	"strings"
+
 	"sigs.k8s.io/yaml"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
@@ -34,6 +36,7 @@ type ComponentDefinition struct {
 	labels             map[string]string // metadata labels for the component definition
 	childResourceKinds []common.ChildResourceKind
 	podSpecPath        string
+	overlays           []*Overlay // cluster-specific field assignments, see Overlay
 }
 
 // WorkloadType represents the workload type for a component.
@@ -175,16 +178,44 @@ func (c *ComponentDefinition) Helper(name string, param Param) *ComponentDefinit
 	return c
 }
 
-// Labels sets metadata labels on the component definition.
+// Labels adds metadata labels to the component definition.
+// The labels are copied and merged with the ones already set, including those
+// set via CatalogLabels; multiple calls accumulate.
 // Usage: component.Labels(map[string]string{"ui-hidden": "true"})
 func (c *ComponentDefinition) Labels(labels map[string]string) *ComponentDefinition {
-	c.labels = labels
+	if c.labels == nil {
+		c.labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		c.labels[k] = v
+	}
 	return c
 }
 
 // GetLabels returns the component's metadata labels.
 func (c *ComponentDefinition) GetLabels() map[string]string { return c.labels }
 
+// CatalogLabelPrefix is the label prefix VelaUX uses to classify definitions in its catalog.
+const CatalogLabelPrefix = "custom.definition.oam.dev/"
+
+// CatalogLabels adds catalog classification labels to the component definition.
+// Keys without a prefix are placed under CatalogLabelPrefix, so "category" becomes
+// "custom.definition.oam.dev/category". Keys that already contain a "/" are used as-is.
+// Labels are merged with any set via Labels; multiple calls accumulate.
+// Usage: component.CatalogLabels(map[string]string{"category": "database"})
+func (c *ComponentDefinition) CatalogLabels(labels map[string]string) *ComponentDefinition {
+	if c.labels == nil {
+		c.labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		if !strings.Contains(k, "/") {
+			k = CatalogLabelPrefix + k
+		}
+		c.labels[k] = v
+	}
+	return c
+}
+
 // ChildResourceKind adds a child resource kind entry to the component definition.
 // Multiple calls accumulate entries.
 func (c *ComponentDefinition) ChildResourceKind(apiVersion, kind string, selector map[string]string) *ComponentDefinition {
@@ -339,6 +370,10 @@ func (c *ComponentDefinition) ToYAML() ([]byte, error) {
 		}
 	}
 
+	if len(c.labels) > 0 {
+		cr["metadata"].(map[string]any)["labels"] = c.labels
+	}
+
 	if len(c.childResourceKinds) > 0 {
 		cr["spec"].(map[string]any)["childResourceKinds"] = c.childResourceKinds
 	}
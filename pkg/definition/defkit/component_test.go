@@ -837,6 +837,51 @@ var _ = Describe("ComponentDefinition", func() {
 		})
 	})
 
+	Context("CatalogLabels", func() {
+		It("should prefix bare keys with the catalog label prefix", func() {
+			c := defkit.NewComponent("mysql").CatalogLabels(map[string]string{"category": "database"})
+			Expect(c.GetLabels()).To(HaveKeyWithValue("custom.definition.oam.dev/category", "database"))
+		})
+
+		It("should keep fully qualified keys as-is", func() {
+			c := defkit.NewComponent("mysql").CatalogLabels(map[string]string{"custom.definition.oam.dev/ui-hidden": "true"})
+			Expect(c.GetLabels()).To(HaveKeyWithValue("custom.definition.oam.dev/ui-hidden", "true"))
+		})
+
+		It("should merge with labels set via Labels", func() {
+			c := defkit.NewComponent("mysql").
+				Labels(map[string]string{"team": "data"}).
+				CatalogLabels(map[string]string{"category": "database"})
+			Expect(c.GetLabels()).To(HaveLen(2))
+			Expect(c.GetLabels()).To(HaveKeyWithValue("team", "data"))
+		})
+
+		It("should keep catalog labels when Labels is called afterwards", func() {
+			c := defkit.NewComponent("mysql").
+				CatalogLabels(map[string]string{"category": "database"}).
+				Labels(map[string]string{"team": "data"})
+			Expect(c.GetLabels()).To(HaveKeyWithValue("custom.definition.oam.dev/category", "database"))
+			Expect(c.GetLabels()).To(HaveKeyWithValue("team", "data"))
+		})
+
+		It("should not mutate the map passed to Labels", func() {
+			labels := map[string]string{"team": "data"}
+			defkit.NewComponent("mysql").
+				Labels(labels).
+				CatalogLabels(map[string]string{"category": "database"})
+			Expect(labels).To(Equal(map[string]string{"team": "data"}))
+		})
+
+		It("should emit catalog labels in CUE and YAML", func() {
+			c := defkit.NewComponent("mysql").CatalogLabels(map[string]string{"category": "database"})
+			Expect(c.ToCue()).To(ContainSubstring(`"custom.definition.oam.dev/category": "database"`))
+
+			yamlBytes, err := c.ToYAML()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(yamlBytes)).To(ContainSubstring("custom.definition.oam.dev/category: database"))
+		})
+	})
+
 	Context("Outputs ordering", func() {
 		It("should render output names sorted alphabetically in CUE", func() {
 			comp := defkit.NewComponent("webservice").
@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ConditionError reports a condition of a template which can never be true,
+// so the block it guards is dead CUE.
+type ConditionError struct {
+	// Location is the output and field guarded by the condition, e.g. "output: spec.replicas"
+	Location string
+	// Condition is the condition rendered as CUE
+	Condition string
+	// Reason explains why the condition can never be true
+	Reason string
+}
+
+func (e *ConditionError) Error() string {
+	return fmt.Sprintf("%s: condition `%s` can never be true: %s", e.Location, e.Condition, e.Reason)
+}
+
+// Validate checks the conditions of the component template and reports the
+// ones which can never be true, such as And(p.IsSet(), Not(p.IsSet())) or the
+// comparison of an enum parameter with a value outside the enum.
+// The returned error joins a ConditionError per unreachable block.
+func (c *ComponentDefinition) Validate() error {
+	return validateTemplateConditions(c.GetTemplate(), c.GetParams())
+}
+
+// Validate checks the conditions of the trait template and reports the ones
+// which can never be true, see ComponentDefinition.Validate.
+func (t *TraitDefinition) Validate() error {
+	return validateTemplateConditions(t.GetTemplate(), t.GetParams())
+}
+
+// validateTemplateConditions executes the template and checks the conditions
+// of its outputs and patch.
+func validateTemplateConditions(templateFn func(tpl *Template), params []Param) error {
+	if templateFn == nil {
+		return nil
+	}
+	tpl := NewTemplate()
+	templateFn(tpl)
+
+	v := &conditionValidator{params: map[string]Param{}, gen: NewCUEGenerator()}
+	for _, p := range params {
+		v.params[p.Name()] = p
+	}
+	if output := tpl.GetOutput(); output != nil {
+		v.checkOps("output", output.Ops(), nil)
+	}
+	outputs := tpl.GetOutputs()
+	for _, name := range sortedKeys(outputs) {
+		res := outputs[name]
+		location := "outputs." + name
+		if res.outputCondition != nil && !v.check(location, res.outputCondition) {
+			continue
+		}
+		v.checkOps(location, res.Ops(), res.outputCondition)
+	}
+	for _, group := range tpl.GetOutputGroups() {
+		names := sortedKeys(group.outputs)
+		if !v.check(fmt.Sprintf("outputs group %v", names), group.cond) {
+			continue
+		}
+		for _, name := range names {
+			v.checkOps("outputs."+name, group.outputs[name].Ops(), group.cond)
+		}
+	}
+	if patch := tpl.GetPatch(); patch != nil {
+		v.checkOps("patch", patch.Ops(), nil)
+	}
+	return errors.Join(v.errs...)
+}
+
+// conditionValidator collects the conditions which can never be true.
+type conditionValidator struct {
+	params map[string]Param
+	gen    *CUEGenerator
+	errs   []error
+}
+
+// checkOps checks the conditions of the operations, combined with the
+// condition of the enclosing block. The operations of an unreachable block
+// are not checked again.
+func (v *conditionValidator) checkOps(location string, ops []ResourceOp, outer Condition) {
+	for _, op := range ops {
+		switch o := op.(type) {
+		case *SetIfOp:
+			v.check(location+": "+o.Path(), andCondition(outer, o.Cond()))
+		case *SpreadIfOp:
+			v.check(location+": "+o.Path(), andCondition(outer, o.Cond()))
+		case *IfBlock:
+			cond := andCondition(outer, o.Cond())
+			if v.check(location+": if block", cond) {
+				v.checkOps(location, o.Ops(), cond)
+			}
+		}
+	}
+}
+
+// check records an error if the condition can never be true, and returns
+// whether the condition may be true.
+func (v *conditionValidator) check(location string, cond Condition) bool {
+	if cond == nil {
+		return true
+	}
+	f := newConditionFacts(v.params)
+	reason := f.add(cond, false)
+	if reason == "" {
+		return true
+	}
+	v.errs = append(v.errs, &ConditionError{Location: location, Condition: v.gen.conditionToCUE(cond), Reason: reason})
+	return false
+}
+
+// andCondition returns the conjunction of the conditions, outer may be nil.
+func andCondition(outer, cond Condition) Condition {
+	if outer == nil {
+		return cond
+	}
+	return &AndCondition{left: outer, right: cond}
+}
+
+// conditionFacts are the facts about the parameters implied by a conjunction
+// of conditions.
+type conditionFacts struct {
+	params map[string]Param
+	set    map[string]bool
+	eq     map[string]any
+	ne     map[string][]any
+}
+
+func newConditionFacts(params map[string]Param) *conditionFacts {
+	return &conditionFacts{params: params, set: map[string]bool{}, eq: map[string]any{}, ne: map[string][]any{}}
+}
+
+func (f *conditionFacts) clone() *conditionFacts {
+	c := newConditionFacts(f.params)
+	for k, v := range f.set {
+		c.set[k] = v
+	}
+	for k, v := range f.eq {
+		c.eq[k] = v
+	}
+	for k, v := range f.ne {
+		c.ne[k] = append([]any(nil), v...)
+	}
+	return c
+}
+
+// add adds the facts implied by the condition, or by its negation, and returns
+// the reason of the contradiction if the facts can not all be true.
+// Conditions which are not understood imply no facts.
+func (f *conditionFacts) add(cond Condition, negated bool) string {
+	switch c := cond.(type) {
+	case *NotExpr:
+		return f.add(c.Cond(), !negated)
+	case *AndCondition:
+		if negated {
+			return f.addAny([]Condition{c.left, c.right}, true)
+		}
+		return f.addAll([]Condition{c.left, c.right}, false)
+	case *AllConditionsCondition:
+		if negated {
+			return f.addAny(c.Conditions(), true)
+		}
+		return f.addAll(c.Conditions(), false)
+	case *LogicalExpr:
+		if (c.Op() == OpAnd) != negated {
+			return f.addAll(c.Conditions(), negated)
+		}
+		return f.addAny(c.Conditions(), negated)
+	case *IsSetCondition:
+		return f.addSet(c.ParamName(), !negated)
+	case *ParamCompareCondition:
+		return f.addComparison(c.ParamName(), c.Op(), c.CompareValue(), negated)
+	case *Comparison:
+		name, value, ok := paramLiteralComparison(c)
+		if !ok {
+			return ""
+		}
+		return f.addComparison(name, string(c.Op()), value, negated)
+	}
+	return ""
+}
+
+// addAll adds the facts of all the conditions.
+func (f *conditionFacts) addAll(conds []Condition, negated bool) string {
+	for _, cond := range conds {
+		if reason := f.add(cond, negated); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// addAny adds the facts of one of the conditions. It is a contradiction only
+// if each of the conditions contradicts the known facts.
+func (f *conditionFacts) addAny(conds []Condition, negated bool) string {
+	var reasons []string
+	for _, cond := range conds {
+		branch := f.clone()
+		reason := branch.add(cond, negated)
+		if reason == "" {
+			return ""
+		}
+		reasons = append(reasons, reason)
+	}
+	switch len(reasons) {
+	case 0:
+		return ""
+	case 1:
+		return reasons[0]
+	}
+	return fmt.Sprintf("every alternative is unreachable (%v)", reasons)
+}
+
+func (f *conditionFacts) addSet(name string, set bool) string {
+	if known, ok := f.set[name]; ok && known != set {
+		return fmt.Sprintf("parameter %q is required to be both set and not set", name)
+	}
+	f.set[name] = set
+	return ""
+}
+
+func (f *conditionFacts) addComparison(name, op string, value any, negated bool) string {
+	switch {
+	case op == string(OpEq) && !negated, op == string(OpNe) && negated:
+		if values, closed := f.enumValues(name); closed {
+			if s, ok := value.(string); ok && !containsString(values, s) {
+				return fmt.Sprintf("parameter %q is compared with %q which is not one of its values %v", name, s, values)
+			}
+		}
+		if known, ok := f.eq[name]; ok && !reflect.DeepEqual(known, value) {
+			return fmt.Sprintf("parameter %q is required to equal both %v and %v", name, known, value)
+		}
+		for _, excluded := range f.ne[name] {
+			if reflect.DeepEqual(excluded, value) {
+				return fmt.Sprintf("parameter %q is required to both equal and differ from %v", name, value)
+			}
+		}
+		f.eq[name] = value
+	case op == string(OpNe) && !negated, op == string(OpEq) && negated:
+		if known, ok := f.eq[name]; ok && reflect.DeepEqual(known, value) {
+			return fmt.Sprintf("parameter %q is required to both equal and differ from %v", name, value)
+		}
+		f.ne[name] = append(f.ne[name], value)
+	}
+	return ""
+}
+
+// enumValues returns the allowed values of the parameter, closed is false if
+// the parameter accepts any value.
+func (f *conditionFacts) enumValues(name string) (values []string, closed bool) {
+	var param Param = f.params[name]
+	if e, ok := param.(typedEnum); ok {
+		param = e.enum()
+	}
+	switch p := param.(type) {
+	case *EnumParam:
+		values = p.GetValues()
+	case *StringParam:
+		if p.IsOpenEnum() {
+			return nil, false
+		}
+		values = p.GetEnumValues()
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	values = append([]string(nil), values...)
+	sort.Strings(values)
+	return values, true
+}
+
+// paramLiteralComparison returns the parameter and the literal compared by the
+// comparison, in either order.
+func paramLiteralComparison(c *Comparison) (name string, value any, ok bool) {
+	param, lit := c.Left(), c.Right()
+	if _, isLit := param.(*Literal); isLit {
+		param, lit = lit, param
+	}
+	if e, isEnum := param.(typedEnum); isEnum {
+		param = e.enum()
+	}
+	p, isParam := param.(Param)
+	l, isLit := lit.(*Literal)
+	if !isParam || !isLit {
+		return "", nil, false
+	}
+	return p.Name(), l.Val(), true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Condition Validation", func() {
+	replicas := defkit.Int("replicas")
+	exposeType := defkit.Enum("exposeType").Values("ClusterIP", "NodePort")
+
+	newComponent := func(fn func(r *defkit.Resource)) *defkit.ComponentDefinition {
+		return defkit.NewComponent("test").
+			Workload("apps/v1", "Deployment").
+			Params(replicas, exposeType).
+			Template(func(tpl *defkit.Template) {
+				r := defkit.NewResource("apps/v1", "Deployment")
+				fn(r)
+				tpl.Output(r)
+			})
+	}
+
+	It("should accept satisfiable conditions", func() {
+		c := newComponent(func(r *defkit.Resource) {
+			r.SetIf(replicas.IsSet(), "spec.replicas", replicas).
+				SetIf(defkit.Or(replicas.IsSet(), defkit.Not(replicas.IsSet())), "spec.paused", defkit.Lit(false)).
+				SetIf(exposeType.Eq("NodePort"), "metadata.labels.exposed", defkit.Lit("true"))
+		})
+		Expect(c.Validate()).To(Succeed())
+	})
+
+	It("should report a parameter both set and not set", func() {
+		c := newComponent(func(r *defkit.Resource) {
+			r.SetIf(defkit.And(replicas.IsSet(), defkit.Not(replicas.IsSet())), "spec.replicas", replicas)
+		})
+		err := c.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("output: spec.replicas"))
+		Expect(err.Error()).To(ContainSubstring(`parameter "replicas" is required to be both set and not set`))
+
+		var condErr *defkit.ConditionError
+		Expect(errors.As(err, &condErr)).To(BeTrue())
+		Expect(condErr.Location).To(Equal("output: spec.replicas"))
+	})
+
+	It("should report a comparison with a value outside the enum", func() {
+		c := newComponent(func(r *defkit.Resource) {
+			r.SetIf(defkit.Eq(exposeType, defkit.Lit("LoadBalancer")), "spec.type", exposeType)
+		})
+		Expect(c.Validate()).To(MatchError(ContainSubstring(`compared with "LoadBalancer" which is not one of its values`)))
+	})
+
+	It("should report contradicting comparisons combined with the enclosing block", func() {
+		c := newComponent(func(r *defkit.Resource) {
+			r.If(exposeType.Eq("ClusterIP")).
+				SetIf(exposeType.Eq("NodePort"), "spec.externalTrafficPolicy", defkit.Lit("Local")).
+				SetIf(replicas.Gt(1), "spec.replicas", replicas).
+				EndIf()
+		})
+		err := c.Validate()
+		Expect(err).To(MatchError(ContainSubstring(`parameter "exposeType" is required to equal both ClusterIP and NodePort`)))
+		Expect(err.Error()).NotTo(ContainSubstring("spec.replicas"))
+	})
+
+	It("should report an unreachable block only once", func() {
+		c := newComponent(func(r *defkit.Resource) {
+			r.If(defkit.And(exposeType.Eq("NodePort"), exposeType.Ne("NodePort"))).
+				SetIf(replicas.IsSet(), "spec.replicas", replicas).
+				EndIf()
+		})
+		err := c.Validate()
+		Expect(err).To(MatchError(ContainSubstring("output: if block")))
+		Expect(err.Error()).NotTo(ContainSubstring("spec.replicas"))
+	})
+
+	It("should validate the patch of a trait", func() {
+		enabled := defkit.Bool("enabled")
+		t := defkit.NewTrait("test").
+			Params(enabled).
+			Template(func(tpl *defkit.Template) {
+				tpl.Patch().SetIf(defkit.And(enabled.IsSet(), defkit.Not(enabled.IsSet())), "spec.paused", enabled)
+			})
+		Expect(t.Validate()).To(MatchError(ContainSubstring("patch: spec.paused")))
+	})
+})
@@ -66,6 +66,11 @@ func (c *VelaContext) AppRevisionNum() *ContextRef {
 	return &ContextRef{path: "context.appRevisionNum"}
 }
 
+// Cluster returns the name of the cluster the component is dispatched to from context.
+func (c *VelaContext) Cluster() *ContextRef {
+	return &ContextRef{path: "context.cluster"}
+}
+
 // ClusterVersion returns the Kubernetes cluster version from context.
 func (c *VelaContext) ClusterVersion() *ClusterVersionRef {
 	return &ClusterVersionRef{basePath: "context.clusterVersion"}
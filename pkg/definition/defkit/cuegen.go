@@ -18,6 +18,8 @@ package defkit
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"sort"
 	"strings"
 )
@@ -53,8 +55,13 @@ func cueLabel(name string) string {
 
 // CUEGenerator generates CUE definitions from Go component definitions.
 type CUEGenerator struct {
-	indent  string
-	imports []string
+	indent        string
+	imports       []string
+	stableAnchors bool                 // emit fields in declared order and section markers, see WithStableAnchors
+	fieldDocs     map[string]FieldDocs // field docs by apiVersion/kind of resource, see WithFieldDocs
+	sourceVars    bool                 // name iteration variables after their source, see WithSourceVarNames
+	iterVars      []string             // iteration variables of the comprehensions being generated
+	sections      *sectionCache        // sections reused across generations, see WithIncremental
 }
 
 // CUEImports defines standard imports that may be needed in CUE definitions.
@@ -160,6 +167,38 @@ func (g *CUEGenerator) detectRequiredImports(c *ComponentDefinition) {
 	}
 }
 
+// WithStableAnchors makes the generated template stable across regenerations for
+// tools patching or diffing the generated CUE:
+//   - the fields of a resource are emitted in the order they were declared, the
+//     consecutive fields sharing a condition are grouped in one if block, instead
+//     of emitting the unconditional fields first and grouping the conditional
+//     ones by condition;
+//   - the regions of the template are delimited by section markers such as
+//     "// defkit:begin output" and "// defkit:end output", each auxiliary output
+//     having its own "outputs.<name>" section.
+func (g *CUEGenerator) WithStableAnchors() *CUEGenerator {
+	g.stableAnchors = true
+	return g
+}
+
+// writeSection writes the content generated by fn, delimited by section markers
+// when stable anchors are enabled. Nothing is written for an empty content. The
+// input holds the values fn renders the section from, see WithIncremental.
+func (g *CUEGenerator) writeSection(sb *strings.Builder, name string, depth int, input any, fn func(sb *strings.Builder)) {
+	section := g.renderSection(name, input, fn)
+	if section == "" {
+		return
+	}
+	indent := strings.Repeat(g.indent, depth)
+	if g.stableAnchors {
+		sb.WriteString(fmt.Sprintf("%s// defkit:begin %s\n", indent, name))
+	}
+	sb.WriteString(section)
+	if g.stableAnchors {
+		sb.WriteString(fmt.Sprintf("%s// defkit:end %s\n", indent, name))
+	}
+}
+
 // addImportIfMissing adds an import if it's not already present.
 func (g *CUEGenerator) addImportIfMissing(imp string) {
 	for _, existing := range g.imports {
@@ -314,20 +353,15 @@ func (g *CUEGenerator) GenerateParameterSchema(c *ComponentDefinition) string {
 
 // GenerateFullDefinition generates the complete CUE definition from a component.
 func (g *CUEGenerator) GenerateFullDefinition(c *ComponentDefinition) string {
-	// Auto-detect required imports from template
+	// Auto-detect required imports from template, the explicit imports come first
+	explicit := len(g.imports)
 	g.detectRequiredImports(c)
+	// Forget the detected imports, so that a generator used again detects them
+	// anew instead of keeping the ones no longer used as explicit imports
+	defer func() { g.imports = g.imports[:explicit] }()
 
 	var sb strings.Builder
 
-	// Write imports if any
-	if len(g.imports) > 0 {
-		sb.WriteString("import (\n")
-		for _, imp := range g.imports {
-			sb.WriteString(fmt.Sprintf("\t%q\n", imp))
-		}
-		sb.WriteString(")\n\n")
-	}
-
 	// Write component header
 	sb.WriteString(fmt.Sprintf("%s: {\n", cueLabel(c.GetName())))
 	sb.WriteString(fmt.Sprintf("%stype: \"component\"\n", g.indent))
@@ -374,8 +408,35 @@ func (g *CUEGenerator) GenerateFullDefinition(c *ComponentDefinition) string {
 
 	// Write template section (includes parameter inside)
 	sb.WriteString(g.GenerateTemplate(c))
+	body := sb.String()
 
-	return sb.String()
+	// Keep the detected imports only if the generated CUE still uses them: the
+	// value requiring an import may sit in a block dropped by the generator, and
+	// CUE rejects unused imports
+	imports := append([]string{}, g.imports[:explicit]...)
+	for _, imp := range g.imports[explicit:] {
+		if usesImport(body, imp) {
+			imports = append(imports, imp)
+		}
+	}
+
+	// Write imports if any
+	var header strings.Builder
+	if len(imports) > 0 {
+		header.WriteString("import (\n")
+		for _, imp := range imports {
+			header.WriteString(fmt.Sprintf("\t%q\n", imp))
+		}
+		header.WriteString(")\n\n")
+	}
+	return header.String() + body
+}
+
+// usesImport returns true if the CUE references an exported identifier of the
+// imported package, e.g. strings.Join for "strings" or json.Marshal for "encoding/json".
+func usesImport(cue, imp string) bool {
+	re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(path.Base(imp)) + `\.[A-Z]`)
+	return re.MatchString(cue)
 }
 
 // GenerateTemplate generates the CUE template block from a component's template function.
@@ -396,46 +457,58 @@ func (g *CUEGenerator) GenerateTemplate(c *ComponentDefinition) string {
 	if templateFn := c.GetTemplate(); templateFn != nil {
 		templateFn(tpl)
 	}
+	g.beginGeneration()
+	defer g.endGeneration()
 
-	// Generate struct-based array helpers first (mountsArray, volumesArray patterns)
-	for _, helper := range tpl.GetStructArrayHelpers() {
-		g.writeStructArrayHelper(&sb, helper, 1)
+	helpersInput := []any{
+		tpl.GetStructArrayHelpers(), tpl.GetConcatHelpers(), tpl.GetDedupeHelpers(),
+		tpl.GetHelpersBeforeOutput(), tpl.GetRawHeaderBlock(),
 	}
+	g.writeSection(&sb, "helpers", 1, helpersInput, func(sb *strings.Builder) {
+		// Generate struct-based array helpers first (mountsArray, volumesArray patterns)
+		for _, helper := range tpl.GetStructArrayHelpers() {
+			g.writeStructArrayHelper(sb, helper, 1)
+		}
 
-	// Generate concat helpers (list.Concat patterns)
-	for _, helper := range tpl.GetConcatHelpers() {
-		g.writeConcatHelper(&sb, helper, 1)
-	}
+		// Generate concat helpers (list.Concat patterns)
+		for _, helper := range tpl.GetConcatHelpers() {
+			g.writeConcatHelper(sb, helper, 1)
+		}
 
-	// Generate dedupe helpers (deDupVolumesArray pattern)
-	for _, helper := range tpl.GetDedupeHelpers() {
-		g.writeDedupeHelper(&sb, helper, 1)
-	}
+		// Generate dedupe helpers (deDupVolumesArray pattern)
+		for _, helper := range tpl.GetDedupeHelpers() {
+			g.writeDedupeHelper(sb, helper, 1)
+		}
 
-	// Generate legacy helper definitions that appear BEFORE output
-	for _, helper := range tpl.GetHelpersBeforeOutput() {
-		g.writeHelper(&sb, helper, 1)
-	}
+		// Generate legacy helper definitions that appear BEFORE output
+		for _, helper := range tpl.GetHelpersBeforeOutput() {
+			g.writeHelper(sb, helper, 1)
+		}
 
-	// Emit raw header block (let bindings, helpers like _claimName)
-	if rawHeader := tpl.GetRawHeaderBlock(); rawHeader != "" {
-		for _, line := range strings.Split(strings.TrimSpace(rawHeader), "\n") {
-			sb.WriteString(g.indent)
-			sb.WriteString(line)
-			sb.WriteString("\n")
+		// Emit raw header block (let bindings, helpers like _claimName)
+		if rawHeader := tpl.GetRawHeaderBlock(); rawHeader != "" {
+			for _, line := range strings.Split(strings.TrimSpace(rawHeader), "\n") {
+				sb.WriteString(g.indent)
+				sb.WriteString(line)
+				sb.WriteString("\n")
+			}
 		}
-	}
+	})
 
 	// Generate output block
 	if output := tpl.GetOutput(); output != nil {
-		g.writeResourceOutput(&sb, "output", output, nil, 1)
+		g.writeSection(&sb, "output", 1, output, func(sb *strings.Builder) {
+			g.writeResourceOutput(sb, "output", output, nil, 1)
+		})
 	}
 
 	// Generate helper definitions that appear AFTER output (used by outputs)
 	// This matches KubeVela convention where exposePorts appears between output and outputs
-	for _, helper := range tpl.GetHelpersAfterOutput() {
-		g.writeHelper(&sb, helper, 1)
-	}
+	g.writeSection(&sb, "output-helpers", 1, tpl.GetHelpersAfterOutput(), func(sb *strings.Builder) {
+		for _, helper := range tpl.GetHelpersAfterOutput() {
+			g.writeHelper(sb, helper, 1)
+		}
+	})
 
 	// Generate outputs block for auxiliary resources.
 	// Includes plain outputs (Outputs/OutputsIf) and grouped outputs (OutputsGroupIf),
@@ -452,7 +525,9 @@ func (g *CUEGenerator) GenerateTemplate(c *ComponentDefinition) string {
 			sort.Strings(outputNames)
 			for _, name := range outputNames {
 				res := outputs[name]
-				g.writeResourceOutput(&sb, name, res, res.outputCondition, 2)
+				g.writeSection(&sb, "outputs."+name, 2, res, func(sb *strings.Builder) {
+					g.writeResourceOutput(sb, name, res, res.outputCondition, 2)
+				})
 			}
 		}
 		for _, group := range outputGroups {
@@ -473,7 +548,10 @@ func (g *CUEGenerator) GenerateTemplate(c *ComponentDefinition) string {
 	}
 
 	// Generate parameter section INSIDE template block (KubeVela convention)
-	sb.WriteString(g.generateParameterBlock(c, 1))
+	parameterInput := []any{c.GetParams(), c.GetValidators(), c.GetConditionalParamBlocks()}
+	g.writeSection(&sb, "parameter", 1, parameterInput, func(sb *strings.Builder) {
+		sb.WriteString(g.generateParameterBlock(c, 1))
+	})
 
 	// Generate helper type definitions (like #HealthProbe)
 	for _, helperDef := range c.GetHelperDefinitions() {
@@ -1087,6 +1165,8 @@ func (g *CUEGenerator) writeMultiSourceHelper(sb *strings.Builder, ms *MultiSour
 func (g *CUEGenerator) writeCollectionOpHelper(sb *strings.Builder, col *CollectionOp, depth int, guard Condition) {
 	sourceStr := g.valueToCUE(col.Source())
 	ops := col.Operations()
+	v := g.enterComprehension(sourceStr, collectionLabels(ops))
+	defer g.exitComprehension()
 
 	// Extract filter condition if present; AND-compose multiple filters
 	var filterCondition string
@@ -1116,9 +1196,9 @@ func (g *CUEGenerator) writeCollectionOpHelper(sb *strings.Builder, col *Collect
 	for _, op := range ops {
 		if wOp, ok := op.(*wrapOp); ok {
 			if filterCondition != "" {
-				sb.WriteString(fmt.Sprintf("[%sfor v in %s if %s { %s: v }]", guardPrefix, sourceStr, filterCondition, wOp.key))
+				sb.WriteString(fmt.Sprintf("[%sfor %s in %s if %s { %s: %s }]", guardPrefix, v, sourceStr, filterCondition, wOp.key, v))
 			} else {
-				sb.WriteString(fmt.Sprintf("[%sfor v in %s { %s: v }]", guardPrefix, sourceStr, wOp.key))
+				sb.WriteString(fmt.Sprintf("[%sfor %s in %s { %s: %s }]", guardPrefix, v, sourceStr, wOp.key, v))
 			}
 			return
 		}
@@ -1133,9 +1213,9 @@ func (g *CUEGenerator) writeCollectionOpHelper(sb *strings.Builder, col *Collect
 			// Include guard and filter condition in the for loop if present
 			// No extra braces - the for loop body directly contains the struct fields
 			if filterCondition != "" {
-				sb.WriteString(fmt.Sprintf("[\n%s%sfor v in %s if %s {\n", innerIndent, guardPrefix, sourceStr, filterCondition))
+				sb.WriteString(fmt.Sprintf("[\n%s%sfor %s in %s if %s {\n", innerIndent, guardPrefix, v, sourceStr, filterCondition))
 			} else {
-				sb.WriteString(fmt.Sprintf("[\n%s%sfor v in %s {\n", innerIndent, guardPrefix, sourceStr))
+				sb.WriteString(fmt.Sprintf("[\n%s%sfor %s in %s {\n", innerIndent, guardPrefix, v, sourceStr))
 			}
 
 			for _, fieldName := range sortedKeys(mOp.mappings) {
@@ -1144,20 +1224,20 @@ func (g *CUEGenerator) writeCollectionOpHelper(sb *strings.Builder, col *Collect
 					// Emit if/else pattern for conditional field reference
 					primaryField := string(condRef.primary)
 					fallbackStr := g.fieldValueToCUE(condRef.fallback)
-					sb.WriteString(fmt.Sprintf("%sif v.%s != _|_ {\n", fieldIndent, primaryField))
-					sb.WriteString(fmt.Sprintf("%s\t%s: v.%s\n", fieldIndent, fieldName, primaryField))
+					sb.WriteString(fmt.Sprintf("%sif %s != _|_ {\n", fieldIndent, g.itemField(primaryField)))
+					sb.WriteString(fmt.Sprintf("%s\t%s: %s\n", fieldIndent, fieldName, g.itemField(primaryField)))
 					sb.WriteString(fmt.Sprintf("%s}\n", fieldIndent))
-					sb.WriteString(fmt.Sprintf("%sif v.%s == _|_ {\n", fieldIndent, primaryField))
+					sb.WriteString(fmt.Sprintf("%sif %s == _|_ {\n", fieldIndent, g.itemField(primaryField)))
 					sb.WriteString(fmt.Sprintf("%s\t%s: %s\n", fieldIndent, fieldName, fallbackStr))
 					sb.WriteString(fmt.Sprintf("%s}\n", fieldIndent))
 				} else if optField, isOptional := fieldVal.(*OptionalField); isOptional {
-					sb.WriteString(fmt.Sprintf("%sif v.%s != _|_ {\n", fieldIndent, optField.field))
-					sb.WriteString(fmt.Sprintf("%s\t%s: v.%s\n", fieldIndent, fieldName, optField.field))
+					sb.WriteString(fmt.Sprintf("%sif %s != _|_ {\n", fieldIndent, g.itemField(optField.field)))
+					sb.WriteString(fmt.Sprintf("%s\t%s: %s\n", fieldIndent, fieldName, g.itemField(optField.field)))
 					sb.WriteString(fmt.Sprintf("%s}\n", fieldIndent))
 				} else if compOpt, isCompound := fieldVal.(*CompoundOptionalField); isCompound {
 					condStr := g.conditionToCUE(compOpt.additionalCond)
-					sb.WriteString(fmt.Sprintf("%sif v.%s != _|_ if %s {\n", fieldIndent, compOpt.field, condStr))
-					sb.WriteString(fmt.Sprintf("%s\t%s: v.%s\n", fieldIndent, fieldName, compOpt.field))
+					sb.WriteString(fmt.Sprintf("%sif %s != _|_ if %s {\n", fieldIndent, g.itemField(compOpt.field), condStr))
+					sb.WriteString(fmt.Sprintf("%s\t%s: %s\n", fieldIndent, fieldName, g.itemField(compOpt.field)))
 					sb.WriteString(fmt.Sprintf("%s}\n", fieldIndent))
 				} else {
 					valStr := g.fieldValueToCUE(fieldVal)
@@ -1201,7 +1281,7 @@ func (g *CUEGenerator) writeCollectionOpHelper(sb *strings.Builder, col *Collect
 	}
 
 	// Default: simple list comprehension
-	sb.WriteString(fmt.Sprintf("[for v in %s { v }]", sourceStr))
+	sb.WriteString(fmt.Sprintf("[for %s in %s { %s }]", v, sourceStr, v))
 }
 
 // writeFieldMapAsHelper writes a FieldMap as CUE fields.
@@ -1303,6 +1383,9 @@ func (g *CUEGenerator) writeResourceOutput(sb *strings.Builder, name string, res
 
 	// Build a tree structure from the regular operations
 	tree := g.buildFieldTree(regularOps)
+	if docs := g.resourceFieldDocs(res); docs != nil {
+		documentFieldTree(tree, docs, "")
+	}
 
 	// Write the tree as CUE
 	g.writeFieldTree(sb, tree, depth+1)
@@ -1344,6 +1427,7 @@ type fieldNode struct {
 	spreadAll     *SpreadAllOp     // SpreadAll operation (for array constraint patches)
 	patchStrategy string           // e.g. "retainKeys" → generates // +patchStrategy=retainKeys
 	directives    []string         // e.g. ["patchKey=ip"] → generates // +patchKey=ip
+	comments      []string         // explanatory comments → generates // text
 	condValues    []condValueEntry // additional conditional values at same path
 }
 
@@ -1400,6 +1484,8 @@ func (g *CUEGenerator) buildFieldTree(ops []ResourceOp) *fieldNode {
 			g.insertAnnotationIntoTree(root, o.Path(), o.Strategy())
 		case *DirectiveOp:
 			g.insertDirectiveIntoTree(root, o.Path(), o.GetDirective())
+		case *CommentOp:
+			g.insertCommentIntoTree(root, o.Path(), o.Text())
 		case *IfBlock:
 			// For if blocks, process inner ops with the block's condition
 			for _, innerOp := range o.Ops() {
@@ -1442,6 +1528,8 @@ func (g *CUEGenerator) buildFieldTree(ops []ResourceOp) *fieldNode {
 					g.insertAnnotationIntoTree(root, inner.Path(), inner.Strategy())
 				case *DirectiveOp:
 					g.insertDirectiveIntoTree(root, inner.Path(), inner.GetDirective())
+				case *CommentOp:
+					g.insertCommentIntoTree(root, inner.Path(), inner.Text())
 				}
 			}
 		}
@@ -1464,6 +1552,42 @@ func (g *CUEGenerator) insertDirectiveIntoTree(root *fieldNode, path string, dir
 	current.directives = append(current.directives, directive)
 }
 
+// insertCommentIntoTree navigates to a node by path and adds an explanatory comment.
+// Array and map key accesses are resolved like in insertIntoTree so that the
+// comment lands on the node holding the field value.
+func (g *CUEGenerator) insertCommentIntoTree(root *fieldNode, path string, text string) {
+	current := root
+	for _, part := range splitPath(path) {
+		name, key, index := parseBracketAccess(part)
+		if _, exists := current.children[name]; !exists {
+			current.children[name] = newFieldNode()
+			current.childOrder = append(current.childOrder, name)
+		}
+		node := current.children[name]
+		switch {
+		case index >= 0:
+			node.isArray = true
+			idxKey := fmt.Sprintf("[%d]", index)
+			if _, exists := node.children[idxKey]; !exists {
+				node.children[idxKey] = newFieldNode()
+				node.children[idxKey].arrayIndex = index
+				node.childOrder = append(node.childOrder, idxKey)
+			}
+			current = node.children[idxKey]
+		case key != "":
+			keyNode := fmt.Sprintf("[%s]", key)
+			if _, exists := node.children[keyNode]; !exists {
+				node.children[keyNode] = newFieldNode()
+				node.childOrder = append(node.childOrder, keyNode)
+			}
+			current = node.children[keyNode]
+		default:
+			current = node
+		}
+	}
+	current.comments = append(current.comments, text)
+}
+
 // insertAnnotationIntoTree navigates to a node by path and sets its patchStrategy annotation.
 func (g *CUEGenerator) insertAnnotationIntoTree(root *fieldNode, path string, strategy string) {
 	parts := splitPath(path)
@@ -1739,6 +1863,11 @@ func (g *CUEGenerator) writeFieldTree(sb *strings.Builder, node *fieldNode, dept
 		sb.WriteString(fmt.Sprintf("%s}\n", indent))
 	}
 
+	if g.stableAnchors {
+		g.writeFieldsInDeclaredOrder(sb, node, depth)
+		return
+	}
+
 	// Group fields by their condition for cleaner output
 	unconditional := make([]string, 0)
 	conditional := make(map[string][]string) // condition string -> field names
@@ -1779,6 +1908,48 @@ func (g *CUEGenerator) writeFieldTree(sb *strings.Builder, node *fieldNode, dept
 	}
 }
 
+// writeFieldsInDeclaredOrder writes the children of the node in the order they
+// were declared. Consecutive children sharing the same condition are written
+// in a single if block.
+func (g *CUEGenerator) writeFieldsInDeclaredOrder(sb *strings.Builder, node *fieldNode, depth int) {
+	indent := strings.Repeat(g.indent, depth)
+	var (
+		groupCond  string
+		groupNames []string
+	)
+	flush := func() {
+		if len(groupNames) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("%sif %s {\n", indent, groupCond))
+		for _, name := range groupNames {
+			// Clear condition since we're inside the if block
+			childCopy := *node.children[name]
+			childCopy.cond = nil
+			g.writeFieldNode(sb, name, &childCopy, depth+1)
+		}
+		sb.WriteString(fmt.Sprintf("%s}\n", indent))
+		groupCond, groupNames = "", nil
+	}
+
+	for _, name := range node.childOrder {
+		child := node.children[name]
+		// Multi-conditional nodes render their own if blocks internally
+		if child.cond == nil || len(child.condValues) > 0 {
+			flush()
+			g.writeFieldNode(sb, name, child, depth)
+			continue
+		}
+		condStr := g.conditionToCUE(child.cond)
+		if condStr != groupCond {
+			flush()
+			groupCond = condStr
+		}
+		groupNames = append(groupNames, name)
+	}
+	flush()
+}
+
 // liftChildConditions promotes a shared child condition to the parent node.
 // It recursively processes the tree so inner nodes are normalized before parent rendering.
 func (g *CUEGenerator) liftChildConditions(node *fieldNode) {
@@ -1912,6 +2083,13 @@ func (g *CUEGenerator) tryDecomposeOrLift(sb *strings.Builder, name string, node
 func (g *CUEGenerator) writeFieldNode(sb *strings.Builder, name string, node *fieldNode, depth int) {
 	indent := strings.Repeat(g.indent, depth)
 
+	// Emit explanatory comments before the field
+	for _, comment := range node.comments {
+		for _, line := range strings.Split(comment, "\n") {
+			sb.WriteString(fmt.Sprintf("%s// %s\n", indent, line))
+		}
+	}
+
 	// Handle bracket notation in name (like [app.oam.dev/name])
 	if strings.HasPrefix(name, "[") && !strings.HasPrefix(name, "[0]") {
 		g.writeBracketKeyNode(sb, name, node, indent, depth)
@@ -2165,6 +2343,9 @@ func (g *CUEGenerator) valueToCUE(v Value) string {
 	if s := g.tryRenderBuilder(v); s != "" {
 		return s
 	}
+	if e, ok := v.(typedEnum); ok {
+		v = e.enum()
+	}
 
 	switch val := v.(type) {
 	case *Literal:
@@ -2184,7 +2365,8 @@ func (g *CUEGenerator) valueToCUE(v Value) string {
 	case *HelperVar:
 		// Return reference to the helper by name
 		return val.Name()
-	case *StringParam, *IntParam, *BoolParam, *FloatParam, *ArrayParam, *MapParam, *StringKeyMapParam, *EnumParam, *OneOfParam:
+	case *StringParam, *IntParam, *BoolParam, *FloatParam, *ArrayParam, *MapParam, *StringKeyMapParam, *EnumParam, *OneOfParam,
+		*DurationParam, *TimestampParam:
 		// Dot syntax is safe here: the call sites that wrap value refs in a
 		// guarded if-block (e.g. `if len(parameter.X | []) > 0 { foo: parameter.X }`)
 		// have already established the field is concrete before the body
@@ -2516,11 +2698,13 @@ func (g *CUEGenerator) writeItemBuilderOps(sb *strings.Builder, ops []itemOp, de
 func (g *CUEGenerator) collectionOpToCUE(col *CollectionOp) string {
 	sourceStr := g.valueToCUE(col.Source())
 	ops := col.Operations()
+	v := g.enterComprehension(sourceStr, collectionLabels(ops))
+	defer g.exitComprehension()
 
 	// Check for wrap operation (e.g., imagePullSecrets wrapping string to {name: string})
 	for _, op := range ops {
 		if wOp, ok := op.(*wrapOp); ok {
-			return fmt.Sprintf("[for v in %s { %s: v }]", sourceStr, wOp.key)
+			return fmt.Sprintf("[for %s in %s { %s: %s }]", v, sourceStr, wOp.key, v)
 		}
 	}
 
@@ -2599,7 +2783,7 @@ func (g *CUEGenerator) collectionOpToCUE(col *CollectionOp) string {
 		sb.WriteString(" ")
 	}
 
-	sb.WriteString("for v in ")
+	sb.WriteString(fmt.Sprintf("for %s in ", v))
 	sb.WriteString(sourceStr)
 	if filterCondition != "" {
 		sb.WriteString(" if ")
@@ -2615,22 +2799,22 @@ func (g *CUEGenerator) collectionOpToCUE(col *CollectionOp) string {
 				for _, fieldName := range sortedKeys(mOp.mappings) {
 					fieldVal := mOp.mappings[fieldName]
 					if optField, isOptional := fieldVal.(*OptionalField); isOptional {
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif v.%s != _|_ {\n", optField.field))
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: v.%s\n", fieldName, optField.field))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif %s != _|_ {\n", g.itemField(optField.field)))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: %s\n", fieldName, g.itemField(optField.field)))
 						sb.WriteString("\t\t\t\t\t}\n")
 					} else if compOpt, isCompound := fieldVal.(*CompoundOptionalField); isCompound {
 						condStr := g.conditionToCUE(compOpt.additionalCond)
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif v.%s != _|_ if %s {\n", compOpt.field, condStr))
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: v.%s\n", fieldName, compOpt.field))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif %s != _|_ if %s {\n", g.itemField(compOpt.field), condStr))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: %s\n", fieldName, g.itemField(compOpt.field)))
 						sb.WriteString("\t\t\t\t\t}\n")
 					} else if condRef, isConditional := fieldVal.(*ConditionalOrFieldRef); isConditional {
 						// Emit if/else pattern for conditional field reference
 						primaryField := string(condRef.primary)
 						fallbackStr := g.fieldValueToCUE(condRef.fallback)
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif v.%s != _|_ {\n", primaryField))
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: v.%s\n", fieldName, primaryField))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif %s != _|_ {\n", g.itemField(primaryField)))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: %s\n", fieldName, g.itemField(primaryField)))
 						sb.WriteString("\t\t\t\t\t}\n")
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif v.%s == _|_ {\n", primaryField))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\tif %s == _|_ {\n", g.itemField(primaryField)))
 						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t%s: %s\n", fieldName, fallbackStr))
 						sb.WriteString("\t\t\t\t\t}\n")
 					} else {
@@ -2643,12 +2827,12 @@ func (g *CUEGenerator) collectionOpToCUE(col *CollectionOp) string {
 		// MapVariant operations: render conditional field blocks
 		for _, op := range ops {
 			if mvOp, ok := op.(*mapVariantOp); ok {
-				sb.WriteString(fmt.Sprintf("\t\t\t\t\tif v.%s == %q {\n", mvOp.discriminator, mvOp.variantName))
+				sb.WriteString(fmt.Sprintf("\t\t\t\t\tif %s == %q {\n", g.itemField(mvOp.discriminator), mvOp.variantName))
 				for _, fieldName := range sortedKeys(mvOp.mappings) {
 					fieldVal := mvOp.mappings[fieldName]
 					if optField, isOptional := fieldVal.(*OptionalField); isOptional {
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\tif v.%s != _|_ {\n", optField.field))
-						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t\t%s: v.%s\n", fieldName, optField.field))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\tif %s != _|_ {\n", g.itemField(optField.field)))
+						sb.WriteString(fmt.Sprintf("\t\t\t\t\t\t\t%s: %s\n", fieldName, g.itemField(optField.field)))
 						sb.WriteString("\t\t\t\t\t\t}\n")
 					} else {
 						valStr := g.fieldValueToCUE(fieldVal)
@@ -2662,7 +2846,7 @@ func (g *CUEGenerator) collectionOpToCUE(col *CollectionOp) string {
 		sb.WriteString("\t\t\t}]")
 	} else {
 		// Filter-only: pass through the iteration variable
-		sb.WriteString(" {v}]")
+		sb.WriteString(fmt.Sprintf(" {%s}]", v))
 	}
 
 	return sb.String()
@@ -2673,10 +2857,10 @@ func (g *CUEGenerator) predicateToCUE(pred Predicate) string {
 	switch p := pred.(type) {
 	case FieldEq:
 		// Generate: v.field == value
-		return fmt.Sprintf("v.%s == %s", p.field, formatCUEValue(p.value))
+		return fmt.Sprintf("%s == %s", g.itemField(p.field), formatCUEValue(p.value))
 	case FieldIsSet:
 		// Generate: v.field != _|_
-		return fmt.Sprintf("v.%s != _|_", p.field)
+		return fmt.Sprintf("%s != _|_", g.itemField(p.field))
 	default:
 		return cueBoolTrue
 	}
@@ -2894,9 +3078,9 @@ func (g *CUEGenerator) generateMapBySourceCUE(sourceStr string, sources []string
 func (g *CUEGenerator) fieldValueToCUE(fv FieldValue) string {
 	switch val := fv.(type) {
 	case FieldRef:
-		return "v." + string(val)
+		return g.itemField(string(val))
 	case *OrFieldRef:
-		primary := "v." + string(val.primary)
+		primary := g.itemField(string(val.primary))
 		fallback := g.fieldValueToCUE(val.fallback)
 		return fmt.Sprintf("*%s | %s", primary, fallback)
 	case LitVal:
@@ -2929,8 +3113,8 @@ func (g *CUEGenerator) nestedFieldToCUE(nf *NestedField) string {
 		fieldVal := nf.mapping[fieldName]
 		// Handle optional fields specially - generate conditional inclusion
 		if optField, isOptional := fieldVal.(*OptionalField); isOptional {
-			sb.WriteString(fmt.Sprintf("\t\t\t\tif v.%s != _|_ {\n", optField.field))
-			sb.WriteString(fmt.Sprintf("\t\t\t\t\t%s: v.%s\n", fieldName, optField.field))
+			sb.WriteString(fmt.Sprintf("\t\t\t\tif %s != _|_ {\n", g.itemField(optField.field)))
+			sb.WriteString(fmt.Sprintf("\t\t\t\t\t%s: %s\n", fieldName, g.itemField(optField.field)))
 			sb.WriteString("\t\t\t\t}\n")
 		} else {
 			valStr := g.fieldValueToCUE(fieldVal)
@@ -3271,6 +3455,9 @@ func (g *CUEGenerator) writeStatus(sb *strings.Builder, c *ComponentDefinition,
 
 // writeParam writes a single parameter definition.
 func (g *CUEGenerator) writeParam(sb *strings.Builder, param Param, depth int) {
+	if e, ok := param.(typedEnum); ok {
+		param = e.enum()
+	}
 	indent := strings.Repeat(g.indent, depth)
 
 	// Write // +ignore directive if set (before +usage)
@@ -3288,6 +3475,11 @@ func (g *CUEGenerator) writeParam(sb *strings.Builder, param Param, depth int) {
 		sb.WriteString(fmt.Sprintf("%s// +short=%s\n", indent, sp.GetShort()))
 	}
 
+	// Write // +insertSecretTo=X directive if set (after +short)
+	if ip, ok := param.(interface{ GetInsertSecretTo() string }); ok && ip.GetInsertSecretTo() != "" {
+		sb.WriteString(fmt.Sprintf("%s// +insertSecretTo=%s\n", indent, ip.GetInsertSecretTo()))
+	}
+
 	name := param.Name()
 	marker := fieldMarkerNone
 	if param.IsRequired() {
@@ -3316,6 +3508,10 @@ func (g *CUEGenerator) writeParam(sb *strings.Builder, param Param, depth int) {
 		g.writeStructParam(sb, p, indent, name, marker, depth)
 	case *EnumParam:
 		g.writeEnumParam(sb, p, indent, name, marker)
+	case *DurationParam:
+		g.writeConstrainedStringParam(sb, p, "time.Duration", indent, name, marker)
+	case *TimestampParam:
+		g.writeConstrainedStringParam(sb, p, "time.Time", indent, name, marker)
 	case *OneOfParam:
 		g.writeOneOfParam(sb, p, indent, name, marker, depth)
 	case *ClosedUnionParam:
@@ -3368,6 +3564,11 @@ func (g *CUEGenerator) writeStringParam(sb *strings.Builder, p *StringParam, ind
 			constraints = append(constraints, `!=""`)
 		}
 
+		// Format constraint: net.FQDN, net.IPCIDR, ...
+		if format := p.GetFormat(); format != "" {
+			constraints = append(constraints, format)
+		}
+
 		// Pattern constraint: =~"pattern"
 		if pattern := p.GetPattern(); pattern != "" {
 			constraints = append(constraints, fmt.Sprintf(`=~%q`, pattern))
@@ -3713,6 +3914,16 @@ func (g *CUEGenerator) writeStructField(sb *strings.Builder, f *StructField, dep
 	}
 }
 
+// writeConstrainedStringParam writes a string parameter validated by a CUE
+// constraint such as time.Duration, e.g. timeout: *"30s" | time.Duration
+func (g *CUEGenerator) writeConstrainedStringParam(sb *strings.Builder, p Param, constraint, indent, name, optional string) {
+	if p.HasDefault() {
+		sb.WriteString(fmt.Sprintf("%s%s%s: *%q | %s\n", indent, name, optional, p.GetDefault(), constraint))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%s%s%s: %s\n", indent, name, optional, constraint))
+}
+
 // writeEnumParam writes an enum parameter.
 func (g *CUEGenerator) writeEnumParam(sb *strings.Builder, p *EnumParam, indent, name, optional string) {
 	values := p.GetValues()
@@ -243,6 +243,17 @@ var _ = Describe("CUEGenerator", func() {
 			Expect(usageIdx).To(BeNumerically(">", ignoreIdx))
 			Expect(shortIdx).To(BeNumerically(">", usageIdx))
 		})
+
+		It("should generate // +insertSecretTo directive for secret reference params", func() {
+			comp := defkit.NewComponent("test").
+				Params(
+					defkit.String("dbSecret").Optional().Description("Referred db secret").InsertSecretTo("dbConn"),
+				)
+
+			cue := gen.GenerateParameterSchema(comp)
+
+			Expect(cue).To(ContainSubstring("// +usage=Referred db secret\n\t// +insertSecretTo=dbConn\n\tdbSecret?: string"))
+		})
 	})
 
 	Describe("GenerateParameterSchema with complex types", func() {
@@ -1159,6 +1170,58 @@ var _ = Describe("CUEGenerator", func() {
 
 			Expect(cue).To(MatchRegexp(`import\s+\(\s+"strings"\s+\)`))
 		})
+
+		It("should prune a detected import the generated CUE does not use", func() {
+			// Format only emits strconv.FormatInt for the "port-%v" shape, other
+			// formats are emitted as string literals
+			ports := defkit.List("ports").WithFields(defkit.Int("port"))
+			comp := defkit.NewComponent("test").
+				Workload("v1", "Service").
+				Params(ports).
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(
+						defkit.NewResource("v1", "Service").
+							Set("spec.ports", defkit.Each(ports).Map(defkit.FieldMap{
+								"name": defkit.Format("svc-%v", defkit.FieldRef("port")),
+								"port": defkit.FieldRef("port"),
+							})),
+					)
+				})
+
+			cue := gen.GenerateFullDefinition(comp)
+
+			Expect(cue).NotTo(ContainSubstring(`"strconv"`))
+			Expect(cue).NotTo(ContainSubstring("import ("))
+		})
+
+		It("should keep a detected import the generated CUE uses", func() {
+			ports := defkit.List("ports").WithFields(defkit.Int("port"))
+			comp := defkit.NewComponent("test").
+				Workload("v1", "Service").
+				Params(ports).
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(
+						defkit.NewResource("v1", "Service").
+							Set("spec.ports", defkit.Each(ports).Map(defkit.FieldMap{
+								"name": defkit.Format("port-%v", defkit.FieldRef("port")),
+							})),
+					)
+				})
+
+			cue := gen.GenerateFullDefinition(comp)
+
+			Expect(cue).To(MatchRegexp(`import\s+\(\s+"strconv"\s+\)`))
+			Expect(cue).To(ContainSubstring("strconv.FormatInt("))
+		})
+
+		It("should keep explicit imports even if unused", func() {
+			comp := defkit.NewComponent("test").
+				Workload("apps/v1", "Deployment")
+
+			cue := gen.WithImports("encoding/json").GenerateFullDefinition(comp)
+
+			Expect(cue).To(MatchRegexp(`import\s+\(\s+"encoding/json"\s+\)`))
+		})
 	})
 
 	Describe("GenerateFullDefinition with ConditionalOrFieldRef", func() {
@@ -1222,6 +1285,85 @@ var _ = Describe("CUEGenerator", func() {
 		})
 	})
 
+	Describe("GenerateFullDefinition with Comment", func() {
+		It("should render comments before the field", func() {
+			gen := defkit.NewCUEGenerator()
+
+			image := defkit.String("image")
+			comp := defkit.NewComponent("test").
+				Workload("apps/v1", "Deployment").
+				Params(image).
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(
+						defkit.NewResource("apps/v1", "Deployment").
+							Comment("spec.selector", "selector is immutable once the Deployment is created").
+							Set("spec.selector.matchLabels.app", defkit.VelaCtx().Name()).
+							Set("spec.template.spec.containers[0].image", image).
+							Comment("spec.template.spec.containers[0].image", "the image is pulled on every node\nrunning a replica"),
+					)
+				})
+
+			cue := gen.GenerateFullDefinition(comp)
+
+			commentIdx := strings.Index(cue, "// selector is immutable once the Deployment is created")
+			selectorIdx := strings.Index(cue, "selector:")
+			Expect(commentIdx).To(BeNumerically(">=", 0))
+			Expect(commentIdx).To(BeNumerically("<", selectorIdx))
+			Expect(cue).To(ContainSubstring("// the image is pulled on every node\n"))
+			Expect(cue).To(ContainSubstring("// running a replica\n"))
+		})
+	})
+
+	Describe("GenerateFullDefinition with stable anchors", func() {
+		newComponent := func() *defkit.ComponentDefinition {
+			replicas := defkit.Int("replicas")
+			image := defkit.String("image")
+			return defkit.NewComponent("test").
+				Workload("apps/v1", "Deployment").
+				Params(replicas, image).
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(
+						defkit.NewResource("apps/v1", "Deployment").
+							SetIf(replicas.IsSet(), "spec.replicas", replicas).
+							Set("spec.paused", defkit.Lit(false)).
+							Set("spec.template.spec.containers[0].image", image),
+					)
+					tpl.Outputs("service", defkit.NewResource("v1", "Service").
+						Set("spec.type", defkit.Lit("ClusterIP")))
+				})
+		}
+
+		It("should not emit section markers by default", func() {
+			cue := defkit.NewCUEGenerator().GenerateFullDefinition(newComponent())
+			Expect(cue).NotTo(ContainSubstring("defkit:begin"))
+
+			// Unconditional fields come first by default
+			Expect(strings.Index(cue, "paused:")).To(BeNumerically("<", strings.Index(cue, "replicas:")))
+		})
+
+		It("should emit section markers and fields in declared order", func() {
+			cue := defkit.NewCUEGenerator().WithStableAnchors().GenerateFullDefinition(newComponent())
+
+			for _, section := range []string{"output", "outputs.service", "parameter"} {
+				begin := strings.Index(cue, "// defkit:begin "+section+"\n")
+				end := strings.Index(cue, "// defkit:end "+section+"\n")
+				Expect(begin).To(BeNumerically(">=", 0), section)
+				Expect(end).To(BeNumerically(">", begin), section)
+			}
+			// No helpers are declared, so no empty helpers section is emitted
+			Expect(cue).NotTo(ContainSubstring("defkit:begin helpers"))
+
+			// The conditional replicas field is declared before paused
+			Expect(strings.Index(cue, "replicas:")).To(BeNumerically("<", strings.Index(cue, "paused:")))
+		})
+
+		It("should generate the same output across regenerations", func() {
+			first := defkit.NewCUEGenerator().WithStableAnchors().GenerateFullDefinition(newComponent())
+			second := defkit.NewCUEGenerator().WithStableAnchors().GenerateFullDefinition(newComponent())
+			Expect(second).To(Equal(first))
+		})
+	})
+
 	Describe("GenerateFullDefinition with CompoundOptionalField", func() {
 		It("should generate compound conditional for OptionalFieldWithCond in collection Map", func() {
 			gen := defkit.NewCUEGenerator()
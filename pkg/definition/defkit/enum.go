@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+// TypedEnumParam is an enum parameter whose values are Go constants of type T.
+// It generates the same CUE as EnumParam, and its comparisons only accept
+// values of type T so that a typo in a compared value fails to compile
+// instead of producing a condition that never matches.
+//
+// Example:
+//
+//	type Exposure string
+//
+//	const (
+//	    ClusterIP    Exposure = "ClusterIP"
+//	    NodePort     Exposure = "NodePort"
+//	    LoadBalancer Exposure = "LoadBalancer"
+//	)
+//
+//	exposure := defkit.EnumOf("exposeType", ClusterIP, NodePort, LoadBalancer).Default(ClusterIP)
+//	tpl.Output(...).SetIf(exposure.Eq(NodePort), "spec.type", exposure)
+type TypedEnumParam[T ~string] struct {
+	*EnumParam
+}
+
+// typedEnum is implemented by TypedEnumParam of any type, it gives the
+// generators access to the underlying EnumParam.
+type typedEnum interface {
+	enum() *EnumParam
+}
+
+// EnumOf creates a new enum parameter with the given name and the values of
+// the Go constants.
+func EnumOf[T ~string](name string, values ...T) *TypedEnumParam[T] {
+	return &TypedEnumParam[T]{EnumParam: Enum(name).Values(enumStrings(values)...)}
+}
+
+func (p *TypedEnumParam[T]) enum() *EnumParam { return p.EnumParam }
+
+// Values sets the allowed enum values.
+func (p *TypedEnumParam[T]) Values(values ...T) *TypedEnumParam[T] {
+	p.EnumParam.Values(enumStrings(values)...)
+	return p
+}
+
+// Default sets a default value for the parameter.
+func (p *TypedEnumParam[T]) Default(value T) *TypedEnumParam[T] {
+	p.EnumParam.Default(string(value))
+	return p
+}
+
+// Required marks the parameter as required in input, emitting the "!" CUE marker.
+func (p *TypedEnumParam[T]) Required() *TypedEnumParam[T] {
+	p.EnumParam.Required()
+	return p
+}
+
+// Optional marks the parameter as optional, emitting the "?" CUE marker.
+func (p *TypedEnumParam[T]) Optional() *TypedEnumParam[T] {
+	p.EnumParam.Optional()
+	return p
+}
+
+// Description sets the parameter description.
+func (p *TypedEnumParam[T]) Description(desc string) *TypedEnumParam[T] {
+	p.EnumParam.Description(desc)
+	return p
+}
+
+// Short sets a short flag alias for the parameter.
+func (p *TypedEnumParam[T]) Short(s string) *TypedEnumParam[T] {
+	p.EnumParam.Short(s)
+	return p
+}
+
+// Ignore marks the parameter as ignored by the UI.
+func (p *TypedEnumParam[T]) Ignore() *TypedEnumParam[T] {
+	p.EnumParam.Ignore()
+	return p
+}
+
+// GetTypedValues returns the allowed enum values as Go constants.
+func (p *TypedEnumParam[T]) GetTypedValues() []T {
+	values := make([]T, 0, len(p.GetValues()))
+	for _, v := range p.GetValues() {
+		values = append(values, T(v))
+	}
+	return values
+}
+
+// Eq creates a condition that checks if the parameter equals the value.
+// Example: exposure.Eq(NodePort) generates: parameter.exposeType == "NodePort"
+func (p *TypedEnumParam[T]) Eq(value T) Condition {
+	return Eq(p.EnumParam, Lit(string(value)))
+}
+
+// Ne creates a condition that checks if the parameter does not equal the value.
+// Example: exposure.Ne(NodePort) generates: parameter.exposeType != "NodePort"
+func (p *TypedEnumParam[T]) Ne(value T) Condition {
+	return Ne(p.EnumParam, Lit(string(value)))
+}
+
+// In creates a condition that checks if the parameter equals one of the values.
+// Example: exposure.In(NodePort, LoadBalancer) generates:
+// parameter.exposeType == "NodePort" || parameter.exposeType == "LoadBalancer"
+func (p *TypedEnumParam[T]) In(values ...T) Condition {
+	conds := make([]Condition, 0, len(values))
+	for _, v := range values {
+		conds = append(conds, p.Eq(v))
+	}
+	return Or(conds...)
+}
+
+// enumStrings converts the Go constants of an enum to strings.
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, string(v))
+	}
+	return out
+}
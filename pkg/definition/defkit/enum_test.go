@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+type exposure string
+
+const (
+	clusterIP    exposure = "ClusterIP"
+	nodePort     exposure = "NodePort"
+	loadBalancer exposure = "LoadBalancer"
+)
+
+var _ = Describe("TypedEnumParam", func() {
+	newComponent := func() (*defkit.ComponentDefinition, *defkit.TypedEnumParam[exposure]) {
+		exposeType := defkit.EnumOf("exposeType", clusterIP, nodePort, loadBalancer).
+			Default(clusterIP).
+			Description("Specify what kind of Service you want")
+		comp := defkit.NewComponent("web").
+			Workload("v1", "Service").
+			Params(exposeType).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(
+					defkit.NewResource("v1", "Service").
+						Set("spec.type", exposeType).
+						SetIf(exposeType.Eq(nodePort), "spec.externalTrafficPolicy", defkit.Lit("Local")).
+						SetIf(exposeType.In(nodePort, loadBalancer), "metadata.labels.external", defkit.Lit("true")),
+				)
+			})
+		return comp, exposeType
+	}
+
+	It("should keep the typed values", func() {
+		_, exposeType := newComponent()
+		Expect(exposeType.Name()).To(Equal("exposeType"))
+		Expect(exposeType.GetValues()).To(Equal([]string{"ClusterIP", "NodePort", "LoadBalancer"}))
+		Expect(exposeType.GetTypedValues()).To(Equal([]exposure{clusterIP, nodePort, loadBalancer}))
+		Expect(exposeType.GetDefault()).To(Equal("ClusterIP"))
+	})
+
+	It("should generate the same CUE as an enum", func() {
+		comp, _ := newComponent()
+		cue := comp.ToCue()
+		Expect(cue).To(ContainSubstring(`exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"`))
+		Expect(cue).To(ContainSubstring(`type: parameter.exposeType`))
+		Expect(cue).To(ContainSubstring(`parameter.exposeType == "NodePort"`))
+		Expect(cue).To(ContainSubstring(`parameter.exposeType == "NodePort" || parameter.exposeType == "LoadBalancer"`))
+	})
+
+	It("should evaluate the typed conditions when rendering", func() {
+		comp, _ := newComponent()
+
+		rendered := comp.Render(defkit.TestContext())
+		Expect(rendered.Get("spec.type")).To(Equal("ClusterIP"))
+		Expect(rendered.Get("spec.externalTrafficPolicy")).To(BeNil())
+
+		rendered = comp.Render(defkit.TestContext().WithParam("exposeType", "NodePort"))
+		Expect(rendered.Get("spec.type")).To(Equal("NodePort"))
+		Expect(rendered.Get("spec.externalTrafficPolicy")).To(Equal("Local"))
+		Expect(rendered.Get("metadata.labels.external")).To(Equal("true"))
+	})
+})
@@ -451,6 +451,7 @@ type PatchKeyOp struct {
 	path     string
 	key      string // e.g., "name" for containers
 	elements []Value
+	list     bool // the single element is a list whose items are appended, see AppendTo
 }
 
 func (p *PatchKeyOp) resourceOp() {}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// maxFieldDocDepth bounds the depth of the fields documented from a schema,
+// recursive schemas such as JSONSchemaProps would never end otherwise.
+const maxFieldDocDepth = 16
+
+// maxFieldDocLength is the length after which the doc of a field is cut.
+const maxFieldDocLength = 120
+
+// FieldDocs holds the short docs of the fields of a resource, keyed by the
+// path of the field, e.g. "spec.replicas". The items of an array are denoted
+// by "[]", e.g. "spec.template.spec.containers[].image".
+type FieldDocs map[string]string
+
+// FieldDocsFromOpenAPI extracts the docs of the fields of a resource from its
+// OpenAPI v3 schema, given as JSON or YAML. The schema may be either the
+// schema of the resource itself, such as the openAPIV3Schema of a CRD, or an
+// OpenAPI document such as the ones served under /openapi/v3, in which case
+// the schema of the resource is found by its x-kubernetes-group-version-kind
+// and its references are resolved against the schemas of the document.
+//
+// Like kubectl explain, only the first sentence of each description is kept.
+func FieldDocsFromOpenAPI(apiVersion, kind string, data []byte) (FieldDocs, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI schema of %s %s: %w", apiVersion, kind, err)
+	}
+
+	schemas := map[string]any{}
+	if components, ok := doc["components"].(map[string]any); ok {
+		schemas, _ = components["schemas"].(map[string]any)
+	} else if definitions, ok := doc["definitions"].(map[string]any); ok {
+		schemas = definitions
+	}
+
+	root := doc
+	if len(schemas) > 0 {
+		root = findGVKSchema(schemas, apiVersion, kind)
+		if root == nil {
+			return nil, fmt.Errorf("no schema found for %s %s in the OpenAPI document", apiVersion, kind)
+		}
+	}
+
+	docs := FieldDocs{}
+	collectFieldDocs(docs, schemas, root, "", map[string]bool{}, 0)
+	return docs, nil
+}
+
+// findGVKSchema returns the schema of the document tagged with the given
+// apiVersion and kind.
+func findGVKSchema(schemas map[string]any, apiVersion, kind string) map[string]any {
+	group, version := "", apiVersion
+	if i := strings.Index(apiVersion, "/"); i >= 0 {
+		group, version = apiVersion[:i], apiVersion[i+1:]
+	}
+	for _, name := range sortedKeys(schemas) {
+		schema, ok := schemas[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		gvks, _ := schema["x-kubernetes-group-version-kind"].([]any)
+		for _, item := range gvks {
+			gvk, _ := item.(map[string]any)
+			if gvk["group"] == group && gvk["version"] == version && gvk["kind"] == kind {
+				return schema
+			}
+		}
+	}
+	return nil
+}
+
+// collectFieldDocs records the docs of the properties of the schema under the
+// given path. seen holds the references being walked, so that a recursive
+// reference is not followed again.
+func collectFieldDocs(docs FieldDocs, schemas map[string]any, schema map[string]any, path string, seen map[string]bool, depth int) {
+	if depth > maxFieldDocDepth {
+		return
+	}
+	schema, ref := resolveSchemaRef(schemas, schema)
+	if ref != "" {
+		if seen[ref] {
+			return
+		}
+		seen[ref] = true
+		defer delete(seen, ref)
+	}
+
+	if items, ok := schema["items"].(map[string]any); ok {
+		collectFieldDocs(docs, schemas, items, path+"[]", seen, depth+1)
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	for name, prop := range properties {
+		prop, ok := prop.(map[string]any)
+		if !ok {
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		description, _ := prop["description"].(string)
+		if description == "" {
+			// The description of a referenced schema sits next to the reference
+			// wrapped in allOf, fall back to the one of the referenced schema.
+			resolved, _ := resolveSchemaRef(schemas, prop)
+			description, _ = resolved["description"].(string)
+		}
+		if doc := shortFieldDoc(description); doc != "" {
+			docs[fieldPath] = doc
+		}
+		collectFieldDocs(docs, schemas, prop, fieldPath, seen, depth+1)
+	}
+}
+
+// resolveSchemaRef returns the schema referenced by the given schema, either
+// directly or through a single allOf item as emitted by the Kubernetes OpenAPI
+// v3 publisher, along with the name of the reference. Schemas without a
+// reference are returned as is.
+func resolveSchemaRef(schemas map[string]any, schema map[string]any) (map[string]any, string) {
+	ref, _ := schema["$ref"].(string)
+	if ref == "" {
+		if allOf, ok := schema["allOf"].([]any); ok && len(allOf) == 1 {
+			if item, ok := allOf[0].(map[string]any); ok {
+				ref, _ = item["$ref"].(string)
+			}
+		}
+	}
+	if ref == "" {
+		return schema, ""
+	}
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if resolved, ok := schemas[name].(map[string]any); ok {
+		return resolved, name
+	}
+	return schema, ""
+}
+
+// shortFieldDoc returns the first sentence of a description on a single line,
+// cut after maxFieldDocLength characters.
+func shortFieldDoc(description string) string {
+	doc := strings.Join(strings.Fields(description), " ")
+	if i := strings.Index(doc, ". "); i >= 0 {
+		doc = doc[:i+1]
+	}
+	if runes := []rune(doc); len(runes) > maxFieldDocLength {
+		doc = strings.TrimSpace(string(runes[:maxFieldDocLength])) + "..."
+	}
+	return doc
+}
+
+// WithFieldDocs documents the fields of the generated resources of the given
+// apiVersion and kind with the given docs, such as the ones returned by
+// FieldDocsFromOpenAPI, so that the generated definition explains the fields
+// it sets. A field is documented only when it has no explicit comment.
+func (g *CUEGenerator) WithFieldDocs(apiVersion, kind string, docs FieldDocs) *CUEGenerator {
+	if g.fieldDocs == nil {
+		g.fieldDocs = map[string]FieldDocs{}
+	}
+	g.fieldDocs[apiVersion+"/"+kind] = docs
+	return g
+}
+
+// resourceFieldDocs returns the field docs registered for the resource.
+func (g *CUEGenerator) resourceFieldDocs(res *Resource) FieldDocs {
+	if len(g.fieldDocs) == 0 || res.HasVersionConditionals() {
+		return nil
+	}
+	return g.fieldDocs[res.APIVersion()+"/"+res.Kind()]
+}
+
+// documentFieldTree adds the field docs matching the path of the children of
+// the node to the comments of the children that have none.
+func documentFieldTree(node *fieldNode, docs FieldDocs, path string) {
+	for _, name := range node.childOrder {
+		child := node.children[name]
+		if strings.HasPrefix(name, "[") {
+			// Array items are documented under "[]", map keys are not fields
+			// of the schema.
+			if node.isArray {
+				documentFieldTree(child, docs, path+"[]")
+			}
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if doc, ok := docs[fieldPath]; ok && len(child.comments) == 0 {
+			child.comments = append(child.comments, doc)
+		}
+		documentFieldTree(child, docs, fieldPath)
+	}
+}
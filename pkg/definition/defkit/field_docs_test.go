@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("FieldDocs", func() {
+	// An excerpt of the /openapi/v3/apis/apps/v1 document
+	const openAPIDocument = `
+components:
+  schemas:
+    io.k8s.api.apps.v1.Deployment:
+      x-kubernetes-group-version-kind:
+      - group: apps
+        version: v1
+        kind: Deployment
+      properties:
+        metadata:
+          allOf:
+          - $ref: '#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta'
+          description: Standard object's metadata. More info at the API conventions.
+        spec:
+          allOf:
+          - $ref: '#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec'
+          description: Specification of the desired behavior of the Deployment.
+    io.k8s.api.apps.v1.DeploymentSpec:
+      properties:
+        replicas:
+          description: |-
+            Number of desired pods. This is a pointer to distinguish between explicit
+            zero and not specified. Defaults to 1.
+          type: integer
+        template:
+          allOf:
+          - $ref: '#/components/schemas/io.k8s.api.core.v1.PodTemplateSpec'
+    io.k8s.api.core.v1.PodTemplateSpec:
+      description: PodTemplateSpec describes the data a pod should have when created from a template.
+      properties:
+        spec:
+          properties:
+            containers:
+              description: List of containers belonging to the pod.
+              items:
+                properties:
+                  image:
+                    description: Container image name.
+                  name:
+                    description: Name of the container specified as a DNS_LABEL.
+    io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta:
+      properties:
+        labels:
+          description: Map of string keys and values that can be used to organize and categorize objects.
+        ownerReferences:
+          items:
+            properties:
+              metadata:
+                allOf:
+                - $ref: '#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta'
+`
+
+	It("should extract the first sentence of the docs from an OpenAPI document", func() {
+		docs, err := defkit.FieldDocsFromOpenAPI("apps/v1", "Deployment", []byte(openAPIDocument))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(docs).To(HaveKeyWithValue("spec", "Specification of the desired behavior of the Deployment."))
+		Expect(docs).To(HaveKeyWithValue("spec.replicas", "Number of desired pods."))
+		Expect(docs).To(HaveKeyWithValue("spec.template", "PodTemplateSpec describes the data a pod should have when created from a template."))
+		Expect(docs).To(HaveKeyWithValue("spec.template.spec.containers[].image", "Container image name."))
+		Expect(docs).To(HaveKeyWithValue("metadata.labels", "Map of string keys and values that can be used to organize and categorize objects."))
+		// The recursive reference of ObjectMeta is not followed
+		Expect(docs).NotTo(HaveKey("metadata.ownerReferences[].metadata.labels"))
+	})
+
+	It("should fail when the document has no schema for the kind", func() {
+		_, err := defkit.FieldDocsFromOpenAPI("apps/v1", "StatefulSet", []byte(openAPIDocument))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should extract the docs from the schema of a CRD", func() {
+		schema := `
+properties:
+  spec:
+    properties:
+      size:
+        description: Size of the volume in gigabytes.
+`
+		docs, err := defkit.FieldDocsFromOpenAPI("example.com/v1", "Volume", []byte(schema))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(docs).To(Equal(defkit.FieldDocs{"spec.size": "Size of the volume in gigabytes."}))
+	})
+
+	It("should document the fields of the generated resources", func() {
+		docs, err := defkit.FieldDocsFromOpenAPI("apps/v1", "Deployment", []byte(openAPIDocument))
+		Expect(err).NotTo(HaveOccurred())
+
+		image := defkit.String("image")
+		replicas := defkit.Int("replicas").Default(1)
+		comp := defkit.NewComponent("webservice").
+			Workload("apps/v1", "Deployment").
+			Params(image, replicas).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(
+					defkit.NewResource("apps/v1", "Deployment").
+						Set("spec.replicas", replicas).
+						Set("spec.template.spec.containers[0].image", image),
+				)
+				tpl.Outputs("service",
+					defkit.NewResource("v1", "Service").
+						Set("spec.replicas", replicas),
+				)
+			})
+
+		cue := defkit.NewCUEGenerator().WithFieldDocs("apps/v1", "Deployment", docs).GenerateFullDefinition(comp)
+		Expect(cue).To(ContainSubstring("// Number of desired pods.\n"))
+		Expect(cue).To(ContainSubstring("// Container image name.\n"))
+		Expect(cue).To(ContainSubstring("// Specification of the desired behavior of the Deployment.\n"))
+		// The Service is not documented with the docs of the Deployment
+		Expect(strings.Count(cue, "// Number of desired pods.")).To(Equal(1))
+
+		plain := defkit.NewCUEGenerator().GenerateFullDefinition(comp)
+		Expect(plain).NotTo(ContainSubstring("// Number of desired pods."))
+	})
+})
@@ -0,0 +1,320 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// sectionCache holds the sections of the template rendered by an incremental
+// generator, see WithIncremental.
+type sectionCache struct {
+	sections  map[string]cachedSection // rendered sections by name
+	resources map[*Resource]string     // section rendered from each output in the last generation
+	written   map[string]bool          // sections written by the generation in progress
+}
+
+// cachedSection is a rendered section with the imports added while rendering
+// it, which are added again when the section is reused.
+type cachedSection struct {
+	key     string // hash of the generator state and, when it can be hashed, of the input
+	content string
+	imports []string
+}
+
+// WithIncremental makes the generator reuse the sections of the template it
+// rendered in the previous generation of the definition: the helpers, the
+// output, the helpers of the outputs, each auxiliary output and the parameter,
+// see WithStableAnchors. The sections marked as stale with Invalidate since are
+// rendered again, so that tools regenerating a large definition on each change,
+// such as editors, stay responsive. The sections are also rendered again when
+// a hash of the values they are rendered from changed, which catches the
+// changes that were not invalidated unless the input holds a function, such as
+// the builder of a conditional struct. The other parts of the definition are
+// always rendered.
+//
+// An incremental generator must be used for a single definition.
+func (g *CUEGenerator) WithIncremental() *CUEGenerator {
+	g.sections = &sectionCache{
+		sections:  map[string]cachedSection{},
+		resources: map[*Resource]string{},
+	}
+	return g
+}
+
+// Invalidate marks the sections of the template depending on the given
+// targets as stale, so that the next generation renders them again:
+//   - a Param invalidates the parameter section and the sections referring to
+//     the parameter;
+//   - a *Resource set as output by the template in the previous generation
+//     invalidates the section of that output;
+//   - a string invalidates the section of that name, such as "output",
+//     "outputs.service", "helpers" or "parameter".
+//
+// Invalidate without target invalidates all the sections. It has no effect
+// on a generator that is not incremental.
+func (g *CUEGenerator) Invalidate(targets ...any) {
+	if g.sections == nil {
+		return
+	}
+	if len(targets) == 0 {
+		g.sections.sections = map[string]cachedSection{}
+		return
+	}
+	for _, target := range targets {
+		switch t := target.(type) {
+		case Param:
+			delete(g.sections.sections, "parameter")
+			for name, section := range g.sections.sections {
+				if refersToParam(section.content, t.Name()) {
+					delete(g.sections.sections, name)
+				}
+			}
+		case *Resource:
+			if name, ok := g.sections.resources[t]; ok {
+				delete(g.sections.sections, name)
+			}
+		case string:
+			delete(g.sections.sections, t)
+		}
+	}
+}
+
+// refersToParam returns true if the CUE refers to the named parameter.
+func refersToParam(cue, name string) bool {
+	if strings.Contains(cue, fmt.Sprintf("parameter[%q]", name)) {
+		return true
+	}
+	ref := "parameter." + name
+	for i := strings.Index(cue, ref); i >= 0; {
+		end := i + len(ref)
+		if end == len(cue) || !isIdentRune(rune(cue[end])) {
+			return true
+		}
+		next := strings.Index(cue[end:], ref)
+		if next < 0 {
+			break
+		}
+		i = end + next
+	}
+	return false
+}
+
+// isIdentRune returns true if r may appear in a CUE identifier.
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '$' || r == '#' ||
+		('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+// beginGeneration starts tracking the sections written by a generation.
+func (g *CUEGenerator) beginGeneration() {
+	if g.sections != nil {
+		g.sections.written = map[string]bool{}
+		g.sections.resources = map[*Resource]string{}
+	}
+}
+
+// endGeneration drops the cached sections the generation did not write, such
+// as the ones of removed outputs.
+func (g *CUEGenerator) endGeneration() {
+	if g.sections == nil {
+		return
+	}
+	for name := range g.sections.sections {
+		if !g.sections.written[name] {
+			delete(g.sections.sections, name)
+		}
+	}
+}
+
+// renderSection renders a section with fn, or returns the content rendered by
+// the previous generation when the generator is incremental and the section
+// was neither invalidated nor rendered from another input.
+func (g *CUEGenerator) renderSection(name string, input any, fn func(sb *strings.Builder)) string {
+	render := func() string {
+		var section strings.Builder
+		fn(&section)
+		return section.String()
+	}
+	if g.sections == nil {
+		return render()
+	}
+	key, ok := g.sectionKey(name, input)
+	if !ok {
+		return render()
+	}
+	g.sections.written[name] = true
+	if res, isResource := input.(*Resource); isResource {
+		g.sections.resources[res] = name
+	}
+	if cached, found := g.sections.sections[name]; found && cached.key == key {
+		for _, imp := range cached.imports {
+			g.addImportIfMissing(imp)
+		}
+		return cached.content
+	}
+	imports := len(g.imports)
+	content := render()
+	g.sections.sections[name] = cachedSection{key: key, content: content, imports: append([]string(nil), g.imports[imports:]...)}
+	return content
+}
+
+// sectionKey returns the hash of the state of the generator the rendering of
+// a section depends on, together with the hash of the input of the section if
+// it can be hashed. It returns false if the state cannot be hashed.
+func (g *CUEGenerator) sectionKey(name string, input any) (string, bool) {
+	state := newInputHasher()
+	if !state.write(reflect.ValueOf([]any{name, g.indent, g.imports, g.stableAnchors, g.fieldDocs, g.sourceVars, g.iterVars})) {
+		return "", false
+	}
+	key := fmt.Sprintf("%x", state.Sum(nil))
+	if h := newInputHasher(); h.write(reflect.ValueOf(input)) {
+		key += fmt.Sprintf("/%x", h.Sum(nil))
+	}
+	return key, true
+}
+
+// pointerKey identifies a value reached through a pointer. The type is part
+// of the key since a struct and its first field share the same address.
+type pointerKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// inputHasher hashes the values a section is rendered from, including their
+// unexported fields and the values they point to.
+type inputHasher struct {
+	hash.Hash
+	visited map[pointerKey]int // index of the values already hashed, for shared and cyclic values
+}
+
+func newInputHasher() *inputHasher {
+	return &inputHasher{Hash: sha256.New(), visited: map[pointerKey]int{}}
+}
+
+func (h *inputHasher) writeUint(n uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	_, _ = h.Write(buf[:])
+}
+
+func (h *inputHasher) writeString(s string) {
+	h.writeUint(uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+// write hashes v and returns false if it holds a value that cannot be hashed:
+// a function, a channel or an unsafe pointer.
+func (h *inputHasher) write(v reflect.Value) bool {
+	if !v.IsValid() {
+		h.writeString("<invalid>")
+		return true
+	}
+	h.writeString(v.Type().String())
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.writeUint(1)
+		} else {
+			h.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeUint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		h.writeUint(math.Float64bits(real(v.Complex())))
+		h.writeUint(math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		h.writeString(v.String())
+	case reflect.Pointer:
+		if v.IsNil() {
+			h.writeString("<nil>")
+			return true
+		}
+		key := pointerKey{addr: v.Pointer(), typ: v.Type()}
+		if index, ok := h.visited[key]; ok {
+			h.writeUint(uint64(index))
+			return true
+		}
+		h.visited[key] = len(h.visited)
+		return h.write(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			h.writeString("<nil>")
+			return true
+		}
+		return h.write(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			h.writeString("<nil>")
+			return true
+		}
+		h.writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if !h.write(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			h.writeString("<nil>")
+			return true
+		}
+		// The entries are hashed apart and sorted, since the iteration order
+		// of maps is random.
+		entries := make([][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := newInputHasher()
+			if !entry.write(iter.Key()) || !entry.write(iter.Value()) {
+				return false
+			}
+			entries = append(entries, entry.Sum(nil))
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i], entries[j]) < 0 })
+		h.writeUint(uint64(len(entries)))
+		for _, entry := range entries {
+			_, _ = h.Write(entry)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			h.writeString(v.Type().Field(i).Name)
+			if !h.write(v.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Func:
+		if !v.IsNil() {
+			return false
+		}
+		h.writeString("<nil>")
+	default:
+		return false
+	}
+	return true
+}
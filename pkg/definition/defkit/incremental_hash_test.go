@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Section input hash", func() {
+	hashOf := func(input any) (string, bool) {
+		h := newInputHasher()
+		if !h.write(reflect.ValueOf(input)) {
+			return "", false
+		}
+		return string(h.Sum(nil)), true
+	}
+
+	ginkgo.It("should hash equal resources alike", func() {
+		first, ok := hashOf(NewResource("v1", "Service").Set("metadata.name", Lit("web")))
+		gomega.Expect(ok).To(gomega.BeTrue())
+		second, _ := hashOf(NewResource("v1", "Service").Set("metadata.name", Lit("web")))
+		gomega.Expect(second).To(gomega.Equal(first))
+		third, _ := hashOf(NewResource("v1", "Service").Set("metadata.name", Lit("api")))
+		gomega.Expect(third).NotTo(gomega.Equal(first))
+	})
+
+	ginkgo.It("should not depend on the iteration order of maps", func() {
+		input := map[string]int{}
+		for i, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			input[key] = i
+		}
+		first, _ := hashOf(input)
+		for i := 0; i < 10; i++ {
+			again, _ := hashOf(input)
+			gomega.Expect(again).To(gomega.Equal(first))
+		}
+	})
+
+	ginkgo.It("should hash cyclic values", func() {
+		type node struct{ next *node }
+		n := &node{}
+		n.next = n
+		_, ok := hashOf(n)
+		gomega.Expect(ok).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should not hash functions", func() {
+		_, ok := hashOf([]any{"helpers", func() {}})
+		gomega.Expect(ok).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should reuse the sections rendered from the same input", func() {
+		gen := NewCUEGenerator().WithIncremental()
+		renders := 0
+		render := func(sb *strings.Builder) {
+			renders++
+			sb.WriteString("output: {}\n")
+		}
+		for i := 0; i < 2; i++ {
+			gen.beginGeneration()
+			gen.renderSection("output", NewResource("v1", "Service"), render)
+			gen.endGeneration()
+		}
+		gomega.Expect(renders).To(gomega.Equal(1))
+
+		gen.beginGeneration()
+		gen.renderSection("output", NewResource("v1", "ConfigMap"), render)
+		gen.endGeneration()
+		gomega.Expect(renders).To(gomega.Equal(2))
+		gomega.Expect(gen.sections.sections).To(gomega.HaveLen(1))
+	})
+
+	ginkgo.It("should render again only the invalidated sections", func() {
+		gen := NewCUEGenerator().WithIncremental()
+		renders := map[string]int{}
+		output, service := NewResource("apps/v1", "Deployment"), NewResource("v1", "Service")
+		generate := func() {
+			gen.beginGeneration()
+			// the builders of conditional structs cannot be hashed, the
+			// sections rendered from them are only reused until invalidated
+			for name, res := range map[string]*Resource{"output": output, "outputs.service": service} {
+				gen.renderSection(name, []any{res, func() {}}, func(sb *strings.Builder) {
+					renders[name]++
+					sb.WriteString(name + ": {}\n")
+				})
+			}
+			gen.renderSection("parameter", []any{func() {}}, func(sb *strings.Builder) {
+				renders["parameter"]++
+				sb.WriteString("image: string\n")
+			})
+			gen.endGeneration()
+		}
+		generate()
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(map[string]int{"output": 1, "outputs.service": 1, "parameter": 1}))
+
+		gen.Invalidate("outputs.service")
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(map[string]int{"output": 1, "outputs.service": 2, "parameter": 1}))
+
+		gen.Invalidate(String("image"))
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(map[string]int{"output": 1, "outputs.service": 2, "parameter": 2}))
+
+		gen.Invalidate()
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(map[string]int{"output": 2, "outputs.service": 3, "parameter": 3}))
+	})
+
+	ginkgo.It("should render again the section of an invalidated output", func() {
+		gen := NewCUEGenerator().WithIncremental()
+		renders := 0
+		output := NewResource("apps/v1", "Deployment")
+		generate := func() {
+			gen.beginGeneration()
+			gen.renderSection("output", output, func(sb *strings.Builder) {
+				renders++
+				sb.WriteString("output: {}\n")
+			})
+			gen.endGeneration()
+		}
+		generate()
+		gen.Invalidate(NewResource("apps/v1", "Deployment"))
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(1))
+
+		gen.Invalidate(output)
+		generate()
+		gomega.Expect(renders).To(gomega.Equal(2))
+	})
+})
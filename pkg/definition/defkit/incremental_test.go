@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Incremental generation", func() {
+	var (
+		image *defkit.StringParam
+		tag   string
+		comp  *defkit.ComponentDefinition
+	)
+
+	BeforeEach(func() {
+		image = defkit.String("image")
+		tag = "v1"
+		comp = defkit.NewComponent("test").
+			Workload("apps/v1", "Deployment").
+			Params(image).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(defkit.NewResource("apps/v1", "Deployment").
+					Set("spec.template.spec.containers[0].image", image).
+					Set("metadata.labels.tag", defkit.Lit(tag)))
+				tpl.Outputs("service", defkit.NewResource("v1", "Service").
+					Set("metadata.labels.tag", defkit.Lit(tag)))
+			})
+	})
+
+	It("should render every section again when not incremental", func() {
+		gen := defkit.NewCUEGenerator()
+		Expect(gen.GenerateFullDefinition(comp)).To(ContainSubstring(`tag: "v1"`))
+		tag = "v2"
+		Expect(gen.GenerateFullDefinition(comp)).NotTo(ContainSubstring(`tag: "v1"`))
+	})
+
+	It("should render the same definition as a generator that is not incremental", func() {
+		gen := defkit.NewCUEGenerator().WithIncremental()
+		first := gen.GenerateFullDefinition(comp)
+		Expect(gen.GenerateFullDefinition(comp)).To(Equal(first))
+
+		tag = "v2"
+		cue := gen.GenerateFullDefinition(comp)
+		Expect(cue).To(ContainSubstring(`tag: "v2"`))
+		Expect(cue).NotTo(ContainSubstring(`tag: "v1"`))
+		Expect(cue).To(Equal(defkit.NewCUEGenerator().GenerateFullDefinition(comp)))
+	})
+
+	It("should render again the parameter section when a parameter changes", func() {
+		gen := defkit.NewCUEGenerator().WithIncremental()
+		gen.GenerateFullDefinition(comp)
+
+		image.Description("The image of the container")
+		cue := gen.GenerateFullDefinition(comp)
+		Expect(cue).To(ContainSubstring("The image of the container"))
+		Expect(cue).To(Equal(defkit.NewCUEGenerator().GenerateFullDefinition(comp)))
+	})
+
+	It("should drop the sections of removed outputs", func() {
+		gen := defkit.NewCUEGenerator().WithIncremental()
+		Expect(gen.GenerateFullDefinition(comp)).To(ContainSubstring(`"Service"`))
+
+		comp.Template(func(tpl *defkit.Template) {
+			tpl.Output(defkit.NewResource("apps/v1", "Deployment").
+				Set("spec.template.spec.containers[0].image", image))
+		})
+		cue := gen.GenerateFullDefinition(comp)
+		Expect(cue).NotTo(ContainSubstring("Service"))
+		Expect(cue).To(Equal(defkit.NewCUEGenerator().GenerateFullDefinition(comp)))
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// defaultIterVar is the iteration variable of the comprehensions when
+// WithSourceVarNames is not set.
+const defaultIterVar = "v"
+
+// reservedIterVars are the identifiers an iteration variable must not shadow:
+// the CUE keywords and builtins and the top-level fields of a definition.
+var reservedIterVars = map[string]bool{
+	"for": true, "in": true, "if": true, "let": true, "import": true, "package": true,
+	"true": true, "false": true, "null": true,
+	"len": true, "close": true, "and": true, "or": true, "div": true, "mod": true, "quo": true, "rem": true,
+	"parameter": true, "context": true, "output": true, "outputs": true, "patch": true, "status": true,
+}
+
+// WithSourceVarNames names the iteration variable of each collection
+// comprehension after its source instead of "v", e.g.
+// `for port in parameter.ports`. The name is the singular of the last element
+// of the source path, suffixed with "Item" when it would shadow an identifier
+// the comprehension refers to: a field set in its body, an import, a CUE
+// keyword or builtin, a top-level field of the definition or the variable of
+// an enclosing comprehension. Sources that do not end with an identifier keep
+// "v".
+func (g *CUEGenerator) WithSourceVarNames() *CUEGenerator {
+	g.sourceVars = true
+	return g
+}
+
+// enterComprehension returns the iteration variable of a comprehension over
+// source setting the given labels, and makes it the variable the items of the
+// collection are referred to by until exitComprehension is called.
+func (g *CUEGenerator) enterComprehension(source string, labels []string) string {
+	v := defaultIterVar
+	if g.sourceVars {
+		v = g.sourceIterVar(source, labels)
+	}
+	g.iterVars = append(g.iterVars, v)
+	return v
+}
+
+// exitComprehension restores the iteration variable of the enclosing
+// comprehension.
+func (g *CUEGenerator) exitComprehension() {
+	g.iterVars = g.iterVars[:len(g.iterVars)-1]
+}
+
+// itemField returns the reference to the given field of the item of the
+// comprehension being generated.
+func (g *CUEGenerator) itemField(field string) string {
+	v := defaultIterVar
+	if len(g.iterVars) > 0 {
+		v = g.iterVars[len(g.iterVars)-1]
+	}
+	return v + "." + field
+}
+
+// sourceIterVar derives the iteration variable of a comprehension over source
+// from its last path element, avoiding the identifiers it would shadow.
+func (g *CUEGenerator) sourceIterVar(source string, labels []string) string {
+	name := source[strings.LastIndex(source, ".")+1:]
+	if !isIterVarIdent(name) {
+		return defaultIterVar
+	}
+	base := singular(name)
+
+	taken := make(map[string]bool, len(labels)+len(g.imports)+len(g.iterVars)+1)
+	for _, label := range labels {
+		taken[label] = true
+	}
+	for _, imp := range g.imports {
+		taken[imp[strings.LastIndex(imp, "/")+1:]] = true
+	}
+	for _, v := range g.iterVars {
+		taken[v] = true
+	}
+	// The root of the source, e.g. parameter, must stay reachable.
+	taken[strings.SplitN(source, ".", 2)[0]] = true
+
+	free := func(v string) bool { return !taken[v] && !reservedIterVars[v] }
+	// A name that is not a plural would read as the collection itself.
+	if base != name && free(base) {
+		return base
+	}
+	candidate := base + "Item"
+	for i := 2; !free(candidate); i++ {
+		candidate = fmt.Sprintf("%sItem%d", base, i)
+	}
+	return candidate
+}
+
+// isIterVarIdent reports whether name can be used as a CUE identifier without
+// quoting and is not hidden or a definition.
+func isIterVarIdent(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// singular returns the singular of an English plural noun, or the noun itself
+// when it is not a plural.
+func singular(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ies") && len(noun) > 3:
+		return noun[:len(noun)-3] + "y"
+	case strings.HasSuffix(noun, "sses"), strings.HasSuffix(noun, "xes"),
+		strings.HasSuffix(noun, "ches"), strings.HasSuffix(noun, "shes"):
+		return noun[:len(noun)-2]
+	case strings.HasSuffix(noun, "s") && !strings.HasSuffix(noun, "ss") && !strings.HasSuffix(noun, "us") && len(noun) > 1:
+		return noun[:len(noun)-1]
+	default:
+		return noun
+	}
+}
+
+// collectionLabels returns the fields set by the body of a comprehension
+// applying the given operations.
+func collectionLabels(ops []collectionOperation) []string {
+	var labels []string
+	var addMapping func(mapping FieldMap)
+	addMapping = func(mapping FieldMap) {
+		for label, value := range mapping {
+			labels = append(labels, strings.SplitN(label, ".", 2)[0])
+			if nested, ok := value.(*NestedField); ok {
+				addMapping(nested.mapping)
+			}
+		}
+	}
+	for _, op := range ops {
+		switch o := op.(type) {
+		case *mapOp:
+			addMapping(o.mappings)
+		case *mapVariantOp:
+			addMapping(o.mappings)
+		case *wrapOp:
+			labels = append(labels, o.key)
+		}
+	}
+	return labels
+}
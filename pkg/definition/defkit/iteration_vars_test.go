@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Iteration variable naming", func() {
+	newComponent := func(mapping defkit.FieldMap) *defkit.ComponentDefinition {
+		ports := defkit.List("ports")
+		env := defkit.List("env")
+		return defkit.NewComponent("test").
+			Workload("apps/v1", "Deployment").
+			Params(ports, env).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(
+					defkit.NewResource("apps/v1", "Deployment").
+						Set("spec.template.spec.containers[0].ports",
+							defkit.Each(ports).Filter(defkit.FieldEquals("expose", true)).Map(mapping)).
+						Set("spec.template.spec.containers[0].env",
+							defkit.Each(env).Map(defkit.FieldMap{"name": defkit.F("name")})),
+				)
+			})
+	}
+
+	It("should name every iteration variable v by default", func() {
+		cue := defkit.NewCUEGenerator().GenerateFullDefinition(newComponent(defkit.FieldMap{
+			"containerPort": defkit.F("port"),
+		}))
+		Expect(cue).To(ContainSubstring("for v in parameter.ports if v.expose == true"))
+		Expect(cue).To(ContainSubstring("containerPort: v.port"))
+		Expect(cue).To(ContainSubstring("for v in parameter.env"))
+	})
+
+	It("should name the iteration variables after their source", func() {
+		cue := defkit.NewCUEGenerator().WithSourceVarNames().GenerateFullDefinition(newComponent(defkit.FieldMap{
+			"containerPort": defkit.F("port"),
+		}))
+		Expect(cue).To(ContainSubstring("for port in parameter.ports if port.expose == true"))
+		Expect(cue).To(ContainSubstring("containerPort: port.port"))
+		// env is not a plural
+		Expect(cue).To(ContainSubstring("for envItem in parameter.env"))
+		Expect(cue).To(ContainSubstring("name: envItem.name"))
+	})
+
+	It("should not shadow the fields set in the body of the comprehension", func() {
+		cue := defkit.NewCUEGenerator().WithSourceVarNames().GenerateFullDefinition(newComponent(defkit.FieldMap{
+			"port":     defkit.F("port"),
+			"portItem": defkit.F("name"),
+		}))
+		Expect(cue).To(ContainSubstring("for portItem2 in parameter.ports if portItem2.expose == true"))
+		Expect(cue).To(ContainSubstring("port: portItem2.port"))
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import "fmt"
+
+// CronSchedulePattern is the regular expression a cron schedule parameter must
+// match: five fields in cron format, optionally preceded by a CRON_TZ or TZ
+// time zone, or one of the predefined schedules such as "@daily".
+const CronSchedulePattern = `^(@(annually|yearly|monthly|weekly|daily|midnight|hourly)|((CRON_TZ|TZ)=\S+ )?[0-9A-Za-z*/,?#-]+( [0-9A-Za-z*/,?#-]+){4})$`
+
+// CronSchedule creates a string parameter which must be a schedule in cron
+// format, such as the schedule of a CronJob.
+// This generates CUE like: schedule: string & =~"^(@(annually|..."
+func CronSchedule(name string) *StringParam {
+	p := String(name)
+	p.format = fmt.Sprintf("=~%q", CronSchedulePattern)
+	return p
+}
+
+// RestartPolicy creates an enum parameter for the restart policy of the pods
+// of a Job, which can only be Never or OnFailure, defaulting to Never.
+// This generates CUE like: restart: *"Never" | "OnFailure"
+func RestartPolicy(name string) *EnumParam {
+	return Enum(name).Values("Never", "OnFailure").Default("Never")
+}
+
+// ConcurrencyPolicy creates an enum parameter for the concurrency policy of a
+// CronJob, defaulting to Allow.
+// This generates CUE like: concurrencyPolicy: *"Allow" | "Forbid" | "Replace"
+func ConcurrencyPolicy(name string) *EnumParam {
+	return Enum(name).Values("Allow", "Forbid", "Replace").Default("Allow")
+}
+
+// JobParams holds the parameters of the run of a Job, named and defaulted
+// like the ones of the built-in task and cron-task components.
+//
+// Example:
+//
+//	job := defkit.NewJobParams()
+//	defkit.NewComponent("task").
+//		Workload("batch/v1", "Job").
+//		Params(job.Params()...).
+//		Template(func(tpl *defkit.Template) {
+//			tpl.Output(job.Apply(defkit.NewResource("batch/v1", "Job"), "spec"))
+//		})
+type JobParams struct {
+	// Count is the number of pods to run in parallel and to complete.
+	Count *IntParam
+	// Restart is the restart policy of the pods.
+	Restart *EnumParam
+	// BackoffLimit is the number of retries before marking the Job failed.
+	BackoffLimit *IntParam
+	// ActiveDeadlineSeconds limits the duration the Job may be active.
+	ActiveDeadlineSeconds *IntParam
+	// TTLSecondsAfterFinished limits the lifetime of a finished Job.
+	TTLSecondsAfterFinished *IntParam
+}
+
+// NewJobParams creates the parameters of the run of a Job.
+func NewJobParams() *JobParams {
+	return &JobParams{
+		Count: Int("count").Default(1).Short("c").
+			Description("Specify number of tasks to run in parallel"),
+		Restart: RestartPolicy("restart").
+			Description("Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never."),
+		BackoffLimit: Int("backoffLimit").Default(6).Min(0).
+			Description("The number of retries before marking this job failed"),
+		ActiveDeadlineSeconds: Int("activeDeadlineSeconds").Optional().Min(1).
+			Description("The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it"),
+		TTLSecondsAfterFinished: Int("ttlSecondsAfterFinished").Optional().Min(0).
+			Description("Limits the lifetime of a Job that has finished"),
+	}
+}
+
+// Params returns the parameters to declare in the definition.
+func (p *JobParams) Params() []Param {
+	return []Param{p.Count, p.Restart, p.BackoffLimit, p.ActiveDeadlineSeconds, p.TTLSecondsAfterFinished}
+}
+
+// Apply sets the fields of the Job spec found at specPath in the resource from
+// the parameters: "spec" for a Job, "spec.jobTemplate.spec" for a CronJob.
+// The restart policy is set in the pod template of the Job.
+func (p *JobParams) Apply(r *Resource, specPath string) *Resource {
+	return r.
+		Set(specPath+".parallelism", p.Count).
+		Set(specPath+".completions", p.Count).
+		SetIf(p.TTLSecondsAfterFinished.IsSet(), specPath+".ttlSecondsAfterFinished", p.TTLSecondsAfterFinished).
+		SetIf(p.ActiveDeadlineSeconds.IsSet(), specPath+".activeDeadlineSeconds", p.ActiveDeadlineSeconds).
+		Set(specPath+".backoffLimit", p.BackoffLimit).
+		Set(specPath+".template.spec.restartPolicy", p.Restart)
+}
+
+// CronJobParams holds the parameters of the schedule of a CronJob and of the
+// run of its Jobs, named and defaulted like the ones of the built-in cron-task
+// component.
+type CronJobParams struct {
+	JobParams
+	// Schedule is the schedule of the CronJob in cron format.
+	Schedule *StringParam
+	// StartingDeadlineSeconds is the deadline for starting a Job missing its
+	// scheduled time.
+	StartingDeadlineSeconds *IntParam
+	// Suspend suspends the subsequent executions.
+	Suspend *BoolParam
+	// ConcurrencyPolicy specifies how to treat concurrent executions.
+	ConcurrencyPolicy *EnumParam
+	// SuccessfulJobsHistoryLimit is the number of successful Jobs to retain.
+	SuccessfulJobsHistoryLimit *IntParam
+	// FailedJobsHistoryLimit is the number of failed Jobs to retain.
+	FailedJobsHistoryLimit *IntParam
+}
+
+// NewCronJobParams creates the parameters of a CronJob.
+func NewCronJobParams() *CronJobParams {
+	return &CronJobParams{
+		JobParams: *NewJobParams(),
+		Schedule: CronSchedule("schedule").
+			Description("Specify the schedule in Cron format, see https://en.wikipedia.org/wiki/Cron"),
+		StartingDeadlineSeconds: Int("startingDeadlineSeconds").Optional().Min(0).
+			Description("Specify deadline in seconds for starting the job if it misses scheduled"),
+		Suspend: Bool("suspend").Default(false).
+			Description("suspend subsequent executions"),
+		ConcurrencyPolicy: ConcurrencyPolicy("concurrencyPolicy").
+			Description("Specifies how to treat concurrent executions of a Job"),
+		SuccessfulJobsHistoryLimit: Int("successfulJobsHistoryLimit").Default(3).Min(0).
+			Description("The number of successful finished jobs to retain"),
+		FailedJobsHistoryLimit: Int("failedJobsHistoryLimit").Default(1).Min(0).
+			Description("The number of failed finished jobs to retain"),
+	}
+}
+
+// Params returns the parameters to declare in the definition.
+func (p *CronJobParams) Params() []Param {
+	return append([]Param{
+		p.Schedule, p.StartingDeadlineSeconds, p.Suspend, p.ConcurrencyPolicy,
+		p.SuccessfulJobsHistoryLimit, p.FailedJobsHistoryLimit,
+	}, p.JobParams.Params()...)
+}
+
+// Apply sets the fields of the CronJob spec of the resource from the
+// parameters, and the fields of its Job template from the Job parameters.
+func (p *CronJobParams) Apply(r *Resource) *Resource {
+	r.
+		Set("spec.schedule", p.Schedule).
+		Set("spec.concurrencyPolicy", p.ConcurrencyPolicy).
+		Set("spec.suspend", p.Suspend).
+		Set("spec.successfulJobsHistoryLimit", p.SuccessfulJobsHistoryLimit).
+		Set("spec.failedJobsHistoryLimit", p.FailedJobsHistoryLimit).
+		SetIf(p.StartingDeadlineSeconds.IsSet(), "spec.startingDeadlineSeconds", p.StartingDeadlineSeconds)
+	return p.JobParams.Apply(r, "spec.jobTemplate.spec")
+}
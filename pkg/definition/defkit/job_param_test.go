@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Job Params", func() {
+	It("should match cron schedules only", func() {
+		re := regexp.MustCompile(defkit.CronSchedulePattern)
+		Expect(re.MatchString("*/5 * * * *")).To(BeTrue())
+		Expect(re.MatchString("0 0 1,15 * MON-FRI")).To(BeTrue())
+		Expect(re.MatchString("CRON_TZ=Europe/Paris 0 6 * * *")).To(BeTrue())
+		Expect(re.MatchString("@daily")).To(BeTrue())
+		Expect(re.MatchString("* * * *")).To(BeFalse())
+		Expect(re.MatchString("@every-day")).To(BeFalse())
+		Expect(re.MatchString("")).To(BeFalse())
+	})
+
+	It("should generate the parameters of the built-in task component", func() {
+		job := defkit.NewJobParams()
+		cue := defkit.NewComponent("task").
+			Workload("batch/v1", "Job").
+			Params(job.Params()...).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(job.Apply(defkit.NewResource("batch/v1", "Job"), "spec"))
+			}).
+			ToCue()
+
+		Expect(cue).To(ContainSubstring(`restart: *"Never" | "OnFailure"`))
+		Expect(cue).To(ContainSubstring("backoffLimit: *6 | int"))
+		Expect(cue).To(ContainSubstring("ttlSecondsAfterFinished?: int"))
+		Expect(cue).To(ContainSubstring("parallelism: parameter.count"))
+		Expect(cue).To(ContainSubstring("completions: parameter.count"))
+		Expect(cue).To(ContainSubstring("restartPolicy: parameter.restart"))
+		Expect(cue).To(ContainSubstring(`if parameter["activeDeadlineSeconds"] != _|_`))
+	})
+
+	It("should wire the parameters of the built-in cron-task component", func() {
+		cron := defkit.NewCronJobParams()
+		Expect(cron.Params()).To(HaveLen(11))
+
+		cue := defkit.NewComponent("cron-task").
+			Workload("batch/v1", "CronJob").
+			Params(cron.Params()...).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(cron.Apply(defkit.NewResource("batch/v1", "CronJob")))
+			}).
+			ToCue()
+
+		Expect(cue).To(ContainSubstring(`schedule: string & =~"^(@(annually|yearly`))
+		Expect(cue).To(ContainSubstring(`concurrencyPolicy: *"Allow" | "Forbid" | "Replace"`))
+		Expect(cue).To(ContainSubstring("successfulJobsHistoryLimit: *3 | int"))
+		Expect(cue).To(ContainSubstring("schedule: parameter.schedule"))
+		Expect(cue).To(ContainSubstring("jobTemplate:"))
+		Expect(cue).To(ContainSubstring("backoffLimit: parameter.backoffLimit"))
+	})
+})
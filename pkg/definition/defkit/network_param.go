@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import "fmt"
+
+// URLPattern is the regular expression an URL parameter must match: an http
+// or https scheme followed by a host, and an optional path, query or fragment.
+const URLPattern = `^https?://[^\s/?#]+([/?#]\S*)?$`
+
+// URL creates a string parameter which must be an http or https URL.
+// This generates CUE like: endpoint: string & =~"^https?://..."
+func URL(name string) *StringParam {
+	p := String(name)
+	p.format = fmt.Sprintf("=~%q", URLPattern)
+	return p
+}
+
+// Hostname creates a string parameter which must be a fully qualified domain
+// name, such as the host of an ingress rule.
+// This generates CUE like: host: string & net.FQDN
+func Hostname(name string) *StringParam {
+	p := String(name)
+	p.format = "net.FQDN"
+	return p
+}
+
+// CIDR creates a string parameter which must be an IP address range in CIDR
+// notation, such as "10.0.0.0/16".
+// This generates CUE like: sourceRange: string & net.IPCIDR
+func CIDR(name string) *StringParam {
+	p := String(name)
+	p.format = "net.IPCIDR"
+	return p
+}
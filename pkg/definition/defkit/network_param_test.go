@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Network Params", func() {
+	It("should generate validators of the net package", func() {
+		host := defkit.Hostname("host")
+		sourceRange := defkit.CIDR("sourceRange").Optional()
+		Expect(host.GetFormat()).To(Equal("net.FQDN"))
+		Expect(host.RequiredImports()).To(Equal([]string{"net"}))
+
+		cue := defkit.NewComponent("gateway").
+			Workload("networking.k8s.io/v1", "Ingress").
+			Params(host, sourceRange).
+			ToCue()
+		Expect(cue).To(ContainSubstring(`"net"`))
+		Expect(cue).To(ContainSubstring("host: string & net.FQDN"))
+		Expect(cue).To(ContainSubstring("sourceRange?: string & net.IPCIDR"))
+	})
+
+	It("should combine the URL pattern with the other string constraints", func() {
+		endpoint := defkit.URL("endpoint").Default("https://example.com").NotEmpty()
+		Expect(endpoint.RequiredImports()).To(BeEmpty())
+
+		cue := defkit.NewComponent("client").
+			Workload("v1", "ConfigMap").
+			Params(endpoint).
+			ToCue()
+		Expect(cue).To(ContainSubstring(`endpoint: *"https://example.com" | string & !="" & =~"^https?://`))
+	})
+
+	It("should match http and https URLs only", func() {
+		re := regexp.MustCompile(defkit.URLPattern)
+		Expect(re.MatchString("https://example.com")).To(BeTrue())
+		Expect(re.MatchString("http://example.com:8080/api?q=1#top")).To(BeTrue())
+		Expect(re.MatchString("example.com")).To(BeFalse())
+		Expect(re.MatchString("ftp://example.com")).To(BeFalse())
+		Expect(re.MatchString("https:// example.com")).To(BeFalse())
+	})
+})
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+// Overlay holds the cluster-specific field assignments of a component.
+// The assignments are applied only when the component is dispatched to the
+// cluster, by generating blocks conditioned on context.cluster.
+type Overlay struct {
+	cluster string
+	ops     []overlayOp
+}
+
+// overlayOp is a field assignment of an overlay on the primary output
+// (empty output) or on a named auxiliary output.
+type overlayOp struct {
+	output string
+	path   string
+	value  Value
+}
+
+// Cluster returns the name of the cluster the overlay applies to.
+func (o *Overlay) Cluster() string { return o.cluster }
+
+// Set assigns the field of the primary output on the cluster.
+func (o *Overlay) Set(path string, value Value) *Overlay {
+	o.ops = append(o.ops, overlayOp{path: path, value: value})
+	return o
+}
+
+// SetOutputs assigns the field of the named auxiliary output on the cluster.
+func (o *Overlay) SetOutputs(name, path string, value Value) *Overlay {
+	o.ops = append(o.ops, overlayOp{output: name, path: path, value: value})
+	return o
+}
+
+// Overlay adds cluster-specific differences to the component, such as the
+// image registry or the storage class used on a cluster. A field set by an
+// overlay replaces the value set by the template at the same path on that
+// cluster, the template value is kept on the other clusters.
+//
+// Example:
+//
+//	defkit.NewComponent("app").
+//	    Template(...).
+//	    Overlay("cn-hangzhou", func(o *defkit.Overlay) {
+//	        o.Set("spec.template.spec.containers[0].image", defkit.Lit("registry.cn-hangzhou.aliyuncs.com/app:v1"))
+//	    })
+//
+// Generates:
+//
+//	if context.cluster != "cn-hangzhou" {
+//	    image: parameter.image
+//	}
+//	if context.cluster == "cn-hangzhou" {
+//	    image: "registry.cn-hangzhou.aliyuncs.com/app:v1"
+//	}
+//
+// Multiple Overlay calls for the same cluster accumulate.
+func (c *ComponentDefinition) Overlay(cluster string, fn func(o *Overlay)) *ComponentDefinition {
+	for _, o := range c.overlays {
+		if o.cluster == cluster {
+			fn(o)
+			return c
+		}
+	}
+	o := &Overlay{cluster: cluster}
+	fn(o)
+	c.overlays = append(c.overlays, o)
+	return c
+}
+
+// GetOverlays returns the cluster overlays of the component.
+func (c *ComponentDefinition) GetOverlays() []*Overlay { return c.overlays }
+
+// GetTemplate returns the template function of the component with the
+// cluster overlays applied to its outputs.
+func (c *ComponentDefinition) GetTemplate() func(tpl *Template) {
+	if c.template == nil || len(c.overlays) == 0 {
+		return c.template
+	}
+	return func(tpl *Template) {
+		c.template(tpl)
+		applyOverlays(tpl, c.overlays)
+	}
+}
+
+// applyOverlays guards the template assignments overridden by the overlays
+// with the clusters they are not overridden on, then adds the assignments of
+// each overlay guarded by its cluster.
+func applyOverlays(tpl *Template, overlays []*Overlay) {
+	cluster := VelaCtx().Cluster()
+	resourceOf := func(output string) *Resource {
+		if output == "" {
+			return tpl.output
+		}
+		return tpl.outputs[output]
+	}
+
+	overridden := map[*Resource]map[string][]string{}
+	for _, o := range overlays {
+		for _, op := range o.ops {
+			r := resourceOf(op.output)
+			if r == nil {
+				continue
+			}
+			if overridden[r] == nil {
+				overridden[r] = map[string][]string{}
+			}
+			overridden[r][op.path] = append(overridden[r][op.path], o.cluster)
+		}
+	}
+	for r, paths := range overridden {
+		for i, op := range r.ops {
+			switch o := op.(type) {
+			case *SetOp:
+				if clusters, ok := paths[o.path]; ok {
+					r.ops[i] = &SetIfOp{path: o.path, value: o.value, cond: notOnClusters(cluster, clusters)}
+				}
+			case *SetIfOp:
+				if clusters, ok := paths[o.path]; ok {
+					r.ops[i] = &SetIfOp{path: o.path, value: o.value, cond: And(o.cond, notOnClusters(cluster, clusters))}
+				}
+			}
+		}
+	}
+	for _, o := range overlays {
+		for _, op := range o.ops {
+			if r := resourceOf(op.output); r != nil {
+				r.ops = append(r.ops, &SetIfOp{path: op.path, value: op.value, cond: Eq(cluster, Lit(o.cluster))})
+			}
+		}
+	}
+}
+
+// notOnClusters returns the condition matching none of the clusters.
+func notOnClusters(cluster *ContextRef, clusters []string) Condition {
+	if len(clusters) == 1 {
+		return Ne(cluster, Lit(clusters[0]))
+	}
+	conds := make([]Condition, 0, len(clusters))
+	for _, name := range clusters {
+		conds = append(conds, Ne(cluster, Lit(name)))
+	}
+	return And(conds...)
+}
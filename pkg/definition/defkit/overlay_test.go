@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Overlay", func() {
+	newComponent := func() *defkit.ComponentDefinition {
+		image := defkit.String("image").Default("nginx")
+		return defkit.NewComponent("web").
+			Workload("apps/v1", "Deployment").
+			Params(image).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(
+					defkit.NewResource("apps/v1", "Deployment").
+						Set("spec.replicas", defkit.Lit(1)).
+						Set("spec.template.spec.containers[0].image", image),
+				)
+				tpl.Outputs("pvc",
+					defkit.NewResource("v1", "PersistentVolumeClaim").
+						Set("spec.storageClassName", defkit.Lit("standard")),
+				)
+			})
+	}
+
+	It("should accumulate the overlays of the same cluster", func() {
+		c := newComponent().
+			Overlay("hangzhou", func(o *defkit.Overlay) {
+				o.Set("spec.replicas", defkit.Lit(3))
+			}).
+			Overlay("beijing", func(o *defkit.Overlay) {
+				o.Set("spec.replicas", defkit.Lit(2))
+			}).
+			Overlay("hangzhou", func(o *defkit.Overlay) {
+				o.SetOutputs("pvc", "spec.storageClassName", defkit.Lit("alicloud-disk"))
+			})
+
+		overlays := c.GetOverlays()
+		Expect(overlays).To(HaveLen(2))
+		Expect(overlays[0].Cluster()).To(Equal("hangzhou"))
+		Expect(overlays[1].Cluster()).To(Equal("beijing"))
+	})
+
+	It("should generate blocks conditioned on context.cluster", func() {
+		c := newComponent().
+			Overlay("hangzhou", func(o *defkit.Overlay) {
+				o.Set("spec.template.spec.containers[0].image", defkit.Lit("registry.cn-hangzhou.aliyuncs.com/nginx"))
+				o.SetOutputs("pvc", "spec.storageClassName", defkit.Lit("alicloud-disk"))
+			})
+
+		cue := c.ToCue()
+		Expect(cue).To(ContainSubstring(`context.cluster == "hangzhou"`))
+		Expect(cue).To(ContainSubstring(`context.cluster != "hangzhou"`))
+		Expect(cue).To(ContainSubstring(`"registry.cn-hangzhou.aliyuncs.com/nginx"`))
+		Expect(cue).To(ContainSubstring(`"alicloud-disk"`))
+	})
+
+	It("should render the overlay values on the cluster only", func() {
+		c := newComponent().
+			Overlay("hangzhou", func(o *defkit.Overlay) {
+				o.Set("spec.replicas", defkit.Lit(3))
+				o.SetOutputs("pvc", "spec.storageClassName", defkit.Lit("alicloud-disk"))
+			}).
+			Overlay("beijing", func(o *defkit.Overlay) {
+				o.Set("spec.replicas", defkit.Lit(2))
+			})
+
+		rendered := c.RenderAll(defkit.TestContext().WithCluster("hangzhou"))
+		Expect(rendered.Primary.Get("spec.replicas")).To(Equal(3))
+		Expect(rendered.Auxiliary["pvc"].Get("spec.storageClassName")).To(Equal("alicloud-disk"))
+
+		rendered = c.RenderAll(defkit.TestContext().WithCluster("beijing"))
+		Expect(rendered.Primary.Get("spec.replicas")).To(Equal(2))
+		Expect(rendered.Auxiliary["pvc"].Get("spec.storageClassName")).To(Equal("standard"))
+
+		rendered = c.RenderAll(defkit.TestContext().WithCluster("local"))
+		Expect(rendered.Primary.Get("spec.replicas")).To(Equal(1))
+		Expect(rendered.Auxiliary["pvc"].Get("spec.storageClassName")).To(Equal("standard"))
+	})
+
+	It("should not change a component without overlays", func() {
+		Expect(newComponent().ToCue()).NotTo(ContainSubstring("context.cluster"))
+	})
+})
@@ -31,20 +31,22 @@ type baseParam struct {
 	description  string
 	short        string // short flag alias (e.g. "i" → // +short=i)
 	ignore       bool   // when true, emits // +ignore directive
+	insertSecret string // secret injection target (e.g. "dbConn" → // +insertSecretTo=dbConn)
 }
 
 func (p *baseParam) expr()      {}
 func (p *baseParam) value()     {}
 func (p *baseParam) condition() {}
 
-func (p *baseParam) Name() string           { return p.name }
-func (p *baseParam) IsRequired() bool       { return p.required }
-func (p *baseParam) IsOptional() bool       { return p.optional }
-func (p *baseParam) HasDefault() bool       { return p.defaultValue != nil }
-func (p *baseParam) GetDefault() any        { return p.defaultValue }
-func (p *baseParam) GetDescription() string { return p.description }
-func (p *baseParam) GetShort() string       { return p.short }
-func (p *baseParam) IsIgnore() bool         { return p.ignore }
+func (p *baseParam) Name() string              { return p.name }
+func (p *baseParam) IsRequired() bool          { return p.required }
+func (p *baseParam) IsOptional() bool          { return p.optional }
+func (p *baseParam) HasDefault() bool          { return p.defaultValue != nil }
+func (p *baseParam) GetDefault() any           { return p.defaultValue }
+func (p *baseParam) GetDescription() string    { return p.description }
+func (p *baseParam) GetShort() string          { return p.short }
+func (p *baseParam) IsIgnore() bool            { return p.ignore }
+func (p *baseParam) GetInsertSecretTo() string { return p.insertSecret }
 
 // IsSet returns a condition that checks if the parameter has a value.
 // This is used with SetIf for conditional field assignment.
@@ -120,6 +122,7 @@ type StringParam struct {
 	notEmpty   bool     // when true, emits !="" constraint
 	minLen     *int     // minimum length constraint
 	maxLen     *int     // maximum length constraint
+	format     string   // CUE validator constraint (e.g., net.FQDN), see URL, Hostname and CIDR
 }
 
 // String creates a new string parameter with the given name.
@@ -162,6 +165,15 @@ func (p *StringParam) Ignore() *StringParam {
 	return p
 }
 
+// InsertSecretTo marks the parameter as a reference to a Secret whose data is
+// injected into the named top-level CUE field when the component is rendered.
+// This generates a // +insertSecretTo=X directive in the CUE output and is used
+// by components that consume connection secrets written by cloud resources.
+func (p *StringParam) InsertSecretTo(target string) *StringParam {
+	p.insertSecret = target
+	return p
+}
+
 // Default sets a default value for the parameter.
 func (p *StringParam) Default(value string) *StringParam {
 	p.defaultValue = value
@@ -234,12 +246,22 @@ func (p *StringParam) GetMaxLen() *int {
 }
 
 // RequiredImports returns the CUE imports needed by this parameter's constraints.
-// MinLen/MaxLen generate strings.MinRunes()/strings.MaxRunes() which require "strings".
+// MinLen/MaxLen generate strings.MinRunes()/strings.MaxRunes() which require "strings",
+// the Hostname and CIDR formats use validators of the "net" package.
 func (p *StringParam) RequiredImports() []string {
+	var imports []string
 	if p.minLen != nil || p.maxLen != nil {
-		return []string{"strings"}
+		imports = append(imports, "strings")
 	}
-	return nil
+	if strings.HasPrefix(p.format, "net.") {
+		imports = append(imports, "net")
+	}
+	return imports
+}
+
+// GetFormat returns the CUE validator constraint of the string, or "" if none.
+func (p *StringParam) GetFormat() string {
+	return p.format
 }
 
 // NotEmpty adds a non-empty string constraint.
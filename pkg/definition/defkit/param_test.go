@@ -1007,6 +1007,17 @@ var _ = Describe("Parameters", func() {
 		})
 	})
 
+	Context("InsertSecretTo method", func() {
+		It("should set the secret injection target", func() {
+			p := defkit.String("dbSecret").InsertSecretTo("dbConn")
+			Expect(p.GetInsertSecretTo()).To(Equal("dbConn"))
+		})
+		It("should be empty by default", func() {
+			p := defkit.String("dbSecret")
+			Expect(p.GetInsertSecretTo()).To(BeEmpty())
+		})
+	})
+
 	Context("Ignore method", func() {
 		It("should mark StringParam as ignored", func() {
 			p := defkit.String("port").Ignore()
@@ -122,6 +122,38 @@ func (p *PatchResource) PatchKey(path string, key string, elements ...Value) *Pa
 	return p
 }
 
+// AppendTo appends value to the array at path, merging the items having the
+// same patchKey field as an item of the array, like the sidecar and env
+// injection traits do. value is either a list, such as an array parameter or
+// a collection, whose items are appended, or a single item.
+// This generates: // +patchKey=key
+//
+//	path: value
+//
+// Example:
+//
+//	tpl.Patch().AppendTo("spec.template.spec.containers[0].env", defkit.Each(env).Map(...), "name")
+func (p *PatchResource) AppendTo(path string, value Value, patchKey string) *PatchResource {
+	op := &PatchKeyOp{path: path, key: patchKey, elements: []Value{value}, list: isListValue(value)}
+	if p.currentIf != nil {
+		p.currentIf.ops = append(p.currentIf.ops, op)
+	} else {
+		p.ops = append(p.ops, op)
+	}
+	return p
+}
+
+// isListValue returns true if the value is a list rather than a list item.
+func isListValue(v Value) bool {
+	switch v.(type) {
+	case *ArrayParam, *OpenArrayParam, *CollectionOp, *ArrayBuilder, *ArrayConcatValue,
+		*ListComprehension, *MultiSource, *ConcatExprValue, *InlineArrayValue:
+		return true
+	default:
+		return false
+	}
+}
+
 // SpreadAll adds a spread constraint that applies to all array elements.
 // This generates: path: [...{element1}, ...{element2}]
 // Used for applying the same patch to every element in an array.
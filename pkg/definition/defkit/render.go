@@ -28,8 +28,8 @@ func (c *ComponentDefinition) Render(ctx *TestContextBuilder) *RenderedResource
 
 	// Create and execute template
 	tpl := NewTemplate()
-	if c.template != nil {
-		c.template(tpl)
+	if templateFn := c.GetTemplate(); templateFn != nil {
+		templateFn(tpl)
 	}
 
 	// Render the output resource with resolved values
@@ -43,8 +43,8 @@ func (c *ComponentDefinition) RenderAll(ctx *TestContextBuilder) *RenderedOutput
 	defer clearCurrentTestContext()
 
 	tpl := NewTemplate()
-	if c.template != nil {
-		c.template(tpl)
+	if templateFn := c.GetTemplate(); templateFn != nil {
+		templateFn(tpl)
 	}
 
 	outputs := &RenderedOutputs{
@@ -166,6 +166,9 @@ func resolveValue(v Value, ctx *TestRuntimeContext) any {
 	if v == nil {
 		return nil
 	}
+	if e, ok := v.(typedEnum); ok {
+		v = e.enum()
+	}
 
 	switch val := v.(type) {
 	case *StringParam:
@@ -204,6 +207,8 @@ func resolveValue(v Value, ctx *TestRuntimeContext) any {
 		return resolveMultiSource(val, ctx)
 	case *StringKeyMapParam:
 		return ctx.GetParamOr(val.Name(), val.GetDefault())
+	case *DurationSecondsValue:
+		return durationSeconds(resolveValue(val.source, ctx))
 	default:
 		// For any Param interface, use method access
 		if p, ok := v.(Param); ok {
@@ -224,6 +229,8 @@ func resolveContextRef(ref *ContextRef, ctx *TestRuntimeContext) any {
 		return ctx.AppName()
 	case "context.appRevision":
 		return ctx.AppRevision()
+	case "context.cluster":
+		return ctx.Cluster()
 	default:
 		return ref.String()
 	}
@@ -278,17 +285,22 @@ func evaluateCondition(cond Condition, ctx *TestRuntimeContext) bool {
 
 // hasExposedPorts checks if a ports array has any port with expose=true.
 func hasExposedPorts(ports any) bool {
-	portList, ok := ports.([]any)
-	if !ok {
-		return false
-	}
-	for _, p := range portList {
-		if portMap, ok := p.(map[string]any); ok {
-			if expose, ok := portMap["expose"].(bool); ok && expose {
-				return true
+	var portList []map[string]any
+	switch p := ports.(type) {
+	case []map[string]any:
+		portList = p
+	case []any:
+		for _, port := range p {
+			if portMap, ok := port.(map[string]any); ok {
+				portList = append(portList, portMap)
 			}
 		}
 	}
+	for _, portMap := range portList {
+		if expose, ok := portMap["expose"].(bool); ok && expose {
+			return true
+		}
+	}
 	return false
 }
 
@@ -254,6 +254,38 @@ func (d *DirectiveOp) Path() string { return d.path }
 // GetDirective returns the directive string.
 func (d *DirectiveOp) GetDirective() string { return d.directive }
 
+// Comment records an explanatory CUE comment emitted above the field at the path.
+// Multi-line text is rendered as one // line per line of text.
+//
+// Example:
+//
+//	NewResource("apps/v1", "Deployment").
+//	    Comment("spec.selector", "selector is immutable once the Deployment is created").
+//	    Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name())
+func (r *Resource) Comment(path string, text string) *Resource {
+	op := &CommentOp{path: path, text: text}
+	if r.currentIf != nil {
+		r.currentIf.ops = append(r.currentIf.ops, op)
+	} else {
+		r.ops = append(r.ops, op)
+	}
+	return r
+}
+
+// CommentOp records an explanatory comment on a field path.
+type CommentOp struct {
+	path string
+	text string
+}
+
+func (c *CommentOp) resourceOp() {}
+
+// Path returns the field path.
+func (c *CommentOp) Path() string { return c.path }
+
+// Text returns the comment text.
+func (c *CommentOp) Text() string { return c.text }
+
 // ConditionalStructOp represents a conditional struct block emitted in the output.
 // When the condition is true, the struct builder function is called to generate
 // CUE fields at the given path.
@@ -344,4 +344,30 @@ var _ = Describe("Resource", func() {
 			Expect(isDirOp).To(BeTrue())
 		})
 	})
+
+	Context("Comment", func() {
+		It("should record a Comment operation", func() {
+			r := defkit.NewResource("apps/v1", "Deployment").
+				Comment("spec.selector", "selector is immutable")
+			Expect(r.Ops()).To(HaveLen(1))
+			commentOp, ok := r.Ops()[0].(*defkit.CommentOp)
+			Expect(ok).To(BeTrue())
+			Expect(commentOp.Path()).To(Equal("spec.selector"))
+			Expect(commentOp.Text()).To(Equal("selector is immutable"))
+		})
+
+		It("should record Comment within If block", func() {
+			replicas := defkit.Int("replicas")
+			r := defkit.NewResource("apps/v1", "Deployment").
+				If(replicas.IsSet()).
+				Comment("spec.replicas", "unset to let the autoscaler manage replicas").
+				Set("spec.replicas", replicas).
+				EndIf()
+			Expect(r.Ops()).To(HaveLen(1))
+			ifBlock, ok := r.Ops()[0].(*defkit.IfBlock)
+			Expect(ok).To(BeTrue())
+			_, isCommentOp := ifBlock.Ops()[0].(*defkit.CommentOp)
+			Expect(isCommentOp).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParamNode is a read-only view of a parameter in the schema of a definition.
+// It lets tools such as the APIServer, the CLI or the docs generator consume
+// the parameters of a definition without parsing the generated CUE.
+//
+// The tree is built on each call to Schema, changing it does not change the
+// definition.
+type ParamNode struct {
+	// Name is the parameter or field name, "parameter" for the root node
+	Name string
+	// Type is the parameter type
+	Type ParamType
+	// Required is true if the field emits the "!" CUE marker
+	Required bool
+	// Optional is true if the field emits the "?" CUE marker
+	Optional bool
+	// Default is the default value, or nil if none
+	Default any
+	// Description is the parameter description
+	Description string
+	// Enum holds the allowed values of an enum or a string restricted to values
+	Enum []string
+	// Format is the CUE constraint of a string, such as "time.Duration" or "net.FQDN"
+	Format string
+	// Min and Max are the bounds of an int or float parameter
+	Min, Max *float64
+	// SchemaRef is the helper definition the parameter refers to, such as "#HealthProbe"
+	SchemaRef string
+	// Open is true if the parameter accepts fields, elements or values not described by the node
+	Open bool
+	// Condition is the CUE guard under which the parameter exists, empty if it always exists
+	Condition string
+	// Children are the fields of a struct or map parameter
+	Children []*ParamNode
+	// Element describes the elements of an array or the values of a map
+	Element *ParamNode
+	// Variants are the alternatives of a oneof or closed union parameter
+	Variants []*ParamNode
+}
+
+// Child returns the child with the given name, or nil if not found.
+func (n *ParamNode) Child(name string) *ParamNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Lookup returns the node at the dotted path below this node, descending into
+// array elements and map values, or nil if not found.
+// Example: schema.Lookup("ports.port")
+func (n *ParamNode) Lookup(path string) *ParamNode {
+	node := n
+	for _, name := range strings.Split(path, ".") {
+		for node.Child(name) == nil && node.Element != nil {
+			node = node.Element
+		}
+		if node = node.Child(name); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Walk calls fn for this node and each node below it, depth first, with the
+// dotted path of the node relative to this node.
+func (n *ParamNode) Walk(fn func(path string, node *ParamNode)) {
+	n.walk("", fn)
+}
+
+func (n *ParamNode) walk(path string, fn func(path string, node *ParamNode)) {
+	fn(path, n)
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+	for _, c := range n.Children {
+		c.walk(join(c.Name), fn)
+	}
+	if n.Element != nil {
+		n.Element.walk(join("[]"), fn)
+	}
+	for i, v := range n.Variants {
+		name := v.Name
+		if name == "" {
+			name = fmt.Sprintf("%d", i)
+		}
+		v.walk(join("<"+name+">"), fn)
+	}
+}
+
+// Schema returns the parameter tree of the definition, including the
+// parameters of conditional parameter blocks with their guard.
+func (b *baseDefinition) Schema() *ParamNode {
+	s := &schemaBuilder{gen: NewCUEGenerator()}
+	root := &ParamNode{Name: "parameter", Type: ParamTypeStruct}
+	for _, p := range b.params {
+		switch p.(type) {
+		case *DynamicMapParam, *OpenStructParam:
+			// These describe the whole parameter rather than one of its fields
+			node := s.param(p)
+			node.Name = root.Name
+			root = node
+			continue
+		}
+		root.Children = append(root.Children, s.param(p))
+	}
+	for _, block := range b.conditionalParamBlocks {
+		root.Children = append(root.Children, s.branches(block.Branches())...)
+	}
+	return root
+}
+
+// schemaBuilder converts parameters into ParamNodes.
+type schemaBuilder struct {
+	gen *CUEGenerator
+}
+
+// branches returns the parameters of the branches, guarded by their condition.
+func (s *schemaBuilder) branches(branches []*ConditionalBranch) []*ParamNode {
+	var nodes []*ParamNode
+	for _, branch := range branches {
+		cond := s.gen.conditionToCUE(branch.Condition())
+		for _, p := range branch.GetParams() {
+			node := s.param(p)
+			node.Condition = cond
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func (s *schemaBuilder) param(param Param) *ParamNode {
+	if e, ok := param.(typedEnum); ok {
+		param = e.enum()
+	}
+	node := &ParamNode{
+		Name:        param.Name(),
+		Required:    param.IsRequired(),
+		Optional:    param.IsOptional(),
+		Default:     param.GetDefault(),
+		Description: param.GetDescription(),
+	}
+	switch p := param.(type) {
+	case *StringParam:
+		node.Type = ParamTypeString
+		node.Enum = p.GetEnumValues()
+		node.Open = p.IsOpenEnum()
+		node.Format = p.GetFormat()
+	case *IntParam:
+		node.Type = ParamTypeInt
+		node.Min, node.Max = intBound(p.GetMin()), intBound(p.GetMax())
+	case *FloatParam:
+		node.Type = ParamTypeFloat
+		node.Min, node.Max = p.GetMin(), p.GetMax()
+	case *BoolParam:
+		node.Type = ParamTypeBool
+	case *EnumParam:
+		node.Type = ParamTypeEnum
+		node.Enum = p.GetValues()
+	case *DurationParam:
+		node.Type = ParamTypeString
+		node.Format = "time.Duration"
+	case *TimestampParam:
+		node.Type = ParamTypeString
+		node.Format = "time.Time"
+	case *ArrayParam:
+		node.Type = ParamTypeArray
+		node.SchemaRef = p.GetSchemaRef()
+		node.Element = s.element(p.ElementType(), p.GetFields())
+	case *OpenArrayParam:
+		node.Type = ParamTypeArray
+		node.Open = true
+	case *MapParam:
+		node.Type = ParamTypeMap
+		node.SchemaRef = p.GetSchemaRef()
+		if p.ValueType() != "" {
+			node.Element = &ParamNode{Type: p.ValueType()}
+		}
+		for _, f := range p.GetFields() {
+			node.Children = append(node.Children, s.param(f))
+		}
+		node.Children = append(node.Children, s.branches(p.GetConditionalFields())...)
+		node.Open = len(node.Children) == 0 && node.Element == nil && p.GetSchema() == "" && p.GetSchemaRef() == "" && !p.IsClosed()
+	case *StringKeyMapParam:
+		node.Type = ParamTypeMap
+		node.Element = &ParamNode{Type: ParamTypeString}
+	case *DynamicMapParam:
+		node.Type = ParamTypeMap
+		node.Element = &ParamNode{Type: p.GetValueType()}
+	case *OpenStructParam:
+		node.Type = ParamTypeStruct
+		node.Open = true
+	case *StructParam:
+		node.Type = ParamTypeStruct
+		node.SchemaRef = p.GetSchemaRef()
+		node.Children = s.fields(p.GetFields())
+	case *OneOfParam:
+		node.Type = ParamTypeOneOf
+		for _, v := range p.GetVariants() {
+			node.Variants = append(node.Variants, &ParamNode{
+				Name:      v.Name(),
+				Type:      ParamTypeStruct,
+				Condition: fmt.Sprintf("%s == %q", p.GetDiscriminator(), v.Name()),
+				Children:  s.fields(v.GetFields()),
+			})
+		}
+	case *ClosedUnionParam:
+		node.Type = ParamTypeClosedUnion
+		for _, o := range p.GetOptions() {
+			node.Variants = append(node.Variants, &ParamNode{Type: ParamTypeStruct, Children: s.fields(o.GetFields())})
+		}
+	}
+	return node
+}
+
+// element returns the node of the elements of an array.
+func (s *schemaBuilder) element(elemType ParamType, fields []Param) *ParamNode {
+	if len(fields) == 0 {
+		if elemType == "" {
+			return nil
+		}
+		return &ParamNode{Type: elemType}
+	}
+	elem := &ParamNode{Type: ParamTypeStruct}
+	for _, f := range fields {
+		elem.Children = append(elem.Children, s.param(f))
+	}
+	return elem
+}
+
+func (s *schemaBuilder) fields(fields []*StructField) []*ParamNode {
+	nodes := make([]*ParamNode, 0, len(fields))
+	for _, f := range fields {
+		node := &ParamNode{
+			Name:        f.Name(),
+			Type:        f.FieldType(),
+			Required:    f.IsRequired(),
+			Optional:    f.IsOptional(),
+			Default:     f.GetDefault(),
+			Description: f.GetDescription(),
+			Enum:        f.GetEnumValues(),
+			SchemaRef:   f.GetSchemaRef(),
+		}
+		var nested []*ParamNode
+		if n := f.GetNested(); n != nil {
+			nested = s.fields(n.GetFields())
+		}
+		if node.Type == ParamTypeArray {
+			node.Element = &ParamNode{Type: f.GetElementType(), Children: nested}
+		} else {
+			node.Children = nested
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func intBound(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Schema", func() {
+	existing := defkit.Bool("existing").Default(false)
+
+	comp := defkit.NewComponent("webservice").
+		Params(
+			defkit.String("image").Required().Description("Container image"),
+			defkit.Int("replicas").Default(1).Min(0).Max(10),
+			defkit.Enum("exposeType").Values("ClusterIP", "NodePort").Default("ClusterIP"),
+			defkit.Duration("timeout").Default("30s"),
+			defkit.Array("ports").Optional().WithFields(
+				defkit.Int("port").Required(),
+				defkit.String("protocol").Values("TCP", "UDP").Default("TCP"),
+			),
+			defkit.StringKeyMap("labels").Optional(),
+			defkit.Struct("resources").WithFields(
+				defkit.Field("cpu", defkit.ParamTypeString).Default("100m"),
+			),
+			existing,
+		).
+		ConditionalParams(defkit.ConditionalParams(
+			defkit.WhenParam(existing.Eq(false)).Params(defkit.Bool("forceDestroy").Default(false)),
+		))
+
+	It("should describe the top-level parameters in declaration order", func() {
+		schema := comp.Schema()
+		Expect(schema.Name).To(Equal("parameter"))
+		Expect(schema.Type).To(Equal(defkit.ParamTypeStruct))
+
+		var names []string
+		for _, c := range schema.Children {
+			names = append(names, c.Name)
+		}
+		Expect(names).To(Equal([]string{"image", "replicas", "exposeType", "timeout", "ports", "labels", "resources", "existing", "forceDestroy"}))
+	})
+
+	It("should expose types, defaults and constraints", func() {
+		schema := comp.Schema()
+
+		image := schema.Child("image")
+		Expect(image.Type).To(Equal(defkit.ParamTypeString))
+		Expect(image.Required).To(BeTrue())
+		Expect(image.Description).To(Equal("Container image"))
+
+		replicas := schema.Child("replicas")
+		Expect(replicas.Default).To(Equal(1))
+		Expect(*replicas.Min).To(Equal(0.0))
+		Expect(*replicas.Max).To(Equal(10.0))
+
+		exposeType := schema.Child("exposeType")
+		Expect(exposeType.Type).To(Equal(defkit.ParamTypeEnum))
+		Expect(exposeType.Enum).To(Equal([]string{"ClusterIP", "NodePort"}))
+		Expect(exposeType.Default).To(Equal("ClusterIP"))
+
+		Expect(schema.Child("timeout").Format).To(Equal("time.Duration"))
+		Expect(schema.Child("labels").Element.Type).To(Equal(defkit.ParamTypeString))
+	})
+
+	It("should describe array elements and struct fields", func() {
+		schema := comp.Schema()
+
+		ports := schema.Child("ports")
+		Expect(ports.Type).To(Equal(defkit.ParamTypeArray))
+		Expect(ports.Optional).To(BeTrue())
+		Expect(ports.Element.Child("port").Required).To(BeTrue())
+		Expect(schema.Lookup("ports.protocol").Enum).To(Equal([]string{"TCP", "UDP"}))
+		Expect(schema.Lookup("resources.cpu").Default).To(Equal("100m"))
+		Expect(schema.Lookup("resources.memory")).To(BeNil())
+	})
+
+	It("should record the guard of conditional parameters", func() {
+		schema := comp.Schema()
+		Expect(schema.Child("image").Condition).To(BeEmpty())
+		Expect(schema.Child("forceDestroy").Condition).To(Equal("parameter.existing == false"))
+	})
+
+	It("should describe the variants of a oneof parameter", func() {
+		schema := defkit.NewComponent("test").Params(
+			defkit.OneOf("volume").Variants(
+				defkit.Variant("pvc").WithFields(defkit.Field("claimName", defkit.ParamTypeString).Required()),
+				defkit.Variant("emptyDir"),
+			),
+		).Schema()
+
+		volume := schema.Child("volume")
+		Expect(volume.Type).To(Equal(defkit.ParamTypeOneOf))
+		Expect(volume.Variants).To(HaveLen(2))
+		Expect(volume.Variants[0].Condition).To(Equal(`type == "pvc"`))
+		Expect(volume.Variants[0].Child("claimName").Required).To(BeTrue())
+	})
+
+	It("should visit every node when walking the tree", func() {
+		var paths []string
+		comp.Schema().Walk(func(path string, _ *defkit.ParamNode) {
+			paths = append(paths, path)
+		})
+		Expect(paths).To(ContainElements("", "ports", "ports.[]", "ports.[].port", "resources.cpu"))
+	})
+
+	It("should not share state with the definition", func() {
+		comp.Schema().Child("image").Description = "changed"
+		Expect(comp.Schema().Child("image").Description).To(Equal("Container image"))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+// StatefulSetParams holds the parameters of the StatefulSet specifics: the
+// governing Service, the PersistentVolumeClaims created for each replica and
+// the update strategy.
+//
+// Example:
+//
+//	sts := defkit.NewStatefulSetParams()
+//	defkit.NewComponent("database").
+//		Workload("apps/v1", "StatefulSet").
+//		Params(append(sts.Params(), image)...).
+//		Template(func(tpl *defkit.Template) {
+//			tpl.Output(sts.Apply(defkit.NewResource("apps/v1", "StatefulSet")).
+//				Set("spec.template.spec.containers[0].image", image).
+//				SetIf(sts.VolumeClaimTemplates.IsSet(), "spec.template.spec.containers[0].volumeMounts", sts.VolumeMounts()))
+//			tpl.Outputs("headless", sts.HeadlessService())
+//		})
+type StatefulSetParams struct {
+	// ServiceName is the name of the Service governing the StatefulSet,
+	// defaulting to the name of the component.
+	ServiceName *StringParam
+	// VolumeClaimTemplates lists the PersistentVolumeClaims created for each
+	// replica, along with the path they are mounted at.
+	VolumeClaimTemplates *ArrayParam
+	// UpdateStrategy is the type of the update strategy.
+	UpdateStrategy *EnumParam
+	// Partition is the ordinal from which the pods are updated by a rolling
+	// update, the pods with a lower ordinal keep their revision.
+	Partition *IntParam
+}
+
+// NewStatefulSetParams creates the parameters of a StatefulSet.
+func NewStatefulSetParams() *StatefulSetParams {
+	return &StatefulSetParams{
+		ServiceName: String("serviceName").Optional().
+			Description("The name of the Service governing the StatefulSet, defaults to the name of the component"),
+		VolumeClaimTemplates: List("volumeClaimTemplates").Optional().
+			Description("The PersistentVolumeClaims created for each replica").
+			WithFields(
+				String("name").Required().Description("The name of the claim, unique in the StatefulSet"),
+				String("mountPath").Required().Description("The path the volume is mounted at in the container"),
+				String("size").Required().Description("The storage requested for each replica, like `8Gi`"),
+				String("storageClassName").Optional().Description("The storage class of the claim, defaults to the default storage class"),
+				StringList("accessModes").Default([]any{"ReadWriteOnce"}).Description("The access modes of the claim"),
+			),
+		UpdateStrategy: Enum("updateStrategy").Values("RollingUpdate", "OnDelete").Default("RollingUpdate").
+			Description("The strategy used to replace the pods when the template changes"),
+		Partition: Int("partition").Optional().Min(0).
+			Description("The ordinal from which the pods are updated by a rolling update, used to stage the update"),
+	}
+}
+
+// Params returns the parameters to declare in the definition.
+func (p *StatefulSetParams) Params() []Param {
+	return []Param{p.ServiceName, p.VolumeClaimTemplates, p.UpdateStrategy, p.Partition}
+}
+
+// serviceName sets the name of the governing Service at the given path of the
+// resource.
+func (p *StatefulSetParams) serviceName(r *Resource, path string) *Resource {
+	return r.
+		SetIf(p.ServiceName.IsSet(), path, p.ServiceName).
+		SetIf(p.ServiceName.NotSet(), path, VelaCtx().Name())
+}
+
+// Apply sets the serviceName, the volumeClaimTemplates and the updateStrategy
+// of the StatefulSet spec of the resource from the parameters. The claims are
+// to be mounted in the containers with VolumeMounts.
+func (p *StatefulSetParams) Apply(r *Resource) *Resource {
+	claims := Each(p.VolumeClaimTemplates).Map(FieldMap{
+		"metadata": Nested(FieldMap{"name": F("name")}),
+		"spec": Nested(FieldMap{
+			"accessModes":      F("accessModes"),
+			"storageClassName": Optional("storageClassName"),
+			"resources":        Nested(FieldMap{"requests": Nested(FieldMap{"storage": F("size")})}),
+		}),
+	})
+	return p.serviceName(r, "spec.serviceName").
+		SetIf(p.VolumeClaimTemplates.IsSet(), "spec.volumeClaimTemplates", claims).
+		Set("spec.updateStrategy.type", p.UpdateStrategy).
+		SetIf(And(p.Partition.IsSet(), p.UpdateStrategy.Eq("RollingUpdate")),
+			"spec.updateStrategy.rollingUpdate.partition", p.Partition)
+}
+
+// VolumeMounts returns the mounts of the claims of the volumeClaimTemplates
+// parameter, to be set in a container of the pod template when the parameter
+// is set.
+func (p *StatefulSetParams) VolumeMounts() Value {
+	return Each(p.VolumeClaimTemplates).Map(FieldMap{
+		"name":      F("name"),
+		"mountPath": F("mountPath"),
+	})
+}
+
+// HeadlessService returns the headless Service governing the StatefulSet,
+// selecting the pods labeled with the name of the component under
+// app.oam.dev/component.
+func (p *StatefulSetParams) HeadlessService() *Resource {
+	svc := NewResource("v1", "Service")
+	return p.serviceName(svc, "metadata.name").
+		Set("spec.clusterIP", Lit("None")).
+		Set("spec.selector[app.oam.dev/component]", VelaCtx().Name())
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("StatefulSet Params", func() {
+	newComponent := func(sts *defkit.StatefulSetParams) *defkit.ComponentDefinition {
+		image := defkit.String("image")
+		return defkit.NewComponent("database").
+			Workload("apps/v1", "StatefulSet").
+			Params(append(sts.Params(), image)...).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(sts.Apply(defkit.NewResource("apps/v1", "StatefulSet")).
+					Set("spec.template.spec.containers[0].image", image).
+					SetIf(sts.VolumeClaimTemplates.IsSet(), "spec.template.spec.containers[0].volumeMounts", sts.VolumeMounts()))
+				tpl.Outputs("headless", sts.HeadlessService())
+			})
+	}
+
+	It("should generate the parameters of a StatefulSet", func() {
+		sts := defkit.NewStatefulSetParams()
+		Expect(sts.Params()).To(HaveLen(4))
+
+		cue := newComponent(sts).ToCue()
+		Expect(cue).To(ContainSubstring("serviceName?: string"))
+		Expect(cue).To(ContainSubstring("volumeClaimTemplates?: [...{"))
+		Expect(cue).To(ContainSubstring(`updateStrategy: *"RollingUpdate" | "OnDelete"`))
+		Expect(cue).To(ContainSubstring("partition?: int"))
+	})
+
+	It("should wire the service name, the claims and the update strategy", func() {
+		cue := newComponent(defkit.NewStatefulSetParams()).ToCue()
+		Expect(cue).To(ContainSubstring("serviceName: parameter.serviceName"))
+		Expect(cue).To(ContainSubstring("serviceName: context.name"))
+		Expect(cue).To(ContainSubstring("for v in parameter.volumeClaimTemplates"))
+		Expect(cue).To(ContainSubstring("storage: v.size"))
+		Expect(cue).To(ContainSubstring("mountPath: v.mountPath"))
+		Expect(cue).To(ContainSubstring("type: parameter.updateStrategy"))
+		Expect(cue).To(ContainSubstring(`parameter.updateStrategy == "RollingUpdate"`))
+		Expect(cue).To(ContainSubstring("partition: parameter.partition"))
+		Expect(cue).To(ContainSubstring(`clusterIP: "None"`))
+	})
+
+	It("should render the service name from the component name by default", func() {
+		comp := newComponent(defkit.NewStatefulSetParams())
+
+		outputs := comp.RenderAll(defkit.TestContext().WithName("db").WithParam("image", "postgres"))
+		Expect(outputs.Primary.Get("spec.serviceName")).To(Equal("db"))
+		Expect(outputs.Auxiliary["headless"].Get("metadata.name")).To(Equal("db"))
+
+		outputs = comp.RenderAll(defkit.TestContext().WithName("db").
+			WithParam("image", "postgres").
+			WithParam("serviceName", "db-headless"))
+		Expect(outputs.Primary.Get("spec.serviceName")).To(Equal("db-headless"))
+	})
+})
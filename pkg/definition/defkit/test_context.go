@@ -23,6 +23,7 @@ type TestContextBuilder struct {
 	namespace     string
 	appName       string
 	appRevision   string
+	cluster       string
 	params        map[string]any
 	clusterMajor  int
 	clusterMinor  int
@@ -69,6 +70,12 @@ func (t *TestContextBuilder) WithAppRevision(revision string) *TestContextBuilde
 	return t
 }
 
+// WithCluster sets the cluster the component is dispatched to (context.cluster).
+func (t *TestContextBuilder) WithCluster(cluster string) *TestContextBuilder {
+	t.cluster = cluster
+	return t
+}
+
 // WithParam sets a parameter value for the test context.
 func (t *TestContextBuilder) WithParam(name string, value any) *TestContextBuilder {
 	t.params[name] = value
@@ -116,6 +123,7 @@ func (t *TestContextBuilder) Build() *TestRuntimeContext {
 		namespace:     t.namespace,
 		appName:       t.appName,
 		appRevision:   t.appRevision,
+		cluster:       t.cluster,
 		params:        t.params,
 		clusterMajor:  t.clusterMajor,
 		clusterMinor:  t.clusterMinor,
@@ -146,6 +154,7 @@ type TestRuntimeContext struct {
 	namespace     string
 	appName       string
 	appRevision   string
+	cluster       string
 	params        map[string]any
 	clusterMajor  int
 	clusterMinor  int
@@ -166,6 +175,9 @@ func (c *TestRuntimeContext) AppName() string { return c.appName }
 // AppRevision returns the application revision.
 func (c *TestRuntimeContext) AppRevision() string { return c.appRevision }
 
+// Cluster returns the cluster the component is dispatched to.
+func (c *TestRuntimeContext) Cluster() string { return c.cluster }
+
 // GetParam returns a parameter value by name.
 func (c *TestRuntimeContext) GetParam(name string) (any, bool) {
 	v, ok := c.params[name]
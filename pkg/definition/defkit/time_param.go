@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// DurationParam represents a duration parameter such as "30s" or "1h30m".
+// The value is validated with the CUE time.Duration constraint.
+type DurationParam struct {
+	baseParam
+}
+
+// Duration creates a new duration parameter with the given name.
+// This generates CUE like: timeout: *"30s" | time.Duration
+func Duration(name string) *DurationParam {
+	return &DurationParam{
+		baseParam: baseParam{
+			name:      name,
+			paramType: ParamTypeString,
+		},
+	}
+}
+
+// Required marks the parameter as required in input, emitting the "!" CUE marker.
+func (p *DurationParam) Required() *DurationParam {
+	p.required = true
+	p.optional = false
+	return p
+}
+
+// Optional marks the parameter as optional, emitting the "?" CUE marker.
+func (p *DurationParam) Optional() *DurationParam {
+	p.optional = true
+	p.required = false
+	return p
+}
+
+// Default sets a default value for the parameter, e.g. "30s".
+func (p *DurationParam) Default(value string) *DurationParam {
+	p.defaultValue = value
+	return p
+}
+
+// Description sets the parameter description.
+func (p *DurationParam) Description(desc string) *DurationParam {
+	p.description = desc
+	return p
+}
+
+// Seconds returns the duration as a whole number of seconds, for fields such
+// as terminationGracePeriodSeconds.
+// Example: timeout.Seconds() generates: div(time.ParseDuration(parameter.timeout), 1000000000)
+func (p *DurationParam) Seconds() Value {
+	return &DurationSecondsValue{source: p}
+}
+
+// RequiredImports returns the CUE imports needed by the time.Duration constraint.
+func (p *DurationParam) RequiredImports() []string {
+	return []string{"time"}
+}
+
+// DurationSecondsValue converts a duration value to a whole number of seconds.
+type DurationSecondsValue struct {
+	source Value
+}
+
+func (d *DurationSecondsValue) value() {}
+func (d *DurationSecondsValue) expr()  {}
+
+// Source returns the duration value being converted.
+func (d *DurationSecondsValue) Source() Value { return d.source }
+
+// RenderCUE renders the conversion with time.ParseDuration, which returns nanoseconds.
+func (d *DurationSecondsValue) RenderCUE(renderValue func(Value) string) string {
+	return fmt.Sprintf("div(time.ParseDuration(%s), %d)", renderValue(d.source), int64(time.Second))
+}
+
+// RequiredImports returns the CUE imports needed by time.ParseDuration.
+func (d *DurationSecondsValue) RequiredImports() []string {
+	return []string{"time"}
+}
+
+// TimestampParam represents a point in time formatted as RFC 3339, such as
+// "2025-01-02T15:04:05Z". The value is validated with the CUE time.Time constraint.
+type TimestampParam struct {
+	baseParam
+}
+
+// Timestamp creates a new timestamp parameter with the given name.
+// This generates CUE like: notBefore?: time.Time
+func Timestamp(name string) *TimestampParam {
+	return &TimestampParam{
+		baseParam: baseParam{
+			name:      name,
+			paramType: ParamTypeString,
+		},
+	}
+}
+
+// Required marks the parameter as required in input, emitting the "!" CUE marker.
+func (p *TimestampParam) Required() *TimestampParam {
+	p.required = true
+	p.optional = false
+	return p
+}
+
+// Optional marks the parameter as optional, emitting the "?" CUE marker.
+func (p *TimestampParam) Optional() *TimestampParam {
+	p.optional = true
+	p.required = false
+	return p
+}
+
+// Default sets a default value for the parameter in RFC 3339 format.
+func (p *TimestampParam) Default(value string) *TimestampParam {
+	p.defaultValue = value
+	return p
+}
+
+// Description sets the parameter description.
+func (p *TimestampParam) Description(desc string) *TimestampParam {
+	p.description = desc
+	return p
+}
+
+// RequiredImports returns the CUE imports needed by the time.Time constraint.
+func (p *TimestampParam) RequiredImports() []string {
+	return []string{"time"}
+}
+
+// durationSeconds converts a resolved duration string to seconds for rendering.
+func durationSeconds(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return v
+	}
+	return int(d / time.Second)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defkit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("Time Params", func() {
+	newComponent := func() *defkit.ComponentDefinition {
+		gracePeriod := defkit.Duration("gracePeriod").Default("30s").Description("Termination grace period")
+		notBefore := defkit.Timestamp("notBefore").Optional()
+		return defkit.NewComponent("worker").
+			Workload("apps/v1", "Deployment").
+			Params(gracePeriod, notBefore).
+			Template(func(tpl *defkit.Template) {
+				tpl.Output(
+					defkit.NewResource("apps/v1", "Deployment").
+						Set("spec.template.spec.terminationGracePeriodSeconds", gracePeriod.Seconds()).
+						SetIf(notBefore.IsSet(), "metadata.annotations.notBefore", notBefore),
+				)
+			})
+	}
+
+	It("should generate time constraints and import the time package", func() {
+		cue := newComponent().ToCue()
+		Expect(cue).To(ContainSubstring(`"time"`))
+		Expect(cue).To(ContainSubstring(`gracePeriod: *"30s" | time.Duration`))
+		Expect(cue).To(ContainSubstring(`notBefore?: time.Time`))
+		Expect(cue).To(ContainSubstring(`terminationGracePeriodSeconds: div(time.ParseDuration(parameter.gracePeriod), 1000000000)`))
+	})
+
+	It("should convert the duration to seconds when rendering", func() {
+		rendered := newComponent().Render(defkit.TestContext())
+		Expect(rendered.Get("spec.template.spec.terminationGracePeriodSeconds")).To(Equal(30))
+
+		rendered = newComponent().Render(defkit.TestContext().
+			WithParam("gracePeriod", "2m").
+			WithParam("notBefore", "2025-01-02T15:04:05Z"))
+		Expect(rendered.Get("spec.template.spec.terminationGracePeriodSeconds")).To(Equal(120))
+		Expect(rendered.Get("metadata.annotations.notBefore")).To(Equal("2025-01-02T15:04:05Z"))
+	})
+})
@@ -1094,7 +1094,7 @@ func (g *TraitCUEGenerator) writePatchKeyOp(sb *strings.Builder, gen *CUEGenerat
 	indent := strings.Repeat(g.indent, depth)
 	elements := op.Elements()
 	useArrayValue := len(elements) == 1
-	if useArrayValue {
+	if useArrayValue && !op.list {
 		_, useArrayValue = elements[0].(*ArrayParam)
 	}
 
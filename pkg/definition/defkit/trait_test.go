@@ -544,6 +544,39 @@ template: {
 			Expect(cue).To(ContainSubstring("patchKey"))
 		})
 
+		It("should append the items of a list with AppendTo", func() {
+			env := defkit.List("env").WithFields(defkit.String("name"), defkit.String("value"))
+			trait := defkit.NewTrait("env").
+				AppliesTo("deployments.apps").
+				Params(env).
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().AppendTo("spec.template.spec.containers[0].env",
+						defkit.Each(env).Map(defkit.FieldMap{"name": defkit.F("name"), "value": defkit.F("value")}), "name")
+				})
+
+			cue := trait.ToCue()
+
+			Expect(cue).To(ContainSubstring("// +patchKey=name"))
+			Expect(cue).To(ContainSubstring("env: [for v in parameter.env"))
+			Expect(cue).NotTo(ContainSubstring("env: [[for"))
+		})
+
+		It("should append a single item with AppendTo", func() {
+			image := defkit.String("image")
+			trait := defkit.NewTrait("sidecar").
+				AppliesTo("deployments.apps").
+				Params(image).
+				Template(func(tpl *defkit.Template) {
+					tpl.Patch().AppendTo("spec.template.spec.containers",
+						defkit.NewArrayElement().Set("name", defkit.Lit("sidecar")).Set("image", image), "name")
+				})
+
+			cue := trait.ToCue()
+
+			Expect(cue).To(ContainSubstring("// +patchKey=name"))
+			Expect(cue).To(ContainSubstring("containers: [{"))
+		})
+
 		It("should generate patch with Passthrough", func() {
 			trait := defkit.NewTrait("json-patch").
 				Description("Apply JSON patch").
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature verifies the integrity of definitions. The kind, the name, the security relevant
+// annotations and the whole spec of a definition, i.e. its template, its workload, its health policy and
+// custom status and the workloads it applies to, are signed by the signature annotation or checked by the
+// checksum annotation. Both are computed on the canonical payload returned by Payload, see Payload for the
+// exact bytes. The signature is compatible with `cosign sign-blob` on that payload.
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const checksumPrefix = "sha256:"
+
+// Verifier verifies the signatures and the checksums of definitions
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// LoadVerifier loads the trusted public keys from the PEM file. Definitions must be signed by one of
+// the keys. An empty path returns a verifier which only verifies the checksums of definitions.
+func LoadVerifier(path string) (*Verifier, error) {
+	if path == "" {
+		return &Verifier{}, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the definition signature public keys: %w", err)
+	}
+	return NewVerifier(data)
+}
+
+// NewVerifier returns a verifier trusting the public keys of the PEM data
+func NewVerifier(data []byte) (*Verifier, error) {
+	v := &Verifier{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition signature public key: %w", err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+			v.keys = append(v.keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported definition signature public key %T", key)
+		}
+	}
+	if len(v.keys) == 0 {
+		return nil, errors.New("no public key found for the definition signatures")
+	}
+	return v, nil
+}
+
+// RequireSignature returns true if the definitions must be signed
+func (v *Verifier) RequireSignature() bool {
+	return v != nil && len(v.keys) > 0
+}
+
+// Verify checks the checksum of the payload of the definition if it has one, and its signature if the
+// verifier has public keys. Unsigned definitions are rejected when the verifier has public keys.
+func (v *Verifier) Verify(def client.Object) error {
+	checksum, hasChecksum := def.GetAnnotations()[oam.AnnotationDefinitionChecksum]
+	if !hasChecksum && !v.RequireSignature() {
+		return nil
+	}
+	payload, err := Payload(def)
+	if err != nil {
+		return err
+	}
+	if hasChecksum && checksum != Checksum(payload) {
+		return fmt.Errorf("%s does not match its checksum %s", def.GetName(), checksum)
+	}
+	if !v.RequireSignature() {
+		return nil
+	}
+	encoded, found := def.GetAnnotations()[oam.AnnotationDefinitionSignature]
+	if !found {
+		return fmt.Errorf("%s is not signed: the %s annotation is required", def.GetName(), oam.AnnotationDefinitionSignature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", def.GetName(), err)
+	}
+	digest := sha256.Sum256(payload)
+	for _, key := range v.keys {
+		if verifySignature(key, payload, digest[:], sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the signature of %s is not signed by a trusted key", def.GetName())
+}
+
+func verifySignature(key crypto.PublicKey, payload, digest, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest, sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil
+	default:
+		return false
+	}
+}
+
+// Checksum returns the checksum of the payload in the format of the checksum annotation
+func Checksum(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return checksumPrefix + hex.EncodeToString(sum[:])
+}
+
+// SignedAnnotations are the annotations of a definition covered by its signature and checksum, as they
+// change which applications can use the definition and which definitions are installed with it.
+var SignedAnnotations = []string{
+	oam.AnnotationDefinitionRequires,
+	oam.AnnotationDefinitionVisibleNamespaces,
+}
+
+// Payload returns the signed payload of the definition. It is the JSON object
+//
+//	{"kind":<kind>,"metadata":{"annotations":{<signed annotations>},"name":<name>},"spec":<spec>}
+//
+// where the signed annotations are the SignedAnnotations the definition has, the object being empty if it
+// has none. The JSON is compact, without a trailing newline, with the keys of all the objects sorted and
+// without escaping of <, > and &, so that every signed field is covered independently of the formatting of
+// the manifest the definition was applied from. The spec is encoded with the KubeVela API types, so that the
+// fields without omitempty are present even if the manifest omits them, e.g. the definitionRef of the
+// TraitDefinitions. External signers get the same bytes from a manifest encoded that way with
+//
+//	jq -cjS '{kind, metadata: {annotations: ((.metadata.annotations // {}) | with_entries(select(.key |
+//	  IN("definition.oam.dev/requires", "definition.oam.dev/visible-namespaces")))), name: .metadata.name}, spec}'
+func Payload(def client.Object) ([]byte, error) {
+	var kind string
+	var spec interface{}
+	switch d := def.(type) {
+	case *v1beta1.ComponentDefinition:
+		kind, spec = v1beta1.ComponentDefinitionKind, d.Spec
+	case *v1beta1.TraitDefinition:
+		kind, spec = v1beta1.TraitDefinitionKind, d.Spec
+	case *v1beta1.PolicyDefinition:
+		kind, spec = v1beta1.PolicyDefinitionKind, d.Spec
+	case *v1beta1.WorkflowStepDefinition:
+		kind, spec = v1beta1.WorkflowStepDefinitionKind, d.Spec
+	default:
+		return nil, fmt.Errorf("unsupported definition %T", def)
+	}
+	annotations := map[string]string{}
+	for _, key := range SignedAnnotations {
+		if value, found := def.GetAnnotations()[key]; found {
+			annotations[key] = value
+		}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"kind":     kind,
+		"metadata": map[string]interface{}{"name": def.GetName(), "annotations": annotations},
+		"spec":     spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %s: %w", def.GetName(), err)
+	}
+	// Decoding into generic values sorts the keys of the raw extensions too, e.g. of the workload
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("cannot encode %s: %w", def.GetName(), err)
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, fmt.Errorf("cannot encode %s: %w", def.GetName(), err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
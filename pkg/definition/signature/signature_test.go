@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const template = "parameter: replicas: *1 | int\n"
+
+func newTrait(annotations map[string]string) *v1beta1.TraitDefinition {
+	return &v1beta1.TraitDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "scaler", Annotations: annotations},
+		Spec: v1beta1.TraitDefinitionSpec{
+			Schematic: &common.Schematic{CUE: &common.CUE{Template: template}},
+		},
+	}
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keysFile := filepath.Join(t.TempDir(), "keys.pem")
+	require.NoError(t, os.WriteFile(keysFile, append(publicKeyPEM(t, &ecKey.PublicKey), publicKeyPEM(t, edPub)...), 0600))
+	verifier, err := LoadVerifier(keysFile)
+	require.NoError(t, err)
+	require.True(t, verifier.RequireSignature())
+
+	payload, err := Payload(newTrait(nil))
+	require.NoError(t, err)
+	digest := sha256.Sum256(payload)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	untrustedSig, err := ecdsa.SignASN1(rand.Reader, untrustedKey, digest[:])
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edKey, payload)
+
+	require.NoError(t, verifier.Verify(newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig)})))
+	require.NoError(t, verifier.Verify(newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(edSig)})))
+	require.ErrorContains(t, verifier.Verify(newTrait(nil)), "scaler is not signed")
+	require.ErrorContains(t, verifier.Verify(newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(untrustedSig)})),
+		"not signed by a trusted key")
+
+	tampered := newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig)})
+	tampered.Spec.Schematic.CUE.Template += "parameter: max: int\n"
+	require.Error(t, verifier.Verify(tampered))
+
+	// the fields besides the template are signed too
+	tampered = newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig)})
+	tampered.Spec.AppliesToWorkloads = []string{"deployments.apps"}
+	require.ErrorContains(t, verifier.Verify(tampered), "not signed by a trusted key")
+
+	// so are the name and the security relevant annotations, but not the other annotations
+	tampered = newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig)})
+	tampered.Name = "autoscaler"
+	require.ErrorContains(t, verifier.Verify(tampered), "not signed by a trusted key")
+	for _, key := range SignedAnnotations {
+		tampered = newTrait(map[string]string{oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig), key: "team=a"})
+		require.ErrorContains(t, verifier.Verify(tampered), "not signed by a trusted key", key)
+	}
+	require.NoError(t, verifier.Verify(newTrait(map[string]string{
+		oam.AnnotationDefinitionSignature: base64.StdEncoding.EncodeToString(ecSig),
+		oam.AnnotationDefinitionPaused:    "true",
+	})))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	verifier, err := LoadVerifier("")
+	require.NoError(t, err)
+	require.False(t, verifier.RequireSignature())
+
+	require.NoError(t, verifier.Verify(newTrait(nil)))
+	payload, err := Payload(newTrait(nil))
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(newTrait(map[string]string{oam.AnnotationDefinitionChecksum: Checksum(payload)})))
+	require.ErrorContains(t, verifier.Verify(newTrait(map[string]string{oam.AnnotationDefinitionChecksum: Checksum([]byte("parameter: {}"))})),
+		"does not match its checksum")
+}
+
+func TestNewVerifier(t *testing.T) {
+	_, err := NewVerifier([]byte("not a key"))
+	require.Error(t, err)
+	_, err = NewVerifier(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")}))
+	require.Error(t, err)
+}
+
+func TestPayload(t *testing.T) {
+	comp := &v1beta1.ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Annotations: map[string]string{
+			oam.AnnotationDefinitionVisibleNamespaces: "team in (a,b)",
+			oam.AnnotationDefinitionChecksum:          "sha256:0",
+		}},
+		Spec: v1beta1.ComponentDefinitionSpec{
+			Workload:  common.WorkloadTypeDescriptor{Definition: common.WorkloadGVK{APIVersion: "apps/v1", Kind: "Deployment"}},
+			Schematic: &common.Schematic{CUE: &common.CUE{Template: "output: {}"}},
+			Extension: &runtime.RawExtension{Raw: []byte(`{"b": 1, "a": "<x>"}`)},
+		},
+	}
+	payload, err := Payload(comp)
+	require.NoError(t, err)
+	require.Equal(t, `{"kind":"ComponentDefinition","metadata":{"annotations":{"definition.oam.dev/visible-namespaces":"team in (a,b)"},"name":"worker"},`+
+		`"spec":{"extension":{"a":"<x>","b":1},"schematic":{"cue":{"template":"output: {}"}},`+
+		`"workload":{"definition":{"apiVersion":"apps/v1","kind":"Deployment"}}}}`, string(payload))
+
+	payload, err = Payload(newTrait(nil))
+	require.NoError(t, err)
+	require.Equal(t, `{"kind":"TraitDefinition","metadata":{"annotations":{},"name":"scaler"},`+
+		`"spec":{"definitionRef":{"name":""},"schematic":{"cue":{"template":"parameter: replicas: *1 | int\n"}}}}`, string(payload))
+
+	_, err = Payload(&v1beta1.Application{})
+	require.ErrorContains(t, err, "unsupported definition")
+}
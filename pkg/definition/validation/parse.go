@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition"
+)
+
+// DefinitionKinds are the kinds of definitions parsed from the files
+var DefinitionKinds = []string{
+	v1beta1.ComponentDefinitionKind,
+	v1beta1.TraitDefinitionKind,
+	v1beta1.PolicyDefinitionKind,
+	v1beta1.WorkflowStepDefinitionKind,
+}
+
+func isDefinitionKind(kind string) bool {
+	for _, k := range DefinitionKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseYAML parses the definitions in the YAML manifests. Manifests of other kinds are ignored.
+func ParseYAML(data []byte) ([]*unstructured.Unstructured, error) {
+	var defs []*unstructured.Unstructured
+	decoder := kyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return defs, nil
+			}
+			return nil, err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		def := &unstructured.Unstructured{Object: obj}
+		if def.GroupVersionKind().Group != v1beta1.Group || !isDefinitionKind(def.GetKind()) {
+			klog.V(4).InfoS("Skip the object which is not a definition", "kind", def.GetKind(), "name", def.GetName())
+			continue
+		}
+		if def.GetName() == "" {
+			return nil, fmt.Errorf("%s without name", def.GetKind())
+		}
+		defs = append(defs, def)
+	}
+}
+
+// ParseCUE parses the definition in the CUE file.
+func ParseCUE(data []byte) ([]*unstructured.Unstructured, error) {
+	def := definition.Definition{Unstructured: unstructured.Unstructured{}}
+	if err := def.FromCUEString(string(data), nil); err != nil {
+		return nil, err
+	}
+	if !isDefinitionKind(def.GetKind()) {
+		return nil, fmt.Errorf("unsupported definition kind %s", def.GetKind())
+	}
+	return []*unstructured.Unstructured{&def.Unstructured}, nil
+}
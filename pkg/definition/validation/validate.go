@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation parses and validates definitions without a cluster, e.g. in CI before they are
+// applied or synced from a DefinitionSource.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/controller/utils"
+)
+
+// Diagnostic is the result of the validation of a definition
+type Diagnostic struct {
+	// File is the file the definition is defined in
+	File string
+	// Kind and Name identify the definition, they are empty if the file cannot be parsed
+	Kind string
+	Name string
+	// Errors are the parse, compile and schema generation errors of the definition
+	Errors []string
+}
+
+// Valid returns true if the definition has no errors
+func (d Diagnostic) Valid() bool {
+	return len(d.Errors) == 0
+}
+
+// ValidateDefinitions validates the definitions in the YAML manifests and the CUE files, keyed by
+// their path, without a cluster. Each definition goes through the same steps as in the definition
+// controllers: its CUE template is compiled and the OpenAPI schema of its parameter is generated.
+// A diagnostic is returned for each definition, and for each file that cannot be parsed, in the
+// order of the files. Manifests of other kinds are ignored.
+func ValidateDefinitions(ctx context.Context, files map[string][]byte) []Diagnostic {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var diagnostics []Diagnostic
+	seen := map[string]string{}
+	for _, p := range paths {
+		var parsed []*unstructured.Unstructured
+		var err error
+		switch path.Ext(p) {
+		case ".yaml", ".yml":
+			parsed, err = ParseYAML(files[p])
+		case ".cue":
+			parsed, err = ParseCUE(files[p])
+		default:
+			continue
+		}
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{File: p, Errors: []string{fmt.Sprintf("parse: %s", err.Error())}})
+			continue
+		}
+		for _, def := range parsed {
+			diagnostic := Diagnostic{File: p, Kind: def.GetKind(), Name: def.GetName()}
+			key := def.GetKind() + "/" + def.GetName()
+			if previous, found := seen[key]; found {
+				diagnostic.Errors = append(diagnostic.Errors, fmt.Sprintf("parse: %s is also defined in %s", key, previous))
+			} else {
+				seen[key] = p
+			}
+			diagnostic.Errors = append(diagnostic.Errors, validateDefinition(ctx, def)...)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics
+}
+
+// validateDefinition compiles the CUE template of the definition and generates its schema
+func validateDefinition(ctx context.Context, def *unstructured.Unstructured) []string {
+	var errs []string
+	template, _, _ := unstructured.NestedString(def.Object, "spec", "schematic", "cue", "template")
+	if template != "" {
+		if err := core.CompileTemplate(ctx, template); err != nil {
+			errs = append(errs, fmt.Sprintf("compile: %s", err.Error()))
+		}
+	}
+	if err := generateDefinitionSchema(ctx, def); err != nil {
+		errs = append(errs, fmt.Sprintf("schema: %s", err.Error()))
+	}
+	return errs
+}
+
+// generateDefinitionSchema generates the OpenAPI schema of the definition as the definition
+// controllers do, without a client since there is no cluster to read from
+func generateDefinitionSchema(ctx context.Context, def *unstructured.Unstructured) error {
+	switch def.GetKind() {
+	case v1beta1.ComponentDefinitionKind:
+		componentDef := &v1beta1.ComponentDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(def.Object, componentDef); err != nil {
+			return err
+		}
+		if schematic := componentDef.Spec.Schematic; schematic != nil && schematic.Terraform != nil &&
+			schematic.Terraform.GitCredentialsSecretReference != nil {
+			// The git credentials are read from a secret in the cluster
+			klog.V(4).InfoS("Skip the schema of the Terraform component with git credentials", "name", def.GetName())
+			return nil
+		}
+		capability := utils.NewCapabilityComponentDef(componentDef)
+		_, err := capability.GenerateDefinitionSchema(ctx, nil, def.GetName())
+		return err
+	case v1beta1.TraitDefinitionKind:
+		traitDef := &v1beta1.TraitDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(def.Object, traitDef); err != nil {
+			return err
+		}
+		capability := utils.NewCapabilityTraitDef(traitDef)
+		_, err := capability.GenerateDefinitionSchema(ctx, nil, def.GetName())
+		return err
+	case v1beta1.PolicyDefinitionKind:
+		policyDef := &v1beta1.PolicyDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(def.Object, policyDef); err != nil {
+			return err
+		}
+		capability := utils.NewCapabilityPolicyDef(policyDef)
+		_, err := capability.GenerateDefinitionSchema(ctx, nil, def.GetName())
+		return err
+	case v1beta1.WorkflowStepDefinitionKind:
+		stepDef := &v1beta1.WorkflowStepDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(def.Object, stepDef); err != nil {
+			return err
+		}
+		capability := utils.NewCapabilityStepDef(stepDef)
+		_, err := capability.GenerateDefinitionSchema(ctx, nil, def.GetName())
+		return err
+	}
+	return fmt.Errorf("unsupported definition kind %s", def.GetKind())
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const scalerYAML = `apiVersion: core.oam.dev/v1beta1
+kind: TraitDefinition
+metadata:
+  name: scaler
+spec:
+  schematic:
+    cue:
+      template: |
+        parameter: replicas: *1 | int
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-definition
+`
+
+const gatewayCUE = `gateway: {
+	type: "trait"
+	annotations: {}
+	labels: {}
+	description: "Expose the component"
+	attributes: appliesToWorkloads: ["deployments.apps"]
+}
+template: {
+	parameter: domain: string
+}
+`
+
+const brokenTemplateYAML = `apiVersion: core.oam.dev/v1beta1
+kind: TraitDefinition
+metadata:
+  name: broken
+spec:
+  schematic:
+    cue:
+      template: |
+        parameter: replicas: *1 | int
+        patch: spec: replicas: parameter.replica
+`
+
+func TestValidateDefinitions(t *testing.T) {
+	files := map[string][]byte{
+		"definitions/scaler.yaml": []byte(scalerYAML),
+		"definitions/gateway.cue": []byte(gatewayCUE),
+		"definitions/broken.yaml": []byte(brokenTemplateYAML),
+		"definitions/invalid.cue": []byte("abc:]{xa}"),
+		"definitions/README.md":   []byte("# definitions"),
+		"other/scaler.yaml":       []byte(scalerYAML),
+	}
+	diagnostics := ValidateDefinitions(context.Background(), files)
+	require.Len(t, diagnostics, 5)
+
+	require.Equal(t, "definitions/broken.yaml", diagnostics[0].File)
+	require.Equal(t, "broken", diagnostics[0].Name)
+	require.False(t, diagnostics[0].Valid())
+	require.Contains(t, diagnostics[0].Errors[0], "compile: line 2")
+
+	require.Equal(t, "gateway", diagnostics[1].Name)
+	require.True(t, diagnostics[1].Valid(), diagnostics[1].Errors)
+
+	require.Equal(t, "definitions/invalid.cue", diagnostics[2].File)
+	require.Empty(t, diagnostics[2].Name)
+	require.Contains(t, diagnostics[2].Errors[0], "parse:")
+
+	require.Equal(t, "TraitDefinition", diagnostics[3].Kind)
+	require.Equal(t, "scaler", diagnostics[3].Name)
+	require.True(t, diagnostics[3].Valid(), diagnostics[3].Errors)
+
+	// the same definition is found in two files
+	require.Equal(t, "other/scaler.yaml", diagnostics[4].File)
+	require.Equal(t, []string{"parse: TraitDefinition/scaler is also defined in definitions/scaler.yaml"}, diagnostics[4].Errors)
+}
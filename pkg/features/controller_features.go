@@ -138,6 +138,40 @@ const (
 	// CUE definition schema. When enabled, any parameter field not present in the template's
 	// parameter stanza will cause a validation error at admission time.
 	ValidateUndeclaredParameters = "ValidateUndeclaredParameters"
+
+	// ValidatePropertiesSchema enables the validation of the properties of components and traits against the OpenAPI
+	// schema stored for their definition revision at admission time. Unknown fields and type mismatches are rejected
+	// by the application webhook instead of failing the workflow while rendering the Application.
+	ValidatePropertiesSchema featuregate.Feature = "ValidatePropertiesSchema"
+
+	// CompressDefinitionSchema enables the gzip compression for the OpenAPI schema stored in the schema ConfigMap
+	// of definitions. It can be useful for definitions with very large schemas, the consumers of the schema must
+	// decode it according to the format annotation of the ConfigMap.
+	CompressDefinitionSchema featuregate.Feature = "CompressDefinitionSchema"
+
+	// StoreDefinitionSchemaInStatus stores the OpenAPI schema of definitions in their status instead of in schema
+	// ConfigMaps, which halves the objects created per definition. The schema is compressed if
+	// CompressDefinitionSchema is enabled. Schemas of definition revisions are not stored in this mode.
+	StoreDefinitionSchemaInStatus featuregate.Feature = "StoreDefinitionSchemaInStatus"
+
+	// DefinitionSourceController enables the controller syncing definitions from the OCI artifacts and the Git
+	// repositories declared in DefinitionSources. The DefinitionSource CRD must be installed.
+	DefinitionSourceController featuregate.Feature = "DefinitionSourceController"
+
+	// DefinitionTemplateCache enables the cache of the templates of definitions shared by the reconciles of the
+	// applications, keyed by the definition and its revision. It saves the lookups of the definitions and their
+	// DefinitionRevisions, the CUE templates are still compiled at every render. The cached templates are dropped
+	// when the DefinitionRevisions or the definitions change.
+	DefinitionTemplateCache featuregate.Feature = "DefinitionTemplateCache"
+
+	// DefinitionVisibility enforces the visible-namespaces annotation of definitions. The application webhook
+	// rejects and the application controller stops rendering the Applications using existing definitions which are
+	// not visible to their namespace.
+	DefinitionVisibility featuregate.Feature = "DefinitionVisibility"
+
+	// EnableCompressionMetrics exports the serialized size and the compression ratio of ResourceTrackers and
+	// ApplicationRevisions per application. Each write is serialized once more to measure the raw size.
+	EnableCompressionMetrics featuregate.Feature = "EnableCompressionMetrics"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -169,8 +203,39 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	EnableGlobalPolicies:                          {Default: false, PreRelease: featuregate.Alpha},
 	EnableApplicationScopedPolicies:               {Default: false, PreRelease: featuregate.Alpha},
 	ValidateUndeclaredParameters:                  {Default: false, PreRelease: featuregate.Alpha},
+	ValidatePropertiesSchema:                      {Default: false, PreRelease: featuregate.Alpha},
+	CompressDefinitionSchema:                      {Default: false, PreRelease: featuregate.Alpha},
+	StoreDefinitionSchemaInStatus:                 {Default: false, PreRelease: featuregate.Alpha},
+	DefinitionSourceController:                    {Default: false, PreRelease: featuregate.Alpha},
+	EnableCompressionMetrics:                      {Default: false, PreRelease: featuregate.Alpha},
+	DefinitionTemplateCache:                       {Default: false, PreRelease: featuregate.Alpha},
+	DefinitionVisibility:                          {Default: false, PreRelease: featuregate.Alpha},
+}
+
+var defaultFeatureDependencies = []FeatureDependency{
+	{Feature: SharedDefinitionStorageForApplicationRevision, Type: DependencyRequires, Target: InformerCacheFilterUnnecessaryFields,
+		Reason: "the shared definition storage relies on the filtered informer cache"},
+	{Feature: EnableGlobalPolicies, Type: DependencyRequires, Target: EnableApplicationScopedPolicies,
+		Reason: "global policies are Application-scoped and are not applied without it"},
+	{Feature: GzipResourceTracker, Type: DependencyConflicts, Target: ZstdResourceTracker,
+		Reason: "zstd takes precedence and gzip is ignored", Warn: true},
+	{Feature: GzipApplicationRevision, Type: DependencyConflicts, Target: ZstdApplicationRevision,
+		Reason: "zstd takes precedence and gzip is ignored", Warn: true},
+}
+
+// defaultFeatureLifecycles deprecates the compatibility features, which only
+// keep the behaviour of the releases before v1.6 and are not scheduled for
+// removal yet.
+var defaultFeatureLifecycles = []FeatureLifecycle{
+	{Feature: DeprecatedPolicySpec, Deprecated: "v1.6.0"},
+	{Feature: LegacyObjectTypeIdentifier, Deprecated: "v1.6.0"},
+	{Feature: DeprecatedObjectLabelSelector, Deprecated: "v1.6.0"},
+	{Feature: LegacyResourceTrackerGC, Deprecated: "v1.6.0"},
+	{Feature: LegacyResourceOwnerValidation, Deprecated: "v1.6.0"},
 }
 
 func init() {
 	runtime.Must(feature.DefaultMutableFeatureGate.Add(defaultFeatureGates))
+	RegisterDependency(defaultFeatureDependencies...)
+	RegisterLifecycle(defaultFeatureLifecycles...)
 }
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	goversion "github.com/hashicorp/go-version"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/oam-dev/kubevela/version"
+)
+
+// DependencyType is the relation a FeatureDependency declares between two gates.
+type DependencyType string
+
+const (
+	// DependencyRequires means Target must be enabled when Feature is enabled.
+	DependencyRequires DependencyType = "Requires"
+	// DependencyConflicts means Target must not be enabled together with Feature.
+	DependencyConflicts DependencyType = "ConflictsWith"
+)
+
+// FeatureDependency declares a relation between two feature gates that is
+// checked by the DependencyValidator.
+type FeatureDependency struct {
+	Feature featuregate.Feature
+	Type    DependencyType
+	Target  featuregate.Feature
+	// Reason explains the relation to the user.
+	Reason string
+	// Warn reports a violation as a warning instead of an error, for relations
+	// the controller resolves on its own.
+	Warn bool
+}
+
+var (
+	dependencyMu sync.RWMutex
+	dependencies []FeatureDependency
+)
+
+// RegisterDependency adds dependencies to the registry validated by
+// NewDependencyValidator. It is called next to the definition of the feature
+// gates, and can be called by third-party builds registering their own gates.
+func RegisterDependency(deps ...FeatureDependency) {
+	dependencyMu.Lock()
+	defer dependencyMu.Unlock()
+	dependencies = append(dependencies, deps...)
+}
+
+// Dependencies returns a copy of the registered dependencies.
+func Dependencies() []FeatureDependency {
+	dependencyMu.RLock()
+	defer dependencyMu.RUnlock()
+	return append([]FeatureDependency(nil), dependencies...)
+}
+
+// FeatureLifecycle records the releases a feature gate graduated or is
+// deprecated and removed in. Empty releases are not scheduled.
+type FeatureLifecycle struct {
+	Feature featuregate.Feature
+	// GA is the release the feature became generally available in. Disabling
+	// it from then on is reported as a warning.
+	GA string
+	// Deprecated is the release the feature was deprecated in. Enabling it
+	// from then on is reported as a warning.
+	Deprecated string
+	// Removed is the release the feature stops having any effect in. Enabling
+	// it from then on is reported as an error.
+	Removed string
+}
+
+var lifecycles []FeatureLifecycle
+
+// RegisterLifecycle adds lifecycles to the registry validated by NewDependencyValidator.
+func RegisterLifecycle(lcs ...FeatureLifecycle) {
+	dependencyMu.Lock()
+	defer dependencyMu.Unlock()
+	lifecycles = append(lifecycles, lcs...)
+}
+
+// Lifecycles returns a copy of the registered lifecycles.
+func Lifecycles() []FeatureLifecycle {
+	dependencyMu.RLock()
+	defer dependencyMu.RUnlock()
+	return append([]FeatureLifecycle(nil), lifecycles...)
+}
+
+// Violation is a dependency or lifecycle not satisfied by a set of feature gates.
+type Violation struct {
+	Feature featuregate.Feature
+	// Warn is true if the violation is only reported as a warning.
+	Warn    bool
+	Message string
+}
+
+// Error describes the violation.
+func (v Violation) Error() string {
+	return v.Message
+}
+
+// DependencyValidator checks a set of feature gates against dependencies and
+// against the lifecycle of the gates in the running release.
+type DependencyValidator struct {
+	Dependencies []FeatureDependency
+	Lifecycles   []FeatureLifecycle
+	// Version is the running release. Lifecycles are not checked if it is not
+	// a release version, e.g. in development builds.
+	Version string
+	// Explicit holds the gates set by the user, e.g. through --feature-gates.
+	// Lifecycles are only checked for these gates, the defaults of the release
+	// are not reported.
+	Explicit map[featuregate.Feature]bool
+}
+
+// NewDependencyValidator creates a validator for the registered dependencies
+// and lifecycles in the running release.
+func NewDependencyValidator() *DependencyValidator {
+	return &DependencyValidator{Dependencies: Dependencies(), Lifecycles: Lifecycles(), Version: version.VelaVersion}
+}
+
+// Validate returns the violations of the dependencies by the gates enabled
+// according to enabled, e.g. feature.DefaultMutableFeatureGate.Enabled.
+func (v *DependencyValidator) Validate(enabled func(featuregate.Feature) bool) []Violation {
+	var violations []Violation
+	for _, d := range v.Dependencies {
+		if !enabled(d.Feature) {
+			continue
+		}
+		switch d.Type {
+		case DependencyRequires:
+			if !enabled(d.Target) {
+				violations = append(violations, Violation{Feature: d.Feature, Warn: d.Warn,
+					Message: fmt.Sprintf("feature gate %s requires %s to be enabled: %s", d.Feature, d.Target, d.Reason)})
+			}
+		case DependencyConflicts:
+			if enabled(d.Target) {
+				violations = append(violations, Violation{Feature: d.Feature, Warn: d.Warn,
+					Message: fmt.Sprintf("feature gate %s conflicts with %s: %s", d.Feature, d.Target, d.Reason)})
+			}
+		default:
+			violations = append(violations, Violation{Feature: d.Feature,
+				Message: fmt.Sprintf("feature gate %s has unknown dependency %s on %s", d.Feature, d.Type, d.Target)})
+		}
+	}
+	return append(violations, v.validateLifecycles()...)
+}
+
+// validateLifecycles returns the explicitly set gates enabled past their
+// deprecation or removal and the GA gates that are explicitly disabled.
+func (v *DependencyValidator) validateLifecycles() []Violation {
+	current, err := goversion.NewVersion(v.Version)
+	if err != nil || len(v.Lifecycles) == 0 {
+		return nil
+	}
+	reached := func(release string) bool {
+		if release == "" {
+			return false
+		}
+		r, err := goversion.NewVersion(release)
+		return err == nil && current.GreaterThanOrEqual(r)
+	}
+	var violations []Violation
+	for _, lc := range v.Lifecycles {
+		on, set := v.Explicit[lc.Feature]
+		if !set {
+			continue
+		}
+		switch {
+		case on && reached(lc.Removed):
+			violations = append(violations, Violation{Feature: lc.Feature,
+				Message: fmt.Sprintf("feature gate %s was removed in %s and has no effect, remove it from --feature-gates", lc.Feature, lc.Removed)})
+		case on && reached(lc.Deprecated):
+			msg := fmt.Sprintf("feature gate %s is deprecated since %s", lc.Feature, lc.Deprecated)
+			if lc.Removed != "" {
+				msg += fmt.Sprintf(" and will be removed in %s", lc.Removed)
+			}
+			violations = append(violations, Violation{Feature: lc.Feature, Warn: true, Message: msg})
+		case !on && reached(lc.GA):
+			violations = append(violations, Violation{Feature: lc.Feature, Warn: true,
+				Message: fmt.Sprintf("feature gate %s is GA since %s and should not be disabled", lc.Feature, lc.GA)})
+		}
+	}
+	return violations
+}
+
+// ParseGates parses feature gates in the A=true,B=false format of the
+// --feature-gates flag.
+func ParseGates(value string) (map[featuregate.Feature]bool, error) {
+	gates := map[featuregate.Feature]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, val, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("missing value for feature gate %q", entry)
+		}
+		f := featuregate.Feature(strings.TrimSpace(name))
+		enabled, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for feature gate %s: %w", val, f, err)
+		}
+		gates[f] = enabled
+	}
+	return gates, nil
+}
+
+// Err joins the violations that are not warnings, or returns nil if there is none.
+func Err(violations []Violation) error {
+	var errs []error
+	for _, v := range violations {
+		if !v.Warn {
+			errs = append(errs, v)
+		}
+	}
+	return errors.Join(errs...)
+}
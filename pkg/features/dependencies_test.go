@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/oam-dev/kubevela/pkg/features"
+)
+
+func TestDependencyValidator(t *testing.T) {
+	enabled := func(gates ...featuregate.Feature) func(featuregate.Feature) bool {
+		return func(f featuregate.Feature) bool {
+			for _, g := range gates {
+				if g == f {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	v := &features.DependencyValidator{Dependencies: []features.FeatureDependency{
+		{Feature: "A", Type: features.DependencyRequires, Target: "B", Reason: "A needs B"},
+		{Feature: "C", Type: features.DependencyConflicts, Target: "D", Reason: "C replaces D", Warn: true},
+	}}
+
+	assert.Empty(t, v.Validate(enabled("A", "B", "C")))
+
+	violations := v.Validate(enabled("A", "C", "D"))
+	require.Len(t, violations, 2)
+	assert.Equal(t, "feature gate A requires B to be enabled: A needs B", violations[0].Error())
+	assert.Equal(t, "feature gate C conflicts with D: C replaces D", violations[1].Error())
+	assert.EqualError(t, features.Err(violations), "feature gate A requires B to be enabled: A needs B")
+	assert.NoError(t, features.Err(violations[1:]))
+}
+
+func TestRegisterDependency(t *testing.T) {
+	dep := features.FeatureDependency{Feature: "ThirdParty", Type: features.DependencyRequires, Target: features.ApplyOnce}
+	features.RegisterDependency(dep)
+	assert.Contains(t, features.Dependencies(), dep)
+	assert.Contains(t, features.NewDependencyValidator().Dependencies, dep)
+}
+
+func TestDependencyValidatorLifecycles(t *testing.T) {
+	lifecycles := []features.FeatureLifecycle{
+		{Feature: "Old", Deprecated: "v1.9.0", Removed: "v1.11.0"},
+		{Feature: "Stable", GA: "v1.10.0"},
+	}
+	enabled := func(featuregate.Feature) bool { return true }
+
+	v := &features.DependencyValidator{Lifecycles: lifecycles, Version: "v1.10.2",
+		Explicit: map[featuregate.Feature]bool{"Old": true, "Stable": false}}
+	violations := v.Validate(enabled)
+	require.Len(t, violations, 2)
+	assert.Equal(t, "feature gate Old is deprecated since v1.9.0 and will be removed in v1.11.0", violations[0].Error())
+	assert.True(t, violations[0].Warn)
+	assert.Equal(t, "feature gate Stable is GA since v1.10.0 and should not be disabled", violations[1].Error())
+	assert.NoError(t, features.Err(violations))
+
+	v.Version = "v1.11.0"
+	v.Explicit = map[featuregate.Feature]bool{"Old": true, "Stable": true}
+	violations = v.Validate(enabled)
+	require.Len(t, violations, 1)
+	assert.EqualError(t, features.Err(violations), "feature gate Old was removed in v1.11.0 and has no effect, remove it from --feature-gates")
+
+	// the gates that are not set explicitly keep the defaults of the release
+	v.Explicit = nil
+	assert.Empty(t, v.Validate(enabled))
+
+	v.Version = "UNKNOWN"
+	v.Explicit = map[featuregate.Feature]bool{"Old": true}
+	assert.Empty(t, v.Validate(enabled))
+}
+
+func TestParseGates(t *testing.T) {
+	gates, err := features.ParseGates(" A=true, B=false,")
+	require.NoError(t, err)
+	assert.Equal(t, map[featuregate.Feature]bool{"A": true, "B": false}, gates)
+
+	_, err = features.ParseGates("A")
+	assert.ErrorContains(t, err, `missing value for feature gate "A"`)
+	_, err = features.ParseGates("A=yes")
+	assert.ErrorContains(t, err, "invalid value")
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapKey is the key of the feature gate ConfigMap holding the gates,
+	// in the same A=true,B=false format as the --feature-gates flag.
+	ConfigMapKey = "featureGates"
+	// ReasonFeatureGatesApplied is the event reason used when a change is applied.
+	ReasonFeatureGatesApplied = "FeatureGatesApplied"
+	// ReasonFeatureGatesRejected is the event reason used when a change is rejected.
+	ReasonFeatureGatesRejected = "FeatureGatesRejected"
+)
+
+// ReloadableFeatures are the feature gates that are read on every use and can
+// therefore be changed at runtime. Gates consumed once during setup, such as
+// DefinitionTemplateCache or the resource tracker compression gates, are not
+// reloadable because changing them would not take effect until a restart.
+var ReloadableFeatures = []featuregate.Feature{
+	EnableApplicationStatusMetrics,
+	PreDispatchDryRun,
+	EnableCueValidation,
+	ValidateResourcesExist,
+	ValidateUndeclaredParameters,
+}
+
+// ValidateReloadable returns an error if any of the given gates is not one of
+// the ReloadableFeatures.
+func ValidateReloadable(gates []string) error {
+	var invalid []string
+	for _, gate := range gates {
+		if !slices.Contains(ReloadableFeatures, featuregate.Feature(gate)) {
+			invalid = append(invalid, gate)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("feature gates %s cannot be changed at runtime, the reloadable feature gates are %v",
+			strings.Join(invalid, ","), ReloadableFeatures)
+	}
+	return nil
+}
+
+// Reloader applies feature gates from a ConfigMap at runtime. Only the gates
+// listed in Mutable can be changed, and a change is applied only if the
+// resulting set of gates passes the DependencyValidator. Removing a gate from
+// the ConfigMap restores the value it had when the Reloader started. It
+// implements the controller-runtime Runnable interface.
+type Reloader struct {
+	Client    client.Reader
+	Namespace string
+	Name      string
+	Gate      featuregate.MutableFeatureGate
+	Mutable   []featuregate.Feature
+	Validator *DependencyValidator
+	Interval  time.Duration
+	// Recorder is optional. When set, applied and rejected changes are reported
+	// as events on the ConfigMap.
+	Recorder record.EventRecorder
+	// OnChange is called after a change was applied if set.
+	OnChange func(ctx context.Context)
+
+	initial map[featuregate.Feature]bool
+	last    *string
+}
+
+// Start reloads the feature gates every Interval until ctx is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	klog.InfoS("Starting feature gate reloader", "configMap", r.Namespace+"/"+r.Name, "interval", r.Interval, "mutable", r.Mutable)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Reload(ctx); err != nil {
+			klog.ErrorS(err, "Failed to reload feature gates", "configMap", r.Namespace+"/"+r.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes every replica reload its own feature gates.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload reads the ConfigMap once and applies it if it changed since the last
+// reload and passes the validation.
+func (r *Reloader) Reload(ctx context.Context) error {
+	if r.initial == nil {
+		r.initial = map[featuregate.Feature]bool{}
+		for _, f := range r.Mutable {
+			r.initial[f] = r.Gate.Enabled(f)
+		}
+	}
+	cm := &corev1.ConfigMap{}
+	data := ""
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: r.Name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get feature gate ConfigMap %s/%s: %w", r.Namespace, r.Name, err)
+		}
+	} else {
+		data = cm.Data[ConfigMapKey]
+	}
+	if r.last != nil && *r.last == data {
+		return nil
+	}
+	r.last = &data
+
+	if err := r.apply(ctx, data); err != nil {
+		r.event(corev1.EventTypeWarning, ReasonFeatureGatesRejected, "Feature gate change rejected: %v", err)
+		return err
+	}
+	return nil
+}
+
+// apply validates and applies the gates in data on top of the initial gates.
+func (r *Reloader) apply(ctx context.Context, data string) error {
+	overrides, err := r.parse(data)
+	if err != nil {
+		return err
+	}
+	proposed := make(map[string]bool, len(r.initial))
+	for f, v := range r.initial {
+		proposed[string(f)] = v
+	}
+	for f, v := range overrides {
+		proposed[string(f)] = v
+	}
+	enabled := func(f featuregate.Feature) bool {
+		if v, ok := proposed[string(f)]; ok {
+			return v
+		}
+		return r.Gate.Enabled(f)
+	}
+	validator := *r.Validator
+	validator.Explicit = maps.Clone(r.Validator.Explicit)
+	if validator.Explicit == nil {
+		validator.Explicit = map[featuregate.Feature]bool{}
+	}
+	maps.Copy(validator.Explicit, overrides)
+	if err := Err(validator.Validate(enabled)); err != nil {
+		return err
+	}
+
+	var changed []string
+	for f, v := range proposed {
+		if r.Gate.Enabled(featuregate.Feature(f)) != v {
+			changed = append(changed, fmt.Sprintf("%s=%t", f, v))
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := r.Gate.SetFromMap(proposed); err != nil {
+		return fmt.Errorf("failed to set feature gates: %w", err)
+	}
+	slices.Sort(changed)
+	klog.InfoS("Feature gates reloaded", "changed", changed)
+	r.event(corev1.EventTypeNormal, ReasonFeatureGatesApplied, "Feature gates changed: %s", strings.Join(changed, ","))
+	if r.OnChange != nil {
+		r.OnChange(ctx)
+	}
+	return nil
+}
+
+// parse parses data in the A=true,B=false format and checks that every gate is mutable.
+func (r *Reloader) parse(data string) (map[featuregate.Feature]bool, error) {
+	gates, err := ParseGates(data)
+	if err != nil {
+		return nil, err
+	}
+	for f := range gates {
+		if !slices.Contains(r.Mutable, f) {
+			return nil, fmt.Errorf("feature gate %s cannot be changed at runtime", f)
+		}
+	}
+	return gates, nil
+}
+
+func (r *Reloader) event(eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: r.Name}}
+	r.Recorder.Eventf(cm, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/features"
+)
+
+func TestReloader(t *testing.T) {
+	ctx := context.Background()
+	gate := featuregate.NewFeatureGate()
+	require.NoError(t, gate.Add(map[featuregate.Feature]featuregate.FeatureSpec{
+		"A": {Default: false, PreRelease: featuregate.Alpha},
+		"B": {Default: false, PreRelease: featuregate.Alpha},
+		"C": {Default: false, PreRelease: featuregate.Alpha},
+	}))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vela-system", Name: "vela-feature-gates"},
+		Data:       map[string]string{features.ConfigMapKey: "A=true"},
+	}
+	cli := fake.NewClientBuilder().WithObjects(cm).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &features.Reloader{
+		Client: cli, Namespace: "vela-system", Name: "vela-feature-gates", Gate: gate,
+		Mutable:   []featuregate.Feature{"A", "B"},
+		Validator: &features.DependencyValidator{Dependencies: []features.FeatureDependency{{Feature: "A", Type: features.DependencyRequires, Target: "B"}}},
+		Recorder:  recorder,
+	}
+
+	err := r.Reload(ctx)
+	assert.ErrorContains(t, err, "feature gate A requires B to be enabled")
+	assert.False(t, gate.Enabled("A"))
+	assert.Contains(t, <-recorder.Events, features.ReasonFeatureGatesRejected)
+
+	cm.Data[features.ConfigMapKey] = "A=true,B=true"
+	require.NoError(t, cli.Update(ctx, cm))
+	require.NoError(t, r.Reload(ctx))
+	assert.True(t, gate.Enabled("A"))
+	assert.True(t, gate.Enabled("B"))
+	assert.Contains(t, <-recorder.Events, "Feature gates changed: A=true,B=true")
+
+	cm.Data[features.ConfigMapKey] = "C=true"
+	require.NoError(t, cli.Update(ctx, cm))
+	assert.ErrorContains(t, r.Reload(ctx), "feature gate C cannot be changed at runtime")
+	assert.True(t, gate.Enabled("A"))
+	<-recorder.Events
+
+	require.NoError(t, cli.Delete(ctx, cm))
+	require.NoError(t, r.Reload(ctx))
+	assert.False(t, gate.Enabled("A"))
+	assert.False(t, gate.Enabled("B"))
+}
+
+func TestValidateReloadable(t *testing.T) {
+	assert.NoError(t, features.ValidateReloadable([]string{string(features.PreDispatchDryRun), string(features.EnableCueValidation)}))
+	err := features.ValidateReloadable([]string{string(features.PreDispatchDryRun), string(features.DefinitionTemplateCache)})
+	assert.ErrorContains(t, err, "feature gates DefinitionTemplateCache cannot be changed at runtime")
+}
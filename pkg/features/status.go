@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/version"
+)
+
+const (
+	// StatusPath is the path the feature gate status is served at on the metrics server.
+	StatusPath = "/featuregates"
+	// StatusConfigMapKey is the key of the mirrored ConfigMap holding the JSON encoded Status.
+	StatusConfigMapKey = "status"
+)
+
+// Status is the effective feature gate configuration of a controller,
+// published for tools such as `vela system info`.
+type Status struct {
+	Version string       `json:"version"`
+	Time    time.Time    `json:"time"`
+	Gates   []GateStatus `json:"gates"`
+	// Errors and Warnings are the violated feature dependencies.
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// GateStatus is the state of a single feature gate.
+type GateStatus struct {
+	Name       string `json:"name"`
+	Enabled    bool   `json:"enabled"`
+	Default    bool   `json:"default"`
+	PreRelease string `json:"preRelease"`
+}
+
+// CurrentStatus returns the state of every gate known to gate and the
+// outcome of validating them with validator.
+func CurrentStatus(gate featuregate.MutableFeatureGate, validator *DependencyValidator, now time.Time) Status {
+	s := Status{Version: version.VelaVersion, Time: now.UTC()}
+	for f, spec := range gate.GetAll() {
+		s.Gates = append(s.Gates, GateStatus{Name: string(f), Enabled: gate.Enabled(f), Default: spec.Default, PreRelease: string(spec.PreRelease)})
+	}
+	sort.Slice(s.Gates, func(i, j int) bool { return s.Gates[i].Name < s.Gates[j].Name })
+	for _, v := range validator.Validate(gate.Enabled) {
+		if v.Warn {
+			s.Warnings = append(s.Warnings, v.Error())
+		} else {
+			s.Errors = append(s.Errors, v.Error())
+		}
+	}
+	return s
+}
+
+// StatusHandler serves the current Status as JSON.
+func StatusHandler(gate featuregate.MutableFeatureGate, validator *DependencyValidator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(CurrentStatus(gate, validator, time.Now())); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// PublishStatus writes status into the named ConfigMap, creating it if needed.
+func PublishStatus(ctx context.Context, c client.Client, namespace, name string, status Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to encode feature gate status: %w", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get feature gate status ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		cm.Namespace = namespace
+		cm.Name = name
+		cm.Labels = map[string]string{oam.LabelControllerName: types.VelaCoreName}
+		cm.Data = map[string]string{StatusConfigMapKey: string(data)}
+		if err := c.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create feature gate status ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[StatusConfigMapKey] = string(data)
+	if err := c.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update feature gate status ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
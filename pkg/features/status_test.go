@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/features"
+)
+
+func TestStatus(t *testing.T) {
+	gate := featuregate.NewFeatureGate()
+	require.NoError(t, gate.Add(map[featuregate.Feature]featuregate.FeatureSpec{
+		"B": {Default: true, PreRelease: featuregate.Beta},
+		"A": {Default: false, PreRelease: featuregate.Alpha},
+	}))
+	validator := &features.DependencyValidator{Dependencies: []features.FeatureDependency{
+		{Feature: "B", Type: features.DependencyRequires, Target: "A", Reason: "B needs A"},
+	}}
+
+	status := features.CurrentStatus(gate, validator, time.Now())
+	assert.Contains(t, status.Gates, features.GateStatus{Name: "A", Enabled: false, Default: false, PreRelease: "ALPHA"})
+	assert.Contains(t, status.Gates, features.GateStatus{Name: "B", Enabled: true, Default: true, PreRelease: "BETA"})
+	assert.Equal(t, []string{"feature gate B requires A to be enabled: B needs A"}, status.Errors)
+
+	rec := httptest.NewRecorder()
+	features.StatusHandler(gate, validator).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, features.StatusPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	served := features.Status{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.Equal(t, status.Errors, served.Errors)
+
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	require.NoError(t, features.PublishStatus(ctx, cli, "vela-system", "vela-core-feature-gates", status))
+	require.NoError(t, features.PublishStatus(ctx, cli, "vela-system", "vela-core-feature-gates", status))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "vela-system", Name: "vela-core-feature-gates"}, cm))
+	published := features.Status{}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[features.StatusConfigMapKey]), &published))
+	assert.Equal(t, status.Gates, published.Gates)
+}
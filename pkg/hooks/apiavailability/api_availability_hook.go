@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiavailability
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// Options configures the API availability hook.
+type Options struct {
+	// MinVersion is the oldest supported Kubernetes version.
+	MinVersion string
+	// ClientVersion is the Kubernetes version of the client libraries vela-core
+	// is built with. Empty reads it from the build info of k8s.io/client-go.
+	ClientVersion string
+	// MaxMinorSkew is the number of minor versions the cluster may be ahead of
+	// ClientVersion. A negative value disables the check.
+	MaxMinorSkew int
+	// RequiredAPIs are the group versions the cluster must serve.
+	RequiredAPIs []schema.GroupVersion
+}
+
+// DefaultRequiredAPIs returns the APIs vela-core relies on. The cluster-gateway
+// APIs are only required when multicluster is enabled.
+func DefaultRequiredAPIs(clusterGateway bool) []schema.GroupVersion {
+	apis := []schema.GroupVersion{apiextensionsv1.SchemeGroupVersion, admissionregistrationv1.SchemeGroupVersion}
+	if clusterGateway {
+		apis = append(apis, clusterv1alpha1.SchemeGroupVersion)
+	}
+	return apis
+}
+
+// Hook verifies through the discovery API that the cluster runs a supported
+// Kubernetes version and serves the APIs vela-core needs.
+type Hook struct {
+	Discovery discovery.DiscoveryInterface
+	Options   Options
+}
+
+// NewHook creates a new API availability hook with the given discovery client and options
+func NewHook(dc discovery.DiscoveryInterface, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing API availability hook", "minVersion", opts.MinVersion, "requiredAPIs", len(opts.RequiredAPIs))
+	return &Hook{Discovery: dc, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "APIAvailability"
+}
+
+// Severity makes an unsupported cluster non-fatal by default, since newer
+// clusters usually keep working and discovery may fail transiently, e.g. while
+// the cluster-gateway APIService is flapping.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// Run checks the server version and the required APIs and reports all problems,
+// including failed discovery requests, together.
+func (h *Hook) Run(_ context.Context) error {
+	var problems []string
+	gitVersion := ""
+	if info, err := h.Discovery.ServerVersion(); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to get Kubernetes server version: %v", err))
+	} else {
+		gitVersion = info.GitVersion
+		if msg, err := h.checkVersion(gitVersion); err != nil {
+			return err
+		} else if msg != "" {
+			problems = append(problems, msg)
+		}
+	}
+
+	for _, gv := range h.Options.RequiredAPIs {
+		if _, err := h.Discovery.ServerResourcesForGroupVersion(gv.String()); err != nil {
+			if !apierrors.IsNotFound(err) {
+				problems = append(problems, fmt.Sprintf("failed to discover API %s: %v", gv, err))
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("API %s is not served, %s", gv, guidance(gv)))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("cluster does not meet the requirements of vela-core: %s", strings.Join(problems, "; "))
+	}
+	klog.InfoS("Kubernetes version and APIs validated", "version", gitVersion, "apis", len(h.Options.RequiredAPIs))
+	return nil
+}
+
+// checkVersion returns a problem if the server version is outside of the supported range.
+func (h *Hook) checkVersion(gitVersion string) (string, error) {
+	server, err := version.ParseGeneric(gitVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Kubernetes server version %q: %w", gitVersion, err)
+	}
+	if h.Options.MinVersion != "" {
+		minVersion, err := version.ParseGeneric(h.Options.MinVersion)
+		if err != nil {
+			return "", fmt.Errorf("invalid minimum Kubernetes version %q: %w", h.Options.MinVersion, err)
+		}
+		if !server.AtLeast(minVersion) {
+			return fmt.Sprintf("Kubernetes %s is older than the minimum supported version %s, upgrade the cluster or use an older KubeVela release",
+				gitVersion, h.Options.MinVersion), nil
+		}
+	}
+	if h.Options.MaxMinorSkew < 0 {
+		return "", nil
+	}
+	clientVersion := h.Options.ClientVersion
+	if clientVersion == "" {
+		clientVersion = buildClientVersion()
+	}
+	if clientVersion == "" {
+		klog.V(2).InfoS("Kubernetes client version unknown, skipping version skew check")
+		return "", nil
+	}
+	client, err := version.ParseGeneric(clientVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid Kubernetes client version %q: %w", clientVersion, err)
+	}
+	if server.Major() == client.Major() && int(server.Minor())-int(client.Minor()) > h.Options.MaxMinorSkew {
+		return fmt.Sprintf("Kubernetes %s is more than %d minor versions newer than the client %s vela-core is built with, "+
+			"upgrade KubeVela to a release supporting this cluster", gitVersion, h.Options.MaxMinorSkew, clientVersion), nil
+	}
+	return "", nil
+}
+
+// guidance returns how to make a missing API available.
+func guidance(gv schema.GroupVersion) string {
+	switch gv.Group {
+	case clusterv1alpha1.SchemeGroupVersion.Group:
+		return "check that the cluster-gateway is running and its APIService is available, or disable --enable-cluster-gateway"
+	case apiextensionsv1.GroupName, admissionregistrationv1.GroupName:
+		return "upgrade the cluster to a version serving it"
+	}
+	return "install the component providing it"
+}
+
+// buildClientVersion returns the Kubernetes version matching the k8s.io/client-go
+// module vela-core is built with, e.g. v1.31.10 for v0.31.10.
+func buildClientVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "k8s.io/client-go" {
+			continue
+		}
+		v, err := version.ParseSemantic(dep.Version)
+		if err != nil || v.Major() != 0 {
+			return ""
+		}
+		return fmt.Sprintf("v1.%d.%d", v.Minor(), v.Patch())
+	}
+	return ""
+}
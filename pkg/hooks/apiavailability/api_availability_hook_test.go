@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiavailability_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/apiavailability"
+)
+
+func newDiscovery(gitVersion string, groupVersions ...string) *fakediscovery.FakeDiscovery {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}, FakedServerVersion: &version.Info{GitVersion: gitVersion}}
+	for _, gv := range groupVersions {
+		dc.Resources = append(dc.Resources, &metav1.APIResourceList{GroupVersion: gv})
+	}
+	return dc
+}
+
+func TestAPIAvailabilityHook(t *testing.T) {
+	ctx := context.Background()
+	opts := apiavailability.Options{
+		MinVersion:    "v1.26.0",
+		ClientVersion: "v1.31.10",
+		MaxMinorSkew:  2,
+		RequiredAPIs:  apiavailability.DefaultRequiredAPIs(false),
+	}
+
+	t.Run("supported cluster", func(t *testing.T) {
+		dc := newDiscovery("v1.30.2+k3s1", "apiextensions.k8s.io/v1", "admissionregistration.k8s.io/v1")
+		hook := apiavailability.NewHook(dc, opts)
+		assert.Equal(t, "APIAvailability", hook.Name())
+		assert.Equal(t, hooks.SeverityWarn, hook.(hooks.SeverityHook).Severity())
+		assert.NoError(t, hook.Run(ctx))
+	})
+
+	t.Run("too old cluster", func(t *testing.T) {
+		dc := newDiscovery("v1.25.9", "apiextensions.k8s.io/v1", "admissionregistration.k8s.io/v1")
+		assert.ErrorContains(t, apiavailability.NewHook(dc, opts).Run(ctx), "older than the minimum supported version v1.26.0")
+	})
+
+	t.Run("too new cluster", func(t *testing.T) {
+		dc := newDiscovery("v1.34.0", "apiextensions.k8s.io/v1", "admissionregistration.k8s.io/v1")
+		assert.ErrorContains(t, apiavailability.NewHook(dc, opts).Run(ctx), "more than 2 minor versions newer than the client v1.31.10")
+		o := opts
+		o.MaxMinorSkew = -1
+		assert.NoError(t, apiavailability.NewHook(dc, o).Run(ctx))
+	})
+
+	t.Run("missing APIs", func(t *testing.T) {
+		dc := newDiscovery("v1.31.0", "apiextensions.k8s.io/v1")
+		o := opts
+		o.RequiredAPIs = apiavailability.DefaultRequiredAPIs(true)
+		err := apiavailability.NewHook(dc, o).Run(ctx)
+		assert.ErrorContains(t, err, "API admissionregistration.k8s.io/v1 is not served")
+		assert.ErrorContains(t, err, "API cluster.core.oam.dev/v1alpha1 is not served, check that the cluster-gateway is running")
+		assert.NotContains(t, err.Error(), "apiextensions.k8s.io/v1 is not served")
+	})
+
+	t.Run("discovery errors", func(t *testing.T) {
+		dc := newDiscovery("v1.31.0", "apiextensions.k8s.io/v1", "admissionregistration.k8s.io/v1")
+		dc.PrependReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("service unavailable")
+		})
+		dc.PrependReactor("get", "version", func(clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		err := apiavailability.NewHook(dc, opts).Run(ctx)
+		assert.ErrorContains(t, err, "cluster does not meet the requirements of vela-core")
+		assert.ErrorContains(t, err, "failed to get Kubernetes server version: connection refused")
+		assert.ErrorContains(t, err, "failed to discover API apiextensions.k8s.io/v1: service unavailable")
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustergateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/webhookvalidation"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+)
+
+const (
+	// DefaultProbeTimeout is the default timeout of the liveness probe of a cluster
+	DefaultProbeTimeout = 5 * time.Second
+	// DefaultConcurrency is the default number of clusters probed in parallel
+	DefaultConcurrency = 8
+)
+
+// Hook verifies that the cluster-gateway is usable before the controller starts:
+// its APIService is available and backed by an existing service, its CA bundle
+// is valid, and the registered clusters respond to a liveness probe through it.
+// Unreachable clusters are summarized together instead of failing lazily in the
+// reconciliation of each application. Its failures are reported with
+// SeverityWarn by default, since the other clusters remain usable.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// Options configures the cluster-gateway preflight hook.
+type Options struct {
+	// ProbeTimeout bounds the liveness probe of each cluster.
+	ProbeTimeout time.Duration
+	// Concurrency is the number of clusters probed in parallel.
+	Concurrency int
+	// MinCertValidity is the minimum remaining validity of the CA bundle of the
+	// cluster-gateway APIService.
+	MinCertValidity time.Duration
+	// Probe checks the liveness of a registered cluster.
+	Probe func(ctx context.Context, cluster string) error
+}
+
+// NewHook creates a new cluster-gateway preflight hook. The clusters are probed
+// by requesting their /healthz endpoint through the cluster-gateway unless
+// opts.Probe is set.
+func NewHook(c client.Client, cfg *rest.Config, opts Options) hooks.PreStartHook {
+	if opts.Probe == nil {
+		opts.Probe = func(ctx context.Context, cluster string) error {
+			_, err := multicluster.RequestRawK8sAPIForCluster(ctx, "healthz", cluster, rest.CopyConfig(cfg))
+			return err
+		}
+	}
+	klog.V(3).InfoS("Initializing cluster-gateway preflight hook", "probeTimeout", opts.ProbeTimeout, "concurrency", opts.Concurrency)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "ClusterGatewayPreflight"
+}
+
+// Severity makes unreachable clusters non-fatal by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// Run checks the cluster-gateway and probes every registered cluster, all
+// problems are reported together.
+func (h *Hook) Run(ctx context.Context) error {
+	svcRef, err := multicluster.GetClusterGatewayService(ctx, h.Client)
+	if svcRef == nil {
+		return err
+	}
+	var problems []string
+	available := err == nil
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	svc := &corev1.Service{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Namespace: svcRef.Namespace, Name: svcRef.Name}, svc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get cluster-gateway service %s/%s: %w", svcRef.Namespace, svcRef.Name, err)
+		}
+		problems = append(problems, fmt.Sprintf("cluster-gateway service %s/%s does not exist", svcRef.Namespace, svcRef.Name))
+	}
+	problems = append(problems, h.checkCABundle(ctx)...)
+
+	if !available {
+		// every probe would fail through an unavailable APIService
+		return fmt.Errorf("cluster-gateway is not usable: %s", strings.Join(problems, "; "))
+	}
+	clusters, err := multicluster.FindVirtualClustersByLabels(ctx, h.Client, map[string]string{})
+	if err != nil {
+		return fmt.Errorf("failed to list the registered clusters: %w", err)
+	}
+	unreachable := h.probe(ctx, clusters)
+	if len(unreachable) > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d registered clusters are unreachable: %s",
+			len(unreachable), len(clusters), strings.Join(unreachable, ", ")))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("cluster-gateway is not usable: %s", strings.Join(problems, "; "))
+	}
+	klog.InfoS("Cluster-gateway validated", "service", svcRef.Namespace+"/"+svcRef.Name, "clusters", len(clusters))
+	return nil
+}
+
+// checkCABundle returns the problems of the CA bundle of the cluster-gateway APIService.
+func (h *Hook) checkCABundle(ctx context.Context) []string {
+	name := v1alpha1.SchemeGroupVersion.Version + "." + v1alpha1.SchemeGroupVersion.Group
+	apiService := &apiregistrationv1.APIService{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: name}, apiService); err != nil {
+		return []string{fmt.Sprintf("failed to get APIService %s: %v", name, err)}
+	}
+	if apiService.Spec.InsecureSkipTLSVerify {
+		klog.InfoS("The cluster-gateway APIService skips TLS verification, its certificate is not checked", "apiService", name)
+		return nil
+	}
+	cas, err := webhookvalidation.ParseCertificates(apiService.Spec.CABundle)
+	if err != nil {
+		return []string{fmt.Sprintf("APIService %s has an invalid caBundle: %v", name, err)}
+	}
+	var problems []string
+	now := time.Now()
+	for _, ca := range cas {
+		if msg := webhookvalidation.CheckValidity("CA certificate "+ca.Subject.CommonName, ca, now, h.Options.MinCertValidity); msg != "" {
+			problems = append(problems, fmt.Sprintf("APIService %s: %s", name, msg))
+		}
+	}
+	return problems
+}
+
+// probe probes the clusters in parallel and returns the sorted unreachable ones with their errors.
+func (h *Hook) probe(ctx context.Context, clusters []multicluster.VirtualCluster) []string {
+	timeout, concurrency := h.Options.ProbeTimeout, h.Options.Concurrency
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		unreachable []string
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, cluster := range clusters {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := h.Options.Probe(probeCtx, name); err != nil {
+				klog.ErrorS(err, "Cluster is unreachable through the cluster-gateway", "cluster", name)
+				mu.Lock()
+				unreachable = append(unreachable, fmt.Sprintf("%s (%v)", name, err))
+				mu.Unlock()
+			}
+		}(cluster.Name)
+	}
+	wg.Wait()
+	sort.Strings(unreachable)
+	return unreachable
+}
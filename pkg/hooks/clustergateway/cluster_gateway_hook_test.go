@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustergateway_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	clustercommon "github.com/oam-dev/cluster-gateway/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/clustergateway"
+	"github.com/oam-dev/kubevela/pkg/multicluster"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newCA(t *testing.T, validity time.Duration) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "cluster-gateway-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newObjects(caBundle []byte, available apiregistrationv1.ConditionStatus, clusters ...string) []client.Object {
+	apiService := &apiregistrationv1.APIService{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1alpha1.cluster.core.oam.dev"},
+		Spec: apiregistrationv1.APIServiceSpec{
+			Service:  &apiregistrationv1.ServiceReference{Namespace: "vela-system", Name: "kubevela-cluster-gateway-service"},
+			CABundle: caBundle,
+		},
+		Status: apiregistrationv1.APIServiceStatus{Conditions: []apiregistrationv1.APIServiceCondition{{
+			Type: apiregistrationv1.Available, Status: available,
+		}}},
+	}
+	objs := []client.Object{apiService, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "vela-system", Name: "kubevela-cluster-gateway-service"}}}
+	for _, name := range clusters {
+		objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: multicluster.ClusterGatewaySecretNamespace,
+			Name:      name,
+			Labels:    map[string]string{clustercommon.LabelKeyClusterCredentialType: "X509Certificate"},
+		}})
+	}
+	return objs
+}
+
+func TestClusterGatewayHook(t *testing.T) {
+	ctx := context.Background()
+	probe := func(ctx context.Context, cluster string) error {
+		switch cluster {
+		case "edge":
+			return errors.New("connection refused")
+		case "slow":
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+	opts := clustergateway.Options{ProbeTimeout: 100 * time.Millisecond, MinCertValidity: 24 * time.Hour, Probe: probe}
+
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).
+		WithObjects(newObjects(newCA(t, 365*24*time.Hour), apiregistrationv1.ConditionTrue, "prod", "staging")...).Build()
+	hook := clustergateway.NewHook(cli, nil, opts)
+	assert.Equal(t, "ClusterGatewayPreflight", hook.Name())
+	assert.NoError(t, hook.Run(ctx))
+
+	cli = fake.NewClientBuilder().WithScheme(common.Scheme).
+		WithObjects(newObjects(newCA(t, time.Hour), apiregistrationv1.ConditionTrue, "prod", "edge", "slow")...).Build()
+	err := clustergateway.NewHook(cli, nil, opts).Run(ctx)
+	assert.ErrorContains(t, err, "expires at")
+	assert.ErrorContains(t, err, "2 of 3 registered clusters are unreachable: edge (connection refused), slow (context deadline exceeded)")
+
+	cli = fake.NewClientBuilder().WithScheme(common.Scheme).
+		WithObjects(newObjects(newCA(t, 365*24*time.Hour), apiregistrationv1.ConditionFalse, "edge")...).Build()
+	err = clustergateway.NewHook(cli, nil, opts).Run(ctx)
+	assert.ErrorContains(t, err, "is not ready")
+	assert.NotContains(t, err.Error(), "unreachable")
+
+	cli = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	assert.ErrorContains(t, clustergateway.NewHook(cli, nil, opts).Run(ctx), "is not found")
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+// crdsFS holds the CRD manifests shipped with this vela-core binary. The files
+// are copied from charts/vela-core/crds by `make manifests`.
+//
+//go:embed crds/*.yaml
+var crdsFS embed.FS
+
+var (
+	bundledCRDsOnce sync.Once
+	bundledCRDs     map[string]*unstructured.Unstructured
+	bundledCRDsErr  error
+)
+
+// crdEstablishTimeout bounds how long we wait for an applied CRD to be served.
+const crdEstablishTimeout = 30 * time.Second
+
+// BundledCRD returns the CRD manifest embedded in the binary with the given name.
+// The returned object is a copy and may be modified by the caller.
+func BundledCRD(name string) (*unstructured.Unstructured, bool, error) {
+	bundledCRDsOnce.Do(func() {
+		bundledCRDs, bundledCRDsErr = loadBundledCRDs()
+	})
+	if bundledCRDsErr != nil {
+		return nil, false, bundledCRDsErr
+	}
+	crd, ok := bundledCRDs[name]
+	if !ok {
+		return nil, false, nil
+	}
+	return crd.DeepCopy(), true, nil
+}
+
+// loadBundledCRDs parses every embedded manifest and indexes it by CRD name.
+func loadBundledCRDs() (map[string]*unstructured.Unstructured, error) {
+	entries, err := crdsFS.ReadDir("crds")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled CRDs: %w", err)
+	}
+	crds := make(map[string]*unstructured.Unstructured, len(entries))
+	for _, entry := range entries {
+		data, err := crdsFS.ReadFile(path.Join("crds", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundled CRD %s: %w", entry.Name(), err)
+		}
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &u.Object); err != nil {
+			return nil, fmt.Errorf("failed to parse bundled CRD %s: %w", entry.Name(), err)
+		}
+		crds[u.GetName()] = u
+	}
+	return crds, nil
+}
+
+// applyBundledCRDs server-side applies the bundled manifests for the named CRDs.
+// Every CRD is dry-run first so that nothing is written unless all of them
+// would be accepted. CRDs that are not bundled cannot be remediated.
+func applyBundledCRDs(ctx context.Context, c client.Client, names []string) error {
+	objs := make([]*unstructured.Unstructured, 0, len(names))
+	for _, name := range names {
+		crd, ok, err := BundledCRD(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("CRD %s is not bundled with vela-core and cannot be upgraded automatically", name)
+		}
+		objs = append(objs, crd)
+	}
+
+	for _, obj := range objs {
+		klog.V(2).InfoS("Dry-run applying bundled CRD", "crd", obj.GetName())
+		if err := c.Patch(ctx, obj.DeepCopy(), client.Apply, client.FieldOwner(types.VelaCoreName), client.ForceOwnership, client.DryRunAll); err != nil {
+			return fmt.Errorf("dry-run apply of bundled CRD %s failed: %w", obj.GetName(), err)
+		}
+	}
+
+	for _, obj := range objs {
+		klog.InfoS("Applying bundled CRD", "crd", obj.GetName())
+		if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(types.VelaCoreName), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply bundled CRD %s: %w", obj.GetName(), err)
+		}
+		if err := waitCRDEstablished(ctx, c, obj.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitCRDEstablished waits until the API server reports the CRD as Established.
+func waitCRDEstablished(ctx context.Context, c client.Client, name string) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, crdEstablishTimeout, true, func(ctx context.Context) (bool, error) {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			return false, nil //nolint:nilerr // keep polling until the timeout
+		}
+		for _, cond := range crd.Status.Conditions {
+			if cond.Type == crdv1.Established && cond.Status == crdv1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("CRD %s was not established after apply: %w", name, err)
+	}
+	return nil
+}
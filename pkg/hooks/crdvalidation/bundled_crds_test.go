@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Bundled CRDs", func() {
+	It("should embed the core CRDs", func() {
+		for _, req := range crdvalidation.CoreCRDRequirements() {
+			crd, ok, err := crdvalidation.BundledCRD(req.Name)
+			Expect(err).Should(Succeed())
+			Expect(ok).Should(BeTrue(), "CRD %s should be bundled", req.Name)
+			Expect(crd.GetKind()).Should(Equal("CustomResourceDefinition"))
+		}
+	})
+
+	It("should bundle the CRDs of the chart unchanged", func() {
+		chartCRDs, err := filepath.Glob("../../../charts/vela-core/crds/core.oam.dev_*.yaml")
+		Expect(err).Should(Succeed())
+		Expect(chartCRDs).ShouldNot(BeEmpty())
+		for _, chartCRD := range chartCRDs {
+			expected, err := os.ReadFile(chartCRD)
+			Expect(err).Should(Succeed())
+			bundled, err := os.ReadFile(filepath.Join("crds", filepath.Base(chartCRD)))
+			Expect(err).Should(Succeed(), "%s is not bundled, run `make manifests`", filepath.Base(chartCRD))
+			Expect(string(bundled)).Should(Equal(string(expected)), "%s differs from the chart, run `make manifests`", filepath.Base(chartCRD))
+		}
+	})
+
+	It("should not find CRDs that are not bundled", func() {
+		_, ok, err := crdvalidation.BundledCRD("widgets.example.com")
+		Expect(err).Should(Succeed())
+		Expect(ok).Should(BeFalse())
+	})
+
+	Context("with auto upgrade enabled", func() {
+		It("should install a missing core CRD from the bundled manifests", func() {
+			ctx := context.Background()
+			reqs := []crdvalidation.CRDRequirement{
+				{Name: "workflowstepdefinitions.core.oam.dev", RequiredFields: []string{"spec.schematic"}},
+			}
+			Expect(crdvalidation.ValidateCRDs(ctx, singleton.KubeClient.Get(), reqs)).ShouldNot(Succeed())
+
+			hook := crdvalidation.NewHookWithOptions(singleton.KubeClient.Get(), crdvalidation.Options{
+				CRDs:            reqs,
+				AutoUpgradeCRDs: true,
+			})
+			Expect(hook.Run(ctx)).Should(Succeed())
+			Expect(crdvalidation.ValidateCRDs(ctx, singleton.KubeClient.Get(), reqs)).Should(Succeed())
+		})
+
+		It("should fail for CRDs that are not bundled", func() {
+			hook := crdvalidation.NewHookWithOptions(singleton.KubeClient.Get(), crdvalidation.Options{
+				CRDs:            []crdvalidation.CRDRequirement{{Name: "widgets.example.com"}},
+				AutoUpgradeCRDs: true,
+			})
+			err := hook.Run(context.Background())
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("is not bundled with vela-core"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubevela/pkg/util/compression"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+)
+
+// compressedListPageSize bounds the objects listed per request while looking
+// for compressed objects.
+const compressedListPageSize = 100
+
+// compressionGates maps the kinds stored compressed to the feature gate of
+// every compression type.
+var compressionGates = []struct {
+	kind  string
+	gates map[compression.Type]featuregate.Feature
+}{
+	{kind: v1beta1.ApplicationRevisionKind, gates: map[compression.Type]featuregate.Feature{
+		compression.Zstd: features.ZstdApplicationRevision,
+		compression.Gzip: features.GzipApplicationRevision,
+	}},
+	{kind: v1beta1.ResourceTrackerKind, gates: map[compression.Type]featuregate.Feature{
+		compression.Zstd: features.ZstdResourceTracker,
+		compression.Gzip: features.GzipResourceTracker,
+	}},
+}
+
+// CompressedObject is an object stored with a compression whose feature gate
+// is disabled.
+type CompressedObject struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	Compression compression.Type
+	Feature     featuregate.Feature
+}
+
+// String returns a human readable description of the object.
+func (o CompressedObject) String() string {
+	return fmt.Sprintf("%s %s/%s is stored with %s compression but the %s feature gate is disabled",
+		o.Kind, o.Namespace, o.Name, o.Compression, o.Feature)
+}
+
+// DetectCompressedObjects looks for ApplicationRevisions and ResourceTrackers
+// stored with a compression whose feature gate is disabled according to
+// enabled, which happens when the gate was turned off after being enabled.
+// Such objects stay compressed until they are rewritten and can no longer be
+// read once vela-core is rolled back to a release without compression
+// support. One object is returned per kind and compression type.
+func DetectCompressedObjects(ctx context.Context, c client.Client, enabled func(featuregate.Feature) bool) ([]CompressedObject, error) {
+	var objects []CompressedObject
+	for _, kg := range compressionGates {
+		disabled := map[compression.Type]featuregate.Feature{}
+		for typ, gate := range kg.gates {
+			if !enabled(gate) {
+				disabled[typ] = gate
+			}
+		}
+		for cont := ""; len(disabled) > 0; {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(kg.kind + "List"))
+			if err := c.List(ctx, list, client.Limit(compressedListPageSize), client.Continue(cont)); err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", kg.kind, err)
+			}
+			for _, item := range list.Items {
+				typ, _, _ := unstructured.NestedString(item.Object, "spec", "compression", "type")
+				gate, ok := disabled[compression.Type(typ)]
+				if !ok {
+					continue
+				}
+				objects = append(objects, CompressedObject{Kind: kg.kind, Namespace: item.GetNamespace(), Name: item.GetName(),
+					Compression: compression.Type(typ), Feature: gate})
+				delete(disabled, compression.Type(typ))
+			}
+			if cont = list.GetContinue(); cont == "" {
+				break
+			}
+		}
+	}
+	return objects, nil
+}
+
+// compressedObjectsToError joins objects into a single error, or returns nil if there is none.
+func compressedObjectsToError(objects []CompressedObject) error {
+	if len(objects) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(objects))
+	for _, o := range objects {
+		msgs = append(msgs, o.String())
+	}
+	return fmt.Errorf("%s. Re-enable the feature gates until these objects are rewritten uncompressed", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Compression downgrade", func() {
+	cli := &compressedListClient{
+		Client: fake.NewClientBuilder().WithScheme(velacommon.Scheme).Build(),
+		items: map[string][]string{
+			v1beta1.ApplicationRevisionKind + "List": {"", "zstd", "zstd", "gzip"},
+			v1beta1.ResourceTrackerKind + "List":     {"gzip"},
+		},
+	}
+
+	It("should report objects compressed with a disabled compression", func() {
+		enabled := func(f featuregate.Feature) bool { return f == features.GzipApplicationRevision }
+		objects, err := crdvalidation.DetectCompressedObjects(context.Background(), cli, enabled)
+		Expect(err).Should(Succeed())
+		Expect(objects).Should(HaveLen(2))
+		Expect(objects[0].Kind).Should(Equal(v1beta1.ApplicationRevisionKind))
+		Expect(objects[0].Name).Should(Equal("obj-1"))
+		Expect(objects[0].Feature).Should(Equal(features.ZstdApplicationRevision))
+		Expect(objects[1].Kind).Should(Equal(v1beta1.ResourceTrackerKind))
+		Expect(objects[1].String()).Should(ContainSubstring("the GzipResourceTracker feature gate is disabled"))
+	})
+
+	It("should not report anything when the compressions are enabled", func() {
+		objects, err := crdvalidation.DetectCompressedObjects(context.Background(), cli, func(featuregate.Feature) bool { return true })
+		Expect(err).Should(Succeed())
+		Expect(objects).Should(BeEmpty())
+	})
+})
+
+// compressedListClient returns objects stored with the given compression types
+// when listing unstructured objects of the given list kinds.
+type compressedListClient struct {
+	client.Client
+	items map[string][]string
+}
+
+func (c *compressedListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ul, ok := list.(*unstructured.UnstructuredList)
+	if !ok {
+		return c.Client.List(ctx, list, opts...)
+	}
+	for i, typ := range c.items[ul.GetKind()] {
+		item := unstructured.Unstructured{Object: map[string]interface{}{}}
+		item.SetNamespace("default")
+		item.SetName(fmt.Sprintf("obj-%d", i))
+		if typ != "" {
+			Expect(unstructured.SetNestedField(item.Object, typ, "spec", "compression", "type")).Should(Succeed())
+		}
+		ul.Items = append(ul.Items, item)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateConversionWebhooks checks every named CRD that uses a conversion
+// webhook: the webhook service must exist, the CA bundle must contain at least
+// one unexpired certificate and objects must convert between the storage
+// version and every other served version. CRDs without a conversion webhook
+// or that are not installed are skipped. The test objects are written as
+// configured by objs.
+func ValidateConversionWebhooks(ctx context.Context, c client.Client, names []string, objs TestObjects) error {
+	objs = objs.WithDefaults()
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		conv := crd.Spec.Conversion
+		if conv == nil || conv.Strategy != crdv1.WebhookConverter || conv.Webhook == nil || conv.Webhook.ClientConfig == nil {
+			continue
+		}
+		klog.V(2).InfoS("Validating conversion webhook", "crd", name)
+		cc := conv.Webhook.ClientConfig
+		if cc.Service != nil {
+			svc := &corev1.Service{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: cc.Service.Namespace, Name: cc.Service.Name}, svc); err != nil {
+				return fmt.Errorf("conversion webhook service %s/%s of CRD %s is not available: %w", cc.Service.Namespace, cc.Service.Name, name, err)
+			}
+		}
+		if err := validateCABundle(cc.CABundle, time.Now()); err != nil {
+			return fmt.Errorf("conversion webhook of CRD %s has an invalid CA bundle: %w", name, err)
+		}
+		if err := conversionRoundTrip(ctx, c, crd, objs); err != nil {
+			return fmt.Errorf("conversion webhook of CRD %s is not working: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateCABundle checks that the bundle contains at least one certificate and
+// that none of them is expired or not yet valid at now.
+func validateCABundle(bundle []byte, now time.Time) error {
+	if len(bundle) == 0 {
+		return fmt.Errorf("caBundle is empty")
+	}
+	count := 0
+	for rest := bundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %q expired at %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		}
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %q is not valid before %s", cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339))
+		}
+		count++
+	}
+	if count == 0 {
+		return fmt.Errorf("caBundle contains no PEM encoded certificate")
+	}
+	return nil
+}
+
+// conversionRoundTrip creates a minimal object in the storage version and reads
+// it back through every other served version, which makes the API server call
+// the conversion webhook in both directions. If the minimal object is rejected
+// by the schema, existing objects are listed through each version instead.
+func conversionRoundTrip(ctx context.Context, c client.Client, crd *crdv1.CustomResourceDefinition, objs TestObjects) error {
+	var storage string
+	var others []string
+	for _, v := range crd.Spec.Versions {
+		switch {
+		case v.Storage:
+			storage = v.Name
+		case v.Served:
+			others = append(others, v.Name)
+		}
+	}
+	if storage == "" || len(others) == 0 {
+		return nil
+	}
+
+	gvk := func(version string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(crd.Spec.Group + "/" + version)
+		u.SetKind(crd.Spec.Names.Kind)
+		return u
+	}
+
+	obj := gvk(storage)
+	objs.prepare(&obj, crd.Spec.Scope == crdv1.NamespaceScoped)
+	if err := c.Create(ctx, &obj); err != nil {
+		if !apierrors.IsInvalid(err) && !apierrors.IsBadRequest(err) {
+			return fmt.Errorf("failed to create test object in version %s: %w", storage, err)
+		}
+		klog.V(2).InfoS("Minimal test object rejected, falling back to list conversion", "crd", crd.Name, "reason", err.Error())
+		for _, version := range others {
+			list := &unstructured.UnstructuredList{}
+			l := gvk(version)
+			list.SetAPIVersion(l.GetAPIVersion())
+			list.SetKind(l.GetKind() + "List")
+			if err := c.List(ctx, list, client.Limit(1)); err != nil {
+				return fmt.Errorf("failed to list objects in version %s: %w", version, err)
+			}
+		}
+		return nil
+	}
+	defer func() {
+		if err := c.Delete(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to clean up conversion test object", "crd", crd.Name, "name", obj.GetName())
+		}
+	}()
+
+	for _, version := range others {
+		converted := gvk(version)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(&obj), &converted); err != nil {
+			return fmt.Errorf("failed to read test object in version %s: %w", version, err)
+		}
+		if converted.GetName() != obj.GetName() {
+			return fmt.Errorf("test object lost its name when converted to version %s", version)
+		}
+	}
+	return nil
+}
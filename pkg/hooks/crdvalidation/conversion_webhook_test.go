@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Conversion webhook validation", func() {
+	It("should skip CRDs without a conversion webhook", func() {
+		Expect(crdvalidation.ValidateConversionWebhooks(context.Background(), singleton.KubeClient.Get(),
+			[]string{"applicationrevisions.core.oam.dev", "widgets.example.com"}, crdvalidation.TestObjects{})).Should(Succeed())
+	})
+
+	It("should report a missing webhook service and an empty CA bundle", func() {
+		ctx := context.Background()
+		cli := singleton.KubeClient.Get()
+		Expect(k8s.EnsureNamespace(ctx, cli, types.DefaultKubeVelaNS)).Should(Succeed())
+
+		schema := &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+			Type: "object", XPreserveUnknownFields: ptr.To(true),
+		}}
+		crd := &crdv1.CustomResourceDefinition{
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group: "precheck.oam.dev",
+				Names: crdv1.CustomResourceDefinitionNames{Plural: "gadgets", Singular: "gadget", Kind: "Gadget", ListKind: "GadgetList"},
+				Scope: crdv1.NamespaceScoped,
+				Versions: []crdv1.CustomResourceDefinitionVersion{
+					{Name: "v1", Served: true, Storage: true, Schema: schema},
+					{Name: "v2", Served: true, Schema: schema},
+				},
+				Conversion: &crdv1.CustomResourceConversion{
+					Strategy: crdv1.WebhookConverter,
+					Webhook: &crdv1.WebhookConversion{
+						ConversionReviewVersions: []string{"v1"},
+						ClientConfig: &crdv1.WebhookClientConfig{
+							Service: &crdv1.ServiceReference{Namespace: types.DefaultKubeVelaNS, Name: "gadget-conversion", Path: ptr.To("/convert")},
+						},
+					},
+				},
+			},
+		}
+		crd.Name = "gadgets.precheck.oam.dev"
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		Expect(err).Should(Succeed())
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetAPIVersion("apiextensions.k8s.io/v1")
+		u.SetKind("CustomResourceDefinition")
+		Expect(cli.Create(ctx, u)).Should(Succeed())
+		defer func() { Expect(cli.Delete(ctx, u)).Should(Succeed()) }()
+
+		err = crdvalidation.ValidateConversionWebhooks(ctx, cli, []string{crd.Name}, crdvalidation.TestObjects{})
+		Expect(err).ShouldNot(Succeed())
+		Expect(err.Error()).Should(ContainSubstring("conversion webhook service vela-system/gadget-conversion"))
+
+		svc := &corev1.Service{}
+		svc.Name = "gadget-conversion"
+		svc.Namespace = types.DefaultKubeVelaNS
+		svc.Spec.Ports = []corev1.ServicePort{{Port: 443}}
+		Expect(cli.Create(ctx, svc)).Should(Succeed())
+		defer func() { Expect(cli.Delete(ctx, svc)).Should(Succeed()) }()
+
+		err = crdvalidation.ValidateConversionWebhooks(ctx, cli, []string{crd.Name}, crdvalidation.TestObjects{})
+		Expect(err).ShouldNot(Succeed())
+		Expect(err.Error()).Should(ContainSubstring("caBundle is empty"))
+	})
+})
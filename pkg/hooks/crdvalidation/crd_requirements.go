@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigMapCRDsKey is the key in the precheck ConfigMap holding the CRD requirement list.
+const ConfigMapCRDsKey = "crds"
+
+// CRDRequirement describes a CRD that must be installed in the cluster before the
+// controller starts, together with the schema fields the controller relies on.
+type CRDRequirement struct {
+	// Name is the full CRD name, e.g. applications.core.oam.dev
+	Name string `json:"name"`
+	// RequiredFields are dot-separated property paths (e.g. spec.components) that
+	// must be declared in the schema of every served version of the CRD.
+	RequiredFields []string `json:"requiredFields,omitempty"`
+	// RequiredRules are dot-separated property paths that must carry at least one
+	// x-kubernetes-validations rule in every served version of the CRD. An empty
+	// path refers to the root of the schema.
+	RequiredRules []string `json:"requiredRules,omitempty"`
+	// Skip removes the CRD from the validated set. It is only meaningful in overrides.
+	Skip bool `json:"skip,omitempty"`
+}
+
+// CoreCRDRequirements returns the CRDs shipped with vela-core that are validated by default.
+func CoreCRDRequirements() []CRDRequirement {
+	return []CRDRequirement{
+		{Name: "applications.core.oam.dev", RequiredFields: []string{"spec.components", "spec.policies", "spec.workflow"}},
+		{Name: "componentdefinitions.core.oam.dev", RequiredFields: []string{"spec.workload", "spec.schematic", "spec.status"}},
+		{Name: "traitdefinitions.core.oam.dev", RequiredFields: []string{"spec.appliesToWorkloads", "spec.schematic", "spec.status"}},
+		{Name: "policydefinitions.core.oam.dev", RequiredFields: []string{"spec.schematic"}},
+		{Name: "workflowstepdefinitions.core.oam.dev", RequiredFields: []string{"spec.schematic"}},
+		{Name: "resourcetrackers.core.oam.dev", RequiredFields: []string{"spec.managedResources", "spec.compression"}},
+		// Revisions snapshot definitions, a field missing here is silently dropped on upgrade.
+		{Name: "applicationrevisions.core.oam.dev", RequiredFields: []string{"spec.application", "spec.componentDefinitions",
+			"spec.traitDefinitions", "spec.policyDefinitions", "spec.workflowStepDefinitions", "spec.policies", "spec.workflow"}},
+		{Name: "definitionrevisions.core.oam.dev", RequiredFields: []string{"spec.revision", "spec.revisionHash", "spec.definitionType",
+			"spec.componentDefinition", "spec.traitDefinition", "spec.policyDefinition", "spec.workflowStepDefinition"}},
+	}
+}
+
+// ParseCRDRequirements applies the --precheck-crds flag entries on top of base.
+// Each entry takes one of the following forms:
+//
+//	<crd-name>                      validate that the CRD exists
+//	<crd-name>=<field>;<field>...   validate the CRD and the listed schema fields
+//	-<crd-name>                     remove the CRD from the validated set
+//
+// An entry naming a CRD already present in base replaces it.
+func ParseCRDRequirements(base []CRDRequirement, specs []string) ([]CRDRequirement, error) {
+	overrides := make([]CRDRequirement, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		if name, ok := strings.CutPrefix(spec, "-"); ok {
+			overrides = append(overrides, CRDRequirement{Name: name, Skip: true})
+			continue
+		}
+		name, fields, _ := strings.Cut(spec, "=")
+		req := CRDRequirement{Name: name}
+		for _, f := range strings.Split(fields, ";") {
+			if f = strings.TrimSpace(f); f != "" {
+				req.RequiredFields = append(req.RequiredFields, f)
+			}
+		}
+		overrides = append(overrides, req)
+	}
+	return MergeCRDRequirements(base, overrides)
+}
+
+// MergeCRDRequirements returns base with overrides applied in order. An override
+// with Skip set removes the CRD, any other override adds or replaces it.
+func MergeCRDRequirements(base, overrides []CRDRequirement) ([]CRDRequirement, error) {
+	merged := append([]CRDRequirement{}, base...)
+	for _, o := range overrides {
+		if o.Name == "" || !strings.Contains(o.Name, ".") {
+			return nil, fmt.Errorf("invalid CRD name %q: must be of the form <plural>.<group>", o.Name)
+		}
+		idx := -1
+		for i := range merged {
+			if merged[i].Name == o.Name {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case o.Skip && idx >= 0:
+			merged = append(merged[:idx], merged[idx+1:]...)
+		case o.Skip:
+			klog.V(2).InfoS("Ignoring skip for CRD not in validation list", "crd", o.Name)
+		case idx >= 0:
+			merged[idx] = o
+		default:
+			merged = append(merged, o)
+		}
+	}
+	return merged, nil
+}
+
+// LoadCRDRequirementsFromConfigMap applies the overrides stored in the given
+// ConfigMap on top of base. A missing ConfigMap is not an error.
+func LoadCRDRequirementsFromConfigMap(ctx context.Context, c client.Client, namespace, name string, base []CRDRequirement) ([]CRDRequirement, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Precheck ConfigMap not found, using configured CRD list", "namespace", namespace, "name", name)
+			return base, nil
+		}
+		return nil, fmt.Errorf("failed to get precheck ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	raw, ok := cm.Data[ConfigMapCRDsKey]
+	if !ok {
+		return base, nil
+	}
+	var overrides []CRDRequirement
+	if err := yaml.Unmarshal([]byte(raw), &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse key %q of precheck ConfigMap %s/%s: %w", ConfigMapCRDsKey, namespace, name, err)
+	}
+	return MergeCRDRequirements(base, overrides)
+}
+
+// ValidateCoreCRDs validates the CRDs shipped with vela-core.
+func ValidateCoreCRDs(ctx context.Context, c client.Client) error {
+	return ValidateCRDs(ctx, c, CoreCRDRequirements())
+}
+
+// ValidateCRDs checks that every CRD in reqs is installed and that each served
+// version declares all of its RequiredFields. All problems are collected and
+// reported together so that a single restart is enough to see what is missing.
+func ValidateCRDs(ctx context.Context, c client.Client, reqs []CRDRequirement) error {
+	problems, err := checkCRDs(ctx, c, reqs)
+	if err != nil {
+		return err
+	}
+	return problemsToError(problems)
+}
+
+// crdProblem describes why a single CRD failed validation.
+type crdProblem struct {
+	name    string
+	message string
+}
+
+// checkCRDs returns the problems found for each CRD in reqs. An error is only
+// returned when the API server could not be queried.
+func checkCRDs(ctx context.Context, c client.Client, reqs []CRDRequirement) ([]crdProblem, error) {
+	var problems []crdProblem
+	for _, req := range reqs {
+		klog.V(2).InfoS("Validating CRD", "crd", req.Name, "requiredFields", req.RequiredFields)
+		crd, err := getCRD(ctx, c, req.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				problems = append(problems, crdProblem{name: req.Name, message: fmt.Sprintf("CRD %s is not installed", req.Name)})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", req.Name, err)
+		}
+		for _, v := range crd.Spec.Versions {
+			if !v.Served {
+				continue
+			}
+			if missing := missingFields(v.Schema, req.RequiredFields); len(missing) > 0 {
+				problems = append(problems, crdProblem{name: req.Name,
+					message: fmt.Sprintf("CRD %s version %s is missing fields %s", req.Name, v.Name, strings.Join(missing, ", "))})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// problemsToError joins the problems into a single error, or returns nil if there are none.
+func problemsToError(problems []crdProblem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(problems))
+	for _, p := range problems {
+		msgs = append(msgs, p.message)
+	}
+	return fmt.Errorf("%s. Please upgrade your CRDs to the latest ones", strings.Join(msgs, "; "))
+}
+
+// getCRD reads a CRD through an unstructured object so that the client scheme
+// does not need to register the apiextensions types.
+func getCRD(ctx context.Context, c client.Client, name string) (*crdv1.CustomResourceDefinition, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(crdv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, u); err != nil {
+		return nil, err
+	}
+	crd := &crdv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to convert CRD %s: %w", name, err)
+	}
+	return crd, nil
+}
+
+// missingFields returns the entries of fields that are not declared in schema.
+func missingFields(schema *crdv1.CustomResourceValidation, fields []string) []string {
+	var missing []string
+	for _, f := range fields {
+		if schema == nil || schema.OpenAPIV3Schema == nil || !hasField(schema.OpenAPIV3Schema, strings.Split(f, ".")) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// hasField walks the property path through the schema. Array items are
+// traversed transparently and x-kubernetes-preserve-unknown-fields accepts any
+// remaining path since such fields survive a round-trip.
+func hasField(s *crdv1.JSONSchemaProps, path []string) bool {
+	for s != nil && s.Type == "array" && s.Items != nil {
+		s = s.Items.Schema
+	}
+	if s == nil {
+		return false
+	}
+	if len(path) == 0 {
+		return true
+	}
+	if prop, ok := s.Properties[path[0]]; ok {
+		return hasField(&prop, path[1:])
+	}
+	return s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("CRD requirements", func() {
+	Context("ParseCRDRequirements", func() {
+		It("should add, replace and remove CRDs from the base list", func() {
+			base := []crdvalidation.CRDRequirement{
+				{Name: "applications.core.oam.dev", RequiredFields: []string{"spec.components"}},
+				{Name: "traitdefinitions.core.oam.dev"},
+			}
+			reqs, err := crdvalidation.ParseCRDRequirements(base, []string{
+				"-traitdefinitions.core.oam.dev",
+				"applications.core.oam.dev=spec.components;spec.workflow",
+				"widgets.example.com",
+			})
+			Expect(err).Should(Succeed())
+			Expect(reqs).Should(Equal([]crdvalidation.CRDRequirement{
+				{Name: "applications.core.oam.dev", RequiredFields: []string{"spec.components", "spec.workflow"}},
+				{Name: "widgets.example.com"},
+			}))
+			// base must not be modified
+			Expect(base).Should(HaveLen(2))
+		})
+
+		It("should reject invalid CRD names", func() {
+			_, err := crdvalidation.ParseCRDRequirements(nil, []string{"widgets"})
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("invalid CRD name"))
+		})
+	})
+
+	Context("ValidateCRDs", func() {
+		It("should pass when required fields are declared", func() {
+			err := crdvalidation.ValidateCRDs(context.Background(), singleton.KubeClient.Get(), []crdvalidation.CRDRequirement{
+				{Name: "applicationrevisions.core.oam.dev", RequiredFields: []string{"spec.application", "spec.traitDefinitions"}},
+			})
+			Expect(err).Should(Succeed())
+		})
+
+		It("should report missing CRDs and fields together", func() {
+			err := crdvalidation.ValidateCRDs(context.Background(), singleton.KubeClient.Get(), []crdvalidation.CRDRequirement{
+				{Name: "applicationrevisions.core.oam.dev", RequiredFields: []string{"spec.compression"}},
+				{Name: "widgets.example.com"},
+			})
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("CRD applicationrevisions.core.oam.dev version v1beta1 is missing fields spec.compression"))
+			Expect(err.Error()).Should(ContainSubstring("CRD widgets.example.com is not installed"))
+		})
+	})
+
+	Context("ValidateCoreCRDs", func() {
+		It("should cover the revision CRDs", func() {
+			names := []string{}
+			for _, req := range crdvalidation.CoreCRDRequirements() {
+				names = append(names, req.Name)
+			}
+			Expect(names).Should(ContainElements("applicationrevisions.core.oam.dev", "definitionrevisions.core.oam.dev"))
+		})
+
+		It("should report outdated revision CRDs", func() {
+			ctx := context.Background()
+			cli := singleton.KubeClient.Get()
+			// A DefinitionRevision CRD predating the policy and workflow step definitions
+			crd := &crdv1.CustomResourceDefinition{
+				Spec: crdv1.CustomResourceDefinitionSpec{
+					Group: "core.oam.dev",
+					Names: crdv1.CustomResourceDefinitionNames{Plural: "definitionrevisions", Kind: "DefinitionRevision", ListKind: "DefinitionRevisionList"},
+					Scope: crdv1.NamespaceScoped,
+					Versions: []crdv1.CustomResourceDefinitionVersion{{
+						Name: "v1beta1", Served: true, Storage: true,
+						Schema: &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]crdv1.JSONSchemaProps{
+								"spec": {Type: "object", Properties: map[string]crdv1.JSONSchemaProps{
+									"revision":            {Type: "integer"},
+									"revisionHash":        {Type: "string"},
+									"definitionType":      {Type: "string"},
+									"componentDefinition": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+									"traitDefinition":     {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+								}},
+							},
+						}},
+					}},
+				},
+			}
+			crd.Name = "definitionrevisions.core.oam.dev"
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+			Expect(err).Should(Succeed())
+			u := &unstructured.Unstructured{Object: obj}
+			u.SetAPIVersion("apiextensions.k8s.io/v1")
+			u.SetKind("CustomResourceDefinition")
+			Expect(cli.Create(ctx, u)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(cli.Delete(context.Background(), u))).Should(Succeed())
+			})
+
+			Eventually(func(g Gomega) {
+				err := crdvalidation.ValidateCoreCRDs(ctx, cli)
+				g.Expect(err).ShouldNot(Succeed())
+				g.Expect(err.Error()).Should(ContainSubstring(
+					"CRD definitionrevisions.core.oam.dev version v1beta1 is missing fields spec.policyDefinition, spec.workflowStepDefinition"))
+			}).Should(Succeed())
+		})
+	})
+
+	Context("with precheck ConfigMap", func() {
+		It("should apply the ConfigMap overrides on top of the configured list", func() {
+			ctx := context.Background()
+			cli := singleton.KubeClient.Get()
+			Expect(k8s.EnsureNamespace(ctx, cli, types.DefaultKubeVelaNS)).Should(Succeed())
+
+			cm := &corev1.ConfigMap{}
+			cm.Name = "precheck-crds"
+			cm.Namespace = types.DefaultKubeVelaNS
+			cm.Data = map[string]string{crdvalidation.ConfigMapCRDsKey: `
+- name: widgets.example.com
+  skip: true
+- name: applicationrevisions.core.oam.dev
+  requiredFields: [spec.application]
+`}
+			Expect(cli.Create(ctx, cm)).Should(Succeed())
+			defer func() { Expect(cli.Delete(ctx, cm)).Should(Succeed()) }()
+
+			reqs, err := crdvalidation.LoadCRDRequirementsFromConfigMap(ctx, cli, types.DefaultKubeVelaNS, cm.Name,
+				[]crdvalidation.CRDRequirement{{Name: "widgets.example.com"}})
+			Expect(err).Should(Succeed())
+			Expect(reqs).Should(Equal([]crdvalidation.CRDRequirement{
+				{Name: "applicationrevisions.core.oam.dev", RequiredFields: []string{"spec.application"}},
+			}))
+		})
+
+		It("should fall back to the configured list when the ConfigMap is absent", func() {
+			base := []crdvalidation.CRDRequirement{{Name: "widgets.example.com"}}
+			reqs, err := crdvalidation.LoadCRDRequirementsFromConfigMap(context.Background(), singleton.KubeClient.Get(),
+				types.DefaultKubeVelaNS, "not-exist", base)
+			Expect(err).Should(Succeed())
+			Expect(reqs).Should(Equal(base))
+		})
+	})
+})
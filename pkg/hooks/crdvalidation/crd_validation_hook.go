@@ -0,0 +1,700 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kubevela/pkg/util/compression"
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/version"
+)
+
+// applicationRevisionCRDName is the CRD exercised by the compression round-trip test.
+const applicationRevisionCRDName = "applicationrevisions.core.oam.dev"
+
+// crdValidationTimeout bounds a run of the hook.
+const crdValidationTimeout = 2 * time.Minute
+
+// upgradeCRDsRemediation is the remediation hint of outdated CRDs.
+const upgradeCRDsRemediation = "Upgrade the KubeVela CRDs to the ones of the controller release, e.g. by upgrading the " +
+	"Helm chart, or start the controller with --precheck-auto-upgrade-crds"
+
+// Hook validates that CRDs installed in the cluster are compatible with
+// enabled feature gates. This prevents silent data corruption by failing
+// fast at startup if CRDs are out of date.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// Options configures the optional checks performed by the CRD validation hook.
+type Options struct {
+	// Namespace is the runtime namespace of vela-core, which holds ConfigMap
+	// and FingerprintConfigMap. It defaults to the namespace of the running pod.
+	Namespace string
+	// CRDs lists the CRDs whose presence and schema fields are validated.
+	// When empty, only the compression round-trip check is performed.
+	CRDs []CRDRequirement
+	// ConfigMap is the name of a ConfigMap in the runtime namespace whose
+	// "crds" key extends or shrinks CRDs. It is read on every run.
+	ConfigMap string
+	// AutoUpgradeCRDs makes the hook apply the CRD manifests bundled in the
+	// binary when a CRD is missing or outdated, instead of failing right away.
+	AutoUpgradeCRDs bool
+	// DetectSchemaDrift compares the installed schema of every bundled CRD in
+	// CRDs with the bundled schema and fails on removed or changed fields.
+	DetectSchemaDrift bool
+	// CheckVersionCompatibility compares the served versions of every CRD in
+	// CRDs with its storage version and fails on changed types or removed
+	// required fields.
+	CheckVersionCompatibility bool
+	// CheckConversionWebhooks verifies the conversion webhook of every CRD in
+	// CRDs that declares one.
+	CheckConversionWebhooks bool
+	// DefinitionRoundTrip runs create/get/delete round-trips for TraitDefinition,
+	// PolicyDefinition and WorkflowStepDefinition when RBAC permits them.
+	DefinitionRoundTrip bool
+	// VerifyFieldPruning checks that every CRD in CRDs prunes unknown fields.
+	VerifyFieldPruning bool
+	// MigrateStoredVersions creates a StorageVersionMigration for every CRD in
+	// CRDs that still stores objects in a deprecated or unserved version. Such
+	// CRDs are only logged when it is false.
+	MigrateStoredVersions bool
+	// CheckValidationRules verifies that the CRDs in CRDs declare the expected
+	// x-kubernetes-validations rules and that all declared rules compile.
+	CheckValidationRules bool
+	// EnforceSchemaBestPractices fails the hook on the SeverityWarn findings of
+	// the registered schema checks, e.g. when the printer columns, categories
+	// or subresources of a CRD in CRDs fall behind the bundled CRD. Such
+	// findings are only logged with a suggested patch when it is false.
+	EnforceSchemaBestPractices bool
+	// ScaleTraits are the traits requiring the /scale subresource on the CRDs
+	// used as workloads of the component definitions they apply to. CRDs
+	// lacking it are reported like the schema best practices.
+	ScaleTraits []string
+	// ControllerVersion is the release of the running controller the release
+	// labels of the CRDs in CRDs are compared with. It defaults to the version
+	// of this binary.
+	ControllerVersion string
+	// BlockVersionSkew fails the hook when a CRD in CRDs was installed by a
+	// release more than MaxCRDMinorSkew minor versions away from the
+	// controller. Such CRDs are only logged when it is false.
+	BlockVersionSkew bool
+	// DetectCompressionDowngrade looks for ApplicationRevisions and
+	// ResourceTrackers stored with a compression whose feature gate is
+	// disabled. They are logged unless BlockCompressionDowngrade is set.
+	DetectCompressionDowngrade bool
+	// BlockCompressionDowngrade fails the hook on the objects found by
+	// DetectCompressionDowngrade.
+	BlockCompressionDowngrade bool
+	// DryRunComponents is the number of components of the representative
+	// Application and ApplicationRevision created with a server-side dry-run
+	// to catch size limits, CEL rejections and webhook interference. Zero
+	// disables the dry-run.
+	DryRunComponents int
+	// Concurrency bounds the number of CRDs validated at once. CRDs are
+	// validated one after another when it is not positive.
+	Concurrency int
+	// QPS bounds the number of CRD validations started per second to spare
+	// slow API servers. Zero disables the throttling.
+	QPS float32
+	// PreCheckTTL is the age after which the test objects of the round-trips
+	// are deleted at startup, if a previous run died before cleaning them up.
+	// It defaults to DefaultPreCheckTTL.
+	PreCheckTTL time.Duration
+	// TestObjects configures the namespace and the name prefix of the test
+	// objects written by the round-trips.
+	TestObjects TestObjects
+	// RoundTripsOnLeader leaves the round-trips writing test objects, and the
+	// clean up of their leaked objects, to the RoundTripHook so that they run
+	// on the leader only instead of on every replica. The compression
+	// round-trip still runs on every replica before the controllers start.
+	RoundTripsOnLeader bool
+	// FingerprintConfigMap is the name of a ConfigMap in the runtime namespace
+	// the fingerprint of the environment of the last successful run is stored
+	// in. The round-trips and the dry-run are skipped while the CRDs, the
+	// enabled feature gates and the controller version are unchanged. Empty
+	// disables the cache.
+	FingerprintConfigMap string
+}
+
+// NewHook creates a new CRD validation hook with the default singleton client
+func NewHook() hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing CRD validation hook", "client", "singleton")
+	return NewHookWithClient(singleton.KubeClient.Get())
+}
+
+// NewHookWithClient creates a new CRD validation hook with a specified client
+// for improved testability and dependency injection
+func NewHookWithClient(c client.Client) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing CRD validation hook with custom client")
+	return &Hook{Client: c}
+}
+
+// NewHookWithOptions creates a new CRD validation hook with a specified client
+// and the CRD list configured by the operator
+func NewHookWithOptions(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing CRD validation hook with options",
+		"namespace", opts.Namespace,
+		"crds", len(opts.CRDs),
+		"configMap", opts.ConfigMap,
+		"autoUpgradeCRDs", opts.AutoUpgradeCRDs,
+		"detectSchemaDrift", opts.DetectSchemaDrift,
+		"checkVersionCompatibility", opts.CheckVersionCompatibility,
+		"checkConversionWebhooks", opts.CheckConversionWebhooks,
+		"definitionRoundTrip", opts.DefinitionRoundTrip,
+		"verifyFieldPruning", opts.VerifyFieldPruning,
+		"migrateStoredVersions", opts.MigrateStoredVersions,
+		"checkValidationRules", opts.CheckValidationRules,
+		"enforceSchemaBestPractices", opts.EnforceSchemaBestPractices,
+		"scaleTraits", opts.ScaleTraits,
+		"blockVersionSkew", opts.BlockVersionSkew,
+		"detectCompressionDowngrade", opts.DetectCompressionDowngrade,
+		"blockCompressionDowngrade", opts.BlockCompressionDowngrade,
+		"dryRunComponents", opts.DryRunComponents,
+		"concurrency", opts.Concurrency,
+		"qps", opts.QPS,
+		"preCheckTTL", opts.PreCheckTTL,
+		"roundTripsOnLeader", opts.RoundTripsOnLeader,
+		"fingerprintConfigMap", opts.FingerprintConfigMap)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "CRDValidation"
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// DependsOn runs the hook once the API groups of the CRDs it validates are served.
+func (h *Hook) DependsOn() []string {
+	return []string{"APIAvailability"}
+}
+
+// Run executes the CRD validation logic. It first validates the configured CRD
+// list, then checks if compression-related feature gates are enabled and
+// validates that the ApplicationRevision CRD supports the required compression fields.
+// The round-trips writing test objects, except the compression round-trip,
+// are left to the RoundTripHook when RoundTripsOnLeader is set. All of them
+// are skipped together with the dry-run while the environment is unchanged
+// since the last successful run.
+func (h *Hook) Run(ctx context.Context) error {
+	klog.InfoS("Starting CRD validation hook")
+
+	// Add a reasonable timeout to prevent indefinite hanging while allowing
+	// sufficient time for slower clusters or API servers under load.
+	// 2 minutes should be more than enough for any reasonable cluster setup
+	// while still protecting against indefinite hangs.
+	ctx, cancel := context.WithTimeout(ctx, crdValidationTimeout)
+	defer cancel()
+
+	unchanged := h.environmentUnchanged(ctx)
+	roundTrips := !h.Options.RoundTripsOnLeader && !unchanged
+	if !h.Options.RoundTripsOnLeader {
+		h.sweepPreCheckObjects(ctx)
+	}
+
+	if err := h.validateCRDs(ctx, roundTrips); err != nil {
+		klog.ErrorS(err, "CRD schema validation failed")
+		return hooks.WithRemediation(fmt.Errorf("CRD validation failed: %w", err), upgradeCRDsRemediation)
+	}
+
+	if roundTrips {
+		if err := h.validateDefinitionRoundTrips(ctx); err != nil {
+			return err
+		}
+	}
+
+	if h.Options.DryRunComponents > 0 && !unchanged {
+		klog.InfoS("Validating representative objects with server-side dry-run", "components", h.Options.DryRunComponents)
+		if err := ValidateDryRun(ctx, h.Client, h.Options.TestObjects, h.Options.DryRunComponents); err != nil {
+			klog.ErrorS(err, "Dry-run validation failed")
+			return fmt.Errorf("CRD validation failed: %w", err)
+		}
+	}
+
+	if h.Options.DetectCompressionDowngrade {
+		if err := h.checkCompressionDowngrade(ctx); err != nil {
+			return fmt.Errorf("CRD validation failed: %w", err)
+		}
+	}
+
+	// The compression round-trip always gates the controllers, since they write
+	// compressed ResourceTrackers and ApplicationRevisions as soon as they start.
+	if !unchanged {
+		if err := h.validateCompression(ctx); err != nil {
+			return err
+		}
+	}
+	if h.Options.RoundTripsOnLeader {
+		klog.InfoS("CRD validation completed successfully, the other round-trips are left to the leader")
+		return nil
+	}
+	if roundTrips {
+		h.recordFingerprint(ctx)
+	}
+
+	klog.InfoS("CRD validation completed successfully")
+	return nil
+}
+
+// validateDefinitionRoundTrips runs the definition round-trips if enabled.
+func (h *Hook) validateDefinitionRoundTrips(ctx context.Context) error {
+	if !h.Options.DefinitionRoundTrip {
+		return nil
+	}
+	klog.InfoS("Validating definition CRDs with round-trip tests")
+	if err := ValidateDefinitionRoundTrips(ctx, h.Client, h.Options.TestObjects); err != nil {
+		klog.ErrorS(err, "Definition round-trip validation failed")
+		return hooks.WithRemediation(fmt.Errorf("CRD validation failed: %w", err), upgradeCRDsRemediation)
+	}
+	return nil
+}
+
+// validateCompression checks if compression-related feature gates are enabled
+// and validates that the ApplicationRevision CRD supports the required
+// compression fields with a round-trip.
+func (h *Hook) validateCompression(ctx context.Context) error {
+	zstdEnabled := feature.DefaultMutableFeatureGate.Enabled(features.ZstdApplicationRevision)
+	gzipEnabled := feature.DefaultMutableFeatureGate.Enabled(features.GzipApplicationRevision)
+
+	klog.V(2).InfoS("Checking compression feature gates",
+		"zstdEnabled", zstdEnabled,
+		"gzipEnabled", gzipEnabled)
+
+	if !zstdEnabled && !gzipEnabled {
+		klog.InfoS("No compression features enabled, skipping CRD validation")
+		return nil
+	}
+
+	klog.InfoS("Compression features enabled, validating ApplicationRevision CRD compatibility")
+
+	err := h.validateApplicationRevisionCRD(ctx, zstdEnabled, gzipEnabled)
+	if err != nil && h.Options.AutoUpgradeCRDs && ctx.Err() == nil {
+		klog.InfoS("ApplicationRevision CRD does not support compression, upgrading it from bundled manifests", "reason", err.Error())
+		if upgradeErr := applyBundledCRDs(ctx, h.Client, []string{applicationRevisionCRDName}); upgradeErr != nil {
+			klog.ErrorS(upgradeErr, "Failed to upgrade ApplicationRevision CRD")
+			return fmt.Errorf("CRD validation failed: %w; automatic upgrade failed: %w", err, upgradeErr)
+		}
+		err = h.validateApplicationRevisionCRD(ctx, zstdEnabled, gzipEnabled)
+	}
+	if err != nil {
+		// Check if the error was due to context timeout
+		if ctx.Err() == context.DeadlineExceeded {
+			klog.ErrorS(err, "CRD validation timed out - API server may be slow or unresponsive",
+				"timeout", crdValidationTimeout.String(),
+				"suggestion", "Check API server health and network connectivity")
+			return fmt.Errorf("CRD validation timed out after %v: %w. API server may be slow or under heavy load", crdValidationTimeout, err)
+		}
+		klog.ErrorS(err, "CRD validation failed")
+		return hooks.WithRemediation(fmt.Errorf("CRD validation failed: %w", err), upgradeCRDsRemediation)
+	}
+	return nil
+}
+
+// sweepPreCheckObjects deletes the round-trip test objects leaked by previous
+// runs. Failing to delete them is logged but does not fail the startup.
+func (h *Hook) sweepPreCheckObjects(ctx context.Context) {
+	deleted, err := SweepPreCheckObjects(ctx, h.Client, h.Options.TestObjects, crdNames(h.Options.CRDs), h.Options.PreCheckTTL)
+	if err != nil {
+		klog.ErrorS(err, "Failed to clean up leaked pre-check objects")
+	}
+	if deleted > 0 {
+		klog.InfoS("Cleaned up pre-check objects leaked by a previous run", "count", deleted)
+	}
+}
+
+// resolveCRDs returns the configured CRD list with the overrides from the
+// precheck ConfigMap if one is set.
+func (h *Hook) resolveCRDs(ctx context.Context) ([]CRDRequirement, error) {
+	if h.Options.ConfigMap == "" {
+		return h.Options.CRDs, nil
+	}
+	return LoadCRDRequirementsFromConfigMap(ctx, h.Client, h.namespace(), h.Options.ConfigMap, h.Options.CRDs)
+}
+
+// namespace returns the runtime namespace of vela-core the ConfigMaps of the
+// hook are read from and written to.
+func (h *Hook) namespace() string {
+	if h.Options.Namespace != "" {
+		return h.Options.Namespace
+	}
+	return k8s.GetRuntimeNamespace()
+}
+
+// validateCRDs resolves the configured CRD list, applying the overrides from the
+// precheck ConfigMap if one is set, and validates the presence and schema of each CRD.
+// The checks writing test objects only run if roundTrips is set.
+func (h *Hook) validateCRDs(ctx context.Context, roundTrips bool) error {
+	crds, err := h.resolveCRDs(ctx)
+	if err != nil {
+		return err
+	}
+	if len(crds) == 0 {
+		klog.V(2).InfoS("No CRDs configured for schema validation")
+		return nil
+	}
+	klog.InfoS("Validating installed CRDs", "count", len(crds))
+	problems, err := h.checkCRDs(ctx, crds)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		return h.validateCRDBehavior(ctx, crds, roundTrips)
+	}
+	if !h.Options.AutoUpgradeCRDs {
+		return problemsToError(problems)
+	}
+
+	names := make([]string, 0, len(problems))
+	for _, p := range problems {
+		if !slices.Contains(names, p.name) {
+			names = append(names, p.name)
+		}
+	}
+	klog.InfoS("Upgrading outdated CRDs from bundled manifests", "crds", names)
+	if err := applyBundledCRDs(ctx, h.Client, names); err != nil {
+		return fmt.Errorf("%w; automatic upgrade failed: %w", problemsToError(problems), err)
+	}
+	problems, err = h.checkCRDs(ctx, crds)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return problemsToError(problems)
+	}
+	return h.validateCRDBehavior(ctx, crds, roundTrips)
+}
+
+// validateCRDBehavior runs the enabled checks that exercise the API server with
+// objects of the configured CRDs. The conversion webhook and field pruning
+// checks write test objects and only run if roundTrips is set.
+func (h *Hook) validateCRDBehavior(ctx context.Context, crds []CRDRequirement, roundTrips bool) error {
+	names := crdNames(crds)
+	if h.Options.CheckConversionWebhooks && roundTrips {
+		if err := ValidateConversionWebhooks(ctx, h.Client, names, h.Options.TestObjects); err != nil {
+			return err
+		}
+	}
+	findings, err := RunSchemaChecks(ctx, h.Client, names)
+	if err != nil {
+		return err
+	}
+	scaleFindings, err := ValidateScaleSubresources(ctx, h.Client, h.Options.ScaleTraits)
+	if err != nil {
+		return err
+	}
+	findings = append(findings, scaleFindings...)
+	logSchemaFindings(findings)
+	if err := findingsToError(failingFindings(findings, h.Options.EnforceSchemaBestPractices)); err != nil {
+		return err
+	}
+	skews, err := CheckVersionSkew(ctx, h.Client, names, h.controllerVersion())
+	if err != nil {
+		return err
+	}
+	for _, s := range skews {
+		klog.InfoS("CRD was installed by another release than the controller", "crd", s.CRD,
+			"crdVersion", s.CRDVersion, "controllerVersion", s.ControllerVersion)
+	}
+	if h.Options.BlockVersionSkew {
+		if err := versionSkewsToError(skews); err != nil {
+			return err
+		}
+	}
+	if h.Options.CheckValidationRules {
+		klog.InfoS("Validating CRD validation rules")
+		if err := ValidateValidationRules(ctx, h.Client, crds); err != nil {
+			return err
+		}
+	}
+	if h.Options.VerifyFieldPruning && roundTrips {
+		klog.InfoS("Verifying unknown field pruning of CRDs")
+		if err := ValidateFieldPruning(ctx, h.Client, names, h.Options.TestObjects); err != nil {
+			return err
+		}
+	}
+	return h.checkStoredVersions(ctx, names)
+}
+
+// checkStoredVersions warns about CRDs that still store objects in deprecated
+// versions and, if enabled, triggers their storage version migration. Failing
+// to create a migration is logged but does not fail the startup, since the
+// migration API is alpha and may not be served.
+func (h *Hook) checkStoredVersions(ctx context.Context, names []string) error {
+	stale, err := DetectStaleStoredVersions(ctx, h.Client, names)
+	if err != nil {
+		return err
+	}
+	for _, s := range stale {
+		klog.InfoS("CRD has objects stored in deprecated versions, they must be migrated before these versions are removed",
+			"crd", s.CRD, "storedVersions", s.Stale, "storageVersion", s.StorageVersion)
+		if !h.Options.MigrateStoredVersions {
+			continue
+		}
+		if err := MigrateStoredVersions(ctx, h.Client, s); err != nil {
+			klog.ErrorS(err, "Failed to trigger storage version migration", "crd", s.CRD,
+				"suggestion", "Enable the StorageVersionMigrator feature or migrate the objects manually")
+		}
+	}
+	return nil
+}
+
+// checkCompressionDowngrade reports the objects stored with a compression
+// whose feature gate was disabled, failing if BlockCompressionDowngrade is set.
+func (h *Hook) checkCompressionDowngrade(ctx context.Context) error {
+	objects, err := DetectCompressedObjects(ctx, h.Client, feature.DefaultMutableFeatureGate.Enabled)
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		klog.InfoS("Found objects stored with a disabled compression, they cannot be read after rolling back to a "+
+			"release without compression support", "kind", o.Kind, "example", klog.KRef(o.Namespace, o.Name),
+			"compression", o.Compression, "featureGate", o.Feature)
+	}
+	if h.Options.BlockCompressionDowngrade {
+		return compressedObjectsToError(objects)
+	}
+	return nil
+}
+
+// controllerVersion returns the release of the running controller.
+func (h *Hook) controllerVersion() string {
+	if h.Options.ControllerVersion == "" {
+		return version.VelaVersion
+	}
+	return h.Options.ControllerVersion
+}
+
+// crdNames returns the names of crds.
+func crdNames(crds []CRDRequirement) []string {
+	names := make([]string, 0, len(crds))
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+	}
+	return names
+}
+
+// checkCRDs checks every CRD of crds concurrently, bounded by the configured
+// concurrency and QPS, and logs the time spent on each of them.
+func (h *Hook) checkCRDs(ctx context.Context, crds []CRDRequirement) ([]crdProblem, error) {
+	results := make([][]crdProblem, len(crds))
+	pool := newValidationPool(h.Options.Concurrency, h.Options.QPS)
+	err := pool.run(ctx, crdNames(crds), func(ctx context.Context, i int) error {
+		var err error
+		results[i], err = h.checkCRD(ctx, crds[i])
+		return err
+	})
+	pool.logTimings()
+	if err != nil {
+		return nil, err
+	}
+	var problems []crdProblem
+	for _, r := range results {
+		problems = append(problems, r...)
+	}
+	return problems, nil
+}
+
+// checkCRD validates the presence and required fields of crd and, if enabled,
+// adds the incompatibilities between its versions and the breaking schema
+// drifts from the bundled CRD to the problems found.
+func (h *Hook) checkCRD(ctx context.Context, crd CRDRequirement) ([]crdProblem, error) {
+	names := []string{crd.Name}
+	problems, err := checkCRDs(ctx, h.Client, []CRDRequirement{crd})
+	if err != nil {
+		return nil, err
+	}
+	if h.Options.CheckVersionCompatibility {
+		incompatibilities, err := CheckVersionCompatibility(ctx, h.Client, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, inc := range incompatibilities {
+			klog.InfoS("Detected incompatible CRD versions", "crd", inc.CRD, "version", inc.Version,
+				"storageVersion", inc.StorageVersion, "typeChanges", inc.TypeChanges, "removedRequired", inc.RemovedRequired)
+			problems = append(problems, crdProblem{name: inc.CRD, message: inc.String()})
+		}
+	}
+	if !h.Options.DetectSchemaDrift {
+		return problems, nil
+	}
+	drifts, err := DetectSchemaDrift(ctx, h.Client, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range drifts {
+		if !d.Breaking() {
+			klog.InfoS("CRD schema is newer than the bundled schema", "crd", d.CRD, "version", d.Version, "added", d.Added)
+			continue
+		}
+		klog.InfoS("Detected CRD schema drift", "crd", d.CRD, "version", d.Version,
+			"removed", d.Removed, "changed", d.Changed, "added", d.Added)
+		problems = append(problems, crdProblem{name: d.CRD, message: d.String()})
+	}
+	return problems, nil
+}
+
+// validateApplicationRevisionCRD performs a round-trip test to ensure the
+// ApplicationRevision CRD supports compression fields
+func (h *Hook) validateApplicationRevisionCRD(ctx context.Context, zstdEnabled, gzipEnabled bool) error {
+	// Generate test resource
+	objs := h.Options.TestObjects.WithDefaults()
+	testName := objs.newName()
+	namespace := objs.Namespace
+
+	klog.V(2).InfoS("Creating test ApplicationRevision for CRD validation",
+		"name", testName,
+		"namespace", namespace)
+
+	// Ensure the namespace exists before attempting to create resources
+	if err := k8s.EnsureNamespace(ctx, h.Client, namespace); err != nil {
+		klog.ErrorS(err, "Failed to ensure runtime namespace exists",
+			"namespace", namespace)
+		return fmt.Errorf("runtime namespace %q does not exist or is not accessible: %w", namespace, err)
+	}
+
+	appRev := &v1beta1.ApplicationRevision{}
+	appRev.Name = testName
+	appRev.Namespace = namespace
+	appRev.SetLabels(map[string]string{oam.LabelPreCheck: types.VelaCoreName})
+	appRev.Spec.Application.Name = testName
+	appRev.Spec.Application.Spec.Components = []common.ApplicationComponent{}
+
+	// Set compression type based on enabled features
+	var compressionType compression.Type
+	if zstdEnabled {
+		compressionType = compression.Zstd
+		appRev.Spec.Compression.SetType(compression.Zstd)
+		klog.V(3).InfoS("Setting compression type", "type", "zstd")
+	} else if gzipEnabled {
+		compressionType = compression.Gzip
+		appRev.Spec.Compression.SetType(compression.Gzip)
+		klog.V(3).InfoS("Setting compression type", "type", "gzip")
+	}
+
+	// Register cleanup function
+	defer func() {
+		klog.V(2).InfoS("Cleaning up test ApplicationRevision",
+			"name", testName,
+			"namespace", namespace)
+
+		if err := h.Client.Delete(ctx, appRev); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to clean up test ApplicationRevision resources",
+				"name", testName,
+				"namespace", namespace)
+		} else {
+			klog.V(3).InfoS("Successfully cleaned up test ApplicationRevision resources")
+		}
+	}()
+
+	// A mutating webhook may drop the compression fields as well as an outdated
+	// CRD, tell them apart with a dry-run of a copy of the test resource
+	written := appRev.DeepCopy()
+	verifyFailed := func(err error) error {
+		dryRun := written.DeepCopy()
+		dryRun.Name = objs.newName()
+		return diagnoseVerifyFailure(ctx, h.Client, v1beta1.ApplicationRevisionKind, v1beta1.Group, "applicationrevisions", dryRun, err)
+	}
+
+	// Create test resource
+	klog.V(2).InfoS("Writing test ApplicationRevision to cluster")
+	if err := h.Client.Create(ctx, appRev); err != nil {
+		klog.ErrorS(err, "Failed to create test ApplicationRevision",
+			"name", testName,
+			"namespace", namespace)
+		return diagnoseCreateFailure(v1beta1.ApplicationRevisionKind, fmt.Errorf("failed to create test ApplicationRevision: %w", err))
+	}
+	klog.V(3).InfoS("Test ApplicationRevision created successfully")
+
+	// Read back the resource
+	key := client.ObjectKeyFromObject(appRev)
+	klog.V(2).InfoS("Reading back test ApplicationRevision from cluster",
+		"key", key.String())
+
+	if err := h.Client.Get(ctx, key, appRev); err != nil {
+		klog.ErrorS(err, "Failed to read back test ApplicationRevision",
+			"key", key.String())
+		return fmt.Errorf("failed to read test ApplicationRevision: %w", err)
+	}
+	klog.V(3).InfoS("Test ApplicationRevision read successfully")
+
+	// Validate round-trip integrity
+	klog.V(2).InfoS("Validating round-trip data integrity",
+		"expectedName", testName,
+		"actualName", appRev.Spec.Application.Name,
+		"expectedCompression", compressionType,
+		"actualCompression", appRev.Spec.Compression.Type)
+
+	// First check that basic data survived
+	if appRev.Spec.Application.Name != testName {
+		klog.ErrorS(nil, "CRD round-trip validation failed - basic data corruption detected",
+			"expectedName", testName,
+			"actualName", appRev.Spec.Application.Name,
+			"compressionType", compressionType,
+			"issue", "The ApplicationRevision CRD does not support compression fields")
+		return verifyFailed(fmt.Errorf("the ApplicationRevision CRD is not updated. Compression cannot be used. Please upgrade your CRD to latest ones"))
+	}
+
+	// Validate that compression fields survived the round-trip
+	switch compressionType {
+	case compression.Zstd:
+		if appRev.Spec.Compression.Type != compression.Zstd {
+			klog.ErrorS(nil, "CRD round-trip validation failed - zstd compression type lost",
+				"expected", compression.Zstd,
+				"actual", appRev.Spec.Compression.Type,
+				"issue", "The ApplicationRevision CRD does not support zstd compression fields")
+			return verifyFailed(fmt.Errorf("ApplicationRevision CRD missing zstd compression support after round-trip; got=%v. Please upgrade your CRD to latest ones", appRev.Spec.Compression.Type))
+		}
+	case compression.Gzip:
+		if appRev.Spec.Compression.Type != compression.Gzip {
+			klog.ErrorS(nil, "CRD round-trip validation failed - gzip compression type lost",
+				"expected", compression.Gzip,
+				"actual", appRev.Spec.Compression.Type,
+				"issue", "The ApplicationRevision CRD does not support gzip compression fields")
+			return verifyFailed(fmt.Errorf("ApplicationRevision CRD missing gzip compression support after round-trip; got=%v. Please upgrade your CRD to latest ones", appRev.Spec.Compression.Type))
+		}
+	case compression.Uncompressed:
+		// This case should never happen as we only set Zstd or Gzip above,
+		// but we need to handle it to satisfy the exhaustive linter
+		klog.V(3).InfoS("Compression type is uncompressed, which is unexpected in validation",
+			"compressionType", compressionType)
+	}
+
+	klog.V(2).InfoS("Round-trip validation passed - CRD supports compression",
+		"compressionType", compressionType)
+
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// roundTripTemplate is the CUE template written to the test definitions. It is
+// compared after the read-back to detect a CRD that prunes the schematic.
+const roundTripTemplate = "parameter: {}\n"
+
+// CreateTestFunc returns the object a round-trip test writes, without name,
+// namespace and labels which are set by the test. Kinds missing from the
+// scheme of the client are written as unstructured objects with their
+// apiVersion and kind set.
+type CreateTestFunc func() client.Object
+
+// RoundTripTest writes an object of a kind, reads it back and verifies that
+// its CRD preserved what was written. Downstream distributions register tests
+// for their own CRDs with RegisterRoundTripTest.
+type RoundTripTest struct {
+	// Kind names the tested kind in logs and errors.
+	Kind string
+	// Group and Resource are checked with a SelfSubjectAccessReview, the test
+	// is skipped if the controller may not create, get and delete them.
+	Group    string
+	Resource string
+	// Create returns the object to write.
+	Create CreateTestFunc
+	// Verify compares the object read back, an empty object of the same type
+	// as the one returned by Create, with the written one.
+	Verify func(written, got client.Object) error
+}
+
+var (
+	roundTripMu    sync.RWMutex
+	roundTripTests []RoundTripTest
+)
+
+func init() {
+	for _, t := range []RoundTripTest{
+		definitionRoundTrip(v1beta1.TraitDefinitionKind, "traitdefinitions",
+			func() client.Object { return &v1beta1.TraitDefinition{} },
+			func(obj client.Object) **common.Schematic { return &obj.(*v1beta1.TraitDefinition).Spec.Schematic }),
+		definitionRoundTrip(v1beta1.PolicyDefinitionKind, "policydefinitions",
+			func() client.Object { return &v1beta1.PolicyDefinition{} },
+			func(obj client.Object) **common.Schematic { return &obj.(*v1beta1.PolicyDefinition).Spec.Schematic }),
+		definitionRoundTrip(v1beta1.WorkflowStepDefinitionKind, "workflowstepdefinitions",
+			func() client.Object { return &v1beta1.WorkflowStepDefinition{} },
+			func(obj client.Object) **common.Schematic {
+				return &obj.(*v1beta1.WorkflowStepDefinition).Spec.Schematic
+			}),
+	} {
+		if err := RegisterRoundTripTest(t); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterRoundTripTest adds t to the round-trip tests run by the CRD
+// validation hook when definition round-trips are enabled. It must be called
+// before the hook runs, typically from an init function.
+func RegisterRoundTripTest(t RoundTripTest) error {
+	if t.Kind == "" || t.Resource == "" || t.Create == nil || t.Verify == nil {
+		return fmt.Errorf("round-trip test must have a kind, a resource, a create and a verify function")
+	}
+	roundTripMu.Lock()
+	defer roundTripMu.Unlock()
+	for _, rt := range roundTripTests {
+		if rt.Group == t.Group && rt.Resource == t.Resource {
+			return fmt.Errorf("round-trip test for %s is already registered", t.Kind)
+		}
+	}
+	roundTripTests = append(roundTripTests, t)
+	return nil
+}
+
+// RoundTripTests returns a copy of the registered round-trip tests.
+func RoundTripTests() []RoundTripTest {
+	roundTripMu.RLock()
+	defer roundTripMu.RUnlock()
+	return append([]RoundTripTest(nil), roundTripTests...)
+}
+
+// definitionRoundTrip returns the round-trip test of a definition kind, which
+// writes a CUE schematic and checks it is not pruned.
+func definitionRoundTrip(kind, resource string, newObj CreateTestFunc, schematic func(obj client.Object) **common.Schematic) RoundTripTest {
+	return RoundTripTest{
+		Kind:     kind,
+		Group:    v1beta1.Group,
+		Resource: resource,
+		Create: func() client.Object {
+			obj := newObj()
+			*schematic(obj) = &common.Schematic{CUE: &common.CUE{Template: roundTripTemplate}}
+			return obj
+		},
+		Verify: func(_, got client.Object) error {
+			if s := *schematic(got); s == nil || s.CUE == nil || s.CUE.Template != roundTripTemplate {
+				return fmt.Errorf("the %s CRD does not preserve spec.schematic after round-trip. Please upgrade your CRD to latest ones", kind)
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateDefinitionRoundTrips creates, reads back and deletes a test object of
+// every registered round-trip test as configured by objs, by default a TraitDefinition,
+// a PolicyDefinition and a WorkflowStepDefinition verifying their CRDs store
+// the schematic. Each kind is first probed with a SelfSubjectAccessReview and
+// skipped if the controller lacks the permissions.
+func ValidateDefinitionRoundTrips(ctx context.Context, c client.Client, objs TestObjects) error {
+	objs = objs.WithDefaults()
+	for _, rt := range RoundTripTests() {
+		allowed, err := canRoundTrip(ctx, c, rt.Group, rt.Resource, objs.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to check permissions for %s: %w", rt.Kind, err)
+		}
+		if !allowed {
+			klog.InfoS("Skipping round-trip test, permission denied", "kind", rt.Kind, "namespace", objs.Namespace)
+			continue
+		}
+		if err := runRoundTrip(ctx, c, rt, objs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canRoundTrip asks the API server whether the current identity may create, get
+// and delete the given resource in namespace.
+func canRoundTrip(ctx context.Context, c client.Client, group, resource, namespace string) (bool, error) {
+	for _, verb := range []string{"create", "get", "delete"} {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     group,
+					Resource:  resource,
+				},
+			},
+		}
+		if err := c.Create(ctx, review); err != nil {
+			return false, err
+		}
+		if !review.Status.Allowed {
+			klog.V(2).InfoS("Access review denied", "resource", resource, "verb", verb, "reason", review.Status.Reason)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// runRoundTrip writes the test object of rt and verifies what is read back.
+// The object is labeled as a pre-check object and deleted afterwards. Failures
+// caused by admission webhooks are reported as a WebhookInterference.
+func runRoundTrip(ctx context.Context, c client.Client, rt RoundTripTest, objs TestObjects) error {
+	name, namespace := objs.newName(), objs.Namespace
+	obj := rt.Create()
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetLabels(map[string]string{oam.LabelPreCheck: types.VelaCoreName})
+
+	klog.V(2).InfoS("Creating test object for CRD validation", "kind", rt.Kind, "name", name, "namespace", namespace)
+	if err := c.Create(ctx, obj); err != nil {
+		return diagnoseCreateFailure(rt.Kind, fmt.Errorf("failed to create test %s: %w", rt.Kind, err))
+	}
+	defer func() {
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to clean up test object", "kind", rt.Kind, "name", name)
+		}
+	}()
+
+	var got client.Object
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		got = &unstructured.Unstructured{}
+		got.GetObjectKind().SetGroupVersionKind(u.GroupVersionKind())
+	} else {
+		got = reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+		return fmt.Errorf("failed to read test %s: %w", rt.Kind, err)
+	}
+	if err := rt.Verify(obj, got); err != nil {
+		dryRun := rt.Create()
+		dryRun.SetName(objs.newName())
+		dryRun.SetNamespace(namespace)
+		dryRun.SetLabels(map[string]string{oam.LabelPreCheck: types.VelaCoreName})
+		return diagnoseVerifyFailure(ctx, c, rt.Kind, rt.Group, rt.Resource, dryRun, err)
+	}
+	klog.V(2).InfoS("Round-trip validation passed", "kind", rt.Kind)
+	return nil
+}
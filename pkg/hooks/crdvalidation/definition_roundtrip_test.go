@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+var _ = Describe("Definition round-trip validation", func() {
+	newClient := func(allowed bool, created *[]string) client.Client {
+		return fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = allowed
+						return nil
+					}
+					*created = append(*created, obj.GetObjectKind().GroupVersionKind().Kind)
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+	}
+
+	It("should round-trip every definition kind when permitted", func() {
+		var created []string
+		cli := newClient(true, &created)
+		Expect(crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})).Should(Succeed())
+		Expect(created).Should(HaveLen(3))
+
+		traits := &v1beta1.TraitDefinitionList{}
+		Expect(cli.List(context.Background(), traits)).Should(Succeed())
+		Expect(traits.Items).Should(BeEmpty())
+	})
+
+	It("should skip definition kinds the controller is not permitted to write", func() {
+		var created []string
+		cli := newClient(false, &created)
+		Expect(crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})).Should(Succeed())
+		Expect(created).Should(BeEmpty())
+	})
+
+	It("should run the round-trip tests registered for other kinds", func() {
+		Expect(crdvalidation.RegisterRoundTripTest(crdvalidation.RoundTripTest{
+			Kind:     "ConfigMap",
+			Resource: "configmaps",
+			Create: func() client.Object {
+				return &corev1.ConfigMap{Data: map[string]string{"key": "value"}}
+			},
+			Verify: func(written, got client.Object) error {
+				if got.(*corev1.ConfigMap).Data["key"] != written.(*corev1.ConfigMap).Data["key"] {
+					return errors.New("data was pruned")
+				}
+				return nil
+			},
+		})).Should(Succeed())
+		Expect(crdvalidation.RegisterRoundTripTest(crdvalidation.RoundTripTest{Kind: "ConfigMap", Resource: "configmaps"})).ShouldNot(Succeed())
+
+		var created []string
+		cli := newClient(true, &created)
+		Expect(crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})).Should(Succeed())
+		Expect(created).Should(HaveLen(4))
+
+		cms := &corev1.ConfigMapList{}
+		Expect(cli.List(context.Background(), cms, client.HasLabels{oam.LabelPreCheck})).Should(Succeed())
+		Expect(cms.Items).Should(BeEmpty())
+	})
+})
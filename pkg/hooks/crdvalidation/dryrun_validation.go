@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// RepresentativeApplication returns an Application resembling a large
+// production one, with the given number of components each carrying
+// properties and traits, a deploy step per component and a few policies. It is
+// named and namespaced as configured by objs.
+func RepresentativeApplication(objs TestObjects, components int) *v1beta1.Application {
+	objs = objs.WithDefaults()
+	app := &v1beta1.Application{}
+	app.SetGroupVersionKind(v1beta1.ApplicationKindVersionKind)
+	app.SetName(objs.newName())
+	app.SetNamespace(objs.Namespace)
+	app.SetLabels(map[string]string{oam.LabelPreCheck: types.VelaCoreName})
+	app.Spec.Workflow = &v1beta1.Workflow{}
+	for i := 0; i < components; i++ {
+		name := fmt.Sprintf("component-%d", i)
+		comp := common.ApplicationComponent{
+			Name: name,
+			Type: "webservice",
+			Properties: util.Object2RawExtension(map[string]interface{}{
+				"image": "nginx:1.25",
+				"ports": []interface{}{map[string]interface{}{"port": 80, "expose": true}},
+				"env": []interface{}{
+					map[string]interface{}{"name": "COMPONENT", "value": name},
+					map[string]interface{}{"name": "DESCRIPTION", "value": strings.Repeat("x", 256)},
+				},
+				"cpu":    "500m",
+				"memory": "512Mi",
+			}),
+			Traits: []common.ApplicationTrait{
+				{Type: "scaler", Properties: util.Object2RawExtension(map[string]interface{}{"replicas": 3})},
+				{Type: "labels", Properties: util.Object2RawExtension(map[string]interface{}{"app": name, "tier": "backend"})},
+			},
+		}
+		if i > 0 {
+			comp.DependsOn = []string{fmt.Sprintf("component-%d", i-1)}
+		}
+		app.Spec.Components = append(app.Spec.Components, comp)
+		app.Spec.Workflow.Steps = append(app.Spec.Workflow.Steps, wfTypesv1alpha1.WorkflowStep{
+			WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{
+				Name:       "deploy-" + name,
+				Type:       "deploy",
+				Properties: util.Object2RawExtension(map[string]interface{}{"policies": []interface{}{"topology", "override"}}),
+			},
+		})
+	}
+	app.Spec.Policies = []v1beta1.AppPolicy{
+		{Name: "topology", Type: "topology", Properties: util.Object2RawExtension(map[string]interface{}{"clusters": []interface{}{"local"}})},
+		{Name: "override", Type: "override", Properties: util.Object2RawExtension(map[string]interface{}{
+			"components": []interface{}{map[string]interface{}{"type": "webservice", "properties": map[string]interface{}{"cpu": "1"}}},
+		})},
+		{Name: "garbage-collect", Type: "garbage-collect", Properties: util.Object2RawExtension(map[string]interface{}{"keepLegacyResource": true})},
+	}
+	return app
+}
+
+// ValidateDryRun creates a representative Application with the given number of
+// components and an ApplicationRevision embedding it, as configured by objs,
+// with a server-side dry-run. Nothing is persisted, but the objects go through the
+// schema validation, the CEL rules, the request size limits and the admission
+// webhooks a real application of that size would meet.
+func ValidateDryRun(ctx context.Context, c client.Client, objs TestObjects, components int) error {
+	app := RepresentativeApplication(objs, components)
+	namespace := app.Namespace
+	appRev := &v1beta1.ApplicationRevision{}
+	appRev.SetGroupVersionKind(v1beta1.ApplicationRevisionGroupVersionKind)
+	appRev.SetName(app.Name + "-v1")
+	appRev.SetNamespace(namespace)
+	appRev.SetLabels(app.Labels)
+	appRev.Spec.Application = *app.DeepCopy()
+
+	for _, obj := range []client.Object{app, appRev} {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		klog.V(2).InfoS("Creating representative object with server-side dry-run", "kind", kind,
+			"name", obj.GetName(), "namespace", namespace, "components", components)
+		if err := c.Create(ctx, obj, client.DryRunAll); err != nil {
+			return fmt.Errorf("the API server rejected a representative %s with %d components: %w", kind, components, err)
+		}
+	}
+	klog.V(2).InfoS("Dry-run validation of representative objects passed", "components", components)
+	return nil
+}
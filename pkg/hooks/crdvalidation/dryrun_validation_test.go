@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Dry-run validation", func() {
+	It("should build a representative application", func() {
+		app := crdvalidation.RepresentativeApplication(crdvalidation.TestObjects{Namespace: "vela-system"}, 10)
+		Expect(app.Spec.Components).Should(HaveLen(10))
+		Expect(app.Spec.Workflow.Steps).Should(HaveLen(10))
+		Expect(app.Spec.Policies).ShouldNot(BeEmpty())
+		Expect(app.Spec.Components[9].DependsOn).Should(Equal([]string{"component-8"}))
+	})
+
+	It("should create the representative objects without persisting them", func() {
+		var dryRuns int
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				createOpts := &client.CreateOptions{}
+				createOpts.ApplyOptions(opts)
+				Expect(createOpts.DryRun).Should(Equal([]string{"All"}))
+				dryRuns++
+				return nil
+			},
+		}).Build()
+		Expect(crdvalidation.ValidateDryRun(context.Background(), cli, crdvalidation.TestObjects{Namespace: "vela-system"}, 5)).Should(Succeed())
+		Expect(dryRuns).Should(Equal(2))
+		apps := &v1beta1.ApplicationList{}
+		Expect(cli.List(context.Background(), apps)).Should(Succeed())
+		Expect(apps.Items).Should(BeEmpty())
+	})
+
+	It("should report the rejected object", func() {
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*v1beta1.ApplicationRevision); ok {
+					return errors.New("request entity too large")
+				}
+				return nil
+			},
+		}).Build()
+		err := crdvalidation.ValidateDryRun(context.Background(), cli, crdvalidation.TestObjects{Namespace: "vela-system"}, 5)
+		Expect(err).Should(MatchError(ContainSubstring("rejected a representative ApplicationRevision with 5 components")))
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pruningProbeField is the unknown top-level field written by the pruning
+// round-trip. A CRD with a structural schema must drop it.
+const pruningProbeField = "precheckUnknownField"
+
+// ValidateFieldPruning checks that every named CRD prunes unknown fields. CRDs
+// that still set spec.preserveUnknownFields are reported directly, the others
+// are verified by writing an object with an unknown top-level field in the
+// storage version and checking that the API server dropped it. CRDs that are
+// not installed are skipped. The test objects are written as configured by objs.
+func ValidateFieldPruning(ctx context.Context, c client.Client, names []string, objs TestObjects) error {
+	objs = objs.WithDefaults()
+	var problems []crdProblem
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		if crd.Spec.PreserveUnknownFields {
+			problems = append(problems, crdProblem{name: name, message: fmt.Sprintf(
+				"CRD %s sets spec.preserveUnknownFields to true, so unknown fields are stored instead of pruned", name)})
+			continue
+		}
+		pruned, err := pruningRoundTrip(ctx, c, crd, objs)
+		if err != nil {
+			return fmt.Errorf("pruning round-trip of CRD %s failed: %w", name, err)
+		}
+		if !pruned {
+			problems = append(problems, crdProblem{name: name, message: fmt.Sprintf(
+				"CRD %s does not prune unknown fields, its schema preserves unknown fields at the root", name)})
+		}
+	}
+	return problemsToError(problems)
+}
+
+// pruningRoundTrip writes a minimal object carrying pruningProbeField and
+// reports whether the field was dropped. If the minimal object is rejected by
+// the schema the CRD is assumed to prune, since a schema strict enough to
+// reject it is structural.
+func pruningRoundTrip(ctx context.Context, c client.Client, crd *crdv1.CustomResourceDefinition, objs TestObjects) (bool, error) {
+	var storage string
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			storage = v.Name
+		}
+	}
+	if storage == "" {
+		return true, nil
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{pruningProbeField: "pre-check"}}
+	obj.SetAPIVersion(crd.Spec.Group + "/" + storage)
+	obj.SetKind(crd.Spec.Names.Kind)
+	objs.prepare(obj, crd.Spec.Scope == crdv1.NamespaceScoped)
+
+	klog.V(2).InfoS("Creating test object for pruning validation", "crd", crd.Name, "version", storage)
+	if err := c.Create(ctx, obj); err != nil {
+		if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+			klog.V(2).InfoS("Minimal test object rejected, skipping pruning round-trip", "crd", crd.Name, "reason", err.Error())
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to create test object: %w", err)
+	}
+	defer func() {
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to clean up pruning test object", "crd", crd.Name, "name", obj.GetName())
+		}
+	}()
+
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+		return false, fmt.Errorf("failed to read test object: %w", err)
+	}
+	_, kept := got.Object[pruningProbeField]
+	return !kept, nil
+}
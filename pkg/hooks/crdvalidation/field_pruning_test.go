@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Unknown field pruning validation", func() {
+	createCRD := func(plural, kind string, schema *crdv1.JSONSchemaProps) *unstructured.Unstructured {
+		crd := &crdv1.CustomResourceDefinition{
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group: "precheck.oam.dev",
+				Names: crdv1.CustomResourceDefinitionNames{Plural: plural, Kind: kind, ListKind: kind + "List"},
+				Scope: crdv1.NamespaceScoped,
+				Versions: []crdv1.CustomResourceDefinitionVersion{{
+					Name: "v1", Served: true, Storage: true,
+					Schema: &crdv1.CustomResourceValidation{OpenAPIV3Schema: schema},
+				}},
+			},
+		}
+		crd.Name = plural + ".precheck.oam.dev"
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		Expect(err).Should(Succeed())
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetAPIVersion("apiextensions.k8s.io/v1")
+		u.SetKind("CustomResourceDefinition")
+		Expect(singleton.KubeClient.Get().Create(context.Background(), u)).Should(Succeed())
+		Eventually(func() error {
+			return crdvalidation.ValidateCRDs(context.Background(), singleton.KubeClient.Get(),
+				[]crdvalidation.CRDRequirement{{Name: crd.Name}})
+		}).Should(Succeed())
+		return u
+	}
+
+	BeforeEach(func() {
+		Expect(k8s.EnsureNamespace(context.Background(), singleton.KubeClient.Get(), types.DefaultKubeVelaNS)).Should(Succeed())
+	})
+
+	It("should pass for CRDs with a structural schema", func() {
+		u := createCRD("sprockets", "Sprocket", &crdv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]crdv1.JSONSchemaProps{
+				"spec": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+			},
+		})
+		defer func() { Expect(singleton.KubeClient.Get().Delete(context.Background(), u)).Should(Succeed()) }()
+
+		Expect(crdvalidation.ValidateFieldPruning(context.Background(), singleton.KubeClient.Get(),
+			[]string{u.GetName(), "widgets.example.com"}, crdvalidation.TestObjects{})).Should(Succeed())
+	})
+
+	It("should report CRDs preserving unknown fields at the root", func() {
+		u := createCRD("cogs", "Cog", &crdv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: ptr.To(true)})
+		defer func() { Expect(singleton.KubeClient.Get().Delete(context.Background(), u)).Should(Succeed()) }()
+
+		err := crdvalidation.ValidateFieldPruning(context.Background(), singleton.KubeClient.Get(), []string{u.GetName()}, crdvalidation.TestObjects{})
+		Expect(err).ShouldNot(Succeed())
+		Expect(err.Error()).Should(ContainSubstring("CRD cogs.precheck.oam.dev does not prune unknown fields"))
+	})
+})
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// FingerprintConfigMapKey is the key of the fingerprint ConfigMap holding the
+// fingerprint of the environment of the last successful run.
+const FingerprintConfigMapKey = "fingerprint"
+
+// EnvironmentFingerprint identifies the environment the round-trips were run
+// against. The round-trips are skipped while it stays unchanged, since they
+// would only repeat the writes of the last successful run.
+type EnvironmentFingerprint struct {
+	// CRDs maps the name of every CRD exercised by the round-trips to its
+	// resourceVersion. CRDs that are not installed map to an empty string.
+	CRDs map[string]string `json:"crds"`
+	// FeatureGates lists the enabled feature gates in alphabetical order.
+	FeatureGates []string `json:"featureGates"`
+	// ControllerVersion is the release of the running controller.
+	ControllerVersion string `json:"controllerVersion"`
+	// Checks describes the enabled round-trips, so that enabling one is not
+	// skipped.
+	Checks string `json:"checks"`
+}
+
+// Sum returns the SHA-256 digest of the fingerprint.
+func (f EnvironmentFingerprint) Sum() string {
+	data, _ := json.Marshal(f)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CurrentFingerprint reads the resourceVersions of the named CRDs and the
+// enabled gates of gate into the fingerprint of the environment.
+func CurrentFingerprint(ctx context.Context, c client.Client, names []string, gate featuregate.MutableFeatureGate, controllerVersion, checks string) (EnvironmentFingerprint, error) {
+	f := EnvironmentFingerprint{CRDs: map[string]string{}, ControllerVersion: controllerVersion, Checks: checks}
+	for _, name := range names {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(crdv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, u); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return f, fmt.Errorf("failed to get CRD %s: %w", name, err)
+			}
+		}
+		f.CRDs[name] = u.GetResourceVersion()
+	}
+	for name := range gate.GetAll() {
+		if gate.Enabled(name) {
+			f.FeatureGates = append(f.FeatureGates, string(name))
+		}
+	}
+	sort.Strings(f.FeatureGates)
+	return f, nil
+}
+
+// LoadFingerprint returns the fingerprint sum stored in the named ConfigMap,
+// or an empty string if there is none.
+func LoadFingerprint(ctx context.Context, c client.Client, namespace, name string) (string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get fingerprint ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return cm.Data[FingerprintConfigMapKey], nil
+}
+
+// StoreFingerprint writes sum into the named ConfigMap, creating it if needed.
+func StoreFingerprint(ctx context.Context, c client.Client, namespace, name, sum string) error {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get fingerprint ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		cm.Namespace = namespace
+		cm.Name = name
+		cm.Labels = map[string]string{oam.LabelControllerName: types.VelaCoreName}
+		cm.Data = map[string]string{FingerprintConfigMapKey: sum}
+		if err := c.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create fingerprint ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[FingerprintConfigMapKey] = sum
+	if err := c.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update fingerprint ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// roundTripCRDs returns the names of the CRDs exercised by the round-trips:
+// the configured ones, the ApplicationRevision CRD and the CRDs of the
+// registered round-trip tests.
+func roundTripCRDs(crds []CRDRequirement) []string {
+	names := crdNames(crds)
+	add := func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	add(applicationRevisionCRDName)
+	for _, rt := range RoundTripTests() {
+		if rt.Group != "" {
+			add(rt.Resource + "." + rt.Group)
+		}
+	}
+	return names
+}
+
+// fingerprint returns the fingerprint of the environment of the round-trips.
+func (h *Hook) fingerprint(ctx context.Context) (EnvironmentFingerprint, error) {
+	crds, err := h.resolveCRDs(ctx)
+	if err != nil {
+		return EnvironmentFingerprint{}, err
+	}
+	checks := fmt.Sprintf("definitionRoundTrip=%t,conversionWebhooks=%t,fieldPruning=%t,dryRunComponents=%d,testObjects=%+v",
+		h.Options.DefinitionRoundTrip, h.Options.CheckConversionWebhooks, h.Options.VerifyFieldPruning,
+		h.Options.DryRunComponents, h.Options.TestObjects.WithDefaults())
+	return CurrentFingerprint(ctx, h.Client, roundTripCRDs(crds), feature.DefaultMutableFeatureGate, h.controllerVersion(), checks)
+}
+
+// environmentUnchanged reports whether the environment is unchanged since the
+// last successful run, in which case the round-trips can be skipped. Failing
+// to read either fingerprint is logged and runs the round-trips.
+func (h *Hook) environmentUnchanged(ctx context.Context) bool {
+	if h.Options.FingerprintConfigMap == "" {
+		return false
+	}
+	stored, err := LoadFingerprint(ctx, h.Client, h.namespace(), h.Options.FingerprintConfigMap)
+	if err != nil {
+		klog.ErrorS(err, "Failed to load the environment fingerprint of the last successful run")
+		return false
+	}
+	if stored == "" {
+		return false
+	}
+	f, err := h.fingerprint(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the environment fingerprint")
+		return false
+	}
+	if f.Sum() != stored {
+		klog.InfoS("Environment changed since the last successful run, running the CRD round-trips")
+		return false
+	}
+	klog.InfoS("Environment unchanged since the last successful run, skipping the CRD round-trips",
+		"configMap", h.Options.FingerprintConfigMap)
+	return true
+}
+
+// recordFingerprint stores the fingerprint of the environment after a
+// successful run. It is computed again since the run may have upgraded CRDs.
+// Failing to store it is logged but does not fail the startup.
+func (h *Hook) recordFingerprint(ctx context.Context) {
+	if h.Options.FingerprintConfigMap == "" {
+		return
+	}
+	f, err := h.fingerprint(ctx)
+	if err == nil {
+		err = StoreFingerprint(ctx, h.Client, h.namespace(), h.Options.FingerprintConfigMap, f.Sum())
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to store the environment fingerprint")
+	}
+}
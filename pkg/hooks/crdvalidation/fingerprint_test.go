@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apiserver/pkg/util/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Environment fingerprint", func() {
+	var created int
+	var cli client.Client
+
+	BeforeEach(func() {
+		created = 0
+		cli = fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = true
+						return nil
+					}
+					created++
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+	})
+
+	It("should store and load the fingerprint", func() {
+		ctx := context.Background()
+		sum, err := crdvalidation.LoadFingerprint(ctx, cli, "vela-system", "precheck-fingerprint")
+		Expect(err).Should(Succeed())
+		Expect(sum).Should(BeEmpty())
+
+		Expect(crdvalidation.StoreFingerprint(ctx, cli, "vela-system", "precheck-fingerprint", "first")).Should(Succeed())
+		Expect(crdvalidation.StoreFingerprint(ctx, cli, "vela-system", "precheck-fingerprint", "second")).Should(Succeed())
+		sum, err = crdvalidation.LoadFingerprint(ctx, cli, "vela-system", "precheck-fingerprint")
+		Expect(err).Should(Succeed())
+		Expect(sum).Should(Equal("second"))
+	})
+
+	It("should change with the controller version and the checks", func() {
+		ctx := context.Background()
+		names := []string{"applicationrevisions.core.oam.dev"}
+		f, err := crdvalidation.CurrentFingerprint(ctx, cli, names, feature.DefaultMutableFeatureGate, "v1.10.0", "")
+		Expect(err).Should(Succeed())
+		Expect(f.CRDs).Should(HaveKeyWithValue("applicationrevisions.core.oam.dev", ""))
+
+		same, err := crdvalidation.CurrentFingerprint(ctx, cli, names, feature.DefaultMutableFeatureGate, "v1.10.0", "")
+		Expect(err).Should(Succeed())
+		Expect(same.Sum()).Should(Equal(f.Sum()))
+
+		upgraded, err := crdvalidation.CurrentFingerprint(ctx, cli, names, feature.DefaultMutableFeatureGate, "v1.11.0", "")
+		Expect(err).Should(Succeed())
+		Expect(upgraded.Sum()).ShouldNot(Equal(f.Sum()))
+
+		checked, err := crdvalidation.CurrentFingerprint(ctx, cli, names, feature.DefaultMutableFeatureGate, "v1.10.0", "dryRun")
+		Expect(err).Should(Succeed())
+		Expect(checked.Sum()).ShouldNot(Equal(f.Sum()))
+	})
+
+	It("should skip the round-trips while the environment is unchanged", func() {
+		ctx := context.Background()
+		opts := crdvalidation.Options{DefinitionRoundTrip: true, FingerprintConfigMap: "precheck-fingerprint"}
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(ctx)).Should(Succeed())
+		Expect(created).ShouldNot(BeZero())
+		sum, err := crdvalidation.LoadFingerprint(ctx, cli, k8s.GetRuntimeNamespace(), "precheck-fingerprint")
+		Expect(err).Should(Succeed())
+		Expect(sum).ShouldNot(BeEmpty())
+
+		created = 0
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(ctx)).Should(Succeed())
+		Expect(created).Should(BeZero())
+
+		opts.ControllerVersion = "v99.0.0"
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(ctx)).Should(Succeed())
+		Expect(created).ShouldNot(BeZero())
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// DefaultPreCheckTTL is the age after which a test object left behind by a
+// round-trip is considered leaked. It leaves enough time to the round-trips of
+// another replica starting at the same time.
+const DefaultPreCheckTTL = 10 * time.Minute
+
+// preCheckTarget is a kind the round-trips write test objects of.
+type preCheckTarget struct {
+	gvk        schema.GroupVersionKind
+	namespaced bool
+}
+
+// SweepPreCheckObjects deletes the test objects labeled as pre-check objects
+// which are older than ttl. The round-trips delete their objects when they
+// return, but the objects leak if the process dies in between, so the sweep
+// runs before the next round-trips. It covers the ApplicationRevisions of the
+// compression round-trip, the objects of the registered round-trip tests and
+// the objects written to the named CRDs by the pruning and conversion checks.
+// Only the objects named with the prefix of objs are deleted, from its
+// namespace for the namespaced kinds, and kinds that are not served or may not
+// be listed are skipped. It returns the number of deleted objects.
+func SweepPreCheckObjects(ctx context.Context, c client.Client, objs TestObjects, names []string, ttl time.Duration) (int, error) {
+	objs = objs.WithDefaults()
+	if ttl <= 0 {
+		ttl = DefaultPreCheckTTL
+	}
+	targets, err := preCheckTargets(ctx, c, names)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, t := range targets {
+		n, err := sweepPreCheckKind(ctx, c, t, objs, ttl)
+		deleted += n
+		if err != nil {
+			return deleted, fmt.Errorf("failed to clean up pre-check %s objects: %w", t.gvk.Kind, err)
+		}
+	}
+	return deleted, nil
+}
+
+// preCheckTargets returns the kinds the round-trips write test objects of.
+func preCheckTargets(ctx context.Context, c client.Client, names []string) ([]preCheckTarget, error) {
+	targets := []preCheckTarget{{gvk: v1beta1.ApplicationRevisionGroupVersionKind, namespaced: true}}
+	seen := map[schema.GroupKind]bool{v1beta1.ApplicationRevisionGroupVersionKind.GroupKind(): true}
+	add := func(gvk schema.GroupVersionKind, namespaced bool) {
+		if !seen[gvk.GroupKind()] {
+			seen[gvk.GroupKind()] = true
+			targets = append(targets, preCheckTarget{gvk: gvk, namespaced: namespaced})
+		}
+	}
+	for _, rt := range RoundTripTests() {
+		gvk, err := apiutil.GVKForObject(rt.Create(), c.Scheme())
+		if err != nil {
+			klog.V(2).InfoS("Skipping clean up of round-trip test objects of unknown kind", "kind", rt.Kind, "reason", err.Error())
+			continue
+		}
+		add(gvk, true)
+	}
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Storage {
+				add(schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind},
+					crd.Spec.Scope == crdv1.NamespaceScoped)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// sweepPreCheckKind deletes the pre-check objects of t older than ttl.
+func sweepPreCheckKind(ctx context.Context, c client.Client, t preCheckTarget, objs TestObjects, ttl time.Duration) (int, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(t.gvk.GroupVersion().WithKind(t.gvk.Kind + "List"))
+	opts := []client.ListOption{client.MatchingLabels{oam.LabelPreCheck: types.VelaCoreName}}
+	if t.namespaced {
+		opts = append(opts, client.InNamespace(objs.Namespace))
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			klog.V(2).InfoS("Skipping clean up of pre-check objects", "kind", t.gvk.Kind, "reason", err.Error())
+			return 0, nil
+		}
+		return 0, err
+	}
+	deleted := 0
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetDeletionTimestamp() != nil || time.Since(obj.GetCreationTimestamp().Time) < ttl {
+			continue
+		}
+		if !objs.owns(obj) {
+			klog.InfoS("Not deleting object carrying the pre-check label without the pre-check name prefix", "kind", t.gvk.Kind,
+				"object", klog.KObj(obj), "label", oam.LabelPreCheck, "namePrefix", objs.NamePrefix)
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return deleted, err
+		}
+		klog.InfoS("Deleted leaked pre-check object", "kind", t.gvk.Kind, "object", klog.KObj(obj),
+			"age", time.Since(obj.GetCreationTimestamp().Time).Round(time.Second).String())
+		deleted++
+	}
+	return deleted, nil
+}
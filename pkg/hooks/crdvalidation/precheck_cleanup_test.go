@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+var _ = Describe("Pre-check object clean up", func() {
+	meta := func(name string, age time.Duration, preCheck bool) metav1.ObjectMeta {
+		m := metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         types.DefaultKubeVelaNS,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		}
+		if preCheck {
+			m.Labels = map[string]string{oam.LabelPreCheck: types.VelaCoreName}
+		}
+		return m
+	}
+
+	It("should delete the leaked pre-check objects older than the TTL", func() {
+		cli := fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).WithObjects(
+			&v1beta1.ApplicationRevision{ObjectMeta: meta("core.pre-check.1", time.Hour, true)},
+			&v1beta1.ApplicationRevision{ObjectMeta: meta("core.pre-check.2", time.Second, true)},
+			&v1beta1.ApplicationRevision{ObjectMeta: meta("app-v1", time.Hour, false)},
+			&v1beta1.ApplicationRevision{ObjectMeta: meta("app-v2", time.Hour, true)},
+			&v1beta1.TraitDefinition{ObjectMeta: meta("core.pre-check.3", time.Hour, true)},
+		).Build()
+
+		deleted, err := crdvalidation.SweepPreCheckObjects(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS}, nil, 0)
+		Expect(err).Should(Succeed())
+		Expect(deleted).Should(Equal(2))
+
+		revisions := &v1beta1.ApplicationRevisionList{}
+		Expect(cli.List(context.Background(), revisions)).Should(Succeed())
+		var names []string
+		for _, r := range revisions.Items {
+			names = append(names, r.Name)
+		}
+		Expect(names).Should(ConsistOf("core.pre-check.2", "app-v1", "app-v2"))
+
+		traits := &v1beta1.TraitDefinitionList{}
+		Expect(cli.List(context.Background(), traits, client.HasLabels{oam.LabelPreCheck})).Should(Succeed())
+		Expect(traits.Items).Should(BeEmpty())
+	})
+
+	It("should honor a custom TTL", func() {
+		cli := fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).WithObjects(
+			&v1beta1.ApplicationRevision{ObjectMeta: meta("core.pre-check.1", time.Hour, true)},
+		).Build()
+
+		deleted, err := crdvalidation.SweepPreCheckObjects(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS}, nil, 2*time.Hour)
+		Expect(err).Should(Succeed())
+		Expect(deleted).Should(BeZero())
+	})
+})
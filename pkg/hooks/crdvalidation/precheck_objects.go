@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// DefaultTestNamePrefix is the name prefix of the test objects written by the
+// round-trips, followed by a timestamp.
+const DefaultTestNamePrefix = "core.pre-check."
+
+// TestObjects configures the test objects written by the round-trips, e.g. for
+// clusters where admission policies restrict writes to the runtime namespace.
+type TestObjects struct {
+	// Namespace is the namespace of the namespaced test objects. It defaults to
+	// the runtime namespace.
+	Namespace string
+	// NamePrefix is the name prefix of the test objects. It defaults to
+	// DefaultTestNamePrefix.
+	NamePrefix string
+}
+
+// WithDefaults returns the test objects with the defaults applied.
+func (t TestObjects) WithDefaults() TestObjects {
+	if t.Namespace == "" {
+		t.Namespace = k8s.GetRuntimeNamespace()
+	}
+	if t.NamePrefix == "" {
+		t.NamePrefix = DefaultTestNamePrefix
+	}
+	return t
+}
+
+// Validate checks that the namespace is a valid namespace name and that the
+// names built from the prefix are valid object names. Since the clean up of
+// leaked test objects deletes the objects carrying the pre-check label, the
+// prefix must also be specific enough to never be the prefix of user objects
+// labeled by mistake: it must contain "pre-check" or "precheck" and end with a
+// separator.
+func (t TestObjects) Validate() error {
+	if t.Namespace != "" {
+		if errs := validation.IsDNS1123Label(t.Namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid pre-check namespace %q: %s", t.Namespace, strings.Join(errs, ", "))
+		}
+	}
+	if t.NamePrefix == "" {
+		return nil
+	}
+	if !strings.Contains(t.NamePrefix, "pre-check") && !strings.Contains(t.NamePrefix, "precheck") {
+		return fmt.Errorf("invalid pre-check name prefix %q: it must contain \"pre-check\" or \"precheck\" to never match user objects", t.NamePrefix)
+	}
+	if !strings.HasSuffix(t.NamePrefix, ".") && !strings.HasSuffix(t.NamePrefix, "-") {
+		return fmt.Errorf("invalid pre-check name prefix %q: it must end with \".\" or \"-\"", t.NamePrefix)
+	}
+	if errs := validation.IsDNS1123Subdomain(t.NamePrefix + "0"); len(errs) > 0 {
+		return fmt.Errorf("invalid pre-check name prefix %q: %s", t.NamePrefix, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// newName returns a unique name for a test object.
+func (t TestObjects) newName() string {
+	return fmt.Sprintf("%s%d", t.NamePrefix, time.Now().UnixNano())
+}
+
+// owns reports whether obj is a test object: it must carry both the pre-check
+// label and the name prefix, so that user objects carrying the label are never
+// deleted.
+func (t TestObjects) owns(obj client.Object) bool {
+	return obj.GetLabels()[oam.LabelPreCheck] == types.VelaCoreName && strings.HasPrefix(obj.GetName(), t.NamePrefix)
+}
+
+// prepare names and labels obj as a test object, in the test namespace if namespaced.
+func (t TestObjects) prepare(obj client.Object, namespaced bool) {
+	obj.SetName(t.newName())
+	if namespaced {
+		obj.SetNamespace(t.Namespace)
+	}
+	obj.SetLabels(map[string]string{oam.LabelPreCheck: types.VelaCoreName})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Pre-check test objects", func() {
+	It("should default to the runtime namespace and the default prefix", func() {
+		objs := crdvalidation.TestObjects{}.WithDefaults()
+		Expect(objs.Namespace).ShouldNot(BeEmpty())
+		Expect(objs.NamePrefix).Should(Equal(crdvalidation.DefaultTestNamePrefix))
+	})
+
+	It("should name the test objects with the configured prefix", func() {
+		objs := crdvalidation.TestObjects{Namespace: "vela-precheck", NamePrefix: "precheck-"}
+		Expect(objs.Validate()).Should(Succeed())
+
+		app := crdvalidation.RepresentativeApplication(objs, 1)
+		Expect(app.Name).Should(HavePrefix("precheck-"))
+		Expect(app.Namespace).Should(Equal("vela-precheck"))
+	})
+
+	DescribeTable("should reject configurations that could match user objects or are invalid",
+		func(objs crdvalidation.TestObjects) {
+			Expect(objs.Validate()).ShouldNot(Succeed())
+		},
+		Entry("prefix without pre-check", crdvalidation.TestObjects{NamePrefix: "app-"}),
+		Entry("prefix without separator", crdvalidation.TestObjects{NamePrefix: "core.pre-check"}),
+		Entry("prefix with invalid characters", crdvalidation.TestObjects{NamePrefix: "my_pre-check."}),
+		Entry("invalid namespace", crdvalidation.TestObjects{Namespace: "vela.system"}),
+	)
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// RoundTripHook runs the checks of the CRD validation hook that write test
+// objects: the clean up of leaked test objects, the conversion webhook,
+// field pruning and definition round-trips. It runs on the leader only, so
+// that the replicas of an HA deployment do not all write test objects. The
+// CRD validation hook skips these checks when Options.RoundTripsOnLeader is
+// set.
+//
+// The round-trips are best-effort: the hook runs as a post-start hook once the
+// leader is elected, concurrently with the controllers and independently of
+// the pre-start hooks, so it cannot be ordered after the CRD validation and
+// its failures are logged as warnings instead of stopping the manager. The
+// compression round-trip is therefore kept in the CRD validation hook, since
+// storing objects that cannot be read back must be ruled out before the
+// controllers write them.
+type RoundTripHook struct {
+	hook *Hook
+}
+
+// NewRoundTripHook creates the hook running the round-trips of the CRD
+// validation hook with the given options on the leader.
+func NewRoundTripHook(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing CRD round-trip hook")
+	return &RoundTripHook{hook: &Hook{Client: c, Options: opts}}
+}
+
+// Name returns the hook name for logging
+func (h *RoundTripHook) Name() string {
+	return "CRDRoundTrip"
+}
+
+// LeaderOnly makes the hook run on the leader only.
+func (h *RoundTripHook) LeaderOnly() {}
+
+// Severity makes the failures of the best-effort round-trips warnings.
+func (h *RoundTripHook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Run executes the round-trips.
+func (h *RoundTripHook) Run(ctx context.Context) error {
+	klog.InfoS("Starting CRD round-trip hook")
+	ctx, cancel := context.WithTimeout(ctx, crdValidationTimeout)
+	defer cancel()
+
+	h.hook.sweepPreCheckObjects(ctx)
+	if h.hook.environmentUnchanged(ctx) {
+		return nil
+	}
+
+	if h.hook.Options.CheckConversionWebhooks || h.hook.Options.VerifyFieldPruning {
+		crds, err := h.hook.resolveCRDs(ctx)
+		if err != nil {
+			return fmt.Errorf("CRD round-trips failed: %w", err)
+		}
+		names := crdNames(crds)
+		if h.hook.Options.CheckConversionWebhooks {
+			if err := ValidateConversionWebhooks(ctx, h.hook.Client, names, h.hook.Options.TestObjects); err != nil {
+				return hooks.WithRemediation(fmt.Errorf("CRD round-trips failed: %w", err), upgradeCRDsRemediation)
+			}
+		}
+		if h.hook.Options.VerifyFieldPruning {
+			klog.InfoS("Verifying unknown field pruning of CRDs")
+			if err := ValidateFieldPruning(ctx, h.hook.Client, names, h.hook.Options.TestObjects); err != nil {
+				return hooks.WithRemediation(fmt.Errorf("CRD round-trips failed: %w", err), upgradeCRDsRemediation)
+			}
+		}
+	}
+
+	if err := h.hook.validateDefinitionRoundTrips(ctx); err != nil {
+		return err
+	}
+	h.hook.recordFingerprint(ctx)
+	klog.InfoS("CRD round-trips completed successfully")
+	return nil
+}
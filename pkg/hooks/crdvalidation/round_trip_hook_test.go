@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Round-trips on the leader", func() {
+	var created []string
+	var cli client.Client
+
+	BeforeEach(func() {
+		created = nil
+		cli = fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = true
+						return nil
+					}
+					created = append(created, reflect.TypeOf(obj).Elem().Name())
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+	})
+
+	It("should leave the round-trips to the round-trip hook", func() {
+		opts := crdvalidation.Options{DefinitionRoundTrip: true, RoundTripsOnLeader: true}
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(context.Background())).Should(Succeed())
+		Expect(created).Should(BeEmpty())
+
+		roundTrips := crdvalidation.NewRoundTripHook(cli, opts)
+		Expect(roundTrips).Should(BeAssignableToTypeOf(&crdvalidation.RoundTripHook{}))
+		_, leaderOnly := roundTrips.(hooks.LeaderOnlyHook)
+		Expect(leaderOnly).Should(BeTrue())
+		_, dependent := roundTrips.(hooks.DependentHook)
+		Expect(dependent).Should(BeFalse())
+		Expect(roundTrips.(hooks.SeverityHook).Severity()).Should(Equal(hooks.SeverityWarn))
+		Expect(roundTrips.Run(context.Background())).Should(Succeed())
+		Expect(created).ShouldNot(BeEmpty())
+	})
+
+	It("should keep the compression round-trip in the CRD validation hook", func() {
+		featuregatetesting.SetFeatureGateDuringTest(GinkgoT(), utilfeature.DefaultFeatureGate, features.ZstdApplicationRevision, true)
+		opts := crdvalidation.Options{RoundTripsOnLeader: true}
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(context.Background())).Should(Succeed())
+		Expect(created).Should(ContainElement(v1beta1.ApplicationRevisionKind))
+
+		created = nil
+		Expect(crdvalidation.NewRoundTripHook(cli, opts).Run(context.Background())).Should(Succeed())
+		Expect(created).ShouldNot(ContainElement(v1beta1.ApplicationRevisionKind))
+	})
+
+	It("should run the round-trips in the CRD validation hook by default", func() {
+		opts := crdvalidation.Options{DefinitionRoundTrip: true}
+		Expect(crdvalidation.NewHookWithOptions(cli, opts).Run(context.Background())).Should(Succeed())
+		Expect(created).ShouldNot(BeEmpty())
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// scaleSubresourceCheck is the check name of the findings reported by
+// ValidateScaleSubresources.
+const scaleSubresourceCheck = "ScaleSubresource"
+
+// ValidateScaleSubresources reports the CRDs used as workload of a
+// ComponentDefinition that one of scaleTraits applies to but whose served
+// versions do not expose the /scale subresource. Applying such a trait only
+// fails once an application uses it, so the mismatch is surfaced at startup.
+// Scale traits that are not installed are ignored.
+func ValidateScaleSubresources(ctx context.Context, c client.Client, scaleTraits []string) ([]SchemaFinding, error) {
+	if len(scaleTraits) == 0 {
+		return nil, nil
+	}
+	traitDefs := &v1beta1.TraitDefinitionList{}
+	if err := c.List(ctx, traitDefs); err != nil {
+		return nil, fmt.Errorf("failed to list trait definitions: %w", err)
+	}
+	var traits []v1beta1.TraitDefinition
+	for _, td := range traitDefs.Items {
+		if slices.Contains(scaleTraits, td.Name) {
+			traits = append(traits, td)
+		}
+	}
+	if len(traits) == 0 {
+		return nil, nil
+	}
+
+	compDefs := &v1beta1.ComponentDefinitionList{}
+	if err := c.List(ctx, compDefs); err != nil {
+		return nil, fmt.Errorf("failed to list component definitions: %w", err)
+	}
+	crds := &crdv1.CustomResourceDefinitionList{}
+	if err := c.List(ctx, crds); err != nil {
+		return nil, fmt.Errorf("failed to list CRDs: %w", err)
+	}
+	byGroupKind := map[schema.GroupKind]*crdv1.CustomResourceDefinition{}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		byGroupKind[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd
+	}
+
+	// several component definitions may share a workload, report each CRD once
+	users := map[string][]string{}
+	for _, cd := range compDefs.Items {
+		gvk := cd.Spec.Workload.Definition
+		if gvk.Kind == "" {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(gvk.APIVersion)
+		if err != nil {
+			continue
+		}
+		crd, ok := byGroupKind[gv.WithKind(gvk.Kind).GroupKind()]
+		if !ok {
+			continue
+		}
+		for _, td := range traits {
+			if appliesToWorkload(td, cd.Name, crd.Name) && !slices.Contains(users[crd.Name], td.Name) {
+				users[crd.Name] = append(users[crd.Name], td.Name)
+			}
+		}
+	}
+
+	var findings []SchemaFinding
+	for _, crd := range crds.Items {
+		traitNames, ok := users[crd.Name]
+		if !ok {
+			continue
+		}
+		sort.Strings(traitNames)
+		for i, v := range crd.Spec.Versions {
+			if !v.Served || (v.Subresources != nil && v.Subresources.Scale != nil) {
+				continue
+			}
+			f := SchemaFinding{
+				CRD:      crd.Name,
+				Check:    scaleSubresourceCheck,
+				Severity: hooks.SeverityWarn,
+				Message: fmt.Sprintf("version %s lacks the scale subresource required by the traits %s",
+					v.Name, strings.Join(traitNames, ", ")),
+			}
+			// .spec.replicas and .status.replicas are a guess following the
+			// convention of the built-in workloads, they must match the CRD schema
+			scale := crdv1.CustomResourceSubresourceScale{SpecReplicasPath: ".spec.replicas", StatusReplicasPath: ".status.replicas"}
+			if v.Subresources == nil {
+				f.Patch = []PatchOperation{{Op: "add", Path: fmt.Sprintf("/spec/versions/%d/subresources", i),
+					Value: crdv1.CustomResourceSubresources{Scale: &scale}}}
+			} else {
+				f.Patch = []PatchOperation{{Op: "add", Path: fmt.Sprintf("/spec/versions/%d/subresources/scale", i), Value: scale}}
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+// appliesToWorkload tells if td applies to the component definition compDef
+// whose workload is the CRD crdName. Traits without appliesToWorkloads apply
+// to every workload.
+func appliesToWorkload(td v1beta1.TraitDefinition, compDef, crdName string) bool {
+	if len(td.Spec.AppliesToWorkloads) == 0 {
+		return true
+	}
+	for _, w := range td.Spec.AppliesToWorkloads {
+		if w == "*" || w == compDef || w == crdName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Scale subresource", func() {
+	workloadCRD := func(name, group, kind string, subresources *crdv1.CustomResourceSubresources) *crdv1.CustomResourceDefinition {
+		return &crdv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group: group,
+				Names: crdv1.CustomResourceDefinitionNames{Kind: kind},
+				Versions: []crdv1.CustomResourceDefinitionVersion{
+					{Name: "v1", Served: true, Storage: true, Subresources: subresources},
+				},
+			},
+		}
+	}
+	compDef := func(name, apiVersion, kind string) *v1beta1.ComponentDefinition {
+		return &v1beta1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vela-system"},
+			Spec: v1beta1.ComponentDefinitionSpec{
+				Workload: common.WorkloadTypeDescriptor{Definition: common.WorkloadGVK{APIVersion: apiVersion, Kind: kind}},
+			},
+		}
+	}
+	traitDef := func(name string, appliesTo ...string) *v1beta1.TraitDefinition {
+		return &v1beta1.TraitDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vela-system"},
+			Spec:       v1beta1.TraitDefinitionSpec{AppliesToWorkloads: appliesTo},
+		}
+	}
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(objs...).Build()
+	}
+
+	It("should report workload CRDs targeted by a scale trait without the scale subresource", func() {
+		cli := newClient(
+			workloadCRD("gears.example.com", "example.com", "Gear", nil),
+			workloadCRD("cogs.example.com", "example.com", "Cog",
+				&crdv1.CustomResourceSubresources{Scale: &crdv1.CustomResourceSubresourceScale{SpecReplicasPath: ".spec.replicas"}}),
+			workloadCRD("wheels.example.com", "example.com", "Wheel", &crdv1.CustomResourceSubresources{Status: &crdv1.CustomResourceSubresourceStatus{}}),
+			compDef("gear", "example.com/v1", "Gear"),
+			compDef("cog", "example.com/v1", "Cog"),
+			compDef("wheel", "example.com/v1", "Wheel"),
+			compDef("webservice", "apps/v1", "Deployment"),
+			traitDef("scaler", "gear", "cogs.example.com", "wheels.example.com", "deployments.apps"),
+		)
+		findings, err := crdvalidation.ValidateScaleSubresources(context.Background(), cli, []string{"scaler"})
+		Expect(err).Should(Succeed())
+		Expect(findings).Should(HaveLen(2))
+		Expect(findings[0].CRD).Should(Equal("gears.example.com"))
+		Expect(findings[0].Check).Should(Equal("ScaleSubresource"))
+		Expect(findings[0].Message).Should(ContainSubstring("required by the traits scaler"))
+		Expect(findings[0].PatchJSON()).Should(Equal(
+			`[{"op":"add","path":"/spec/versions/0/subresources","value":{"scale":{"specReplicasPath":".spec.replicas","statusReplicasPath":".status.replicas"}}}]`))
+		Expect(findings[1].CRD).Should(Equal("wheels.example.com"))
+		Expect(findings[1].Patch[0].Path).Should(Equal("/spec/versions/0/subresources/scale"))
+	})
+
+	It("should ignore workloads the scale traits do not apply to", func() {
+		cli := newClient(
+			workloadCRD("gears.example.com", "example.com", "Gear", nil),
+			compDef("gear", "example.com/v1", "Gear"),
+			traitDef("scaler", "webservice"),
+			traitDef("labels"),
+		)
+		findings, err := crdvalidation.ValidateScaleSubresources(context.Background(), cli, []string{"scaler"})
+		Expect(err).Should(Succeed())
+		Expect(findings).Should(BeEmpty())
+
+		findings, err = crdvalidation.ValidateScaleSubresources(context.Background(), cli, nil)
+		Expect(err).Should(Succeed())
+		Expect(findings).Should(BeEmpty())
+	})
+})
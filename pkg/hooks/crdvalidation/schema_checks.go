@@ -0,0 +1,365 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// PatchOperation is a JSON patch (RFC 6902) operation on a CRD.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// SchemaFinding is a deviation of an installed CRD from the best practices of
+// the bundled CRD, with a JSON patch that fixes it.
+type SchemaFinding struct {
+	CRD      string
+	Check    string
+	Severity hooks.Severity
+	Message  string
+	Patch    []PatchOperation
+}
+
+// PatchJSON returns the suggested patch, ready for
+// `kubectl patch crd <name> --type=json -p '<patch>'`.
+func (f SchemaFinding) PatchJSON() string {
+	data, err := json.Marshal(f.Patch)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// String returns the finding with its suggested patch.
+func (f SchemaFinding) String() string {
+	if len(f.Patch) == 0 {
+		return fmt.Sprintf("CRD %s: %s", f.CRD, f.Message)
+	}
+	return fmt.Sprintf("CRD %s: %s, fix with: kubectl patch crd %s --type=json -p '%s'", f.CRD, f.Message, f.CRD, f.PatchJSON())
+}
+
+// SchemaCheck is a check of installed CRDs run by the CRD validation hook.
+// Additional checks, e.g. for the CRDs installed by an addon, are added with
+// RegisterSchemaCheck.
+type SchemaCheck struct {
+	// Name identifies the check in its findings and must be unique.
+	Name string
+	// Severity of the findings of the check. SeverityBlock findings fail the
+	// hook, SeverityWarn findings only fail it when schema best practices are
+	// enforced and SeverityInfo findings are only logged.
+	Severity hooks.Severity
+	// Selector picks the installed CRDs the check runs on. Checks without a
+	// selector run on the CRDs validated by the hook.
+	Selector func(crd *crdv1.CustomResourceDefinition) bool
+	// Check returns the findings for the installed CRD. bundled is the CRD of
+	// the same name bundled in vela-core, or nil if there is none.
+	Check func(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding
+}
+
+var (
+	schemaCheckMu sync.RWMutex
+	schemaChecks  []SchemaCheck
+)
+
+func init() {
+	for _, sc := range []SchemaCheck{
+		{Name: "PrinterColumns", Severity: hooks.SeverityWarn, Check: bundledOnly(checkPrinterColumns)},
+		{Name: "Categories", Severity: hooks.SeverityWarn, Check: bundledOnly(checkCategories)},
+		{Name: "Subresources", Severity: hooks.SeverityWarn, Check: bundledOnly(checkSubresources)},
+	} {
+		if err := RegisterSchemaCheck(sc); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterSchemaCheck adds check to the checks run by the CRD validation hook.
+// It must be called before the hook runs, typically from an init function.
+func RegisterSchemaCheck(check SchemaCheck) error {
+	if check.Name == "" || check.Check == nil {
+		return fmt.Errorf("schema check must have a name and a check function")
+	}
+	if check.Severity == "" {
+		check.Severity = hooks.SeverityWarn
+	}
+	schemaCheckMu.Lock()
+	defer schemaCheckMu.Unlock()
+	for _, sc := range schemaChecks {
+		if sc.Name == check.Name {
+			return fmt.Errorf("schema check %s is already registered", check.Name)
+		}
+	}
+	schemaChecks = append(schemaChecks, check)
+	return nil
+}
+
+// SchemaChecks returns a copy of the registered schema checks.
+func SchemaChecks() []SchemaCheck {
+	schemaCheckMu.RLock()
+	defer schemaCheckMu.RUnlock()
+	return append([]SchemaCheck(nil), schemaChecks...)
+}
+
+// SelectGroups returns a SchemaCheck selector picking the CRDs of the given
+// API groups.
+func SelectGroups(groups ...string) func(crd *crdv1.CustomResourceDefinition) bool {
+	return func(crd *crdv1.CustomResourceDefinition) bool {
+		return slices.Contains(groups, crd.Spec.Group)
+	}
+}
+
+// bundledOnly skips check for CRDs that are not bundled in vela-core.
+func bundledOnly(check func(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding) func(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	return func(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+		if bundled == nil {
+			return nil
+		}
+		return check(installed, bundled)
+	}
+}
+
+// RunSchemaChecks runs the registered schema checks. Checks without a
+// selector run on each named CRD, the others on every installed CRD they
+// select. CRDs that are not installed are skipped.
+func RunSchemaChecks(ctx context.Context, c client.Client, names []string) ([]SchemaFinding, error) {
+	checks := SchemaChecks()
+	crds := map[string]*crdv1.CustomResourceDefinition{}
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		crds[name] = crd
+	}
+	if slices.ContainsFunc(checks, func(sc SchemaCheck) bool { return sc.Selector != nil }) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(crdv1.SchemeGroupVersion.WithKind("CustomResourceDefinitionList"))
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list CRDs: %w", err)
+		}
+		for _, item := range list.Items {
+			crd := &crdv1.CustomResourceDefinition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, crd); err != nil {
+				return nil, fmt.Errorf("failed to convert CRD %s: %w", item.GetName(), err)
+			}
+			if _, ok := crds[crd.Name]; !ok {
+				crds[crd.Name] = crd
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(crds))
+	for name := range crds {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	var findings []SchemaFinding
+	for _, name := range sorted {
+		bundled, _, err := bundledTypedCRD(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range checks {
+			if (sc.Selector == nil && !slices.Contains(names, name)) || (sc.Selector != nil && !sc.Selector(crds[name])) {
+				continue
+			}
+			findings = append(findings, runSchemaCheck(sc, crds[name], bundled)...)
+		}
+	}
+	return findings, nil
+}
+
+// CompareSchemaBestPractices runs every registered schema check selecting the
+// installed CRD against the bundled one.
+func CompareSchemaBestPractices(name string, installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	var findings []SchemaFinding
+	for _, sc := range SchemaChecks() {
+		if sc.Selector != nil && !sc.Selector(installed) {
+			continue
+		}
+		findings = append(findings, runSchemaCheck(sc, installed, bundled)...)
+	}
+	return findings
+}
+
+// runSchemaCheck runs sc and labels its findings with the CRD, check and severity.
+func runSchemaCheck(sc SchemaCheck, installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	var findings []SchemaFinding
+	for _, f := range sc.Check(installed, bundled) {
+		f.CRD, f.Check = installed.Name, sc.Name
+		if f.Severity == "" {
+			f.Severity = sc.Severity
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// failingFindings returns the findings that fail the hook.
+func failingFindings(findings []SchemaFinding, enforce bool) []SchemaFinding {
+	var failing []SchemaFinding
+	for _, f := range findings {
+		if f.Severity == hooks.SeverityBlock || (enforce && f.Severity == hooks.SeverityWarn) {
+			failing = append(failing, f)
+		}
+	}
+	return failing
+}
+
+// findingsToError joins findings into a single error, or returns nil if there is none.
+func findingsToError(findings []SchemaFinding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(findings))
+	for _, f := range findings {
+		msgs = append(msgs, f.String())
+	}
+	return fmt.Errorf("CRDs do not follow best practices: %s", strings.Join(msgs, "; "))
+}
+
+// versionPair is a version served by the installed CRD, at index in its
+// spec.versions, and the bundled version of the same name.
+type versionPair struct {
+	index   int
+	bundled crdv1.CustomResourceDefinitionVersion
+}
+
+// servedVersions pairs every version served by installed with the bundled
+// version of the same name.
+func servedVersions(installed, bundled *crdv1.CustomResourceDefinition) []versionPair {
+	var pairs []versionPair
+	for i, iv := range installed.Spec.Versions {
+		if !iv.Served {
+			continue
+		}
+		for _, bv := range bundled.Spec.Versions {
+			if bv.Name == iv.Name {
+				pairs = append(pairs, versionPair{index: i, bundled: bv})
+			}
+		}
+	}
+	return pairs
+}
+
+// checkPrinterColumns reports the printer columns of the bundled CRD missing
+// from the installed one.
+func checkPrinterColumns(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	var findings []SchemaFinding
+	for _, pair := range servedVersions(installed, bundled) {
+		i, bv := pair.index, pair.bundled
+		iv := installed.Spec.Versions[i]
+		var missing []crdv1.CustomResourceColumnDefinition
+		for _, col := range bv.AdditionalPrinterColumns {
+			if !slices.ContainsFunc(iv.AdditionalPrinterColumns, func(c crdv1.CustomResourceColumnDefinition) bool { return c.Name == col.Name }) {
+				missing = append(missing, col)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(missing))
+		for _, col := range missing {
+			names = append(names, col.Name)
+		}
+		f := SchemaFinding{Message: fmt.Sprintf("version %s lacks the printer columns %s", iv.Name, strings.Join(names, ", "))}
+		path := fmt.Sprintf("/spec/versions/%d/additionalPrinterColumns", i)
+		if len(iv.AdditionalPrinterColumns) == 0 {
+			f.Patch = []PatchOperation{{Op: "add", Path: path, Value: missing}}
+		} else {
+			for _, col := range missing {
+				f.Patch = append(f.Patch, PatchOperation{Op: "add", Path: path + "/-", Value: col})
+			}
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// checkCategories reports the categories of the bundled CRD missing from the
+// installed one, which hide the resources from `kubectl get <category>`.
+func checkCategories(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	var missing []string
+	for _, cat := range bundled.Spec.Names.Categories {
+		if !slices.Contains(installed.Spec.Names.Categories, cat) {
+			missing = append(missing, cat)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	f := SchemaFinding{Message: fmt.Sprintf("lacks the categories %s", strings.Join(missing, ", "))}
+	if len(installed.Spec.Names.Categories) == 0 {
+		f.Patch = []PatchOperation{{Op: "add", Path: "/spec/names/categories", Value: missing}}
+	} else {
+		for _, cat := range missing {
+			f.Patch = append(f.Patch, PatchOperation{Op: "add", Path: "/spec/names/categories/-", Value: cat})
+		}
+	}
+	return []SchemaFinding{f}
+}
+
+// checkSubresources reports the status subresource of the bundled CRD missing
+// from the installed one. Without it status updates of the controller also
+// rewrite the spec and bump the generation.
+func checkSubresources(installed, bundled *crdv1.CustomResourceDefinition) []SchemaFinding {
+	var findings []SchemaFinding
+	for _, pair := range servedVersions(installed, bundled) {
+		i, bv := pair.index, pair.bundled
+		iv := installed.Spec.Versions[i]
+		if bv.Subresources == nil || bv.Subresources.Status == nil || (iv.Subresources != nil && iv.Subresources.Status != nil) {
+			continue
+		}
+		f := SchemaFinding{Message: fmt.Sprintf("version %s lacks the status subresource", iv.Name)}
+		if iv.Subresources == nil {
+			f.Patch = []PatchOperation{{Op: "add", Path: fmt.Sprintf("/spec/versions/%d/subresources", i), Value: map[string]interface{}{"status": map[string]interface{}{}}}}
+		} else {
+			f.Patch = []PatchOperation{{Op: "add", Path: fmt.Sprintf("/spec/versions/%d/subresources/status", i), Value: map[string]interface{}{}}}
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// logSchemaFindings logs each finding with its patch.
+func logSchemaFindings(findings []SchemaFinding) {
+	for _, f := range findings {
+		klog.InfoS("CRD does not follow best practices", "crd", f.CRD, "check", f.Check, "severity", f.Severity,
+			"finding", f.Message, "patch", f.PatchJSON())
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Schema best practices", func() {
+	const name = "applicationrevisions.core.oam.dev"
+
+	bundled := func() *crdv1.CustomResourceDefinition {
+		u, ok, err := crdvalidation.BundledCRD(name)
+		Expect(err).Should(Succeed())
+		Expect(ok).Should(BeTrue())
+		crd := &crdv1.CustomResourceDefinition{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd)).Should(Succeed())
+		return crd
+	}
+
+	It("should report nothing for a CRD matching the bundled one", func() {
+		Expect(crdvalidation.CompareSchemaBestPractices(name, bundled(), bundled())).Should(BeEmpty())
+	})
+
+	It("should suggest patches for missing printer columns, categories and subresources", func() {
+		installed := bundled()
+		installed.Spec.Names.Categories = nil
+		installed.Spec.Versions[0].AdditionalPrinterColumns = installed.Spec.Versions[0].AdditionalPrinterColumns[:1]
+		installed.Spec.Versions[0].Subresources = nil
+
+		findings := crdvalidation.CompareSchemaBestPractices(name, installed, bundled())
+		Expect(findings).Should(HaveLen(3))
+		checks := map[string]crdvalidation.SchemaFinding{}
+		for _, f := range findings {
+			Expect(f.CRD).Should(Equal(name))
+			checks[f.Check] = f
+		}
+
+		Expect(checks["PrinterColumns"].Patch).ShouldNot(BeEmpty())
+		Expect(checks["PrinterColumns"].Patch[0].Path).Should(Equal("/spec/versions/0/additionalPrinterColumns/-"))
+		Expect(checks["Categories"].PatchJSON()).Should(Equal(`[{"op":"add","path":"/spec/names/categories","value":["oam"]}]`))
+		Expect(checks["Subresources"].PatchJSON()).Should(Equal(`[{"op":"add","path":"/spec/versions/0/subresources","value":{"status":{}}}]`))
+		Expect(checks["Categories"].String()).Should(ContainSubstring("kubectl patch crd " + name + " --type=json"))
+	})
+
+	It("should accept the installed CRDs", func() {
+		findings, err := crdvalidation.RunSchemaChecks(context.Background(), singleton.KubeClient.Get(), []string{name})
+		Expect(err).Should(Succeed())
+		Expect(findings).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("Schema check registry", func() {
+	It("should run registered checks on the CRDs they select", func() {
+		Expect(crdvalidation.RegisterSchemaCheck(crdvalidation.SchemaCheck{
+			Name:     "SingleVersion",
+			Severity: hooks.SeverityBlock,
+			Selector: crdvalidation.SelectGroups("registry.example.com"),
+			Check: func(installed, _ *crdv1.CustomResourceDefinition) []crdvalidation.SchemaFinding {
+				if len(installed.Spec.Versions) < 2 {
+					return nil
+				}
+				return []crdvalidation.SchemaFinding{{Message: "serves several versions"}}
+			},
+		})).Should(Succeed())
+		Expect(crdvalidation.RegisterSchemaCheck(crdvalidation.SchemaCheck{
+			Name:  "SingleVersion",
+			Check: func(_, _ *crdv1.CustomResourceDefinition) []crdvalidation.SchemaFinding { return nil },
+		})).ShouldNot(Succeed())
+		Expect(crdvalidation.RegisterSchemaCheck(crdvalidation.SchemaCheck{Name: "Empty"})).ShouldNot(Succeed())
+
+		crd := func(name, group string, versions ...string) *crdv1.CustomResourceDefinition {
+			obj := &crdv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       crdv1.CustomResourceDefinitionSpec{Group: group},
+			}
+			for _, v := range versions {
+				obj.Spec.Versions = append(obj.Spec.Versions, crdv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+			}
+			return obj
+		}
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(
+			crd("gears.registry.example.com", "registry.example.com", "v1alpha1", "v1"),
+			crd("cogs.registry.example.com", "registry.example.com", "v1"),
+			crd("gears.example.com", "example.com", "v1alpha1", "v1"),
+		).Build()
+		findings, err := crdvalidation.RunSchemaChecks(context.Background(), cli, nil)
+		Expect(err).Should(Succeed())
+		Expect(findings).Should(Equal([]crdvalidation.SchemaFinding{{
+			CRD:      "gears.registry.example.com",
+			Check:    "SingleVersion",
+			Severity: hooks.SeverityBlock,
+			Message:  "serves several versions",
+		}}))
+	})
+})
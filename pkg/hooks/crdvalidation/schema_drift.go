@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SchemaDrift describes how the schema of an installed CRD version differs from
+// the schema bundled with this vela-core binary.
+type SchemaDrift struct {
+	CRD     string
+	Version string
+	// MissingVersion is set when the bundled version is not declared by the installed CRD.
+	MissingVersion bool
+	// Added lists fields declared by the installed CRD but not by the bundled one.
+	Added []string
+	// Removed lists fields declared by the bundled CRD but not by the installed one.
+	Removed []string
+	// Changed lists fields whose type or format differ, as "<path> (<installed> -> <bundled>)".
+	Changed []string
+}
+
+// Breaking returns true if the installed schema can lose data the controller writes.
+// Added fields are tolerated since they only mean the cluster runs newer CRDs.
+func (d SchemaDrift) Breaking() bool {
+	return d.MissingVersion || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// String returns a human readable summary of the drift.
+func (d SchemaDrift) String() string {
+	if d.MissingVersion {
+		return fmt.Sprintf("CRD %s does not serve version %s", d.CRD, d.Version)
+	}
+	var parts []string
+	if len(d.Removed) > 0 {
+		parts = append(parts, "removed fields "+strings.Join(d.Removed, ", "))
+	}
+	if len(d.Changed) > 0 {
+		parts = append(parts, "changed fields "+strings.Join(d.Changed, ", "))
+	}
+	if len(d.Added) > 0 {
+		parts = append(parts, "added fields "+strings.Join(d.Added, ", "))
+	}
+	return fmt.Sprintf("CRD %s version %s drifted from the bundled schema: %s", d.CRD, d.Version, strings.Join(parts, "; "))
+}
+
+// SchemaDigest returns a stable digest of the structural part of a schema, i.e.
+// the field paths and their types. Descriptions and other documentation-only
+// attributes do not affect the digest.
+func SchemaDigest(s *crdv1.CustomResourceValidation) string {
+	fields := flattenSchema(s)
+	paths := make([]string, 0, len(fields))
+	for p := range fields {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, p := range paths {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", p, fields[p])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// BundledSchemaDigests returns the digest of every version of the named bundled CRD.
+func BundledSchemaDigests(name string) (map[string]string, error) {
+	crd, ok, err := bundledTypedCRD(name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	digests := make(map[string]string, len(crd.Spec.Versions))
+	for _, v := range crd.Spec.Versions {
+		digests[v.Name] = SchemaDigest(v.Schema)
+	}
+	return digests, nil
+}
+
+// DetectSchemaDrift compares the installed schema of each named CRD with the
+// bundled one. CRDs that are not bundled or not installed are skipped, since
+// their presence is checked separately.
+func DetectSchemaDrift(ctx context.Context, c client.Client, names []string) ([]SchemaDrift, error) {
+	var drifts []SchemaDrift
+	for _, name := range names {
+		expected, ok, err := bundledTypedCRD(name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			klog.V(3).InfoS("CRD is not bundled, skipping drift detection", "crd", name)
+			continue
+		}
+		installed, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		drifts = append(drifts, diffCRDSchemas(name, installed, expected)...)
+	}
+	return drifts, nil
+}
+
+// diffCRDSchemas compares each bundled version with the installed version of the same name.
+func diffCRDSchemas(name string, installed, expected *crdv1.CustomResourceDefinition) []SchemaDrift {
+	var drifts []SchemaDrift
+	for _, ev := range expected.Spec.Versions {
+		var iv *crdv1.CustomResourceDefinitionVersion
+		for i := range installed.Spec.Versions {
+			if installed.Spec.Versions[i].Name == ev.Name {
+				iv = &installed.Spec.Versions[i]
+				break
+			}
+		}
+		if iv == nil || !iv.Served {
+			drifts = append(drifts, SchemaDrift{CRD: name, Version: ev.Name, MissingVersion: true})
+			continue
+		}
+		if SchemaDigest(iv.Schema) == SchemaDigest(ev.Schema) {
+			continue
+		}
+		want, got := flattenSchema(ev.Schema), flattenSchema(iv.Schema)
+		d := SchemaDrift{CRD: name, Version: ev.Name}
+		for p, t := range want {
+			switch gt, ok := got[p]; {
+			case !ok:
+				d.Removed = append(d.Removed, p)
+			case gt != t:
+				d.Changed = append(d.Changed, fmt.Sprintf("%s (%s -> %s)", p, gt, t))
+			}
+		}
+		for p := range got {
+			if _, ok := want[p]; !ok {
+				d.Added = append(d.Added, p)
+			}
+		}
+		sort.Strings(d.Removed)
+		sort.Strings(d.Changed)
+		sort.Strings(d.Added)
+		drifts = append(drifts, d)
+	}
+	return drifts
+}
+
+// flattenSchema maps every field path of the schema to its type. Array items
+// are addressed with a "[]" suffix and preserved-unknown objects with "*".
+func flattenSchema(s *crdv1.CustomResourceValidation) map[string]string {
+	fields := map[string]string{}
+	if s != nil && s.OpenAPIV3Schema != nil {
+		flattenProps("", s.OpenAPIV3Schema, fields)
+	}
+	return fields
+}
+
+func flattenProps(prefix string, s *crdv1.JSONSchemaProps, fields map[string]string) {
+	t := s.Type
+	if s.Format != "" {
+		t += "/" + s.Format
+	}
+	if s.XIntOrString {
+		t = "int-or-string"
+	}
+	if s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields {
+		t += "*"
+	}
+	if prefix != "" {
+		fields[prefix] = t
+	}
+	for name, prop := range s.Properties {
+		p := name
+		if prefix != "" {
+			p = prefix + "." + name
+		}
+		flattenProps(p, &prop, fields)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		flattenProps(prefix+"[]", s.Items.Schema, fields)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		flattenProps(prefix+".*", s.AdditionalProperties.Schema, fields)
+	}
+}
+
+// bundledTypedCRD returns the bundled CRD converted to its typed representation.
+func bundledTypedCRD(name string) (*crdv1.CustomResourceDefinition, bool, error) {
+	u, ok, err := BundledCRD(name)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	crd := &crdv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, false, fmt.Errorf("failed to convert bundled CRD %s: %w", name, err)
+	}
+	return crd, true, nil
+}
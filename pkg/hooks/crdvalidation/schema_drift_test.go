@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("CRD schema drift", func() {
+	It("should ignore documentation-only changes in the schema digest", func() {
+		schema := func(desc string) *crdv1.CustomResourceValidation {
+			return &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]crdv1.JSONSchemaProps{
+					"spec": {Type: "object", Description: desc, Properties: map[string]crdv1.JSONSchemaProps{
+						"replicas": {Type: "integer", Format: "int32"},
+					}},
+				},
+			}}
+		}
+		Expect(crdvalidation.SchemaDigest(schema("a"))).Should(Equal(crdvalidation.SchemaDigest(schema("b"))))
+
+		changed := schema("a")
+		changed.OpenAPIV3Schema.Properties["spec"].Properties["replicas"] = crdv1.JSONSchemaProps{Type: "string"}
+		Expect(crdvalidation.SchemaDigest(changed)).ShouldNot(Equal(crdvalidation.SchemaDigest(schema("a"))))
+	})
+
+	It("should compute digests for every bundled version", func() {
+		digests, err := crdvalidation.BundledSchemaDigests("applicationrevisions.core.oam.dev")
+		Expect(err).Should(Succeed())
+		Expect(digests).Should(HaveKey("v1beta1"))
+		Expect(digests["v1beta1"]).Should(HaveLen(64))
+	})
+
+	It("should report fields missing from an outdated CRD", func() {
+		drifts, err := crdvalidation.DetectSchemaDrift(context.Background(), singleton.KubeClient.Get(),
+			[]string{"applicationrevisions.core.oam.dev", "widgets.example.com"})
+		Expect(err).Should(Succeed())
+		Expect(drifts).Should(HaveLen(1))
+		Expect(drifts[0].Version).Should(Equal("v1beta1"))
+		Expect(drifts[0].Breaking()).Should(BeTrue())
+		Expect(drifts[0].Removed).Should(ContainElement("spec.compression"))
+		Expect(drifts[0].Added).Should(ContainElement("spec.scopeDefinitions"))
+		Expect(drifts[0].String()).Should(ContainSubstring("removed fields"))
+	})
+})
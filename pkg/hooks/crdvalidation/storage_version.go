@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+)
+
+// storageVersionMigrationGVK is the in-tree StorageVersionMigration API. It is
+// alpha and only served when the StorageVersionMigrator feature is enabled.
+var storageVersionMigrationGVK = schema.GroupVersionKind{
+	Group:   "storagemigration.k8s.io",
+	Version: "v1alpha1",
+	Kind:    "StorageVersionMigration",
+}
+
+// StaleStoredVersion describes a CRD whose status.storedVersions still lists
+// versions that are deprecated or no longer served. Objects stored in such a
+// version must be migrated before the version can be removed from the CRD.
+type StaleStoredVersion struct {
+	CRD            string
+	Resource       schema.GroupResource
+	StorageVersion string
+	Stale          []string
+}
+
+// String returns a human readable summary of the stale versions.
+func (s StaleStoredVersion) String() string {
+	return fmt.Sprintf("CRD %s still has objects stored in version(s) %s, current storage version is %s",
+		s.CRD, strings.Join(s.Stale, ", "), s.StorageVersion)
+}
+
+// DetectStaleStoredVersions returns the named CRDs whose stored versions include
+// a version that is deprecated, not served or no longer declared. CRDs that are
+// not installed are skipped.
+func DetectStaleStoredVersions(ctx context.Context, c client.Client, names []string) ([]StaleStoredVersion, error) {
+	var result []StaleStoredVersion
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		if s, ok := staleStoredVersions(crd); ok {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// staleStoredVersions compares the stored versions of crd with its declared versions.
+func staleStoredVersions(crd *crdv1.CustomResourceDefinition) (StaleStoredVersion, bool) {
+	s := StaleStoredVersion{
+		CRD:      crd.Name,
+		Resource: schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural},
+	}
+	versions := map[string]crdv1.CustomResourceDefinitionVersion{}
+	for _, v := range crd.Spec.Versions {
+		versions[v.Name] = v
+		if v.Storage {
+			s.StorageVersion = v.Name
+		}
+	}
+	for _, stored := range crd.Status.StoredVersions {
+		if stored == s.StorageVersion {
+			continue
+		}
+		if v, ok := versions[stored]; !ok || !v.Served || v.Deprecated {
+			s.Stale = append(s.Stale, stored)
+		}
+	}
+	return s, len(s.Stale) > 0
+}
+
+// MigrateStoredVersions creates a StorageVersionMigration that rewrites every
+// object of the CRD in its current storage version. The API server removes the
+// stale versions from status.storedVersions once the migration succeeded. An
+// existing migration created by a previous run is left untouched.
+func MigrateStoredVersions(ctx context.Context, c client.Client, s StaleStoredVersion) error {
+	svm := &unstructured.Unstructured{}
+	svm.SetGroupVersionKind(storageVersionMigrationGVK)
+	svm.SetName(fmt.Sprintf("%s-%s-%s", types.VelaCoreName, s.CRD, s.StorageVersion))
+	if err := unstructured.SetNestedStringMap(svm.Object, map[string]string{
+		"group":    s.Resource.Group,
+		"version":  s.StorageVersion,
+		"resource": s.Resource.Resource,
+	}, "spec", "resource"); err != nil {
+		return err
+	}
+	klog.InfoS("Creating StorageVersionMigration", "crd", s.CRD, "storageVersion", s.StorageVersion, "stale", s.Stale)
+	if err := c.Create(ctx, svm); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			klog.V(2).InfoS("StorageVersionMigration already exists", "name", svm.GetName())
+			return nil
+		}
+		return fmt.Errorf("failed to create StorageVersionMigration for CRD %s: %w", s.CRD, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Stored version detection", func() {
+	It("should not report CRDs that only store their storage version", func() {
+		stale, err := crdvalidation.DetectStaleStoredVersions(context.Background(), singleton.KubeClient.Get(),
+			[]string{"applicationrevisions.core.oam.dev", "widgets.example.com"})
+		Expect(err).Should(Succeed())
+		Expect(stale).Should(BeEmpty())
+	})
+
+	It("should report deprecated versions left in storedVersions", func() {
+		ctx := context.Background()
+		cli := singleton.KubeClient.Get()
+		schema := &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+			Type: "object", XPreserveUnknownFields: ptr.To(true),
+		}}
+		crd := &crdv1.CustomResourceDefinition{
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group: "precheck.oam.dev",
+				Names: crdv1.CustomResourceDefinitionNames{Plural: "gears", Kind: "Gear", ListKind: "GearList"},
+				Scope: crdv1.NamespaceScoped,
+				Versions: []crdv1.CustomResourceDefinitionVersion{
+					{Name: "v1alpha1", Served: true, Storage: true, Schema: schema},
+				},
+			},
+		}
+		crd.Name = "gears.precheck.oam.dev"
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		Expect(err).Should(Succeed())
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetAPIVersion("apiextensions.k8s.io/v1")
+		u.SetKind("CustomResourceDefinition")
+		Expect(cli.Create(ctx, u)).Should(Succeed())
+		defer func() { Expect(cli.Delete(ctx, u)).Should(Succeed()) }()
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(u), u)).Should(Succeed())
+		Expect(unstructured.SetNestedSlice(u.Object, []interface{}{
+			map[string]interface{}{"name": "v1alpha1", "served": true, "storage": false, "deprecated": true,
+				"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object", "x-kubernetes-preserve-unknown-fields": true}}},
+			map[string]interface{}{"name": "v1", "served": true, "storage": true,
+				"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object", "x-kubernetes-preserve-unknown-fields": true}}},
+		}, "spec", "versions")).Should(Succeed())
+		Expect(cli.Update(ctx, u)).Should(Succeed())
+
+		stale, err := crdvalidation.DetectStaleStoredVersions(ctx, cli, []string{crd.Name})
+		Expect(err).Should(Succeed())
+		Expect(stale).Should(HaveLen(1))
+		Expect(stale[0].StorageVersion).Should(Equal("v1"))
+		Expect(stale[0].Stale).Should(Equal([]string{"v1alpha1"}))
+		Expect(stale[0].Resource.Resource).Should(Equal("gears"))
+		Expect(stale[0].String()).Should(ContainSubstring("still has objects stored in version(s) v1alpha1"))
+	})
+})
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+// CRDTiming is the time spent validating a single CRD.
+type CRDTiming struct {
+	CRD      string
+	Duration time.Duration
+}
+
+// validationPool runs per-CRD validations on a bounded number of workers,
+// throttles them with a client-side rate limiter and records how long each
+// CRD took.
+type validationPool struct {
+	concurrency int
+	limiter     flowcontrol.RateLimiter
+
+	mu      sync.Mutex
+	timings []CRDTiming
+}
+
+// newValidationPool returns a pool running up to concurrency validations at
+// once, starting at most qps of them per second. A non-positive concurrency
+// runs the validations one after another and a non-positive qps disables the
+// throttling.
+func newValidationPool(concurrency int, qps float32) *validationPool {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	p := &validationPool{concurrency: concurrency}
+	if qps > 0 {
+		p.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, concurrency)
+	}
+	return p
+}
+
+// run calls validate for every index of names and returns the first error.
+// Validations that have not started when an error occurs are not run.
+func (p *validationPool) run(ctx context.Context, names []string, validate func(ctx context.Context, i int) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(p.concurrency)
+	for i := range names {
+		g.Go(func() error {
+			if p.limiter != nil {
+				if err := p.limiter.Wait(ctx); err != nil {
+					return err
+				}
+			}
+			start := time.Now()
+			err := validate(ctx, i)
+			p.record(names[i], time.Since(start))
+			return err
+		})
+	}
+	return g.Wait()
+}
+
+func (p *validationPool) record(name string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timings = append(p.timings, CRDTiming{CRD: name, Duration: d})
+}
+
+// Timings returns the recorded timings, slowest first.
+func (p *validationPool) Timings() []CRDTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	timings := append([]CRDTiming(nil), p.timings...)
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].Duration > timings[j].Duration })
+	return timings
+}
+
+// logTimings logs the time spent on every CRD, slowest first.
+func (p *validationPool) logTimings() {
+	timings := p.Timings()
+	if len(timings) == 0 {
+		return
+	}
+	kv := make([]interface{}, 0, 2*len(timings))
+	var total time.Duration
+	for _, t := range timings {
+		kv = append(kv, t.CRD, t.Duration.Round(time.Millisecond).String())
+		total += t.Duration
+	}
+	klog.InfoS("CRD validation timings", append([]interface{}{"crds", len(timings),
+		"total", total.Round(time.Millisecond).String(), "concurrency", p.concurrency}, kv...)...)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Concurrent CRD validation", func() {
+	It("should report the problems of every CRD in order", func() {
+		var objs []client.Object
+		var reqs []crdvalidation.CRDRequirement
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			crdName := name + "s.example.com"
+			reqs = append(reqs, crdvalidation.CRDRequirement{Name: crdName})
+			if name == "b" || name == "e" {
+				continue
+			}
+			objs = append(objs, &crdv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}})
+		}
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(objs...).Build()
+
+		hook := crdvalidation.NewHookWithOptions(cli, crdvalidation.Options{CRDs: reqs, Concurrency: 3, QPS: 100})
+		err := hook.Run(context.Background())
+		Expect(err).Should(HaveOccurred())
+		msg := err.Error()
+		Expect(msg).Should(ContainSubstring("CRD bs.example.com is not installed"))
+		Expect(msg).Should(ContainSubstring("CRD es.example.com is not installed"))
+		Expect(strings.Index(msg, "bs.example.com")).Should(BeNumerically("<", strings.Index(msg, "es.example.com")))
+	})
+})
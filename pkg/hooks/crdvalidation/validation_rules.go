@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/google/cel-go/cel"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/cel/environment"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateValidationRules checks the x-kubernetes-validations (CEL) rules of the
+// CRDs in reqs. Every served version must declare a rule at each path listed in
+// RequiredRules and, for CRDs bundled with vela-core, every rule of the bundled
+// schema. All declared rules must compile. CRDs that are not installed are
+// skipped, since their presence is checked separately.
+func ValidateValidationRules(ctx context.Context, c client.Client, reqs []CRDRequirement) error {
+	env, err := newRuleEnv()
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	var problems []crdProblem
+	for _, req := range reqs {
+		crd, err := getCRD(ctx, c, req.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get CRD %s: %w", req.Name, err)
+		}
+		bundled, _, err := bundledTypedCRD(req.Name)
+		if err != nil {
+			return err
+		}
+		for _, v := range crd.Spec.Versions {
+			if !v.Served {
+				continue
+			}
+			klog.V(2).InfoS("Validating CRD validation rules", "crd", req.Name, "version", v.Name)
+			rules := collectRules(v.Schema)
+			for _, path := range sortedKeys(rules) {
+				for _, rule := range rules[path] {
+					if _, iss := env.Compile(rule); iss != nil && iss.Err() != nil {
+						problems = append(problems, crdProblem{name: req.Name, message: fmt.Sprintf(
+							"CRD %s version %s has a validation rule at %s that does not compile: %v", req.Name, v.Name, rulePath(path), iss.Err())})
+					}
+				}
+			}
+			for _, path := range req.RequiredRules {
+				if len(rules[path]) == 0 {
+					problems = append(problems, crdProblem{name: req.Name, message: fmt.Sprintf(
+						"CRD %s version %s declares no validation rules at %s", req.Name, v.Name, rulePath(path))})
+				}
+			}
+			if bundled == nil {
+				continue
+			}
+			for _, bv := range bundled.Spec.Versions {
+				if bv.Name != v.Name {
+					continue
+				}
+				expected := collectRules(bv.Schema)
+				for _, path := range sortedKeys(expected) {
+					for _, rule := range expected[path] {
+						if !slices.Contains(rules[path], rule) {
+							problems = append(problems, crdProblem{name: req.Name, message: fmt.Sprintf(
+								"CRD %s version %s is missing the validation rule %q at %s", req.Name, v.Name, rule, rulePath(path))})
+						}
+					}
+				}
+			}
+		}
+	}
+	return problemsToError(problems)
+}
+
+// newRuleEnv returns a CEL environment with the libraries available to CRD
+// validation rules. self and oldSelf are declared dynamic, so the compilation
+// catches syntax errors and unknown functions but not type errors.
+func newRuleEnv() (*cel.Env, error) {
+	env, err := environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), true).Env(environment.StoredExpressions)
+	if err != nil {
+		return nil, err
+	}
+	return env.Extend(cel.Variable("self", cel.DynType), cel.Variable("oldSelf", cel.DynType))
+}
+
+// collectRules maps every property path of the schema to the rules declared on it.
+func collectRules(s *crdv1.CustomResourceValidation) map[string][]string {
+	rules := map[string][]string{}
+	if s != nil && s.OpenAPIV3Schema != nil {
+		collectPropRules("", s.OpenAPIV3Schema, rules)
+	}
+	return rules
+}
+
+func collectPropRules(prefix string, s *crdv1.JSONSchemaProps, rules map[string][]string) {
+	for _, r := range s.XValidations {
+		rules[prefix] = append(rules[prefix], r.Rule)
+	}
+	for name, prop := range s.Properties {
+		p := name
+		if prefix != "" {
+			p = prefix + "." + name
+		}
+		collectPropRules(p, &prop, rules)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		collectPropRules(prefix+"[]", s.Items.Schema, rules)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		collectPropRules(prefix+".*", s.AdditionalProperties.Schema, rules)
+	}
+}
+
+// rulePath formats a property path for error messages.
+func rulePath(path string) string {
+	if path == "" {
+		return "the schema root"
+	}
+	return path
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("CRD validation rules", func() {
+	It("should pass for CRDs without expected rules", func() {
+		Expect(crdvalidation.ValidateValidationRules(context.Background(), singleton.KubeClient.Get(), []crdvalidation.CRDRequirement{
+			{Name: "applicationrevisions.core.oam.dev"},
+			{Name: "widgets.example.com", RequiredRules: []string{"spec"}},
+		})).Should(Succeed())
+	})
+
+	It("should report missing rules and accept compiling ones", func() {
+		ctx := context.Background()
+		cli := singleton.KubeClient.Get()
+		crd := &crdv1.CustomResourceDefinition{
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group: "precheck.oam.dev",
+				Names: crdv1.CustomResourceDefinitionNames{Plural: "levers", Kind: "Lever", ListKind: "LeverList"},
+				Scope: crdv1.NamespaceScoped,
+				Versions: []crdv1.CustomResourceDefinitionVersion{{
+					Name: "v1", Served: true, Storage: true,
+					Schema: &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]crdv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]crdv1.JSONSchemaProps{
+									"replicas": {Type: "integer"},
+									"selector": {Type: "string"},
+								},
+								XValidations: crdv1.ValidationRules{{Rule: "!has(self.replicas) || self.replicas >= 0"}},
+							},
+						},
+					}},
+				}},
+			},
+		}
+		crd.Name = "levers.precheck.oam.dev"
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		Expect(err).Should(Succeed())
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetAPIVersion("apiextensions.k8s.io/v1")
+		u.SetKind("CustomResourceDefinition")
+		Expect(cli.Create(ctx, u)).Should(Succeed())
+		defer func() { Expect(cli.Delete(ctx, u)).Should(Succeed()) }()
+
+		Expect(crdvalidation.ValidateValidationRules(ctx, cli, []crdvalidation.CRDRequirement{
+			{Name: crd.Name, RequiredRules: []string{"spec"}},
+		})).Should(Succeed())
+
+		err = crdvalidation.ValidateValidationRules(ctx, cli, []crdvalidation.CRDRequirement{
+			{Name: crd.Name, RequiredRules: []string{"spec", "spec.selector"}},
+		})
+		Expect(err).ShouldNot(Succeed())
+		Expect(err.Error()).Should(ContainSubstring("CRD levers.precheck.oam.dev version v1 declares no validation rules at spec.selector"))
+	})
+})
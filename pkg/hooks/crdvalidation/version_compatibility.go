@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VersionIncompatibility describes how a served version of a multi-version
+// CRD cannot represent the objects stored in its storage version.
+type VersionIncompatibility struct {
+	CRD            string
+	Version        string
+	StorageVersion string
+	// TypeChanges lists fields whose type differs, as "<path> (<version> -> <storage>)".
+	TypeChanges []string
+	// RemovedRequired lists fields required by one of the two versions but not
+	// declared by the other one.
+	RemovedRequired []string
+}
+
+// String returns a human readable summary of the incompatibility.
+func (v VersionIncompatibility) String() string {
+	var parts []string
+	if len(v.TypeChanges) > 0 {
+		parts = append(parts, "changed types of "+strings.Join(v.TypeChanges, ", "))
+	}
+	if len(v.RemovedRequired) > 0 {
+		parts = append(parts, "removed required fields "+strings.Join(v.RemovedRequired, ", "))
+	}
+	return fmt.Sprintf("CRD %s version %s is incompatible with storage version %s: %s",
+		v.CRD, v.Version, v.StorageVersion, strings.Join(parts, "; "))
+}
+
+// CheckVersionCompatibility compares the schema of every served version of
+// the named CRDs with their storage version. Without a conversion webhook the
+// API server only rewrites the apiVersion of stored objects, so a field
+// changing its type or a required field missing from one of the versions
+// breaks the objects read or written through the other one. CRDs converted by
+// a webhook are skipped since the webhook is expected to map their fields.
+func CheckVersionCompatibility(ctx context.Context, c client.Client, names []string) ([]VersionIncompatibility, error) {
+	var incompatibilities []VersionIncompatibility
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == crdv1.WebhookConverter {
+			klog.V(3).InfoS("CRD is converted by a webhook, skipping version compatibility check", "crd", name)
+			continue
+		}
+		incompatibilities = append(incompatibilities, compareVersions(crd)...)
+	}
+	return incompatibilities, nil
+}
+
+// compareVersions compares each served version of crd with its storage version.
+func compareVersions(crd *crdv1.CustomResourceDefinition) []VersionIncompatibility {
+	var storage *crdv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Storage {
+			storage = &crd.Spec.Versions[i]
+		}
+	}
+	if storage == nil {
+		return nil
+	}
+	storageFields, storageRequired := flattenSchema(storage.Schema), requiredFields(storage.Schema)
+	var incompatibilities []VersionIncompatibility
+	for _, v := range crd.Spec.Versions {
+		if !v.Served || v.Name == storage.Name {
+			continue
+		}
+		fields := flattenSchema(v.Schema)
+		inc := VersionIncompatibility{CRD: crd.Name, Version: v.Name, StorageVersion: storage.Name}
+		for p, t := range fields {
+			if st, ok := storageFields[p]; ok && baseType(st) != baseType(t) {
+				inc.TypeChanges = append(inc.TypeChanges, fmt.Sprintf("%s (%s -> %s)", p, t, st))
+			}
+		}
+		inc.RemovedRequired = append(removedRequired(storageRequired, fields), removedRequired(requiredFields(v.Schema), storageFields)...)
+		if len(inc.TypeChanges) == 0 && len(inc.RemovedRequired) == 0 {
+			continue
+		}
+		sort.Strings(inc.TypeChanges)
+		sort.Strings(inc.RemovedRequired)
+		incompatibilities = append(incompatibilities, inc)
+	}
+	return incompatibilities
+}
+
+// removedRequired returns the required fields missing from fields. Fields
+// whose parent is missing too are not reported, the parent already makes the
+// whole subtree unrepresentable and is reported if it is required itself.
+func removedRequired(required map[string]bool, fields map[string]string) []string {
+	var removed []string
+	for p := range required {
+		if _, ok := fields[p]; ok {
+			continue
+		}
+		if parent := parentPath(p); parent != "" {
+			if _, ok := fields[parent]; !ok {
+				continue
+			}
+		}
+		removed = append(removed, p)
+	}
+	return removed
+}
+
+// parentPath returns the path of the object declaring the field at p, in the
+// notation of flattenSchema.
+func parentPath(p string) string {
+	i := strings.LastIndex(p, ".")
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// baseType strips the format and preserve-unknown-fields markers added by
+// flattenSchema, which do not change how stored values are decoded.
+func baseType(t string) string {
+	t = strings.TrimSuffix(t, "*")
+	if i := strings.Index(t, "/"); i >= 0 {
+		t = t[:i]
+	}
+	return t
+}
+
+// requiredFields returns the paths of the required fields of the schema, in
+// the notation of flattenSchema.
+func requiredFields(s *crdv1.CustomResourceValidation) map[string]bool {
+	required := map[string]bool{}
+	if s != nil && s.OpenAPIV3Schema != nil {
+		collectRequired("", s.OpenAPIV3Schema, required)
+	}
+	return required
+}
+
+func collectRequired(prefix string, s *crdv1.JSONSchemaProps, required map[string]bool) {
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+	for _, name := range s.Required {
+		required[join(name)] = true
+	}
+	for name, prop := range s.Properties {
+		collectRequired(join(name), &prop, required)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		collectRequired(prefix+"[]", s.Items.Schema, required)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		collectRequired(prefix+".*", s.AdditionalProperties.Schema, required)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Version compatibility", func() {
+	objectSchema := func(required []string, props map[string]crdv1.JSONSchemaProps) *crdv1.CustomResourceValidation {
+		return &crdv1.CustomResourceValidation{OpenAPIV3Schema: &crdv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]crdv1.JSONSchemaProps{
+				"spec": {Type: "object", Required: required, Properties: props},
+			},
+		}}
+	}
+	gearCRD := func(conversion *crdv1.CustomResourceConversion) *crdv1.CustomResourceDefinition {
+		return &crdv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "gears.example.com"},
+			Spec: crdv1.CustomResourceDefinitionSpec{
+				Group:      "example.com",
+				Conversion: conversion,
+				Versions: []crdv1.CustomResourceDefinitionVersion{
+					{Name: "v1alpha1", Served: true, Schema: objectSchema(nil, map[string]crdv1.JSONSchemaProps{
+						"size":  {Type: "string"},
+						"teeth": {Type: "integer", Format: "int32"},
+					})},
+					{Name: "v1", Served: true, Storage: true, Schema: objectSchema([]string{"ratio"}, map[string]crdv1.JSONSchemaProps{
+						"size":  {Type: "object"},
+						"teeth": {Type: "integer", Format: "int64"},
+						"ratio": {Type: "number"},
+					})},
+					{Name: "v0", Served: false, Schema: objectSchema(nil, nil)},
+				},
+			},
+		}
+	}
+
+	It("should report changed types and removed required fields", func() {
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(gearCRD(nil)).Build()
+		incompatibilities, err := crdvalidation.CheckVersionCompatibility(context.Background(), cli,
+			[]string{"gears.example.com", "widgets.example.com"})
+		Expect(err).Should(Succeed())
+		Expect(incompatibilities).Should(HaveLen(1))
+		inc := incompatibilities[0]
+		Expect(inc.Version).Should(Equal("v1alpha1"))
+		Expect(inc.StorageVersion).Should(Equal("v1"))
+		Expect(inc.TypeChanges).Should(Equal([]string{"spec.size (string -> object)"}))
+		Expect(inc.RemovedRequired).Should(Equal([]string{"spec.ratio"}))
+		Expect(inc.String()).Should(ContainSubstring("is incompatible with storage version v1"))
+	})
+
+	It("should skip CRDs converted by a webhook", func() {
+		cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).
+			WithObjects(gearCRD(&crdv1.CustomResourceConversion{Strategy: crdv1.WebhookConverter})).Build()
+		incompatibilities, err := crdvalidation.CheckVersionCompatibility(context.Background(), cli, []string{"gears.example.com"})
+		Expect(err).Should(Succeed())
+		Expect(incompatibilities).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// VersionLabel is the label recording the release of the chart that
+	// installed a CRD.
+	VersionLabel = "app.kubernetes.io/version"
+	// MaxCRDMinorSkew is the number of minor releases the installed CRDs may
+	// lag behind or run ahead of the controller.
+	MaxCRDMinorSkew = 1
+)
+
+// VersionSkew is a CRD installed by a release too far from the controller's.
+type VersionSkew struct {
+	CRD               string
+	CRDVersion        string
+	ControllerVersion string
+}
+
+// String returns a human readable description of the skew.
+func (s VersionSkew) String() string {
+	return fmt.Sprintf("CRD %s was installed by release %s, more than %d minor version from controller release %s",
+		s.CRD, s.CRDVersion, MaxCRDMinorSkew, s.ControllerVersion)
+}
+
+// CheckVersionSkew compares the VersionLabel of each named CRD with
+// controllerVersion and returns the CRDs whose release is more than
+// MaxCRDMinorSkew minor versions away. Nothing is checked for controllers
+// built without a release version, and CRDs that are not installed or have no
+// valid version label are skipped.
+func CheckVersionSkew(ctx context.Context, c client.Client, names []string, controllerVersion string) ([]VersionSkew, error) {
+	controller, err := version.NewSemver(controllerVersion)
+	if err != nil {
+		klog.V(3).InfoS("Controller has no release version, skipping CRD version skew check", "version", controllerVersion)
+		return nil, nil
+	}
+	var skews []VersionSkew
+	for _, name := range names {
+		crd, err := getCRD(ctx, c, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		label := crd.Labels[VersionLabel]
+		installed, err := version.NewSemver(label)
+		if err != nil {
+			klog.V(3).InfoS("CRD has no release version label, skipping version skew check", "crd", name, "label", label)
+			continue
+		}
+		if minorSkew(installed, controller) > MaxCRDMinorSkew {
+			skews = append(skews, VersionSkew{CRD: name, CRDVersion: label, ControllerVersion: controllerVersion})
+		}
+	}
+	return skews, nil
+}
+
+// minorSkew returns the number of minor versions between a and b. Versions of
+// different major releases are always considered too far apart.
+func minorSkew(a, b *version.Version) int {
+	as, bs := a.Segments(), b.Segments()
+	if as[0] != bs[0] {
+		return MaxCRDMinorSkew + 1
+	}
+	if as[1] > bs[1] {
+		return as[1] - bs[1]
+	}
+	return bs[1] - as[1]
+}
+
+// versionSkewsToError joins skews into a single error, or returns nil if there is none.
+func versionSkewsToError(skews []VersionSkew) error {
+	if len(skews) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(skews))
+	for _, s := range skews {
+		msgs = append(msgs, s.String())
+	}
+	return fmt.Errorf("%s. Please apply the CRDs of the controller release", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+var _ = Describe("Version skew", func() {
+	var cli client.Client
+
+	BeforeEach(func() {
+		crd := func(name, release string) *crdv1.CustomResourceDefinition {
+			obj := &crdv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if release != "" {
+				obj.Labels = map[string]string{crdvalidation.VersionLabel: release}
+			}
+			return obj
+		}
+		cli = fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(
+			crd("applications.core.oam.dev", "v1.10.3"),
+			crd("policies.core.oam.dev", "v1.8.0"),
+			crd("workflows.core.oam.dev", "v2.10.0"),
+			crd("traitdefinitions.core.oam.dev", ""),
+		).Build()
+	})
+
+	It("should report CRDs more than one minor version away from the controller", func() {
+		names := []string{"applications.core.oam.dev", "policies.core.oam.dev", "workflows.core.oam.dev",
+			"traitdefinitions.core.oam.dev", "widgets.example.com"}
+		skews, err := crdvalidation.CheckVersionSkew(context.Background(), cli, names, "v1.11.0-beta.1")
+		Expect(err).Should(Succeed())
+		Expect(skews).Should(HaveLen(2))
+		Expect(skews[0].CRD).Should(Equal("policies.core.oam.dev"))
+		Expect(skews[0].CRDVersion).Should(Equal("v1.8.0"))
+		Expect(skews[1].CRD).Should(Equal("workflows.core.oam.dev"))
+		Expect(skews[1].String()).Should(ContainSubstring("controller release v1.11.0-beta.1"))
+	})
+
+	It("should skip controllers without a release version", func() {
+		skews, err := crdvalidation.CheckVersionSkew(context.Background(), cli, []string{"policies.core.oam.dev"}, "UNKNOWN")
+		Expect(err).Should(Succeed())
+		Expect(skews).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// deniedByWebhook matches the message of the API server when an admission
+// webhook rejects a request.
+var deniedByWebhook = regexp.MustCompile(`admission webhook "([^"]+)" denied the request`)
+
+// WebhookInterference reports a round-trip that failed because of admission
+// webhooks rejecting or mutating the test object rather than because of the
+// schema of the CRD.
+type WebhookInterference struct {
+	// Kind is the kind of the test object.
+	Kind string
+	// Webhooks are the names of the webhooks that rejected the test object or,
+	// if it was mutated, of the mutating webhooks intercepting its creation.
+	Webhooks []string
+	// Denied is true if the test object was rejected.
+	Denied bool
+	// Err is the error of the round-trip.
+	Err error
+}
+
+func (w *WebhookInterference) Error() string {
+	if w.Denied {
+		return fmt.Sprintf("the test %s was rejected by admission webhook %s, not by its CRD: %v. Exclude objects labeled "+
+			"as pre-check objects from the webhook or configure another pre-check namespace", w.Kind, strings.Join(w.Webhooks, ", "), w.Err)
+	}
+	return fmt.Sprintf("%v. The test %s may have been mutated by admission webhook %s rather than pruned by its CRD, exclude "+
+		"objects labeled as pre-check objects from the webhook or configure another pre-check namespace", w.Err, w.Kind, strings.Join(w.Webhooks, ", "))
+}
+
+// Remediation returns a hint on how to stop the webhooks from interfering.
+func (w *WebhookInterference) Remediation() string {
+	return fmt.Sprintf("Exclude objects labeled %s from admission webhook %s, or set --precheck-test-namespace to a "+
+		"namespace the webhook does not cover", oam.LabelPreCheck, strings.Join(w.Webhooks, ", "))
+}
+
+func (w *WebhookInterference) Unwrap() error {
+	return w.Err
+}
+
+// deniedWebhook returns the name of the admission webhook which rejected a
+// request with err, or an empty string if err was not returned by a webhook.
+func deniedWebhook(err error) string {
+	if err == nil {
+		return ""
+	}
+	if m := deniedByWebhook.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// diagnoseCreateFailure wraps err in a WebhookInterference if the creation of
+// the test object was rejected by an admission webhook.
+func diagnoseCreateFailure(kind string, err error) error {
+	if name := deniedWebhook(err); name != "" {
+		return &WebhookInterference{Kind: kind, Webhooks: []string{name}, Denied: true, Err: err}
+	}
+	return err
+}
+
+// diagnoseVerifyFailure tells whether a test object read back differently than
+// it was written because of its CRD or because of a mutating webhook. It
+// creates newObj, a copy of the test object, with a server-side dry-run: a
+// rejection by a webhook or mutating webhooks intercepting the creation of the
+// group and resource are reported as a WebhookInterference, otherwise err is
+// returned as is.
+func diagnoseVerifyFailure(ctx context.Context, c client.Client, kind, group, resource string, newObj client.Object, err error) error {
+	if dryRunErr := c.Create(ctx, newObj, client.DryRunAll); dryRunErr != nil {
+		if name := deniedWebhook(dryRunErr); name != "" {
+			return &WebhookInterference{Kind: kind, Webhooks: []string{name}, Denied: true, Err: err}
+		}
+		klog.V(2).InfoS("Dry-run of the test object failed", "kind", kind, "reason", dryRunErr.Error())
+	}
+	webhooks, listErr := mutatingWebhooksFor(ctx, c, group, resource)
+	if listErr != nil {
+		klog.V(2).InfoS("Failed to list mutating webhooks", "kind", kind, "reason", listErr.Error())
+		return err
+	}
+	if len(webhooks) == 0 {
+		return err
+	}
+	return &WebhookInterference{Kind: kind, Webhooks: webhooks, Err: err}
+}
+
+// mutatingWebhooksFor returns the mutating webhooks, named as
+// <configuration>/<webhook>, intercepting the creation of the resource.
+// It returns no webhooks if the controller may not list them.
+func mutatingWebhooksFor(ctx context.Context, c client.Client, group, resource string) ([]string, error) {
+	configs := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, configs); err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, cfg := range configs.Items {
+		for _, wh := range cfg.Webhooks {
+			if webhookRulesMatch(wh.Rules, group, resource) {
+				names = append(names, cfg.Name+"/"+wh.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// webhookRulesMatch reports whether one of the rules intercepts the creation
+// of the resource.
+func webhookRulesMatch(rules []admissionregistrationv1.RuleWithOperations, group, resource string) bool {
+	matches := func(values []string, value string) bool {
+		return slices.Contains(values, "*") || slices.Contains(values, value)
+	}
+	for _, r := range rules {
+		ops := make([]string, 0, len(r.Operations))
+		for _, op := range r.Operations {
+			ops = append(ops, string(op))
+		}
+		if matches(ops, string(admissionregistrationv1.Create)) && matches(r.APIGroups, group) &&
+			(matches(r.Resources, resource) || slices.Contains(r.Resources, "*/*")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdvalidation_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+)
+
+var _ = Describe("Admission webhook interference", func() {
+	mutatingWebhook := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyverno-resource-mutating"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name: "mutate.kyverno.svc",
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"*"},
+					APIVersions: []string{"*"},
+					Resources:   []string{"traitdefinitions"},
+				},
+			}},
+		}},
+	}
+
+	newClient := func(create func(obj client.Object) error, objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(singleton.KubeClient.Get().Scheme()).WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = true
+						return nil
+					}
+					if err := create(obj); err != nil {
+						return err
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+	}
+	dropSchematic := func(obj client.Object) error {
+		if trait, ok := obj.(*v1beta1.TraitDefinition); ok {
+			trait.Spec.Schematic = nil
+		}
+		return nil
+	}
+
+	It("should report the webhook rejecting the test object", func() {
+		cli := newClient(func(obj client.Object) error {
+			if _, ok := obj.(*v1beta1.TraitDefinition); ok {
+				return &apierrors.StatusError{ErrStatus: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Code:    400,
+					Message: `admission webhook "validate.kyverno.svc" denied the request: namespace vela-system is restricted`,
+				}}
+			}
+			return nil
+		})
+		err := crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})
+		var interference *crdvalidation.WebhookInterference
+		Expect(errors.As(err, &interference)).Should(BeTrue())
+		Expect(interference.Denied).Should(BeTrue())
+		Expect(interference.Webhooks).Should(Equal([]string{"validate.kyverno.svc"}))
+		Expect(err.Error()).Should(ContainSubstring("not by its CRD"))
+	})
+
+	It("should report the mutating webhooks of a test object read back differently", func() {
+		cli := newClient(dropSchematic, mutatingWebhook)
+		err := crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})
+		var interference *crdvalidation.WebhookInterference
+		Expect(errors.As(err, &interference)).Should(BeTrue())
+		Expect(interference.Denied).Should(BeFalse())
+		Expect(interference.Kind).Should(Equal(v1beta1.TraitDefinitionKind))
+		Expect(interference.Webhooks).Should(Equal([]string{"kyverno-resource-mutating/mutate.kyverno.svc"}))
+		Expect(err.Error()).Should(ContainSubstring("does not preserve spec.schematic"))
+	})
+
+	It("should report a schema problem when no webhook intercepts the test object", func() {
+		cli := newClient(dropSchematic)
+		err := crdvalidation.ValidateDefinitionRoundTrips(context.Background(), cli, crdvalidation.TestObjects{Namespace: types.DefaultKubeVelaNS})
+		Expect(err).Should(MatchError(ContainSubstring("does not preserve spec.schematic")))
+		var interference *crdvalidation.WebhookInterference
+		Expect(errors.As(err, &interference)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxTerminationMessageBytes is the size limit of the termination message of a container.
+const maxTerminationMessageBytes = 4096
+
+// Remediator is implemented by hook errors that know how the failure can be
+// fixed. The remediation is published with the results of the hook.
+type Remediator interface {
+	error
+	// Remediation returns a hint on how to fix the failure.
+	Remediation() string
+}
+
+type remediationError struct {
+	err         error
+	remediation string
+}
+
+func (e *remediationError) Error() string       { return e.err.Error() }
+func (e *remediationError) Unwrap() error       { return e.err }
+func (e *remediationError) Remediation() string { return e.remediation }
+
+// WithRemediation attaches a remediation hint to err. Errors already carrying
+// a remediation keep theirs, since it is more specific.
+func WithRemediation(err error, remediation string) error {
+	if err == nil {
+		return nil
+	}
+	var r Remediator
+	if errors.As(err, &r) {
+		return err
+	}
+	return &remediationError{err: err, remediation: remediation}
+}
+
+// remediationOf returns the remediation hint carried by err, if any.
+func remediationOf(err error) string {
+	var r Remediator
+	if errors.As(err, &r) {
+		return r.Remediation()
+	}
+	return ""
+}
+
+// checksOf returns the status of every check of a hook whose error joins the
+// errors of several checks, or nil if err is the error of a single check.
+func checksOf(err error) []CheckStatus {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) < 2 {
+		return nil
+	}
+	var checks []CheckStatus
+	for _, e := range joined.Unwrap() {
+		checks = append(checks, CheckStatus{Message: e.Error(), Remediation: remediationOf(e)})
+	}
+	return checks
+}
+
+// WriteFailureReport writes the JSON encoded Summary of results to path, so
+// that installers and operators can react to specific failures. The file is
+// replaced atomically.
+func WriteFailureReport(path string, results []Result) error {
+	data, err := json.MarshalIndent(Summarize(results, time.Now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pre-start hook results: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of the failure report %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write failure report %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write failure report %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write failure report %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write failure report %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteTerminationMessage writes the failed hooks of results to the
+// termination message file of the container at path, where Kubernetes reports
+// it in the status of the pod. It does nothing if the file does not exist, i.e.
+// outside of Kubernetes. The message is the JSON encoded Summary of the failed
+// hooks, whose per-check results and then messages are dropped while it
+// exceeds the size limit of termination messages.
+func WriteTerminationMessage(path string, results []Result) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat termination message file %s: %w", path, err)
+	}
+	s := Summarize(results, time.Now())
+	failed := s.Hooks[:0]
+	for _, h := range s.Hooks {
+		if h.Status == StatusFailed {
+			failed = append(failed, h)
+		}
+	}
+	s.Hooks = failed
+	data, err := json.Marshal(s)
+	for i := range s.Hooks {
+		if err != nil || len(data) <= maxTerminationMessageBytes {
+			break
+		}
+		s.Hooks[i].Checks = nil
+		data, err = json.Marshal(s)
+	}
+	for i := range s.Hooks {
+		if err != nil || len(data) <= maxTerminationMessageBytes {
+			break
+		}
+		s.Hooks[i].Message = truncate(s.Hooks[i].Message, 256)
+		data, err = json.Marshal(s)
+	}
+	for i := range s.Hooks {
+		if err != nil || len(data) <= maxTerminationMessageBytes {
+			break
+		}
+		s.Hooks[i].Message, s.Hooks[i].Remediation = "", ""
+		data, err = json.Marshal(s)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode pre-start hook results: %w", err)
+	}
+	if len(data) > maxTerminationMessageBytes {
+		data = data[:maxTerminationMessageBytes]
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write termination message %s: %w", path, err)
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "") + "..."
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+func TestSummarizeRemediation(t *testing.T) {
+	outdated := hooks.WithRemediation(errors.New("outdated CRD"), "upgrade the CRDs")
+	s := hooks.Summarize([]hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: fmt.Errorf("failed: %w", outdated)},
+		{Name: "RBACValidation", Severity: hooks.SeverityBlock, Err: errors.Join(
+			hooks.WithRemediation(errors.New("cannot list pods"), "grant list on pods"),
+			errors.New("cannot watch pods"),
+		)},
+	}, time.Now())
+
+	assert.Equal(t, "upgrade the CRDs", s.Hooks[0].Remediation)
+	assert.Empty(t, s.Hooks[0].Checks)
+	assert.Equal(t, []hooks.CheckStatus{
+		{Message: "cannot list pods", Remediation: "grant list on pods"},
+		{Message: "cannot watch pods"},
+	}, s.Hooks[1].Checks)
+
+	// The most specific remediation is kept
+	assert.Equal(t, "upgrade the CRDs", hooks.WithRemediation(outdated, "other").(hooks.Remediator).Remediation())
+	assert.NoError(t, hooks.WithRemediation(nil, "hint"))
+}
+
+func TestWriteFailureReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report", "precheck.json")
+	require.NoError(t, hooks.WriteFailureReport(path, []hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: hooks.WithRemediation(errors.New("outdated CRD"), "upgrade the CRDs")},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	s := hooks.Summary{}
+	require.NoError(t, json.Unmarshal(data, &s))
+	assert.False(t, s.Passed)
+	assert.Equal(t, hooks.StatusFailed, s.Hooks[0].Status)
+	assert.Equal(t, "upgrade the CRDs", s.Hooks[0].Remediation)
+}
+
+func TestWriteTerminationMessage(t *testing.T) {
+	results := []hooks.Result{
+		{Name: "ok", Severity: hooks.SeverityBlock},
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: errors.New(strings.Repeat("x", 10000))},
+	}
+
+	missing := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, hooks.WriteTerminationMessage(missing, results))
+	_, err := os.Stat(missing)
+	assert.True(t, os.IsNotExist(err))
+
+	path := filepath.Join(t.TempDir(), "termination-log")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	require.NoError(t, hooks.WriteTerminationMessage(path, results))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), 4096)
+	s := hooks.Summary{}
+	require.NoError(t, json.Unmarshal(data, &s))
+	require.Len(t, s.Hooks, 1)
+	assert.Equal(t, "CRDValidation", s.Hooks[0].Name)
+}
@@ -29,3 +29,16 @@ type PreStartHook interface {
 	// Name returns a human-readable name for the hook, used in logging.
 	Name() string
 }
+
+// PostStartHook defines a hook that should be run once the controller has started.
+// Post-start hooks run on the leader after the manager's caches have synced and are
+// used for tasks such as warming caches or announcing the running version. They
+// mirror PreStartHook, so the same Runner executes both.
+type PostStartHook interface {
+	// Run executes the hook's logic. If an error is returned and the hook's
+	// severity is Block, the controller manager is stopped.
+	Run(ctx context.Context) error
+
+	// Name returns a human-readable name for the hook, used in logging.
+	Name() string
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacevalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// QuotaResources are the quota resources counting the objects the controller
+// creates in the runtime namespace.
+var QuotaResources = []corev1.ResourceName{
+	"count/applicationrevisions.core.oam.dev",
+	"count/configmaps",
+	corev1.ResourceConfigMaps,
+}
+
+// Options configures the namespace validation hook.
+type Options struct {
+	// Namespace is the runtime namespace of the controller.
+	Namespace string
+	// Labels are the labels the namespace is expected to carry.
+	Labels map[string]string
+	// QuotaWarnRatio is the used/hard ratio of a quota above which a warning
+	// is logged. Exhausted quotas are always reported.
+	QuotaWarnRatio float64
+}
+
+// Hook checks that the runtime namespace exists, is not terminating, carries
+// the expected labels and has ResourceQuota headroom for the objects the
+// controller creates. Its failures are reported with SeverityWarn by default.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// NewHook creates a new namespace validation hook with the given client and options
+func NewHook(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing namespace validation hook", "namespace", opts.Namespace)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "NamespaceValidation"
+}
+
+// Severity makes namespace problems non-fatal by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// Run validates the runtime namespace and its quotas and reports all problems together.
+func (h *Hook) Run(ctx context.Context) error {
+	ns := &corev1.Namespace{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: h.Options.Namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("runtime namespace %s does not exist", h.Options.Namespace)
+		}
+		return fmt.Errorf("failed to get runtime namespace %s: %w", h.Options.Namespace, err)
+	}
+	var problems []string
+	if ns.DeletionTimestamp != nil || ns.Status.Phase == corev1.NamespaceTerminating {
+		problems = append(problems, fmt.Sprintf("runtime namespace %s is terminating", ns.Name))
+	}
+	for k, v := range h.Options.Labels {
+		if actual, ok := ns.Labels[k]; !ok || actual != v {
+			problems = append(problems, fmt.Sprintf("runtime namespace %s is missing label %s=%s", ns.Name, k, v))
+		}
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := h.Client.List(ctx, quotas, client.InNamespace(h.Options.Namespace)); err != nil {
+		return fmt.Errorf("failed to list ResourceQuotas in %s: %w", h.Options.Namespace, err)
+	}
+	for _, quota := range quotas.Items {
+		problems = append(problems, h.checkQuota(quota)...)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid runtime namespace: %s", strings.Join(problems, "; "))
+	}
+	klog.InfoS("Runtime namespace validated", "namespace", ns.Name, "quotas", len(quotas.Items))
+	return nil
+}
+
+// checkQuota returns the exhausted quota resources and logs the nearly exhausted ones.
+func (h *Hook) checkQuota(quota corev1.ResourceQuota) []string {
+	var problems []string
+	for _, res := range QuotaResources {
+		hard, ok := quota.Status.Hard[res]
+		if !ok {
+			hard, ok = quota.Spec.Hard[res]
+		}
+		if !ok {
+			continue
+		}
+		used := quota.Status.Used[res]
+		switch {
+		case used.Cmp(hard) >= 0:
+			problems = append(problems, fmt.Sprintf("ResourceQuota %s has no headroom for %s (used %s of %s)",
+				quota.Name, res, used.String(), hard.String()))
+		case hard.Value() > 0 && float64(used.Value())/float64(hard.Value()) >= h.Options.QuotaWarnRatio:
+			klog.InfoS("ResourceQuota is nearly exhausted", "quota", quota.Name, "resource", res,
+				"used", used.String(), "hard", hard.String())
+		}
+	}
+	return problems
+}
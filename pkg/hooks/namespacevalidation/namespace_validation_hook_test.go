@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacevalidation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/namespacevalidation"
+)
+
+func TestNamespaceValidationHook(t *testing.T) {
+	ctx := context.Background()
+	opts := namespacevalidation.Options{Namespace: "vela-system", Labels: map[string]string{"team": "platform"}, QuotaWarnRatio: 0.9}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vela-system", Labels: map[string]string{"team": "platform"}}}
+	quota := func(used string) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "vela-system", Name: "objects"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{"count/configmaps": resource.MustParse("10")},
+				Used: corev1.ResourceList{"count/configmaps": resource.MustParse(used)},
+			},
+		}
+	}
+
+	t.Run("missing namespace", func(t *testing.T) {
+		err := namespacevalidation.NewHook(fake.NewClientBuilder().Build(), opts).Run(ctx)
+		assert.ErrorContains(t, err, "runtime namespace vela-system does not exist")
+	})
+
+	t.Run("valid namespace with quota headroom", func(t *testing.T) {
+		cli := fake.NewClientBuilder().WithObjects(ns.DeepCopy(), quota("9")).Build()
+		hook := namespacevalidation.NewHook(cli, opts)
+		assert.Equal(t, "NamespaceValidation", hook.Name())
+		assert.NoError(t, hook.Run(ctx))
+	})
+
+	t.Run("terminating, unlabeled namespace with exhausted quota", func(t *testing.T) {
+		bad := ns.DeepCopy()
+		bad.Labels = nil
+		bad.Status.Phase = corev1.NamespaceTerminating
+		cli := fake.NewClientBuilder().WithObjects(bad, quota("10")).Build()
+		err := namespacevalidation.NewHook(cli, opts).Run(ctx)
+		assert.ErrorContains(t, err, "runtime namespace vela-system is terminating")
+		assert.ErrorContains(t, err, "missing label team=platform")
+		assert.ErrorContains(t, err, "ResourceQuota objects has no headroom for count/configmaps (used 10 of 10)")
+	})
+}
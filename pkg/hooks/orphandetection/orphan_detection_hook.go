@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphandetection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// Options configures the orphan detection hook.
+type Options struct {
+	// AppRevisionLimit is the number of ApplicationRevisions kept per
+	// Application besides the latest one, as configured for the controller.
+	AppRevisionLimit int
+	// GarbageCollect deletes the orphaned ResourceTrackers and the stale
+	// ApplicationRevisions instead of only reporting them.
+	GarbageCollect bool
+}
+
+// Report is what the hook found.
+type Report struct {
+	// OrphanedResourceTrackers are the ResourceTrackers whose Application no longer exists.
+	OrphanedResourceTrackers []string
+	// StaleAppRevisions are the ApplicationRevisions whose Application no longer
+	// exists or that exceed the revision limit, oldest first per Application.
+	StaleAppRevisions []types.NamespacedName
+}
+
+// Empty returns true if nothing was found.
+func (r Report) Empty() bool {
+	return len(r.OrphanedResourceTrackers) == 0 && len(r.StaleAppRevisions) == 0
+}
+
+// Hook reports ResourceTrackers left behind by deleted Applications and
+// ApplicationRevisions beyond the revision limit, which usually remain after
+// the controller was down while Applications were deleted. It is informational
+// by default.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// NewHook creates a new orphan detection hook with the given client and options
+func NewHook(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing orphan detection hook", "appRevisionLimit", opts.AppRevisionLimit, "garbageCollect", opts.GarbageCollect)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "OrphanDetection"
+}
+
+// Severity makes the findings informational by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityInfo
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// DependsOn runs the hook once the CRDs of the objects it reads are validated.
+func (h *Hook) DependsOn() []string {
+	return []string{"CRDValidation"}
+}
+
+// Run scans for orphans and reports their counts. When garbage collection is
+// enabled the orphans are deleted and nil is returned on success.
+func (h *Hook) Run(ctx context.Context) error {
+	report, err := h.Scan(ctx)
+	if err != nil {
+		return err
+	}
+	if report.Empty() {
+		klog.InfoS("No orphaned ResourceTrackers or stale ApplicationRevisions found")
+		return nil
+	}
+	if h.Options.GarbageCollect {
+		return h.collect(ctx, report)
+	}
+	return fmt.Errorf("found %d orphaned ResourceTrackers and %d stale ApplicationRevisions, "+
+		"enable --precheck-orphan-gc to garbage collect them: %s",
+		len(report.OrphanedResourceTrackers), len(report.StaleAppRevisions), sample(report))
+}
+
+// Scan lists the ResourceTrackers, ApplicationRevisions and Applications and
+// returns the orphans.
+func (h *Hook) Scan(ctx context.Context) (Report, error) {
+	var report Report
+	apps := &v1beta1.ApplicationList{}
+	if err := h.Client.List(ctx, apps); err != nil {
+		return report, fmt.Errorf("failed to list Applications: %w", err)
+	}
+	existing := make(map[types.NamespacedName]*v1beta1.Application, len(apps.Items))
+	for i := range apps.Items {
+		existing[types.NamespacedName{Namespace: apps.Items[i].Namespace, Name: apps.Items[i].Name}] = &apps.Items[i]
+	}
+
+	rts := &v1beta1.ResourceTrackerList{}
+	if err := h.Client.List(ctx, rts, client.HasLabels{oam.LabelAppName, oam.LabelAppNamespace}); err != nil {
+		return report, fmt.Errorf("failed to list ResourceTrackers: %w", err)
+	}
+	for _, rt := range rts.Items {
+		owner := types.NamespacedName{Namespace: rt.Labels[oam.LabelAppNamespace], Name: rt.Labels[oam.LabelAppName]}
+		if _, ok := existing[owner]; !ok && rt.DeletionTimestamp == nil {
+			report.OrphanedResourceTrackers = append(report.OrphanedResourceTrackers, rt.Name)
+		}
+	}
+
+	revs := &v1beta1.ApplicationRevisionList{}
+	if err := h.Client.List(ctx, revs, client.HasLabels{oam.LabelAppName}); err != nil {
+		return report, fmt.Errorf("failed to list ApplicationRevisions: %w", err)
+	}
+	byApp := map[types.NamespacedName][]string{}
+	for _, rev := range revs.Items {
+		if rev.DeletionTimestamp != nil {
+			continue
+		}
+		owner := types.NamespacedName{Namespace: rev.Namespace, Name: rev.Labels[oam.LabelAppName]}
+		byApp[owner] = append(byApp[owner], rev.Name)
+	}
+	owners := make([]types.NamespacedName, 0, len(byApp))
+	for owner := range byApp {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool { return owners[i].String() < owners[j].String() })
+	for _, owner := range owners {
+		names := byApp[owner]
+		sort.Slice(names, func(i, j int) bool {
+			ir, _ := util.ExtractRevisionNum(names[i], "-")
+			ij, _ := util.ExtractRevisionNum(names[j], "-")
+			return ir < ij
+		})
+		app, ok := existing[owner]
+		if !ok {
+			for _, name := range names {
+				report.StaleAppRevisions = append(report.StaleAppRevisions, types.NamespacedName{Namespace: owner.Namespace, Name: name})
+			}
+			continue
+		}
+		latest := ""
+		if app.Status.LatestRevision != nil {
+			latest = app.Status.LatestRevision.Name
+		}
+		for _, name := range names[:max(0, len(names)-h.Options.AppRevisionLimit-1)] {
+			if name != latest {
+				report.StaleAppRevisions = append(report.StaleAppRevisions, types.NamespacedName{Namespace: owner.Namespace, Name: name})
+			}
+		}
+	}
+	return report, nil
+}
+
+// collect deletes the orphans of report.
+func (h *Hook) collect(ctx context.Context, report Report) error {
+	for _, name := range report.OrphanedResourceTrackers {
+		rt := &v1beta1.ResourceTracker{}
+		rt.Name = name
+		if err := h.Client.Delete(ctx, rt); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete orphaned ResourceTracker %s: %w", name, err)
+		}
+	}
+	for _, key := range report.StaleAppRevisions {
+		rev := &v1beta1.ApplicationRevision{}
+		rev.Namespace, rev.Name = key.Namespace, key.Name
+		if err := h.Client.Delete(ctx, rev); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale ApplicationRevision %s: %w", key, err)
+		}
+	}
+	klog.InfoS("Garbage collected orphans", "resourceTrackers", len(report.OrphanedResourceTrackers),
+		"appRevisions", len(report.StaleAppRevisions))
+	return nil
+}
+
+// sample returns the first orphans of report for the error message.
+func sample(report Report) string {
+	const limit = 5
+	var names []string
+	for _, name := range report.OrphanedResourceTrackers {
+		names = append(names, "resourcetracker "+name)
+	}
+	for _, key := range report.StaleAppRevisions {
+		names = append(names, "applicationrevision "+key.String())
+	}
+	if len(names) > limit {
+		return strings.Join(names[:limit], ", ") + fmt.Sprintf(" and %d more", len(names)-limit)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphandetection_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks/orphandetection"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newObjects() []client.Object {
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "live"}}
+	app.Status.LatestRevision = &common.Revision{Name: "live-v4"}
+	objs := []client.Object{app}
+	for _, owner := range []string{"live", "deleted"} {
+		objs = append(objs, &v1beta1.ResourceTracker{ObjectMeta: metav1.ObjectMeta{
+			Name:   owner + "-v1-default",
+			Labels: map[string]string{oam.LabelAppName: owner, oam.LabelAppNamespace: "default"},
+		}})
+	}
+	for i := 1; i <= 4; i++ {
+		objs = append(objs, &v1beta1.ApplicationRevision{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: fmt.Sprintf("live-v%d", i), Labels: map[string]string{oam.LabelAppName: "live"},
+		}})
+	}
+	objs = append(objs, &v1beta1.ApplicationRevision{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "deleted-v1", Labels: map[string]string{oam.LabelAppName: "deleted"},
+	}})
+	return objs
+}
+
+func TestOrphanDetectionHook(t *testing.T) {
+	ctx := context.Background()
+	opts := orphandetection.Options{AppRevisionLimit: 1}
+
+	cli := fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(newObjects()...).Build()
+	hook := &orphandetection.Hook{Client: cli, Options: opts}
+	report, err := hook.Scan(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deleted-v1-default"}, report.OrphanedResourceTrackers)
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "default", Name: "deleted-v1"},
+		{Namespace: "default", Name: "live-v1"},
+		{Namespace: "default", Name: "live-v2"},
+	}, report.StaleAppRevisions)
+	assert.ErrorContains(t, hook.Run(ctx), "found 1 orphaned ResourceTrackers and 3 stale ApplicationRevisions")
+
+	opts.GarbageCollect = true
+	assert.NoError(t, orphandetection.NewHook(cli, opts).Run(ctx))
+	err = cli.Get(ctx, client.ObjectKey{Name: "deleted-v1-default"}, &v1beta1.ResourceTracker{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "live-v2"}, &v1beta1.ApplicationRevision{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "live-v3"}, &v1beta1.ApplicationRevision{}))
+	assert.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "live-v1-default"}, &v1beta1.ResourceTracker{}))
+
+	report, err = hook.Scan(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Empty())
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// CacheSyncWaiter is satisfied by the controller-runtime cache.
+type CacheSyncWaiter interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// PostStartRunner runs post-start hooks once the manager elected a leader and
+// its caches synced. It implements the controller-runtime Runnable interface.
+// The hooks are executed by Runner, which must not set Runner.Hooks itself.
+type PostStartRunner struct {
+	Runner
+	PostStartHooks []PostStartHook
+	// Cache is waited for before the hooks run if set.
+	Cache CacheSyncWaiter
+}
+
+// Start waits for the caches to sync and runs the post-start hooks once. It
+// returns an error, stopping the manager, only for failures with SeverityBlock.
+func (r *PostStartRunner) Start(ctx context.Context) error {
+	if r.Cache != nil && !r.Cache.WaitForCacheSync(ctx) {
+		return fmt.Errorf("failed to wait for caches to sync before running post-start hooks")
+	}
+	r.Hooks = make([]PreStartHook, 0, len(r.PostStartHooks))
+	for _, hook := range r.PostStartHooks {
+		r.Hooks = append(r.Hooks, hook)
+	}
+	klog.InfoS("Running post-start hooks", "count", len(r.Hooks))
+	if err := r.Run(ctx); err != nil {
+		return fmt.Errorf("post-start hooks failed: %w", err)
+	}
+	klog.InfoS("All post-start hooks completed")
+	return nil
+}
+
+// NeedLeaderElection makes the post-start hooks run on the leader only.
+func (r *PostStartRunner) NeedLeaderElection() bool {
+	return true
+}
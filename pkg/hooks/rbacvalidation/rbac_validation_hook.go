@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// Permission is a set of verbs the controller needs on a resource. An empty
+// Namespace means the permission is needed in all namespaces.
+type Permission struct {
+	Group       string
+	Resource    string
+	Subresource string
+	Namespace   string
+	Verbs       []string
+}
+
+// String returns the permission in the form verbs on group/resource/subresource in namespace.
+func (p Permission) String() string {
+	res := p.Resource
+	if p.Group != "" {
+		res = p.Resource + "." + p.Group
+	}
+	if p.Subresource != "" {
+		res += "/" + p.Subresource
+	}
+	ns := "all namespaces"
+	if p.Namespace != "" {
+		ns = "namespace " + p.Namespace
+	}
+	return fmt.Sprintf("%s %s in %s", strings.Join(p.Verbs, ","), res, ns)
+}
+
+var (
+	readWrite = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	readOnly  = []string{"get", "list", "watch"}
+)
+
+// DefaultPermissions returns the permissions vela-core relies on. Leases are
+// only checked in the runtime namespace, everything else cluster wide.
+func DefaultPermissions(runtimeNamespace string) []Permission {
+	perms := []Permission{
+		{Group: v1beta1.Group, Resource: "applications", Verbs: readWrite},
+		{Group: v1beta1.Group, Resource: "applications", Subresource: "status", Verbs: []string{"update", "patch"}},
+		{Group: v1beta1.Group, Resource: "applicationrevisions", Verbs: readWrite},
+		{Group: v1beta1.Group, Resource: "resourcetrackers", Verbs: readWrite},
+		{Group: v1beta1.Group, Resource: "definitionrevisions", Verbs: readWrite},
+	}
+	for _, def := range []string{"componentdefinitions", "traitdefinitions", "policydefinitions", "workflowstepdefinitions"} {
+		perms = append(perms,
+			Permission{Group: v1beta1.Group, Resource: def, Verbs: readWrite},
+			Permission{Group: v1beta1.Group, Resource: def, Subresource: "status", Verbs: []string{"update", "patch"}})
+	}
+	return append(perms,
+		Permission{Group: "coordination.k8s.io", Resource: "leases", Namespace: runtimeNamespace, Verbs: []string{"get", "create", "update"}},
+		Permission{Resource: "secrets", Verbs: readOnly},
+		Permission{Resource: "configmaps", Verbs: readWrite},
+		Permission{Resource: "events", Verbs: []string{"create", "patch"}},
+		Permission{Resource: "namespaces", Verbs: readOnly},
+	)
+}
+
+// Hook checks with SelfSubjectAccessReviews that the controller has every
+// permission it needs, so that missing RBAC rules are reported at startup
+// instead of failing reconciles later. Its failures are reported with
+// SeverityWarn by default, since deployments may restrict features on purpose.
+type Hook struct {
+	client.Client
+	Permissions []Permission
+}
+
+// NewHook creates a new RBAC validation hook checking the given permissions
+func NewHook(c client.Client, perms []Permission) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing RBAC validation hook", "permissions", len(perms))
+	return &Hook{Client: c, Permissions: perms}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "RBACValidation"
+}
+
+// Severity makes missing permissions non-fatal by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// Run reviews every permission and reports all missing ones together.
+func (h *Hook) Run(ctx context.Context) error {
+	var missing []string
+	for _, p := range h.Permissions {
+		var denied []string
+		for _, verb := range p.Verbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   p.Namespace,
+						Verb:        verb,
+						Group:       p.Group,
+						Resource:    p.Resource,
+						Subresource: p.Subresource,
+					},
+				},
+			}
+			if err := h.Client.Create(ctx, review); err != nil {
+				return fmt.Errorf("failed to review permission %s: %w", p, err)
+			}
+			if !review.Status.Allowed {
+				klog.V(2).InfoS("Access review denied", "resource", p.Resource, "verb", verb, "reason", review.Status.Reason)
+				denied = append(denied, verb)
+			}
+		}
+		if len(denied) > 0 {
+			d := p
+			d.Verbs = denied
+			missing = append(missing, d.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing RBAC permissions: %s", strings.Join(missing, "; "))
+	}
+	klog.InfoS("RBAC permissions validated", "permissions", len(h.Permissions))
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacvalidation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/rbacvalidation"
+)
+
+// newClient returns a client whose access reviews are denied for the given resource/verb pairs.
+func newClient(denied map[string]bool) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+				attrs := review.Spec.ResourceAttributes
+				review.Status.Allowed = !denied[attrs.Resource+"/"+attrs.Verb]
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func TestRBACValidationHook(t *testing.T) {
+	perms := rbacvalidation.DefaultPermissions("vela-system")
+	hook := rbacvalidation.NewHook(newClient(nil), perms)
+	assert.Equal(t, "RBACValidation", hook.Name())
+	assert.NoError(t, hook.Run(context.Background()))
+
+	hook = rbacvalidation.NewHook(newClient(map[string]bool{"secrets/list": true, "leases/update": true}), perms)
+	err := hook.Run(context.Background())
+	assert.ErrorContains(t, err, "list secrets in all namespaces")
+	assert.ErrorContains(t, err, "update leases.coordination.k8s.io in namespace vela-system")
+	assert.NotContains(t, err.Error(), "applications")
+}
+
+func TestPermissionString(t *testing.T) {
+	p := rbacvalidation.Permission{Group: "core.oam.dev", Resource: "applications", Subresource: "status", Verbs: []string{"update", "patch"}}
+	assert.Equal(t, "update,patch applications.core.oam.dev/status in all namespaces", p.String())
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ReadinessGate reports the controller as not ready while blocking hooks fail.
+// Its Check method can be registered as a controller-runtime readiness check.
+type ReadinessGate struct {
+	mu     sync.RWMutex
+	reason string
+}
+
+// NewReadinessGate creates a gate that is closed until Update is called with
+// results that contain no blocking failure.
+func NewReadinessGate() *ReadinessGate {
+	return &ReadinessGate{reason: "pre-start hooks have not completed"}
+}
+
+// Update opens or closes the gate according to results.
+func (g *ReadinessGate) Update(results []Result) {
+	var failed []string
+	for _, res := range results {
+		if res.Blocking() {
+			failed = append(failed, fmt.Sprintf("pre-start hook %s failed: %v", res.Name, res.Err))
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reason = strings.Join(failed, "; ")
+}
+
+// Check returns the reason the gate is closed, or nil if it is open.
+func (g *ReadinessGate) Check(_ *http.Request) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.reason != "" {
+		return errors.New(g.reason)
+	}
+	return nil
+}
+
+// ServeHTTP answers readiness probes with the state of the gate.
+func (g *ReadinessGate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := g.Check(r); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("ok"))
+}
+
+// WaitUntilReady retries runner every interval until no hook fails with
+// SeverityBlock. Meanwhile the readiness and liveness probes are answered on
+// addr, so the pod stays alive but NotReady with the failure reason instead of
+// crash looping. The probe server is shut down before returning so that the
+// manager can bind addr. onResults is called with the results of every retry
+// if set.
+func WaitUntilReady(ctx context.Context, runner *Runner, gate *ReadinessGate, addr string, interval time.Duration,
+	onResults func(context.Context, []Result)) error {
+	if addr != "" && addr != "0" {
+		mux := http.NewServeMux()
+		mux.Handle("/readyz", gate)
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) })
+		srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to serve probes while waiting for pre-start hooks: %w", err)
+		}
+		go func() {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				klog.ErrorS(err, "Pre-start probe server stopped")
+			}
+		}()
+		defer func() {
+			if err := srv.Shutdown(context.Background()); err != nil {
+				klog.ErrorS(err, "Failed to shut down pre-start probe server")
+			}
+		}()
+	}
+
+	klog.InfoS("Pre-start hooks failed, reporting NotReady and retrying", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		err := runner.Run(ctx)
+		results := runner.Results()
+		gate.Update(results)
+		if onResults != nil {
+			onResults(ctx, results)
+		}
+		if err == nil {
+			klog.InfoS("Pre-start hooks passed after retrying")
+			return nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+func TestReadinessGate(t *testing.T) {
+	gate := hooks.NewReadinessGate()
+	assert.ErrorContains(t, gate.Check(nil), "have not completed")
+
+	gate.Update([]hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: errors.New("outdated CRDs")},
+		{Name: "Other", Severity: hooks.SeverityWarn, Err: errors.New("ignored")},
+	})
+	rec := httptest.NewRecorder()
+	gate.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pre-start hook CRDValidation failed: outdated CRDs")
+	assert.NotContains(t, rec.Body.String(), "Other")
+
+	gate.Update([]hooks.Result{{Name: "CRDValidation", Severity: hooks.SeverityBlock}})
+	assert.NoError(t, gate.Check(nil))
+}
+
+type flakyHook struct{ failures atomic.Int32 }
+
+func (h *flakyHook) Name() string { return "flaky" }
+
+func (h *flakyHook) Run(context.Context) error {
+	if h.failures.Add(-1) >= 0 {
+		return errors.New("not yet")
+	}
+	return nil
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	hook := &flakyHook{}
+	hook.failures.Store(2)
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{hook}}
+	gate := hooks.NewReadinessGate()
+	assert.Error(t, runner.Run(context.Background()))
+	gate.Update(runner.Results())
+
+	var retries int
+	err := hooks.WaitUntilReady(context.Background(), runner, gate, "127.0.0.1:0", 10*time.Millisecond,
+		func(context.Context, []hooks.Result) { retries++ })
+	assert.NoError(t, err)
+	assert.Equal(t, 2, retries)
+	assert.NoError(t, gate.Check(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hook.failures.Store(1)
+	assert.ErrorIs(t, hooks.WaitUntilReady(ctx, runner, gate, "", time.Hour, nil), context.Canceled)
+}
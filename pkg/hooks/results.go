@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/version"
+)
+
+const (
+	// ResultsConfigMapKey is the key of the published ConfigMap holding the JSON encoded Summary.
+	ResultsConfigMapKey = "summary"
+	// DefaultResultsConfigMap is the default name of the ConfigMap the results are published to.
+	DefaultResultsConfigMap = "vela-core-prestart-results"
+)
+
+// Status of a single hook in a Summary.
+const (
+	StatusPassed  = "Passed"
+	StatusFailed  = "Failed"
+	StatusWarning = "Warning"
+	StatusSkipped = "Skipped"
+)
+
+// Summary is the structured outcome of a pre-start hook run, published for
+// tools such as the vela CLI and VelaUX that cannot read the controller logs.
+type Summary struct {
+	// Passed is false if any hook failed with SeverityBlock.
+	Passed  bool         `json:"passed"`
+	Version string       `json:"version"`
+	Time    time.Time    `json:"time"`
+	Hooks   []HookStatus `json:"hooks"`
+}
+
+// HookStatus is the outcome of a single hook in a Summary.
+type HookStatus struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message,omitempty"`
+	Duration string   `json:"duration,omitempty"`
+	// Remediation is a hint on how to fix the failure, if the hook knows it.
+	Remediation string `json:"remediation,omitempty"`
+	// Checks are the failures of the individual checks of the hook, if the
+	// hook failed on several of them.
+	Checks []CheckStatus `json:"checks,omitempty"`
+}
+
+// CheckStatus is the failure of a single check of a hook in a Summary.
+type CheckStatus struct {
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Summarize converts the results of a hook run into a Summary.
+func Summarize(results []Result, now time.Time) Summary {
+	s := Summary{Passed: true, Version: version.VelaVersion, Time: now.UTC()}
+	for _, r := range results {
+		st := HookStatus{Name: r.Name, Severity: r.Severity, Status: StatusPassed}
+		switch {
+		case r.Skipped:
+			st.Status = StatusSkipped
+		case r.Blocking():
+			st.Status = StatusFailed
+			s.Passed = false
+		case r.Err != nil:
+			st.Status = StatusWarning
+		}
+		if r.Err != nil {
+			st.Message = r.Err.Error()
+			st.Remediation = remediationOf(r.Err)
+			st.Checks = checksOf(r.Err)
+		}
+		if !r.Skipped {
+			st.Duration = r.Duration.Round(time.Millisecond).String()
+		}
+		s.Hooks = append(s.Hooks, st)
+	}
+	return s
+}
+
+// PublishResults writes the Summary of results into the named ConfigMap,
+// creating it if needed.
+func PublishResults(ctx context.Context, c client.Client, namespace, name string, results []Result) error {
+	data, err := json.Marshal(Summarize(results, time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to encode pre-start hook results: %w", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get pre-start hook results ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		cm.Namespace = namespace
+		cm.Name = name
+		cm.Labels = map[string]string{oam.LabelControllerName: types.VelaCoreName}
+		cm.Data = map[string]string{ResultsConfigMapKey: string(data)}
+		if err := c.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create pre-start hook results ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ResultsConfigMapKey] = string(data)
+	if err := c.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update pre-start hook results ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// LoadResults reads the Summary published by PublishResults into the named
+// ConfigMap. It returns a NotFound error if no results were published yet.
+func LoadResults(ctx context.Context, c client.Reader, namespace, name string) (*Summary, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[ResultsConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("pre-start hook results ConfigMap %s/%s has no %s key", namespace, name, ResultsConfigMapKey)
+	}
+	s := &Summary{}
+	if err := json.Unmarshal([]byte(data), s); err != nil {
+		return nil, fmt.Errorf("failed to decode pre-start hook results of ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := hooks.Summarize([]hooks.Result{
+		{Name: "ok", Severity: hooks.SeverityBlock, Duration: 1500 * time.Microsecond},
+		{Name: "warn", Severity: hooks.SeverityWarn, Err: errors.New("degraded")},
+		{Name: "skipped", Severity: hooks.SeverityBlock, Skipped: true},
+		{Name: "failed", Severity: hooks.SeverityBlock, Err: errors.New("boom")},
+	}, now)
+	assert.False(t, s.Passed)
+	assert.Equal(t, now, s.Time)
+	assert.Equal(t, []hooks.HookStatus{
+		{Name: "ok", Status: hooks.StatusPassed, Severity: hooks.SeverityBlock, Duration: "2ms"},
+		{Name: "warn", Status: hooks.StatusWarning, Severity: hooks.SeverityWarn, Message: "degraded", Duration: "0s"},
+		{Name: "skipped", Status: hooks.StatusSkipped, Severity: hooks.SeverityBlock},
+		{Name: "failed", Status: hooks.StatusFailed, Severity: hooks.SeverityBlock, Message: "boom", Duration: "0s"},
+	}, s.Hooks)
+}
+
+func TestPublishResults(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	key := client.ObjectKey{Namespace: "vela-system", Name: "results"}
+
+	require.NoError(t, hooks.PublishResults(ctx, cli, key.Namespace, key.Name, []hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: errors.New("outdated CRDs")},
+	}))
+	require.NoError(t, hooks.PublishResults(ctx, cli, key.Namespace, key.Name, []hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock},
+	}))
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, key, cm))
+	s := hooks.Summary{}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[hooks.ResultsConfigMapKey]), &s))
+	assert.True(t, s.Passed)
+	assert.Len(t, s.Hooks, 1)
+	assert.Equal(t, hooks.StatusPassed, s.Hooks[0].Status)
+}
+
+func TestLoadResults(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+
+	_, err := hooks.LoadResults(ctx, cli, "vela-system", hooks.DefaultResultsConfigMap)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, hooks.PublishResults(ctx, cli, "vela-system", hooks.DefaultResultsConfigMap, []hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: errors.New("outdated CRDs")},
+	}))
+	s, err := hooks.LoadResults(ctx, cli, "vela-system", hooks.DefaultResultsConfigMap)
+	require.NoError(t, err)
+	assert.False(t, s.Passed)
+	require.Len(t, s.Hooks, 1)
+	assert.Equal(t, hooks.StatusFailed, s.Hooks[0].Status)
+	assert.Equal(t, "outdated CRDs", s.Hooks[0].Message)
+}
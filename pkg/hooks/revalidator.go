@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+const (
+	// ReasonHookRegressed is the event reason used when a hook that passed starts failing.
+	ReasonHookRegressed = "PreStartHookRegressed"
+	// ReasonHookRecovered is the event reason used when a failing hook passes again.
+	ReasonHookRecovered = "PreStartHookRecovered"
+)
+
+// Revalidator re-runs pre-start hooks periodically after the controller
+// started, so that CRDs downgraded or edited at runtime are noticed without a
+// restart. It implements the controller-runtime Runnable interface.
+type Revalidator struct {
+	Runner   *Runner
+	Interval time.Duration
+	// Recorder and Object are optional. When set, regressions and recoveries are
+	// reported as events on Object.
+	Recorder record.EventRecorder
+	Object   runtime.Object
+	// OnResults is called with the results of every run if set.
+	OnResults func(ctx context.Context, results []Result)
+
+	passed map[string]bool
+}
+
+// NewRevalidator creates a Revalidator whose regression tracking starts from
+// the given results, usually those of the pre-start run.
+func NewRevalidator(runner *Runner, interval time.Duration, initial []Result) *Revalidator {
+	v := &Revalidator{Runner: runner, Interval: interval, passed: map[string]bool{}}
+	for _, res := range initial {
+		if !res.Skipped {
+			v.passed[res.Name] = res.Err == nil
+		}
+	}
+	return v
+}
+
+// Start runs the hooks every Interval until ctx is done.
+func (v *Revalidator) Start(ctx context.Context) error {
+	klog.InfoS("Starting periodic pre-start hook revalidation", "interval", v.Interval, "hooks", len(v.Runner.Hooks))
+	ticker := time.NewTicker(v.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			v.Revalidate(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection makes the revalidation run on the leader only, since hooks
+// may write test objects or upgrade CRDs.
+func (v *Revalidator) NeedLeaderElection() bool {
+	return true
+}
+
+// Revalidate runs the hooks once and reports hooks whose outcome changed.
+// Failures never stop the controller.
+func (v *Revalidator) Revalidate(ctx context.Context) {
+	if err := v.Runner.Run(ctx); err != nil {
+		klog.ErrorS(err, "Pre-start hook revalidation failed")
+	}
+	results := v.Runner.Results()
+	if v.passed == nil {
+		v.passed = map[string]bool{}
+	}
+	for _, res := range results {
+		if res.Skipped {
+			continue
+		}
+		ok := res.Err == nil
+		if ok {
+			metrics.PreStartHookHealthyGauge.WithLabelValues(res.Name).Set(1)
+		} else {
+			metrics.PreStartHookHealthyGauge.WithLabelValues(res.Name).Set(0)
+		}
+		prev, seen := v.passed[res.Name]
+		v.passed[res.Name] = ok
+		switch {
+		case (!seen || prev) && !ok:
+			klog.ErrorS(res.Err, "Pre-start hook regressed after startup", "hook", res.Name)
+			v.event(corev1.EventTypeWarning, ReasonHookRegressed, "Pre-start hook %s failed: %v", res.Name, res.Err)
+		case seen && !prev && ok:
+			klog.InfoS("Pre-start hook recovered", "hook", res.Name)
+			v.event(corev1.EventTypeNormal, ReasonHookRecovered, "Pre-start hook %s passed again", res.Name)
+		}
+	}
+	if v.OnResults != nil {
+		v.OnResults(ctx, results)
+	}
+}
+
+func (v *Revalidator) event(eventType, reason, messageFmt string, args ...interface{}) {
+	if v.Recorder == nil || v.Object == nil {
+		return
+	}
+	v.Recorder.Eventf(v.Object, eventType, reason, messageFmt, args...)
+}
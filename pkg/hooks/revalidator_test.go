@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+func TestRevalidatorReportsRegressionsAndRecoveries(t *testing.T) {
+	hook := &testHook{name: "revalidated"}
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{hook}}
+	assert.NoError(t, runner.Run(context.Background()))
+
+	recorder := record.NewFakeRecorder(10)
+	v := hooks.NewRevalidator(runner, time.Minute, runner.Results())
+	v.Recorder = recorder
+	v.Object = &corev1.ConfigMap{}
+	var published int
+	v.OnResults = func(context.Context, []hooks.Result) { published++ }
+
+	v.Revalidate(context.Background())
+	assert.Empty(t, recorder.Events)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.PreStartHookHealthyGauge.WithLabelValues("revalidated")))
+
+	hook.err = errors.New("CRD downgraded")
+	v.Revalidate(context.Background())
+	assert.Contains(t, <-recorder.Events, hooks.ReasonHookRegressed)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.PreStartHookHealthyGauge.WithLabelValues("revalidated")))
+
+	v.Revalidate(context.Background())
+	assert.Empty(t, recorder.Events)
+
+	hook.err = nil
+	v.Revalidate(context.Background())
+	assert.Contains(t, <-recorder.Events, hooks.ReasonHookRecovered)
+	assert.Equal(t, 4, published)
+	assert.True(t, v.NeedLeaderElection())
+}
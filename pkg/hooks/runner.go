@@ -0,0 +1,289 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+// Severity defines how a failure of a pre-start hook affects the startup.
+type Severity string
+
+const (
+	// SeverityBlock aborts the startup when the hook fails. It is the default.
+	SeverityBlock Severity = "Block"
+	// SeverityWarn logs the failure as an error and continues the startup.
+	SeverityWarn Severity = "Warn"
+	// SeverityInfo logs the failure as information and continues the startup.
+	SeverityInfo Severity = "Info"
+)
+
+// ParseSeverity converts s to a Severity, matching case-insensitively.
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range []Severity{SeverityBlock, SeverityWarn, SeverityInfo} {
+		if strings.EqualFold(s, string(sev)) {
+			return sev, nil
+		}
+	}
+	return "", fmt.Errorf("unknown severity %q, must be one of Block, Warn or Info", s)
+}
+
+// SeverityHook is implemented by pre-start hooks whose failures should not
+// block the startup by default.
+type SeverityHook interface {
+	PreStartHook
+	// Severity returns the default severity of the hook's failures.
+	Severity() Severity
+}
+
+// IndependentHook is implemented by pre-start hooks that neither depend on nor
+// affect other hooks. The runner executes them concurrently with each other
+// and with the ordered hooks.
+type IndependentHook interface {
+	PreStartHook
+	// Independent marks the hook as safe to run concurrently.
+	Independent()
+}
+
+// DependentHook is implemented by pre-start hooks that must run after other
+// hooks, e.g. because they read objects whose CRDs another hook validates.
+// The runner starts them once the hooks they depend on returned, and does not
+// run them if one of these failed with SeverityBlock. Dependencies on hooks
+// that are not registered are ignored.
+type DependentHook interface {
+	PreStartHook
+	// DependsOn returns the names of the hooks that must run first.
+	DependsOn() []string
+}
+
+// LeaderOnlyHook is implemented by pre-start hooks that write to the cluster,
+// such as round-trip tests, and only need to run once per deployment. Split
+// them from the other hooks with SplitLeaderOnly to run them as post-start
+// hooks, which run on the leader only. Post-start hooks run concurrently with
+// the controllers once the leader is elected, so leader-only hooks cannot
+// depend on the pre-start hooks and should be best-effort checks.
+type LeaderOnlyHook interface {
+	PreStartHook
+	// LeaderOnly marks the hook as run on the leader only.
+	LeaderOnly()
+}
+
+// SplitLeaderOnly returns the hooks to run on every replica and the
+// LeaderOnlyHooks, to run as post-start hooks on the leader.
+func SplitLeaderOnly(hooks []PreStartHook) ([]PreStartHook, []PostStartHook) {
+	var everywhere []PreStartHook
+	var leader []PostStartHook
+	for _, hook := range hooks {
+		if _, ok := hook.(LeaderOnlyHook); ok {
+			leader = append(leader, hook)
+			continue
+		}
+		everywhere = append(everywhere, hook)
+	}
+	return everywhere, leader
+}
+
+// Result is the outcome of a single pre-start hook.
+type Result struct {
+	Name     string
+	Severity Severity
+	// Skipped is set when the hook was disabled by the operator.
+	Skipped  bool
+	Err      error
+	Duration time.Duration
+}
+
+// Blocking returns true if the result must abort the startup.
+func (r Result) Blocking() bool {
+	return r.Err != nil && r.Severity == SeverityBlock
+}
+
+// Runner executes pre-start hooks. Hooks that are not independent run one after
+// another in the given order, moved after the hooks they depend on, independent
+// hooks run concurrently alongside them.
+type Runner struct {
+	Hooks []PreStartHook
+	// HookTimeout bounds the run of every hook. Zero disables the per-hook timeout.
+	HookTimeout time.Duration
+	// Timeouts overrides HookTimeout for the hooks with the given names.
+	Timeouts map[string]time.Duration
+	// Deadline bounds the run of all hooks. Zero disables the overall deadline.
+	Deadline time.Duration
+	// Skip lists the names of hooks that are not run.
+	Skip []string
+	// Severities overrides the severity of the hooks with the given names.
+	Severities map[string]Severity
+
+	mu      sync.Mutex
+	results []Result
+}
+
+// Run executes all hooks and returns the errors of every hook that failed with
+// SeverityBlock joined together. Ordered hooks following a blocking failure are
+// not run, nor are the hooks depending on a hook with a blocking failure.
+// Failures of other severities are only logged. An error is returned without
+// running any hook if the dependencies of the hooks form a cycle.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := checkDependencyCycles(r.Hooks); err != nil {
+		return err
+	}
+	if r.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Deadline)
+		defer cancel()
+	}
+	r.mu.Lock()
+	r.results = nil
+	r.mu.Unlock()
+
+	s := newScheduler(r.Hooks)
+	var wg sync.WaitGroup
+	var ordered []PreStartHook
+	for _, hook := range r.Hooks {
+		if _, ok := hook.(IndependentHook); !ok {
+			ordered = append(ordered, hook)
+			continue
+		}
+		wg.Add(1)
+		go func(hook PreStartHook) {
+			defer wg.Done()
+			r.runScheduled(ctx, s, hook)
+		}(hook)
+	}
+	ordered = sortOrdered(ordered)
+	for i, hook := range ordered {
+		if res, ok := r.runScheduled(ctx, s, hook); ok && res.Blocking() {
+			for _, rest := range ordered[i+1:] {
+				s.finish(rest.Name(), nil)
+			}
+			break
+		}
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, res := range r.Results() {
+		if res.Blocking() {
+			errs = append(errs, fmt.Errorf("failed to run hook %s: %w", res.Name, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Results returns the results of the hooks run by the last call to Run, in
+// completion order.
+func (r *Runner) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Result{}, r.results...)
+}
+
+// runScheduled runs hook once the hooks it depends on returned, and reports
+// whether it was run.
+func (r *Runner) runScheduled(ctx context.Context, s *scheduler, hook PreStartHook) (Result, bool) {
+	if failed := s.wait(ctx, hook); failed != "" {
+		klog.InfoS("Not running pre-start hook, a hook it depends on failed or did not run", "hook", hook.Name(), "dependency", failed)
+		s.finish(hook.Name(), nil)
+		return Result{}, false
+	}
+	res := r.runHook(ctx, hook)
+	r.record(res)
+	s.finish(hook.Name(), &res)
+	return res, true
+}
+
+func (r *Runner) record(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, res)
+}
+
+// severity returns the effective severity of hook.
+func (r *Runner) severity(hook PreStartHook) Severity {
+	if sev, ok := r.Severities[hook.Name()]; ok {
+		return sev
+	}
+	if h, ok := hook.(SeverityHook); ok {
+		return h.Severity()
+	}
+	return SeverityBlock
+}
+
+// runHook runs a single hook under its timeout. The hook is abandoned if it does
+// not return once its context is done.
+func (r *Runner) runHook(ctx context.Context, hook PreStartHook) Result {
+	name := hook.Name()
+	res := Result{Name: name, Severity: r.severity(hook)}
+	if slices.Contains(r.Skip, name) {
+		klog.InfoS("Skipping pre-start hook", "hook", name)
+		res.Skipped = true
+		return res
+	}
+	timeout := r.HookTimeout
+	if t, ok := r.Timeouts[name]; ok {
+		timeout = t
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	klog.InfoS("Running pre-start hook", "hook", name, "timeout", timeout, "severity", res.Severity)
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- hook.Run(ctx) }()
+
+	select {
+	case res.Err = <-done:
+	case <-ctx.Done():
+		res.Err = fmt.Errorf("timed out after %s: %w", time.Since(start).Round(time.Millisecond), ctx.Err())
+	}
+	res.Duration = time.Since(start)
+	recordMetrics(res)
+	switch {
+	case res.Err == nil:
+		klog.InfoS("Pre-start hook completed successfully", "hook", name, "duration", res.Duration)
+	case res.Severity == SeverityBlock:
+		klog.ErrorS(res.Err, "Failed to run pre-start hook", "hook", name, "duration", res.Duration)
+	case res.Severity == SeverityWarn:
+		klog.ErrorS(res.Err, "Pre-start hook failed, continuing because of its severity", "hook", name, "severity", res.Severity)
+	default:
+		klog.InfoS("Pre-start hook failed, continuing because of its severity", "hook", name, "severity", res.Severity, "err", res.Err.Error())
+	}
+	return res
+}
+
+// recordMetrics exports the outcome of a hook run.
+func recordMetrics(res Result) {
+	status := "succeeded"
+	if res.Err != nil {
+		status = "failed"
+		metrics.PreStartHookFailuresCounter.WithLabelValues(res.Name, string(res.Severity)).Inc()
+	}
+	metrics.PreStartHookDurationHistogram.WithLabelValues(res.Name, status).Observe(res.Duration.Seconds())
+}
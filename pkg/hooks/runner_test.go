@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+type testHook struct {
+	name  string
+	delay time.Duration
+	err   error
+	runs  *atomic.Int32
+}
+
+func (h *testHook) Name() string { return h.name }
+
+func (h *testHook) Run(ctx context.Context) error {
+	if h.runs != nil {
+		h.runs.Add(1)
+	}
+	select {
+	case <-time.After(h.delay):
+		return h.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type independentHook struct{ testHook }
+
+func (h *independentHook) Independent() {}
+
+func TestRunnerRunsIndependentHooksConcurrently(t *testing.T) {
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		&independentHook{testHook{name: "a", delay: 200 * time.Millisecond}},
+		&independentHook{testHook{name: "b", delay: 200 * time.Millisecond}},
+		&independentHook{testHook{name: "c", delay: 200 * time.Millisecond}},
+	}}
+	start := time.Now()
+	assert.NoError(t, runner.Run(context.Background()))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+type dependentHook struct {
+	independentHook
+	deps []string
+}
+
+func (h *dependentHook) DependsOn() []string { return h.deps }
+
+func TestRunnerRunsDependenciesFirst(t *testing.T) {
+	newHook := func(name string, delay time.Duration, deps ...string) *dependentHook {
+		return &dependentHook{independentHook{testHook{name: name, delay: delay}}, deps}
+	}
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		newHook("advisory", 0, "schema"),
+		newHook("schema", 100*time.Millisecond, "api"),
+		newHook("api", 100*time.Millisecond),
+		newHook("other", 0),
+	}}
+	assert.NoError(t, runner.Run(context.Background()))
+
+	var completed []string
+	for _, res := range runner.Results() {
+		completed = append(completed, res.Name)
+	}
+	assert.Equal(t, []string{"other", "api", "schema", "advisory"}, completed)
+}
+
+func TestRunnerSkipsHooksDependingOnFailures(t *testing.T) {
+	var runs atomic.Int32
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		&dependentHook{independentHook{testHook{name: "dependent", runs: &runs}}, []string{"schema", "missing"}},
+		&independentHook{testHook{name: "schema", err: errors.New("outdated"), runs: &runs}},
+		&dependentHook{independentHook{testHook{name: "warned", runs: &runs}}, []string{"advisory"}},
+		&independentHook{testHook{name: "advisory", err: errors.New("large"), runs: &runs}},
+	}, Severities: map[string]hooks.Severity{"advisory": hooks.SeverityWarn}}
+	err := runner.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to run hook schema: outdated")
+	assert.Equal(t, int32(3), runs.Load())
+	assert.Len(t, runner.Results(), 3)
+}
+
+func TestRunnerRejectsDependencyCycles(t *testing.T) {
+	var runs atomic.Int32
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		&dependentHook{independentHook{testHook{name: "a", runs: &runs}}, []string{"b"}},
+		&dependentHook{independentHook{testHook{name: "b", runs: &runs}}, []string{"a"}},
+	}}
+	assert.ErrorContains(t, runner.Run(context.Background()), "pre-start hooks depend on each other: a -> b -> a")
+	assert.Zero(t, runs.Load())
+}
+
+type orderedDependentHook struct {
+	testHook
+	deps []string
+}
+
+func (h *orderedDependentHook) DependsOn() []string { return h.deps }
+
+func TestRunnerRunsOrderedDependenciesFirst(t *testing.T) {
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		&orderedDependentHook{testHook{name: "a"}, []string{"b"}},
+		&testHook{name: "b"},
+	}, Deadline: time.Second}
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(context.Background()) }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the run of an ordered hook depending on a later one did not return")
+	}
+
+	var completed []string
+	for _, res := range runner.Results() {
+		completed = append(completed, res.Name)
+	}
+	assert.Equal(t, []string{"b", "a"}, completed)
+}
+
+type leaderOnlyHook struct{ testHook }
+
+func (h *leaderOnlyHook) LeaderOnly() {}
+
+func TestSplitLeaderOnly(t *testing.T) {
+	everywhere, leader := hooks.SplitLeaderOnly([]hooks.PreStartHook{
+		&testHook{name: "schema"},
+		&leaderOnlyHook{testHook{name: "round-trip"}},
+		&independentHook{testHook{name: "rbac"}},
+	})
+	assert.Len(t, everywhere, 2)
+	assert.Equal(t, "schema", everywhere[0].Name())
+	assert.Equal(t, "rbac", everywhere[1].Name())
+	assert.Len(t, leader, 1)
+	assert.Equal(t, "round-trip", leader[0].Name())
+}
+
+func TestRunnerStopsOrderedHooksAfterFailure(t *testing.T) {
+	var runs atomic.Int32
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{
+		&testHook{name: "first", err: errors.New("boom"), runs: &runs},
+		&testHook{name: "second", runs: &runs},
+	}}
+	err := runner.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to run hook first: boom")
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestRunnerTimeouts(t *testing.T) {
+	runner := &hooks.Runner{
+		Hooks: []hooks.PreStartHook{
+			&independentHook{testHook{name: "slow", delay: time.Second}},
+			&independentHook{testHook{name: "fast", delay: 10 * time.Millisecond}},
+		},
+		HookTimeout: 500 * time.Millisecond,
+		Timeouts:    map[string]time.Duration{"slow": 50 * time.Millisecond},
+	}
+	err := runner.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to run hook slow")
+	assert.NotContains(t, err.Error(), "hook fast")
+
+	runner = &hooks.Runner{
+		Hooks:    []hooks.PreStartHook{&testHook{name: "slow", delay: time.Second}},
+		Deadline: 50 * time.Millisecond,
+	}
+	assert.ErrorIs(t, runner.Run(context.Background()), context.DeadlineExceeded)
+}
+
+func TestRunnerSeverityAndSkip(t *testing.T) {
+	var runs atomic.Int32
+	runner := &hooks.Runner{
+		Hooks: []hooks.PreStartHook{
+			&testHook{name: "warn", err: errors.New("degraded"), runs: &runs},
+			&testHook{name: "skipped", err: errors.New("never run"), runs: &runs},
+			&testHook{name: "ok", runs: &runs},
+		},
+		Skip:       []string{"skipped"},
+		Severities: map[string]hooks.Severity{"warn": hooks.SeverityWarn},
+	}
+	assert.NoError(t, runner.Run(context.Background()))
+	assert.Equal(t, int32(2), runs.Load())
+
+	results := runner.Results()
+	assert.Len(t, results, 3)
+	assert.Equal(t, hooks.SeverityWarn, results[0].Severity)
+	assert.EqualError(t, results[0].Err, "degraded")
+	assert.False(t, results[0].Blocking())
+	assert.True(t, results[1].Skipped)
+	assert.NoError(t, results[2].Err)
+}
+
+func TestParseSeverity(t *testing.T) {
+	sev, err := hooks.ParseSeverity("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, hooks.SeverityWarn, sev)
+	_, err = hooks.ParseSeverity("fatal")
+	assert.ErrorContains(t, err, "unknown severity")
+}
+
+func TestRunnerRecordsMetrics(t *testing.T) {
+	before := testutil.ToFloat64(metrics.PreStartHookFailuresCounter.WithLabelValues("metered", string(hooks.SeverityWarn)))
+	runner := &hooks.Runner{
+		Hooks:      []hooks.PreStartHook{&testHook{name: "metered", err: errors.New("degraded")}},
+		Severities: map[string]hooks.Severity{"metered": hooks.SeverityWarn},
+	}
+	assert.NoError(t, runner.Run(context.Background()))
+	after := testutil.ToFloat64(metrics.PreStartHookFailuresCounter.WithLabelValues("metered", string(hooks.SeverityWarn)))
+	assert.Equal(t, before+1, after)
+}
+
+type syncedCache struct{ synced bool }
+
+func (c syncedCache) WaitForCacheSync(context.Context) bool { return c.synced }
+
+func TestPostStartRunner(t *testing.T) {
+	var runs atomic.Int32
+	r := &hooks.PostStartRunner{
+		PostStartHooks: []hooks.PostStartHook{&testHook{name: "warm", runs: &runs}},
+		Cache:          syncedCache{synced: true},
+	}
+	assert.True(t, r.NeedLeaderElection())
+	assert.NoError(t, r.Start(context.Background()))
+	assert.Equal(t, int32(1), runs.Load())
+
+	r = &hooks.PostStartRunner{
+		PostStartHooks: []hooks.PostStartHook{&testHook{name: "warm", runs: &runs}},
+		Cache:          syncedCache{},
+	}
+	assert.ErrorContains(t, r.Start(context.Background()), "failed to wait for caches to sync")
+	assert.Equal(t, int32(1), runs.Load())
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// dependenciesOf returns the names of the hooks hook depends on.
+func dependenciesOf(hook PreStartHook) []string {
+	if h, ok := hook.(DependentHook); ok {
+		return h.DependsOn()
+	}
+	return nil
+}
+
+// checkDependencyCycles returns an error naming the hooks of a dependency
+// cycle, if any, since such hooks would wait for each other forever.
+func checkDependencyCycles(hooks []PreStartHook) error {
+	deps := make(map[string][]string, len(hooks))
+	for _, hook := range hooks {
+		deps[hook.Name()] = dependenciesOf(hook)
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("pre-start hooks depend on each other: %s -> %s", strings.Join(path, " -> "), name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, hook := range hooks {
+		if err := visit(hook.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortOrdered returns the ordered hooks sorted so that every hook comes after
+// the ordered hooks it depends on, keeping the given order otherwise. Without
+// it an ordered hook depending on a later one would wait for a hook that is
+// only run after it. The dependencies must not form a cycle.
+func sortOrdered(ordered []PreStartHook) []PreStartHook {
+	byName := make(map[string]PreStartHook, len(ordered))
+	for _, hook := range ordered {
+		byName[hook.Name()] = hook
+	}
+	sorted := make([]PreStartHook, 0, len(ordered))
+	added := make(map[string]bool, len(ordered))
+	var add func(hook PreStartHook)
+	add = func(hook PreStartHook) {
+		if added[hook.Name()] {
+			return
+		}
+		added[hook.Name()] = true
+		for _, dep := range dependenciesOf(hook) {
+			if h, ok := byName[dep]; ok {
+				add(h)
+			}
+		}
+		sorted = append(sorted, hook)
+	}
+	for _, hook := range ordered {
+		add(hook)
+	}
+	return sorted
+}
+
+// scheduler tracks the hooks of a run so that hooks wait for the hooks they
+// depend on.
+type scheduler struct {
+	mu    sync.Mutex
+	hooks map[string]*scheduledHook
+}
+
+type scheduledHook struct {
+	done chan struct{}
+	// res is nil if the hook was not run.
+	res *Result
+}
+
+func newScheduler(hooks []PreStartHook) *scheduler {
+	s := &scheduler{hooks: make(map[string]*scheduledHook, len(hooks))}
+	for _, hook := range hooks {
+		s.hooks[hook.Name()] = &scheduledHook{done: make(chan struct{})}
+	}
+	return s
+}
+
+// wait blocks until the hooks hook depends on are finished, and returns the
+// name of the first one that failed with SeverityBlock or was not run, or an
+// empty string if hook can run. A dependency still running when ctx is done is
+// treated as not run.
+func (s *scheduler) wait(ctx context.Context, hook PreStartHook) string {
+	for _, dep := range dependenciesOf(hook) {
+		h, ok := s.hooks[dep]
+		if !ok {
+			continue
+		}
+		select {
+		case <-h.done:
+		case <-ctx.Done():
+			return dep
+		}
+		s.mu.Lock()
+		res := h.res
+		s.mu.Unlock()
+		if res == nil || res.Blocking() {
+			return dep
+		}
+	}
+	return ""
+}
+
+// finish records the result of the named hook, nil if it was not run, and
+// releases the hooks depending on it.
+func (s *scheduler) finish(name string, res *Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.hooks[name]
+	select {
+	case <-h.done:
+		return
+	default:
+	}
+	h.res = res
+	close(h.done)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizeadvisory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// EtcdObjectSizeLimit is the default maximum size of a request to etcd.
+const EtcdObjectSizeLimit = 1536 * 1024
+
+// listPageSize is the number of objects listed per request while sampling.
+const listPageSize = 100
+
+// Options configures the size advisory hook.
+type Options struct {
+	// SampleSize is the number of largest objects of each kind that are reported.
+	SampleSize int
+	// AdviseSize is the stored size above which enabling compression is recommended.
+	AdviseSize int64
+	// WarnRatio is the ratio of EtcdObjectSizeLimit above which objects of an
+	// uncompressed kind make the hook fail.
+	WarnRatio float64
+	// CompressedKinds records the kinds whose compression feature gate
+	// (Zstd or Gzip) is enabled, keyed by kind.
+	CompressedKinds map[string]bool
+}
+
+// ObjectSize is the estimated stored size of an object.
+type ObjectSize struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Size      int64
+}
+
+// String returns the object and its human readable size.
+func (o ObjectSize) String() string {
+	name := o.Name
+	if o.Namespace != "" {
+		name = o.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s (%s)", o.Kind, name, resource.NewQuantity(o.Size, resource.BinarySI).String())
+}
+
+// kinds are the kinds sampled by the hook with the feature gates compressing them.
+var kinds = []struct {
+	kind string
+	zstd string
+	gzip string
+}{
+	{kind: v1beta1.ApplicationRevisionKind, zstd: string(features.ZstdApplicationRevision), gzip: string(features.GzipApplicationRevision)},
+	{kind: v1beta1.ResourceTrackerKind, zstd: string(features.ZstdResourceTracker), gzip: string(features.GzipResourceTracker)},
+}
+
+// Hook samples the largest ApplicationRevisions and ResourceTrackers, estimates
+// their stored size and advises enabling the Zstd or Gzip compression feature
+// gates for kinds whose objects grow towards the etcd request size limit. It
+// complements the compression check of the CRD validation hook, which only
+// verifies that compression works once enabled.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// NewHook creates a new size advisory hook with the given client and options
+func NewHook(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing size advisory hook", "sampleSize", opts.SampleSize, "adviseSize", opts.AdviseSize)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "SizeAdvisory"
+}
+
+// Severity makes the advice non-fatal by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// DependsOn runs the hook once the CRDs of the objects it samples are validated.
+func (h *Hook) DependsOn() []string {
+	return []string{"CRDValidation"}
+}
+
+// Run samples each kind, logs recommendations and fails if objects of an
+// uncompressed kind are close to the etcd limit.
+func (h *Hook) Run(ctx context.Context) error {
+	var problems []string
+	for _, k := range kinds {
+		largest, err := h.Largest(ctx, k.kind)
+		if err != nil {
+			return err
+		}
+		if len(largest) == 0 {
+			continue
+		}
+		compressed := h.Options.CompressedKinds[k.kind]
+		top := largest[0]
+		klog.V(2).InfoS("Sampled largest objects", "kind", k.kind, "largest", top.String(), "compressed", compressed)
+		switch {
+		case compressed:
+		case float64(top.Size) >= h.Options.WarnRatio*EtcdObjectSizeLimit:
+			problems = append(problems, fmt.Sprintf("%s is close to the etcd size limit of %s, enable the %s (or %s) feature gate: %s",
+				top.String(), resource.NewQuantity(EtcdObjectSizeLimit, resource.BinarySI).String(), k.zstd, k.gzip, describe(largest)))
+		case top.Size >= h.Options.AdviseSize:
+			klog.InfoS("Large objects found, enabling compression is recommended", "kind", k.kind,
+				"featureGate", k.zstd, "largest", describe(largest))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("objects close to the etcd size limit: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Largest returns the SampleSize largest objects of kind, largest first.
+func (h *Hook) Largest(ctx context.Context, kind string) ([]ObjectSize, error) {
+	var sizes []ObjectSize
+	for cont := ""; ; {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := h.Client.List(ctx, list, client.Limit(listPageSize), client.Continue(cont)); err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		for _, item := range list.Items {
+			data, err := json.Marshal(item.Object)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s %s: %w", kind, item.GetName(), err)
+			}
+			sizes = append(sizes, ObjectSize{Kind: kind, Namespace: item.GetNamespace(), Name: item.GetName(), Size: int64(len(data))})
+		}
+		sort.Slice(sizes, func(i, j int) bool { return sizes[i].Size > sizes[j].Size })
+		if len(sizes) > h.Options.SampleSize {
+			sizes = sizes[:h.Options.SampleSize]
+		}
+		if cont = list.GetContinue(); cont == "" {
+			return sizes, nil
+		}
+	}
+}
+
+// describe joins the sampled objects for logging.
+func describe(sizes []ObjectSize) string {
+	s := make([]string, 0, len(sizes))
+	for _, o := range sizes {
+		s = append(s, o.String())
+	}
+	return strings.Join(s, ", ")
+}
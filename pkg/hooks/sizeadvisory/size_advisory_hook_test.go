@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizeadvisory_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/hooks/sizeadvisory"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func newRevision(name string, size int) *v1beta1.ApplicationRevision {
+	return &v1beta1.ApplicationRevision{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        name,
+		Annotations: map[string]string{"payload": strings.Repeat("x", size)},
+	}}
+}
+
+func TestSizeAdvisoryHook(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(
+		newRevision("small", 10), newRevision("medium", 100*1024), newRevision("large", 1400*1024),
+	).Build()
+	opts := sizeadvisory.Options{SampleSize: 2, AdviseSize: 64 * 1024, WarnRatio: 0.8}
+
+	hook := &sizeadvisory.Hook{Client: cli, Options: opts}
+	largest, err := hook.Largest(ctx, v1beta1.ApplicationRevisionKind)
+	require.NoError(t, err)
+	require.Len(t, largest, 2)
+	assert.Equal(t, "large", largest[0].Name)
+	assert.Equal(t, "medium", largest[1].Name)
+	assert.Greater(t, largest[0].Size, int64(1400*1024))
+
+	err = sizeadvisory.NewHook(cli, opts).Run(ctx)
+	assert.ErrorContains(t, err, "ApplicationRevision default/large")
+	assert.ErrorContains(t, err, "enable the ZstdApplicationRevision (or GzipApplicationRevision) feature gate")
+
+	opts.CompressedKinds = map[string]bool{v1beta1.ApplicationRevisionKind: true}
+	assert.NoError(t, sizeadvisory.NewHook(cli, opts).Run(ctx))
+}
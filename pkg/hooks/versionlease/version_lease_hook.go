@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versionlease
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/version"
+)
+
+// AnnotationGitRevision records the git revision of the running vela-core.
+const AnnotationGitRevision = "oam.dev/kubevela-git-revision"
+
+// Hook announces the version of the running controller in a Lease, so that
+// clients can tell which vela-core version currently leads without reading
+// the Deployment.
+type Hook struct {
+	client.Client
+	Namespace string
+	LeaseName string
+}
+
+// NewHook creates a new version Lease hook writing the Lease namespace/name.
+func NewHook(c client.Client, namespace, name string) hooks.PostStartHook {
+	klog.V(3).InfoS("Initializing version lease hook", "namespace", namespace, "name", name)
+	return &Hook{Client: c, Namespace: namespace, LeaseName: name}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "VersionLease"
+}
+
+// Severity makes failures to announce the version non-fatal.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Run creates or updates the Lease with the identity and version of this replica.
+func (h *Hook) Run(ctx context.Context) error {
+	identity, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %w", err)
+	}
+	now := metav1.NewMicroTime(time.Now())
+	lease := &coordinationv1.Lease{}
+	err = h.Client.Get(ctx, client.ObjectKey{Namespace: h.Namespace, Name: h.LeaseName}, lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get version lease %s/%s: %w", h.Namespace, h.LeaseName, err)
+	}
+	exists := err == nil
+	if !exists {
+		lease.Namespace = h.Namespace
+		lease.Name = h.LeaseName
+		lease.Spec.AcquireTime = &now
+	}
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[oam.AnnotationKubeVelaVersion] = version.VelaVersion
+	lease.Annotations[AnnotationGitRevision] = version.GitRevision
+	lease.Spec.HolderIdentity = ptr.To(identity)
+	lease.Spec.RenewTime = &now
+
+	if exists {
+		err = h.Client.Update(ctx, lease)
+	} else {
+		err = h.Client.Create(ctx, lease)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write version lease %s/%s: %w", h.Namespace, h.LeaseName, err)
+	}
+	klog.InfoS("Announced controller version", "lease", h.Namespace+"/"+h.LeaseName, "version", version.VelaVersion, "holder", identity)
+	return nil
+}
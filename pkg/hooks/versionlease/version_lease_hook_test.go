@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versionlease_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/versionlease"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/version"
+)
+
+func TestVersionLeaseHook(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	hook := versionlease.NewHook(cli, "vela-system", "vela-core-version")
+	assert.Equal(t, "VersionLease", hook.Name())
+	assert.Equal(t, hooks.SeverityWarn, hook.(hooks.SeverityHook).Severity())
+
+	// the second run updates the existing lease
+	require.NoError(t, hook.Run(ctx))
+	require.NoError(t, hook.Run(ctx))
+
+	lease := &coordinationv1.Lease{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "vela-system", Name: "vela-core-version"}, lease))
+	assert.Equal(t, version.VelaVersion, lease.Annotations[oam.AnnotationKubeVelaVersion])
+	assert.NotNil(t, lease.Spec.HolderIdentity)
+	assert.NotNil(t, lease.Spec.AcquireTime)
+	assert.NotNil(t, lease.Spec.RenewTime)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookvalidation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// Hook validates the Mutating and ValidatingWebhookConfigurations that route to
+// the vela-core webhook service. Its failures are reported with SeverityWarn by
+// default, since the webhook server itself is started after the hooks run.
+type Hook struct {
+	client.Client
+	Options Options
+}
+
+// Options configures the webhook validation hook.
+type Options struct {
+	// Namespace and Service identify the vela-core webhook service. Only the
+	// webhooks whose client config references this service are validated.
+	Namespace string
+	Service   string
+	// CertDir holds the serving certificate (tls.crt). When the file exists the
+	// CA bundles are checked to sign it.
+	CertDir string
+	// MinCertValidity is the minimum remaining validity of the serving
+	// certificate and the CA bundle.
+	MinCertValidity time.Duration
+}
+
+// webhookRef is the part of a mutating or validating webhook the hook checks.
+type webhookRef struct {
+	config        string
+	name          string
+	clientConfig  admissionregistrationv1.WebhookClientConfig
+	failurePolicy *admissionregistrationv1.FailurePolicyType
+}
+
+// NewHook creates a new webhook validation hook with the given client and options
+func NewHook(c client.Client, opts Options) hooks.PreStartHook {
+	klog.V(3).InfoS("Initializing webhook validation hook", "service", opts.Namespace+"/"+opts.Service)
+	return &Hook{Client: c, Options: opts}
+}
+
+// Name returns the hook name for logging
+func (h *Hook) Name() string {
+	return "WebhookValidation"
+}
+
+// Severity makes webhook misconfigurations non-fatal by default.
+func (h *Hook) Severity() hooks.Severity {
+	return hooks.SeverityWarn
+}
+
+// Independent marks the hook as safe to run concurrently with other hooks.
+func (h *Hook) Independent() {}
+
+// Run validates every webhook routed to the vela-core webhook service and
+// reports all problems together.
+func (h *Hook) Run(ctx context.Context) error {
+	refs, err := h.listWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		klog.InfoS("No webhook configuration references the vela-core webhook service", "service", h.Options.Namespace+"/"+h.Options.Service)
+		return nil
+	}
+
+	now := time.Now()
+	var problems []string
+	servingCert, err := h.loadServingCert()
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if servingCert != nil {
+		if msg := CheckValidity("serving certificate", servingCert, now, h.Options.MinCertValidity); msg != "" {
+			problems = append(problems, msg)
+		}
+	}
+
+	svc := &corev1.Service{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Namespace: h.Options.Namespace, Name: h.Options.Service}, svc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get webhook service %s/%s: %w", h.Options.Namespace, h.Options.Service, err)
+		}
+		problems = append(problems, fmt.Sprintf("webhook service %s/%s does not exist", h.Options.Namespace, h.Options.Service))
+		svc = nil
+	}
+
+	for _, ref := range refs {
+		klog.V(2).InfoS("Validating webhook", "configuration", ref.config, "webhook", ref.name)
+		problems = append(problems, h.checkWebhook(ref, svc, servingCert, now)...)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid webhook configuration: %s", strings.Join(problems, "; "))
+	}
+	klog.InfoS("Webhook configurations validated", "webhooks", len(refs))
+	return nil
+}
+
+// listWebhooks returns the webhooks of all configurations that reference the
+// vela-core webhook service.
+func (h *Hook) listWebhooks(ctx context.Context) ([]webhookRef, error) {
+	var refs []webhookRef
+	matches := func(cc admissionregistrationv1.WebhookClientConfig) bool {
+		return cc.Service != nil && cc.Service.Namespace == h.Options.Namespace && cc.Service.Name == h.Options.Service
+	}
+	mutating := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := h.Client.List(ctx, mutating); err != nil {
+		return nil, fmt.Errorf("failed to list MutatingWebhookConfigurations: %w", err)
+	}
+	for _, cfg := range mutating.Items {
+		for _, wh := range cfg.Webhooks {
+			if matches(wh.ClientConfig) {
+				refs = append(refs, webhookRef{config: cfg.Name, name: wh.Name, clientConfig: wh.ClientConfig, failurePolicy: wh.FailurePolicy})
+			}
+		}
+	}
+	validating := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := h.Client.List(ctx, validating); err != nil {
+		return nil, fmt.Errorf("failed to list ValidatingWebhookConfigurations: %w", err)
+	}
+	for _, cfg := range validating.Items {
+		for _, wh := range cfg.Webhooks {
+			if matches(wh.ClientConfig) {
+				refs = append(refs, webhookRef{config: cfg.Name, name: wh.Name, clientConfig: wh.ClientConfig, failurePolicy: wh.FailurePolicy})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// checkWebhook returns the problems of a single webhook.
+func (h *Hook) checkWebhook(ref webhookRef, svc *corev1.Service, servingCert *x509.Certificate, now time.Time) []string {
+	var problems []string
+	prefix := fmt.Sprintf("webhook %s of %s", ref.name, ref.config)
+
+	if svc != nil && ref.clientConfig.Service.Port != nil {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			if p.Port == *ref.clientConfig.Service.Port {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s references port %d which is not exposed by service %s/%s",
+				prefix, *ref.clientConfig.Service.Port, svc.Namespace, svc.Name))
+		}
+	}
+
+	switch {
+	case ref.failurePolicy == nil:
+	case *ref.failurePolicy == admissionregistrationv1.Ignore:
+		klog.InfoS("Webhook failures are ignored, invalid resources may be admitted while vela-core is unavailable",
+			"configuration", ref.config, "webhook", ref.name)
+	case *ref.failurePolicy != admissionregistrationv1.Fail:
+		problems = append(problems, fmt.Sprintf("%s has unknown failurePolicy %q", prefix, *ref.failurePolicy))
+	}
+
+	cas, err := ParseCertificates(ref.clientConfig.CABundle)
+	if err != nil {
+		return append(problems, fmt.Sprintf("%s has an invalid caBundle: %v", prefix, err))
+	}
+	for _, ca := range cas {
+		if msg := CheckValidity("CA certificate "+ca.Subject.CommonName, ca, now, h.Options.MinCertValidity); msg != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", prefix, msg))
+		}
+	}
+	if servingCert != nil {
+		roots := x509.NewCertPool()
+		for _, ca := range cas {
+			roots.AddCert(ca)
+		}
+		if _, err := servingCert.Verify(x509.VerifyOptions{Roots: roots, CurrentTime: now}); err != nil {
+			problems = append(problems, fmt.Sprintf("%s has a caBundle that does not sign the serving certificate: %v", prefix, err))
+		}
+	}
+	return problems
+}
+
+// loadServingCert reads the serving certificate from the cert dir. A missing
+// file is not an error, since the certificate may be mounted later.
+func (h *Hook) loadServingCert() (*x509.Certificate, error) {
+	if h.Options.CertDir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(h.Options.CertDir, "tls.crt")))
+	if err != nil {
+		if os.IsNotExist(err) {
+			klog.V(2).InfoS("Serving certificate not found, skipping CA bundle match", "certDir", h.Options.CertDir)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read serving certificate: %w", err)
+	}
+	certs, err := ParseCertificates(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid serving certificate: %w", err)
+	}
+	return certs[0], nil
+}
+
+// ParseCertificates parses all PEM encoded certificates in data.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// CheckValidity returns a problem if cert is not valid at now or expires within minValidity.
+func CheckValidity(what string, cert *x509.Certificate, now time.Time, minValidity time.Duration) string {
+	switch {
+	case now.Before(cert.NotBefore):
+		return fmt.Sprintf("%s is not valid before %s", what, cert.NotBefore.Format(time.RFC3339))
+	case now.After(cert.NotAfter):
+		return fmt.Sprintf("%s expired at %s", what, cert.NotAfter.Format(time.RFC3339))
+	case cert.NotAfter.Sub(now) < minValidity:
+		return fmt.Sprintf("%s expires at %s, in less than %s", what, cert.NotAfter.Format(time.RFC3339), minValidity)
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookvalidation_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks/webhookvalidation"
+)
+
+// newCert returns a PEM encoded certificate signed by parent, or self-signed if parent is nil.
+func newCert(t *testing.T, cn string, validity time.Duration, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              []string{cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validity),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), cert, key
+}
+
+func TestWebhookValidationHook(t *testing.T) {
+	ctx := context.Background()
+	caPEM, ca, caKey := newCert(t, "vela-ca", 365*24*time.Hour, nil, nil)
+	otherCAPEM, _, _ := newCert(t, "other-ca", 365*24*time.Hour, nil, nil)
+	servingPEM, _, _ := newCert(t, "vela-core-webhook.vela-system.svc", 24*time.Hour, ca, caKey)
+	certDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "tls.crt"), servingPEM, 0600))
+
+	svc := &corev1.Service{}
+	svc.Namespace, svc.Name = "vela-system", "vela-core-webhook"
+	svc.Spec.Ports = []corev1.ServicePort{{Port: 443}}
+	ref := func(port int32) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{Namespace: "vela-system", Name: "vela-core-webhook", Port: ptr.To(port)},
+		}
+	}
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name: "mutating.core.oam.dev.v1beta1.applications", ClientConfig: ref(443),
+			FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+		}},
+	}
+	mutating.Name = "vela-core-admission"
+	mutating.Webhooks[0].ClientConfig.CABundle = caPEM
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name: "validating.core.oam.dev.v1beta1.applications", ClientConfig: ref(8443),
+			FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+		}},
+	}
+	validating.Name = "vela-core-admission"
+	validating.Webhooks[0].ClientConfig.CABundle = otherCAPEM
+	unrelated := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name: "other.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{URL: ptr.To("https://example.com")},
+		}},
+	}
+	unrelated.Name = "other"
+
+	opts := webhookvalidation.Options{Namespace: "vela-system", Service: "vela-core-webhook", CertDir: certDir, MinCertValidity: time.Hour}
+
+	t.Run("missing service", func(t *testing.T) {
+		cli := fake.NewClientBuilder().WithObjects(mutating.DeepCopy()).Build()
+		err := webhookvalidation.NewHook(cli, opts).Run(ctx)
+		assert.ErrorContains(t, err, "webhook service vela-system/vela-core-webhook does not exist")
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		cli := fake.NewClientBuilder().WithObjects(svc.DeepCopy(), mutating.DeepCopy(), unrelated.DeepCopy()).Build()
+		assert.NoError(t, webhookvalidation.NewHook(cli, opts).Run(ctx))
+	})
+
+	t.Run("mismatched CA bundle, port and expiring certificate", func(t *testing.T) {
+		cli := fake.NewClientBuilder().WithObjects(svc.DeepCopy(), mutating.DeepCopy(), validating.DeepCopy()).Build()
+		o := opts
+		o.MinCertValidity = 48 * time.Hour
+		err := webhookvalidation.NewHook(cli, o).Run(ctx)
+		assert.ErrorContains(t, err, "serving certificate expires at")
+		assert.ErrorContains(t, err, "references port 8443 which is not exposed")
+		assert.ErrorContains(t, err, "webhook validating.core.oam.dev.v1beta1.applications of vela-core-admission has a caBundle that does not sign the serving certificate")
+		assert.NotContains(t, err.Error(), "mutating.core.oam.dev.v1beta1.applications of vela-core-admission has a caBundle")
+	})
+}
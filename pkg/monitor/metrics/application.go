@@ -89,6 +89,52 @@ var (
 	}, []string{"app_name", "namespace"})
 )
 
+var (
+	// DispatchInflightGauge report the number of components or resources being dispatched
+	DispatchInflightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevela_dispatch_inflight",
+		Help: "number of components or resources being dispatched.",
+	}, []string{"scope"})
+
+	// DispatchWaitTimeHistogram report the time components or resources wait for the dispatch concurrency limits
+	DispatchWaitTimeHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "kubevela_dispatch_wait_time_seconds",
+		Help:        "dispatch concurrency limit wait duration distributions.",
+		Buckets:     velametrics.FineGrainedBuckets,
+		ConstLabels: prometheus.Labels{},
+	}, []string{"scope"})
+)
+
+var (
+	// RecompressionObjectsCounter report the number of objects processed by the background recompression
+	RecompressionObjectsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevela_recompression_objects_total",
+		Help: "number of ResourceTrackers or ApplicationRevisions processed by the background recompression.",
+	}, []string{"kind", "result"})
+
+	// RecompressionCompletedGauge report whether the background recompression of a kind is completed
+	RecompressionCompletedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevela_recompression_completed",
+		Help: "whether the background recompression is completed (1 = completed, 0 = running).",
+	}, []string{"kind"})
+)
+
+var (
+	// SerializedSizeGauge report the serialized size of ResourceTrackers and ApplicationRevisions, in the raw
+	// format and in the stored format which is compressed if the compression is enabled
+	SerializedSizeGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevela_serialized_size_bytes",
+		Help: "serialized size of ResourceTrackers and ApplicationRevisions per application.",
+	}, []string{"kind", "app_name", "namespace", "tracker_type", "format"})
+
+	// CompressionRatioGauge report the ratio of the raw size to the stored size of ResourceTrackers and
+	// ApplicationRevisions
+	CompressionRatioGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevela_compression_ratio",
+		Help: "compression ratio (raw size / stored size) of ResourceTrackers and ApplicationRevisions per application.",
+	}, []string{"kind", "app_name", "namespace", "tracker_type"})
+)
+
 var (
 	// ListResourceTrackerCounter report the list resource tracker number.
 	ListResourceTrackerCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	velametrics "github.com/kubevela/pkg/monitor/metrics"
+)
+
+var (
+	// DefinitionReconcileTimeHistogram report the reconcile duration of definitions
+	DefinitionReconcileTimeHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "kubevela_definition_reconcile_time_seconds",
+		Help:        "definition reconcile duration distributions.",
+		Buckets:     velametrics.FineGrainedBuckets,
+		ConstLabels: prometheus.Labels{},
+	}, []string{"kind", "name"})
+
+	// DefinitionRevisionCreatedCounter report the number of definition revisions created
+	DefinitionRevisionCreatedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevela_definition_revisions_created_total",
+		Help: "number of definition revisions created.",
+	}, []string{"kind", "name"})
+
+	// DefinitionSchemaStoreFailuresCounter report the number of failures to generate or store the schema of definitions
+	DefinitionSchemaStoreFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevela_definition_schema_store_failures_total",
+		Help: "number of failures to generate or store the schema of definitions.",
+	}, []string{"kind", "name"})
+
+	// DefinitionStatusConflictRetriesCounter report the number of retries of definition status updates on conflicts
+	DefinitionStatusConflictRetriesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevela_definition_status_conflict_retries_total",
+		Help: "number of retries of definition status updates on conflicts.",
+	}, []string{"kind", "name"})
+)
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	velametrics "github.com/kubevela/pkg/monitor/metrics"
+)
+
+var (
+	// PreStartHookDurationHistogram report the run duration of pre-start hooks
+	PreStartHookDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "vela_prestart_hook_duration_seconds",
+		Help:        "pre-start hook duration distributions.",
+		Buckets:     velametrics.FineGrainedBuckets,
+		ConstLabels: prometheus.Labels{},
+	}, []string{"hook", "status"})
+
+	// PreStartHookFailuresCounter report the number of failed pre-start hook runs
+	PreStartHookFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vela_prestart_hook_failures_total",
+		Help: "number of failed pre-start hook runs.",
+	}, []string{"hook", "severity"})
+
+	// PreStartHookHealthyGauge report if a periodically revalidated pre-start hook passed its last run
+	PreStartHookHealthyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vela_prestart_hook_healthy",
+		Help: "if the last revalidation of the pre-start hook passed (1 = passed, 0 = failed).",
+	}, []string{"hook"})
+)
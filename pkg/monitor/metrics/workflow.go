@@ -40,6 +40,12 @@ var collectorGroup = []prometheus.Collector{
 	ListResourceTrackerCounter,
 	ApplicationReconcileTimeHistogram,
 	ApplyComponentTimeHistogram,
+	DispatchInflightGauge,
+	DispatchWaitTimeHistogram,
+	RecompressionObjectsCounter,
+	RecompressionCompletedGauge,
+	SerializedSizeGauge,
+	CompressionRatioGauge,
 	WorkflowFinishedTimeHistogram,
 	ApplicationPhaseCounter,
 	WorkflowStepPhaseGauge,
@@ -54,6 +60,13 @@ var collectorGroup = []prometheus.Collector{
 	ClusterPodAllocatableGauge,
 	ClusterMemoryUsageGauge,
 	ClusterCPUUsageGauge,
+	PreStartHookDurationHistogram,
+	PreStartHookFailuresCounter,
+	PreStartHookHealthyGauge,
+	DefinitionReconcileTimeHistogram,
+	DefinitionRevisionCreatedCounter,
+	DefinitionSchemaStoreFailuresCounter,
+	DefinitionStatusConflictRetriesCounter,
 }
 
 var (
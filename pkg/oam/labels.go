@@ -59,6 +59,8 @@ const (
 	LabelPolicyDefinitionName = "policydefinition.oam.dev/name"
 	// LabelWorkflowStepDefinitionName records the name of WorkflowStepDefinition
 	LabelWorkflowStepDefinitionName = "workflowstepdefinition.oam.dev/name"
+	// LabelDefinitionSource records the name of the DefinitionSource a definition is synced from
+	LabelDefinitionSource = "definition.oam.dev/source"
 
 	// LabelControllerRevisionComponent indicate which component the revision belong to
 	LabelControllerRevisionComponent = "controller.oam.dev/component"
@@ -149,6 +151,14 @@ const (
 	// AnnotationDefinitionRevisionName is used to specify the name of DefinitionRevision in component/trait definition
 	AnnotationDefinitionRevisionName = "definitionrevision.oam.dev/name"
 
+	// AnnotationDefinitionRevisionHistoryLimit overrides the global definition revision limit for a single
+	// definition. The value must be a non-negative integer, invalid values are ignored.
+	AnnotationDefinitionRevisionHistoryLimit = "definition.oam.dev/revision-history-limit"
+
+	// AnnotationDefinitionRevisionProtected exempts a DefinitionRevision from pruning regardless of the revision limit
+	// when set to "true".
+	AnnotationDefinitionRevisionProtected = "definitionrevision.oam.dev/protected"
+
 	// AnnotationLastAppliedConfiguration is kubectl annotations for 3-way merge
 	AnnotationLastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
 
@@ -226,6 +236,65 @@ const (
 	// "1m", "15m", "30s").  Values below 10s are ignored and fall back to the
 	// global default.  Invalid values are also ignored.
 	AnnotationReconcileInterval = "app.oam.dev/reconcile-interval"
+
+	// AnnotationDryRun runs the application in a dry-run mode. When set to "render", the controller renders the
+	// components and traits with the live definitions and records the manifests, with the values of Secrets
+	// redacted, in a ConfigMap without applying them.
+	AnnotationDryRun = "app.oam.dev/dry-run"
+
+	// AnnotationDryRunManifestsHash records the hash of the manifests stored in the ConfigMap of the render-only
+	// dry-run, the ConfigMap is only updated when the rendered manifests change.
+	AnnotationDryRunManifestsHash = "app.oam.dev/dry-run-manifests-hash"
+
+	// AnnotationAppRevisionMaxAge overrides the maximum age of the application revisions of the application,
+	// e.g. "720h". Older revisions are pruned unless they are still referenced.
+	AnnotationAppRevisionMaxAge = "app.oam.dev/revision-max-age"
+
+	// AnnotationAppRevisionMaxTotalSize overrides the maximum total size of the application revisions of the
+	// application as a quantity, e.g. "10Mi". The oldest revisions are pruned until the total size is under it.
+	AnnotationAppRevisionMaxTotalSize = "app.oam.dev/revision-max-total-size"
+
+	// AnnotationForceDelete allows deleting a definition that is still referenced by Applications when set to "true".
+	AnnotationForceDelete = "definition.oam.dev/force-delete"
+
+	// AnnotationDefinitionPaused pauses the reconciliation of a definition when set to "true", so that its edits
+	// are staged without creating new revisions.
+	AnnotationDefinitionPaused = "definition.oam.dev/paused"
+
+	// AnnotationDefinitionRequires declares the definitions required by a definition, as a comma separated
+	// list of <type>/<name>, e.g. "Component/webservice,Trait/gateway".
+	AnnotationDefinitionRequires = "definition.oam.dev/requires"
+
+	// AnnotationDefinitionDeprecated marks a definition as deprecated when set to "true". Applications using it
+	// are admitted with a warning.
+	AnnotationDefinitionDeprecated = "definition.oam.dev/deprecated"
+
+	// AnnotationDefinitionDeprecationMessage tells the users of a deprecated definition what to use instead.
+	AnnotationDefinitionDeprecationMessage = "definition.oam.dev/deprecation-message"
+
+	// AnnotationDefinitionVisibleNamespaces is the label selector of the namespaces whose applications can use
+	// a definition in vela-system, which is visible to all namespaces without it. It is not supported on the
+	// definitions of other namespaces, which are only visible to their own namespace. It is enforced with the
+	// DefinitionVisibility feature gate.
+	AnnotationDefinitionVisibleNamespaces = "definition.oam.dev/visible-namespaces"
+
+	// AnnotationDefinitionRollbackToRevision asks the definition controllers to restore the spec of the definition
+	// from one of its DefinitionRevisions, by revision number or DefinitionRevision name. It is removed once handled.
+	AnnotationDefinitionRollbackToRevision = "definition.oam.dev/rollback-to-revision"
+
+	// AnnotationDefinitionRollbackBy records the user requesting the rollback of the definition. It is set by the
+	// definition mutating webhooks from the user of the admission request, values set by clients are replaced.
+	AnnotationDefinitionRollbackBy = "definition.oam.dev/rollback-by"
+
+	// AnnotationDefinitionSourceRevision records the revision of the DefinitionSource a definition is synced from.
+	AnnotationDefinitionSourceRevision = "definition.oam.dev/source-revision"
+
+	// AnnotationDefinitionSignature carries the base64 encoded signature of a definition, e.g. the output of
+	// `cosign sign-blob` on the canonical payload of the definition, see signature.Payload.
+	AnnotationDefinitionSignature = "definition.oam.dev/signature"
+
+	// AnnotationDefinitionChecksum carries the checksum of the canonical payload of a definition, as sha256:<hex>.
+	AnnotationDefinitionChecksum = "definition.oam.dev/checksum"
 )
 
 const (
@@ -241,6 +310,8 @@ const (
 	// FinalizerOrphanResource indicates that the gc process should orphan managed
 	// resources instead of deleting them
 	FinalizerOrphanResource = "app.oam.dev/orphan-resource"
+	// FinalizerDefinitionInUse keeps a definition from being deleted while Applications still reference it
+	FinalizerDefinitionInUse = "definition.oam.dev/in-use-protection"
 )
 
 // policyContextKeyType is a private type for Go context keys, preventing collisions.
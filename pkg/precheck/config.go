@@ -0,0 +1,569 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package precheck
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+// Config contains configuration for the pre-start validation hooks and the post-start hooks,
+// grouped by the hook it applies to.
+type Config struct {
+	CRD             CRDValidationConfig
+	Runner          HookRunnerConfig
+	VersionLease    VersionLeaseConfig
+	Webhook         WebhookValidationConfig
+	ClusterGateway  ClusterGatewayConfig
+	RBAC            RBACValidationConfig
+	APIAvailability APIAvailabilityConfig
+	Namespace       NamespaceValidationConfig
+	OrphanDetection OrphanDetectionConfig
+	SizeAdvisory    SizeAdvisoryConfig
+}
+
+// NewConfig creates a new Config with defaults.
+func NewConfig() *Config {
+	return &Config{
+		CRD: CRDValidationConfig{
+			CRDs:                       []string{},
+			ConfigMap:                  "",
+			AutoUpgrade:                false,
+			DetectSchemaDrift:          false,
+			CheckVersionCompatibility:  false,
+			CheckConversionWebhooks:    false,
+			DefinitionRoundTrip:        false,
+			VerifyFieldPruning:         false,
+			MigrateStoredVersions:      false,
+			CheckValidationRules:       false,
+			EnforceBestPractices:       false,
+			ScaleTraits:                []string{"scaler"},
+			BlockVersionSkew:           false,
+			DetectCompressionDowngrade: false,
+			BlockCompressionDowngrade:  false,
+			DryRunComponents:           0,
+			Concurrency:                4,
+			QPS:                        20,
+			TestObjectTTL:              10 * time.Minute,
+			TestNamespace:              "",
+			TestNamePrefix:             "core.pre-check.",
+			RoundTripsOnLeader:         false,
+			FingerprintConfigMap:       "",
+		},
+		Runner: HookRunnerConfig{
+			Timeout:                0,
+			Timeouts:               map[string]string{},
+			Deadline:               0,
+			Skip:                   []string{},
+			Severities:             map[string]string{},
+			ResultsConfigMap:       hooks.DefaultResultsConfigMap,
+			FailureReport:          "",
+			TerminationMessagePath: "/dev/termination-log",
+			RevalidateHooks:        []string{"CRDValidation"},
+			RevalidateInterval:     0,
+			ReadinessGate:          false,
+			RetryInterval:          30 * time.Second,
+		},
+		VersionLease: VersionLeaseConfig{
+			Name: "",
+		},
+		Webhook: WebhookValidationConfig{
+			Service:         "vela-core-webhook",
+			CertMinValidity: 7 * 24 * time.Hour,
+		},
+		ClusterGateway: ClusterGatewayConfig{
+			ProbeTimeout:    5 * time.Second,
+			CertMinValidity: 7 * 24 * time.Hour,
+		},
+		RBAC: RBACValidationConfig{
+			Enabled: true,
+		},
+		APIAvailability: APIAvailabilityConfig{
+			MinVersion:   "v1.26.0",
+			MaxMinorSkew: 3,
+			RequiredAPIs: []string{},
+		},
+		Namespace: NamespaceValidationConfig{
+			Labels:         map[string]string{},
+			QuotaWarnRatio: 0.9,
+		},
+		OrphanDetection: OrphanDetectionConfig{
+			GarbageCollect: false,
+		},
+		SizeAdvisory: SizeAdvisoryConfig{
+			Sample:    0,
+			Threshold: "256Ki",
+		},
+	}
+}
+
+// AddFlags registers precheck configuration flags.
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	c.CRD.AddFlags(fs)
+	c.Runner.AddFlags(fs)
+	c.VersionLease.AddFlags(fs)
+	c.Webhook.AddFlags(fs)
+	c.ClusterGateway.AddFlags(fs)
+	c.RBAC.AddFlags(fs)
+	c.APIAvailability.AddFlags(fs)
+	c.Namespace.AddFlags(fs)
+	c.OrphanDetection.AddFlags(fs)
+	c.SizeAdvisory.AddFlags(fs)
+}
+
+// CRDValidationConfig contains configuration for the CRD validation hook.
+type CRDValidationConfig struct {
+	// CRDs adjusts the set of CRDs validated at startup on top of the core CRDs.
+	CRDs []string
+	// ConfigMap is the ConfigMap in the runtime namespace holding CRD validation overrides.
+	ConfigMap string
+	// AutoUpgrade applies the CRDs bundled in the binary when validation finds them missing or outdated.
+	AutoUpgrade bool
+	// DetectSchemaDrift compares installed CRD schemas with the schemas bundled in the binary.
+	DetectSchemaDrift bool
+	// CheckVersionCompatibility compares the served versions of validated CRDs with their storage version.
+	CheckVersionCompatibility bool
+	// CheckConversionWebhooks verifies the conversion webhooks declared by validated CRDs.
+	CheckConversionWebhooks bool
+	// DefinitionRoundTrip writes and reads back test definitions to validate the definition CRDs.
+	DefinitionRoundTrip bool
+	// VerifyFieldPruning writes objects with unknown fields to check that validated CRDs prune them.
+	VerifyFieldPruning bool
+	// MigrateStoredVersions triggers a StorageVersionMigration for CRDs still storing deprecated versions.
+	MigrateStoredVersions bool
+	// CheckValidationRules verifies the x-kubernetes-validations rules of validated CRDs.
+	CheckValidationRules bool
+	// EnforceBestPractices fails the startup when validated CRDs lack the printer columns, categories or
+	// subresources of the bundled CRDs.
+	EnforceBestPractices bool
+	// ScaleTraits are the traits requiring the scale subresource on the workload CRDs they apply to.
+	ScaleTraits []string
+	// BlockVersionSkew fails the startup when validated CRDs were installed by a release more than one minor
+	// version away from the controller.
+	BlockVersionSkew bool
+	// DetectCompressionDowngrade looks for objects stored with a compression whose feature gate is disabled.
+	// It is off by default since it lists all ApplicationRevisions and ResourceTrackers on every replica.
+	DetectCompressionDowngrade bool
+	// BlockCompressionDowngrade fails the startup on objects found by DetectCompressionDowngrade.
+	BlockCompressionDowngrade bool
+	// DryRunComponents is the number of components of the Application validated with a server-side dry-run.
+	DryRunComponents int
+	// Concurrency bounds the number of CRDs validated at once.
+	Concurrency int
+	// QPS bounds the number of CRD validations started per second.
+	QPS float32
+	// TestObjectTTL is the age after which leaked round-trip test objects are deleted at startup.
+	TestObjectTTL time.Duration
+	// TestNamespace is the namespace of the round-trip test objects. Empty means the runtime namespace.
+	TestNamespace string
+	// TestNamePrefix is the name prefix of the round-trip test objects.
+	TestNamePrefix string
+	// RoundTripsOnLeader runs the CRD round-trips writing test objects on the leader only.
+	RoundTripsOnLeader bool
+	// FingerprintConfigMap is the ConfigMap in the runtime namespace the environment fingerprint of the last
+	// successful CRD validation is cached in.
+	FingerprintConfigMap string
+}
+
+// AddFlags registers the flags of the CRD validation hook.
+func (c *CRDValidationConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&c.CRDs,
+		"precheck-crds",
+		c.CRDs,
+		"Adjust the CRDs validated before the controller starts. Each entry is '<crd-name>' to require the CRD, "+
+			"'<crd-name>=<field>;<field>' to also require schema fields such as spec.foo, or '-<crd-name>' to skip a core CRD.")
+	fs.StringVar(&c.ConfigMap,
+		"precheck-crds-configmap",
+		c.ConfigMap,
+		"Name of a ConfigMap in the runtime namespace whose 'crds' key holds a YAML list of {name, requiredFields, skip} entries "+
+			"applied on top of --precheck-crds. Empty disables the ConfigMap lookup.")
+	fs.BoolVar(&c.AutoUpgrade,
+		"precheck-auto-upgrade-crds",
+		c.AutoUpgrade,
+		"If true, core CRDs that are missing or lack required fields are server-side applied from the manifests bundled in vela-core "+
+			"(after a successful dry-run) instead of failing the startup.")
+	fs.BoolVar(&c.DetectSchemaDrift,
+		"precheck-crd-schema-drift",
+		c.DetectSchemaDrift,
+		"If true, the schema of each validated CRD is compared with the schema bundled in vela-core and startup fails "+
+			"when fields were removed or changed. Fields added by newer CRDs are only logged.")
+	fs.BoolVar(&c.CheckVersionCompatibility,
+		"precheck-crd-version-compatibility",
+		c.CheckVersionCompatibility,
+		"If true, startup fails when a served version of a validated CRD changes the type of a field of its storage "+
+			"version or lacks one of their required fields. CRDs converted by a webhook are skipped.")
+	fs.BoolVar(&c.CheckConversionWebhooks,
+		"precheck-conversion-webhooks",
+		c.CheckConversionWebhooks,
+		"If true, validated CRDs that declare a conversion webhook are checked for an existing webhook service, "+
+			"an unexpired CA bundle and a working conversion between the storage and served versions.")
+	fs.BoolVar(&c.DefinitionRoundTrip,
+		"precheck-definition-round-trip",
+		c.DefinitionRoundTrip,
+		"If true, test TraitDefinitions, PolicyDefinitions and WorkflowStepDefinitions are created, read back and deleted "+
+			"in the runtime namespace to validate their CRDs. Kinds the controller is not permitted to write are skipped.")
+	fs.BoolVar(&c.VerifyFieldPruning,
+		"precheck-field-pruning",
+		c.VerifyFieldPruning,
+		"If true, startup fails when a validated CRD sets spec.preserveUnknownFields or stores an unknown field written "+
+			"to a test object, since such CRDs keep stale fields the controller does not expect.")
+	fs.BoolVar(&c.MigrateStoredVersions,
+		"precheck-migrate-stored-versions",
+		c.MigrateStoredVersions,
+		"If true, a StorageVersionMigration (storagemigration.k8s.io/v1alpha1) is created for validated CRDs whose "+
+			"status.storedVersions still include deprecated or unserved versions. Otherwise such CRDs are only logged.")
+	fs.BoolVar(&c.CheckValidationRules,
+		"precheck-crd-validation-rules",
+		c.CheckValidationRules,
+		"If true, startup fails when a validated CRD lacks the x-kubernetes-validations (CEL) rules of the schema bundled "+
+			"in vela-core or of its requiredRules, or declares a rule that does not compile.")
+	fs.BoolVar(&c.EnforceBestPractices,
+		"precheck-crd-best-practices",
+		c.EnforceBestPractices,
+		"If true, startup fails when a validated CRD lacks the printer columns, categories or status subresource of the "+
+			"CRD bundled in vela-core. Otherwise they are logged together with a JSON patch fixing them.")
+	fs.StringSliceVar(&c.ScaleTraits,
+		"precheck-scale-traits",
+		c.ScaleTraits,
+		"Traits scaling their workload through the /scale subresource. Workload CRDs of component definitions these "+
+			"traits apply to are reported when they lack the subresource, failing the startup with "+
+			"--precheck-crd-best-practices.")
+	fs.BoolVar(&c.BlockVersionSkew,
+		"precheck-crd-version-skew-blocking",
+		c.BlockVersionSkew,
+		"If true, startup fails when the app.kubernetes.io/version label of a validated CRD is more than one minor "+
+			"version away from the controller release, e.g. for air-gapped installs applying CRDs separately. "+
+			"Otherwise the skew is only logged.")
+	fs.BoolVar(&c.DetectCompressionDowngrade,
+		"precheck-compression-downgrade",
+		c.DetectCompressionDowngrade,
+		"If true, ApplicationRevisions and ResourceTrackers stored with a compression whose feature gate is disabled "+
+			"are reported, since they cannot be read after rolling back to a release without compression support. "+
+			"It lists all ApplicationRevisions and ResourceTrackers on every replica at startup, enable it before "+
+			"rolling back or disabling a compression feature gate.")
+	fs.BoolVar(&c.BlockCompressionDowngrade,
+		"precheck-compression-downgrade-blocking",
+		c.BlockCompressionDowngrade,
+		"If true, startup fails when --precheck-compression-downgrade finds compressed objects of a disabled "+
+			"compression instead of only logging them.")
+	fs.IntVar(&c.DryRunComponents,
+		"precheck-dry-run-components",
+		c.DryRunComponents,
+		"If positive, an Application with this many components, its workflow and policies, and an ApplicationRevision "+
+			"embedding it are created with a server-side dry-run to catch size limits, CEL rejections and admission "+
+			"webhook interference. Zero disables the dry-run.")
+	fs.IntVar(&c.Concurrency,
+		"precheck-crd-concurrency",
+		c.Concurrency,
+		"The number of CRDs validated concurrently at startup.")
+	fs.Float32Var(&c.QPS,
+		"precheck-crd-qps",
+		c.QPS,
+		"The maximum number of CRD validations started per second, sparing slow API servers. Zero disables the throttling.")
+	fs.DurationVar(&c.TestObjectTTL,
+		"precheck-object-ttl",
+		c.TestObjectTTL,
+		"Age after which the labeled test objects written by the CRD round-trips are deleted at startup, in case a "+
+			"previous run died before cleaning them up.")
+	fs.StringVar(&c.TestNamespace,
+		"precheck-test-namespace",
+		c.TestNamespace,
+		"Namespace of the test objects written by the CRD round-trips, for clusters where admission policies restrict "+
+			"writes to the runtime namespace. Empty means the runtime namespace.")
+	fs.StringVar(&c.TestNamePrefix,
+		"precheck-test-name-prefix",
+		c.TestNamePrefix,
+		"Name prefix of the test objects written by the CRD round-trips. It must contain 'pre-check' or 'precheck' "+
+			"and end with '.' or '-', so that leaked test objects can be told apart from user objects.")
+	fs.BoolVar(&c.RoundTripsOnLeader,
+		"precheck-round-trips-on-leader",
+		c.RoundTripsOnLeader,
+		"If true, the CRD round-trips writing test objects run once on the leader after it is elected instead of on "+
+			"every replica before startup, while the read-only validations still run on every replica. The round-trips "+
+			"then run alongside the controllers as a best-effort check whose failures are logged as warnings. The compression "+
+			"round-trip of the ApplicationRevision CRD keeps running on every replica before the controllers start.")
+	fs.StringVar(&c.FingerprintConfigMap,
+		"precheck-fingerprint-configmap",
+		c.FingerprintConfigMap,
+		"Name of a ConfigMap in the runtime namespace caching a fingerprint of the CRD resourceVersions, enabled feature "+
+			"gates and controller version of the last successful CRD validation. The round-trips and the dry-run are "+
+			"skipped while the fingerprint is unchanged. Empty disables the cache.")
+}
+
+// HookRunnerConfig contains configuration for running the pre-start hooks and reporting their results.
+type HookRunnerConfig struct {
+	// Timeout bounds the run of each pre-start hook.
+	Timeout time.Duration
+	// Timeouts overrides HookTimeout for individual hooks, keyed by hook name.
+	Timeouts map[string]string
+	// Deadline bounds the run of all pre-start hooks.
+	Deadline time.Duration
+	// Skip lists the names of pre-start hooks that are not run.
+	Skip []string
+	// Severities overrides the severity (Block, Warn or Info) of pre-start hooks, keyed by hook name.
+	Severities map[string]string
+	// ResultsConfigMap is the ConfigMap in the runtime namespace the pre-start hook results are published to.
+	ResultsConfigMap string
+	// FailureReport is the path the pre-start hook results are written to as JSON when blocking hooks fail.
+	FailureReport string
+	// TerminationMessagePath is the termination message file the failed pre-start hooks are written to.
+	TerminationMessagePath string
+	// RevalidateHooks lists the pre-start hooks re-run periodically after startup.
+	RevalidateHooks []string
+	// RevalidateInterval is the period of the revalidation. Zero disables it.
+	RevalidateInterval time.Duration
+	// ReadinessGate keeps the controller running but NotReady while blocking pre-start hooks fail.
+	ReadinessGate bool
+	// RetryInterval is the period pre-start hooks are retried at while the readiness gate is closed.
+	RetryInterval time.Duration
+}
+
+// AddFlags registers the flags of the pre-start hook runner.
+func (c *HookRunnerConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.Timeout,
+		"prestart-hook-timeout",
+		c.Timeout,
+		"Maximum duration of each pre-start hook. 0 disables the per-hook timeout.")
+	fs.StringToStringVar(&c.Timeouts,
+		"prestart-hook-timeouts",
+		c.Timeouts,
+		"Per-hook overrides of --prestart-hook-timeout, e.g. CRDValidation=5m.")
+	fs.DurationVar(&c.Deadline,
+		"prestart-hooks-deadline",
+		c.Deadline,
+		"Maximum duration of all pre-start hooks together. Hooks that declare themselves independent run concurrently. "+
+			"0 disables the deadline.")
+	fs.StringSliceVar(&c.Skip,
+		"prestart-hook-skip",
+		c.Skip,
+		"Names of pre-start hooks that are not run, e.g. CRDValidation.")
+	fs.StringToStringVar(&c.Severities,
+		"prestart-hook-severity",
+		c.Severities,
+		"Per-hook severity overrides, e.g. CRDValidation=Warn. Block aborts the startup on failure, "+
+			"Warn and Info only log the failure.")
+	fs.StringVar(&c.ResultsConfigMap,
+		"prestart-hook-results-configmap",
+		c.ResultsConfigMap,
+		"Name of the ConfigMap in the runtime namespace the pre-start hook results (status, message and duration of each hook) "+
+			"are published to. Empty disables publishing.")
+	fs.StringVar(&c.FailureReport,
+		"prestart-hook-failure-report",
+		c.FailureReport,
+		"Path of a file the pre-start hook results, with the failed checks and remediation hints, are written to as JSON "+
+			"when blocking hooks fail, e.g. on a volume shared with an installer. Empty disables the report.")
+	fs.StringVar(&c.TerminationMessagePath,
+		"prestart-hook-termination-message-path",
+		c.TerminationMessagePath,
+		"Path of the container termination message file the failed pre-start hooks are written to as JSON, so that they "+
+			"appear in the pod status. Nothing is written if the file does not exist. Empty disables the message.")
+	fs.StringSliceVar(&c.RevalidateHooks,
+		"prestart-hook-revalidate",
+		c.RevalidateHooks,
+		"Names of pre-start hooks that are re-run periodically by the leader after startup. Regressions are reported as "+
+			"events on the results ConfigMap and by the vela_prestart_hook_healthy metric.")
+	fs.DurationVar(&c.RevalidateInterval,
+		"prestart-hook-revalidate-interval",
+		c.RevalidateInterval,
+		"Interval of the pre-start hook revalidation. 0 disables the revalidation.")
+	fs.BoolVar(&c.ReadinessGate,
+		"prestart-hook-readiness-gate",
+		c.ReadinessGate,
+		"If true, blocking pre-start hook failures do not exit the process. The readiness probe reports NotReady with "+
+			"the failure reason and the hooks are retried until they pass. Revalidation regressions also close the gate.")
+	fs.DurationVar(&c.RetryInterval,
+		"prestart-hook-retry-interval",
+		c.RetryInterval,
+		"Interval at which failed pre-start hooks are retried while the readiness gate is closed.")
+}
+
+// VersionLeaseConfig contains configuration for the version lease post-start hook.
+type VersionLeaseConfig struct {
+	// Name is the Lease in the runtime namespace the running version is announced in.
+	Name string
+}
+
+// AddFlags registers the flags of the version lease post-start hook.
+func (c *VersionLeaseConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Name,
+		"poststart-version-lease",
+		c.Name,
+		"Name of a Lease in the runtime namespace the leader writes its version and identity to once started. "+
+			"Empty disables the announcement.")
+}
+
+// WebhookValidationConfig contains configuration for the webhook validation hook.
+type WebhookValidationConfig struct {
+	// Service is the name of the vela-core webhook service whose webhook configurations are validated.
+	Service string
+	// CertMinValidity is the minimum remaining validity of the webhook certificates.
+	CertMinValidity time.Duration
+}
+
+// AddFlags registers the flags of the webhook validation hook.
+func (c *WebhookValidationConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Service,
+		"precheck-webhook-service",
+		c.Service,
+		"Name of the vela-core webhook service in the runtime namespace. When webhooks are enabled, the webhook configurations "+
+			"routed to it are checked for a resolvable service, CA bundles signing the serving certificate and sane failure policies.")
+	fs.DurationVar(&c.CertMinValidity,
+		"precheck-webhook-cert-min-validity",
+		c.CertMinValidity,
+		"Minimum remaining validity of the webhook serving certificate and CA bundles before the webhook validation hook reports them.")
+}
+
+// ClusterGatewayConfig contains configuration for the cluster-gateway preflight hook.
+type ClusterGatewayConfig struct {
+	// ProbeTimeout bounds the liveness probe of each cluster registered to the cluster-gateway.
+	ProbeTimeout time.Duration
+	// CertMinValidity is the minimum remaining validity of the cluster-gateway CA bundle.
+	CertMinValidity time.Duration
+}
+
+// AddFlags registers the flags of the cluster-gateway preflight hook.
+func (c *ClusterGatewayConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.ProbeTimeout,
+		"precheck-cluster-probe-timeout",
+		c.ProbeTimeout,
+		"Timeout of the liveness probe of each cluster registered to the cluster-gateway. When the cluster-gateway is enabled, "+
+			"the clusters which do not respond are summarized before start.")
+	fs.DurationVar(&c.CertMinValidity,
+		"precheck-cluster-gateway-cert-min-validity",
+		c.CertMinValidity,
+		"Minimum remaining validity of the CA bundle of the cluster-gateway APIService before the cluster-gateway preflight hook reports it.")
+}
+
+// RBACValidationConfig contains configuration for the RBAC permission preflight hook.
+type RBACValidationConfig struct {
+	// Enabled checks the permissions of the controller with SelfSubjectAccessReviews.
+	Enabled bool
+}
+
+// AddFlags registers the flags of the RBAC permission preflight hook.
+func (c *RBACValidationConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.Enabled,
+		"precheck-rbac",
+		c.Enabled,
+		"If true, the permissions vela-core needs on applications, definitions, resourcetrackers, leases, secrets and configmaps "+
+			"are checked with SelfSubjectAccessReviews before start and missing ones are reported.")
+}
+
+// APIAvailabilityConfig contains configuration for the Kubernetes version and API availability hook.
+type APIAvailabilityConfig struct {
+	// MinVersion is the oldest Kubernetes version the controller supports.
+	MinVersion string
+	// MaxMinorSkew is the number of minor versions the cluster may be ahead of the client libraries.
+	MaxMinorSkew int
+	// RequiredAPIs lists additional group versions the cluster must serve.
+	RequiredAPIs []string
+}
+
+// AddFlags registers the flags of the Kubernetes version and API availability hook.
+func (c *APIAvailabilityConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.MinVersion,
+		"precheck-min-kubernetes-version",
+		c.MinVersion,
+		"Oldest Kubernetes version supported by vela-core. Older clusters, unserved APIs and failed discovery requests are "+
+			"logged as warnings unless the APIAvailability severity is raised with --prestart-hook-severity. Empty disables the check.")
+	fs.IntVar(&c.MaxMinorSkew,
+		"precheck-max-kubernetes-minor-skew",
+		c.MaxMinorSkew,
+		"Number of minor versions the cluster may be ahead of the Kubernetes client libraries vela-core is built with. "+
+			"A negative value disables the check.")
+	fs.StringSliceVar(&c.RequiredAPIs,
+		"precheck-required-apis",
+		c.RequiredAPIs,
+		"Additional group versions, e.g. apps.kruise.io/v1alpha1, that must be served by the cluster. apiextensions.k8s.io/v1, "+
+			"admissionregistration.k8s.io/v1 and, with --enable-cluster-gateway, cluster.core.oam.dev/v1alpha1 are always required.")
+}
+
+// NamespaceValidationConfig contains configuration for the runtime namespace validation hook.
+type NamespaceValidationConfig struct {
+	// Labels are the labels the runtime namespace is expected to carry.
+	Labels map[string]string
+	// QuotaWarnRatio is the used/hard ratio above which nearly exhausted quotas of the runtime namespace are logged.
+	QuotaWarnRatio float64
+}
+
+// AddFlags registers the flags of the runtime namespace validation hook.
+func (c *NamespaceValidationConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringToStringVar(&c.Labels,
+		"precheck-namespace-labels",
+		c.Labels,
+		"Labels the runtime namespace is expected to carry, e.g. pod-security.kubernetes.io/enforce=baseline.")
+	fs.Float64Var(&c.QuotaWarnRatio,
+		"precheck-quota-warn-ratio",
+		c.QuotaWarnRatio,
+		"Used/hard ratio above which ResourceQuotas of the runtime namespace limiting ApplicationRevisions or ConfigMaps "+
+			"are logged as nearly exhausted. Exhausted quotas are always reported.")
+}
+
+// OrphanDetectionConfig contains configuration for the orphan detection hook.
+type OrphanDetectionConfig struct {
+	// GarbageCollect deletes orphaned ResourceTrackers and stale ApplicationRevisions found at startup.
+	GarbageCollect bool
+}
+
+// AddFlags registers the flags of the orphan detection hook.
+func (c *OrphanDetectionConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.GarbageCollect,
+		"precheck-orphan-gc",
+		c.GarbageCollect,
+		"If true, ResourceTrackers of deleted Applications and ApplicationRevisions of deleted Applications or beyond "+
+			"--application-revision-limit found at startup are deleted. Otherwise they are only reported.")
+}
+
+// SizeAdvisoryConfig contains configuration for the size advisory hook.
+type SizeAdvisoryConfig struct {
+	// Sample is the number of largest ApplicationRevisions and ResourceTrackers sampled for the size advice.
+	// Zero, the default, disables the hook since it lists all of them on every replica.
+	Sample int
+	// Threshold is the stored object size above which enabling compression is recommended.
+	Threshold string
+}
+
+// AddFlags registers the flags of the size advisory hook.
+func (c *SizeAdvisoryConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.Sample,
+		"precheck-size-advisory-sample",
+		c.Sample,
+		"Number of largest ApplicationRevisions and ResourceTrackers whose stored size is reported by the size advisory hook. "+
+			"The hook lists all ApplicationRevisions and ResourceTrackers on every replica at startup, 0 disables it.")
+	fs.StringVar(&c.Threshold,
+		"precheck-size-advisory-threshold",
+		c.Threshold,
+		"Stored object size, e.g. 256Ki, above which enabling the Zstd or Gzip compression feature gate is recommended. "+
+			"Objects of uncompressed kinds close to the etcd request limit fail the hook.")
+}
+
+// ParseTimeouts returns Timeouts with the values parsed as durations.
+func (c *HookRunnerConfig) ParseTimeouts() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(c.Timeouts))
+	for name, v := range c.Timeouts {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q for pre-start hook %s: %w", v, name, err)
+		}
+		timeouts[name] = d
+	}
+	return timeouts, nil
+}
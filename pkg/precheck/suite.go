@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package precheck holds the configuration of the pre-start hooks vela-core
+// runs before starting its controllers and assembles them, so that the same
+// suite can be replayed on demand, e.g. by the vela CLI, without depending on
+// the vela-core server.
+package precheck
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/hooks/apiavailability"
+	"github.com/oam-dev/kubevela/pkg/hooks/clustergateway"
+	"github.com/oam-dev/kubevela/pkg/hooks/crdvalidation"
+	"github.com/oam-dev/kubevela/pkg/hooks/namespacevalidation"
+	"github.com/oam-dev/kubevela/pkg/hooks/orphandetection"
+	"github.com/oam-dev/kubevela/pkg/hooks/rbacvalidation"
+	"github.com/oam-dev/kubevela/pkg/hooks/sizeadvisory"
+	"github.com/oam-dev/kubevela/pkg/hooks/webhookvalidation"
+)
+
+// DefaultAppRevisionLimit is the default application-revision-limit of vela-core.
+const DefaultAppRevisionLimit = 10
+
+// Options configure the pre-start hooks. The fields besides Precheck mirror
+// the vela-core options the hooks depend on.
+type Options struct {
+	Precheck *Config
+	// Namespace is the runtime namespace of vela-core.
+	Namespace string
+	// AppRevisionLimit is the number of ApplicationRevisions kept per Application.
+	AppRevisionLimit int
+	// UseWebhook enables the validation of the admission webhooks.
+	UseWebhook bool
+	// WebhookCertDir holds the serving certificate of the webhooks.
+	WebhookCertDir string
+	// EnableClusterGateway enables the validation of the cluster gateway.
+	EnableClusterGateway bool
+}
+
+// PreStartHooks returns the pre-start hooks of vela-core, in the order they
+// are run. It includes the LeaderOnlyHooks, split them with
+// hooks.SplitLeaderOnly to run them on the leader only.
+func PreStartHooks(cli client.Client, cfg *rest.Config, opts Options) ([]hooks.PreStartHook, error) {
+	precheck := opts.Precheck
+	crds, err := crdvalidation.ParseCRDRequirements(crdvalidation.CoreCRDRequirements(), precheck.CRD.CRDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid precheck CRD configuration: %w", err)
+	}
+	testNamespace := precheck.CRD.TestNamespace
+	if testNamespace == "" {
+		testNamespace = opts.Namespace
+	}
+	testObjects := crdvalidation.TestObjects{
+		Namespace:  testNamespace,
+		NamePrefix: precheck.CRD.TestNamePrefix,
+	}
+	if err := testObjects.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid precheck test object configuration: %w", err)
+	}
+	crdOptions := crdvalidation.Options{
+		Namespace:                  opts.Namespace,
+		CRDs:                       crds,
+		ConfigMap:                  precheck.CRD.ConfigMap,
+		AutoUpgradeCRDs:            precheck.CRD.AutoUpgrade,
+		DetectSchemaDrift:          precheck.CRD.DetectSchemaDrift,
+		CheckVersionCompatibility:  precheck.CRD.CheckVersionCompatibility,
+		CheckConversionWebhooks:    precheck.CRD.CheckConversionWebhooks,
+		DefinitionRoundTrip:        precheck.CRD.DefinitionRoundTrip,
+		VerifyFieldPruning:         precheck.CRD.VerifyFieldPruning,
+		MigrateStoredVersions:      precheck.CRD.MigrateStoredVersions,
+		CheckValidationRules:       precheck.CRD.CheckValidationRules,
+		EnforceSchemaBestPractices: precheck.CRD.EnforceBestPractices,
+		ScaleTraits:                precheck.CRD.ScaleTraits,
+		BlockVersionSkew:           precheck.CRD.BlockVersionSkew,
+		DetectCompressionDowngrade: precheck.CRD.DetectCompressionDowngrade,
+		BlockCompressionDowngrade:  precheck.CRD.BlockCompressionDowngrade,
+		DryRunComponents:           precheck.CRD.DryRunComponents,
+		Concurrency:                precheck.CRD.Concurrency,
+		QPS:                        precheck.CRD.QPS,
+		PreCheckTTL:                precheck.CRD.TestObjectTTL,
+		TestObjects:                testObjects,
+		RoundTripsOnLeader:         precheck.CRD.RoundTripsOnLeader,
+		FingerprintConfigMap:       precheck.CRD.FingerprintConfigMap,
+	}
+	preStartHooks := []hooks.PreStartHook{crdvalidation.NewHookWithOptions(cli, crdOptions)}
+	if crdOptions.RoundTripsOnLeader {
+		preStartHooks = append(preStartHooks, crdvalidation.NewRoundTripHook(cli, crdOptions))
+	}
+	if precheck.RBAC.Enabled {
+		preStartHooks = append(preStartHooks, rbacvalidation.NewHook(cli, rbacvalidation.DefaultPermissions(opts.Namespace)))
+	}
+	requiredAPIs := apiavailability.DefaultRequiredAPIs(opts.EnableClusterGateway)
+	for _, api := range precheck.APIAvailability.RequiredAPIs {
+		gv, err := schema.ParseGroupVersion(api)
+		if err != nil {
+			return nil, fmt.Errorf("invalid precheck required API %s: %w", api, err)
+		}
+		requiredAPIs = append(requiredAPIs, gv)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client for pre-start hooks: %w", err)
+	}
+	preStartHooks = append(preStartHooks, apiavailability.NewHook(dc, apiavailability.Options{
+		MinVersion:   precheck.APIAvailability.MinVersion,
+		MaxMinorSkew: precheck.APIAvailability.MaxMinorSkew,
+		RequiredAPIs: requiredAPIs,
+	}))
+	preStartHooks = append(preStartHooks, namespacevalidation.NewHook(cli, namespacevalidation.Options{
+		Namespace:      opts.Namespace,
+		Labels:         precheck.Namespace.Labels,
+		QuotaWarnRatio: precheck.Namespace.QuotaWarnRatio,
+	}))
+	preStartHooks = append(preStartHooks, orphandetection.NewHook(cli, orphandetection.Options{
+		AppRevisionLimit: opts.AppRevisionLimit,
+		GarbageCollect:   precheck.OrphanDetection.GarbageCollect,
+	}))
+	if sample := precheck.SizeAdvisory.Sample; sample > 0 {
+		threshold, err := resource.ParseQuantity(precheck.SizeAdvisory.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size advisory threshold %s: %w", precheck.SizeAdvisory.Threshold, err)
+		}
+		gates := utilfeature.DefaultMutableFeatureGate
+		preStartHooks = append(preStartHooks, sizeadvisory.NewHook(cli, sizeadvisory.Options{
+			SampleSize: sample,
+			AdviseSize: threshold.Value(),
+			WarnRatio:  0.8,
+			CompressedKinds: map[string]bool{
+				v1beta1.ApplicationRevisionKind: gates.Enabled(features.ZstdApplicationRevision) || gates.Enabled(features.GzipApplicationRevision),
+				v1beta1.ResourceTrackerKind:     gates.Enabled(features.ZstdResourceTracker) || gates.Enabled(features.GzipResourceTracker),
+			},
+		}))
+	}
+	if opts.UseWebhook {
+		preStartHooks = append(preStartHooks, webhookvalidation.NewHook(cli, webhookvalidation.Options{
+			Namespace:       opts.Namespace,
+			Service:         precheck.Webhook.Service,
+			CertDir:         opts.WebhookCertDir,
+			MinCertValidity: precheck.Webhook.CertMinValidity,
+		}))
+	}
+	if opts.EnableClusterGateway {
+		preStartHooks = append(preStartHooks, clustergateway.NewHook(cli, cfg, clustergateway.Options{
+			ProbeTimeout:    precheck.ClusterGateway.ProbeTimeout,
+			MinCertValidity: precheck.ClusterGateway.CertMinValidity,
+		}))
+	}
+	return preStartHooks, nil
+}
+
+// NewRunner returns the runner of the pre-start hooks, configured with the
+// timeouts, the skipped hooks and the severities of the precheck config.
+func NewRunner(preStartHooks []hooks.PreStartHook, precheck *Config) (*hooks.Runner, error) {
+	timeouts, err := precheck.Runner.ParseTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	severities := make(map[string]hooks.Severity, len(precheck.Runner.Severities))
+	for name, v := range precheck.Runner.Severities {
+		sev, err := hooks.ParseSeverity(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid severity of pre-start hook %s: %w", name, err)
+		}
+		severities[name] = sev
+	}
+	return &hooks.Runner{
+		Hooks:       preStartHooks,
+		HookTimeout: precheck.Runner.Timeout,
+		Timeouts:    timeouts,
+		Deadline:    precheck.Runner.Deadline,
+		Skip:        precheck.Runner.Skip,
+		Severities:  severities,
+	}, nil
+}
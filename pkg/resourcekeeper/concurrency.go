@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcekeeper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+const (
+	// DispatchScopeComponent is the scope of the limits on the components dispatched in parallel
+	DispatchScopeComponent = "component"
+	// DispatchScopeResource is the scope of the limits on the resources dispatched in parallel
+	DispatchScopeResource = "resource"
+)
+
+var (
+	// MaxAppComponentDispatchConcurrent is the max number of components dispatched in parallel per application,
+	// 0 means unlimited
+	MaxAppComponentDispatchConcurrent = 0
+	// MaxGlobalResourceDispatchConcurrent is the max number of resources dispatched in parallel across all
+	// applications, 0 means unlimited
+	MaxGlobalResourceDispatchConcurrent = 0
+
+	globalResourceLimiter     *ConcurrencyLimiter
+	globalResourceLimiterOnce sync.Once
+)
+
+// ConcurrencyLimiter bounds the number of components or resources dispatched in parallel. A nil limiter
+// is unlimited.
+type ConcurrencyLimiter struct {
+	scope string
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter allowing max dispatches in parallel, it returns nil if max is not
+// positive.
+func NewConcurrencyLimiter(scope string, max int) *ConcurrencyLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &ConcurrencyLimiter{scope: scope, slots: make(chan struct{}, max)}
+}
+
+// Acquire waits for a free slot and returns the function releasing it. It returns the error of the context
+// if the context is done before a slot is free.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	begin := time.Now()
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	metrics.DispatchWaitTimeHistogram.WithLabelValues(l.scope).Observe(time.Since(begin).Seconds())
+	metrics.DispatchInflightGauge.WithLabelValues(l.scope).Inc()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			metrics.DispatchInflightGauge.WithLabelValues(l.scope).Dec()
+			<-l.slots
+		})
+	}, nil
+}
+
+// GlobalResourceLimiter returns the limiter shared by all applications on the resources dispatched in
+// parallel, created with MaxGlobalResourceDispatchConcurrent on the first call.
+func GlobalResourceLimiter() *ConcurrencyLimiter {
+	globalResourceLimiterOnce.Do(func() {
+		globalResourceLimiter = NewConcurrencyLimiter(DispatchScopeResource, MaxGlobalResourceDispatchConcurrent)
+	})
+	return globalResourceLimiter
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcekeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	require.Nil(t, NewConcurrencyLimiter(DispatchScopeComponent, 0))
+	var unlimited *ConcurrencyLimiter
+	release, err := unlimited.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+
+	limiter := NewConcurrencyLimiter(DispatchScopeComponent, 1)
+	release, err = limiter.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release()
+	release, err = limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
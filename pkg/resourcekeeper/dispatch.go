@@ -164,6 +164,11 @@ func (h *resourceKeeper) dispatch(ctx context.Context, manifests []*unstructured
 		if manifest == nil {
 			return nil
 		}
+		release, err := GlobalResourceLimiter().Acquire(applyCtx)
+		if err != nil {
+			return err
+		}
+		defer release()
 		return h.applicator.Apply(applyCtx, manifest, ao...)
 	}, velaslices.Parallelism(MaxDispatchConcurrent))
 	return velaerrors.AggregateErrors(errs)
@@ -72,7 +72,9 @@ type gcConfig struct {
 
 	order v1alpha1.GarbageCollectOrder
 
-	appRevisionLimit int
+	appRevisionLimit        int
+	appRevisionMaxAge       time.Duration
+	appRevisionMaxTotalSize int64
 }
 
 func newGCConfig(options ...GCOption) *gcConfig {
@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -75,18 +76,15 @@ func cleanUpApplicationRevision(ctx context.Context, h *gcHandler) error {
 		needKill = len(sortedRevision)
 		appRevisionInUse = nil
 	}
-	if needKill <= 0 {
-		return nil
+	var remaining []v1beta1.ApplicationRevision
+	if needKill > 0 {
+		klog.InfoS("Going to garbage collect app revisions", "limit", h.cfg.appRevisionLimit,
+			"total", len(sortedRevision), "using", len(appRevisionInUse), "kill", needKill)
 	}
-	klog.InfoS("Going to garbage collect app revisions", "limit", h.cfg.appRevisionLimit,
-		"total", len(sortedRevision), "using", len(appRevisionInUse), "kill", needKill)
-
 	for _, rev := range sortedRevision {
-		if needKill <= 0 {
-			break
-		}
 		// don't delete app revision in use
-		if appRevisionInUse[rev.Name] {
+		if needKill <= 0 || appRevisionInUse[rev.Name] {
+			remaining = append(remaining, rev)
 			continue
 		}
 		if err := h.Client.Delete(ctx, rev.DeepCopy()); err != nil && !kerrors.IsNotFound(err) {
@@ -94,9 +92,100 @@ func cleanUpApplicationRevision(ctx context.Context, h *gcHandler) error {
 		}
 		needKill--
 	}
+	if h.app.DeletionTimestamp != nil {
+		return nil
+	}
+	return pruneApplicationRevisions(ctx, h, remaining)
+}
+
+// revisionPruningPolicy prunes the application revisions by age and by total size, in addition to the limit
+// on the number of revisions
+type revisionPruningPolicy struct {
+	maxAge       time.Duration
+	maxTotalSize int64
+}
+
+// getRevisionPruningPolicyForApp returns the pruning policy of the application, the annotations of the
+// application override the global policy
+func getRevisionPruningPolicyForApp(app *v1beta1.Application, fallback revisionPruningPolicy) revisionPruningPolicy {
+	policy := fallback
+	if v, ok := app.GetAnnotations()[oam.AnnotationAppRevisionMaxAge]; ok {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			policy.maxAge = d
+		} else {
+			klog.Warningf("ignore the invalid %s annotation %q of application %s/%s", oam.AnnotationAppRevisionMaxAge, v, app.Namespace, app.Name)
+		}
+	}
+	if v, ok := app.GetAnnotations()[oam.AnnotationAppRevisionMaxTotalSize]; ok {
+		if q, err := resource.ParseQuantity(v); err == nil && q.Sign() >= 0 {
+			policy.maxTotalSize = q.Value()
+		} else {
+			klog.Warningf("ignore the invalid %s annotation %q of application %s/%s", oam.AnnotationAppRevisionMaxTotalSize, v, app.Namespace, app.Name)
+		}
+	}
+	return policy
+}
+
+// pruneApplicationRevisions deletes the revisions older than the max age, then the oldest revisions until the
+// total size of the revisions is under the max total size. The protected revisions are never pruned.
+func pruneApplicationRevisions(ctx context.Context, h *gcHandler, sortedRevision []v1beta1.ApplicationRevision) error {
+	policy := getRevisionPruningPolicyForApp(h.app, revisionPruningPolicy{maxAge: h.cfg.appRevisionMaxAge, maxTotalSize: h.cfg.appRevisionMaxTotalSize})
+	if policy.maxAge <= 0 && policy.maxTotalSize <= 0 {
+		return nil
+	}
+	protected := gatherProtectedAppRevision(h, sortedRevision)
+	sizes := make([]int64, len(sortedRevision))
+	var totalSize int64
+	for i := range sortedRevision {
+		bs, err := json.Marshal(&sortedRevision[i])
+		if err != nil {
+			return err
+		}
+		sizes[i] = int64(len(bs))
+		totalSize += sizes[i]
+	}
+	pruned := 0
+	for i, rev := range sortedRevision {
+		if protected[rev.Name] {
+			continue
+		}
+		expired := policy.maxAge > 0 && time.Since(rev.CreationTimestamp.Time) > policy.maxAge
+		oversized := policy.maxTotalSize > 0 && totalSize > policy.maxTotalSize
+		if !expired && !oversized {
+			continue
+		}
+		if err := h.Client.Delete(ctx, rev.DeepCopy()); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		totalSize -= sizes[i]
+		pruned++
+	}
+	if pruned > 0 {
+		klog.InfoS("Pruned app revisions", "app", klog.KObj(h.app), "maxAge", policy.maxAge,
+			"maxTotalSize", policy.maxTotalSize, "pruned", pruned, "totalSize", totalSize)
+	}
 	return nil
 }
 
+// gatherProtectedAppRevision returns the revisions which must not be pruned: the revision in use, the revisions
+// recorded by the resourcetrackers of the application which are still rolled out, and the latest succeeded
+// revision with a publish version which is the target of the workflow rollback.
+func gatherProtectedAppRevision(h *gcHandler, sortedRevision []v1beta1.ApplicationRevision) map[string]bool {
+	protected := gatherUsingAppRevision(h.app)
+	for _, rt := range append([]*v1beta1.ResourceTracker{h._currentRT}, h._historyRTs...) {
+		if rt != nil && rt.GetLabels()[oam.LabelAppRevision] != "" {
+			protected[rt.GetLabels()[oam.LabelAppRevision]] = true
+		}
+	}
+	for i := len(sortedRevision) - 1; i >= 0; i-- {
+		if rev := sortedRevision[i]; rev.Status.Succeeded && oam.GetPublishVersion(&rev) != "" {
+			protected[rev.Name] = true
+			break
+		}
+	}
+	return protected
+}
+
 func cleanUpComponentRevision(ctx context.Context, h *gcHandler) error {
 	if h.cfg.disableComponentRevisionGC {
 		return nil
@@ -19,9 +19,11 @@ package resourcekeeper
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -230,6 +232,93 @@ func Test_cleanUpApplicationRevision(t *testing.T) {
 	}
 }
 
+func Test_pruneApplicationRevisions(t *testing.T) {
+	now := time.Now()
+	newRev := func(name string, age time.Duration, succeeded bool, publishVersion string) v1beta1.ApplicationRevision {
+		rev := v1beta1.ApplicationRevision{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		}}
+		rev.Status.Succeeded = succeeded
+		if publishVersion != "" {
+			rev.SetAnnotations(map[string]string{oam.AnnotationPublishVersion: publishVersion})
+		}
+		return rev
+	}
+	revisions := []v1beta1.ApplicationRevision{
+		newRev("app-v1", 96*time.Hour, true, "alpha"),
+		newRev("app-v2", 72*time.Hour, false, ""),
+		newRev("app-v3", 48*time.Hour, false, ""),
+		newRev("app-v4", 24*time.Hour, false, ""),
+		newRev("app-v5", time.Hour, false, ""),
+	}
+	testCases := map[string]struct {
+		annotations map[string]string
+		cfg         *gcConfig
+		historyRT   string
+		deleted     []string
+	}{
+		"disabled": {
+			cfg: &gcConfig{},
+		},
+		"prune by age": {
+			cfg:     &gcConfig{appRevisionMaxAge: 36 * time.Hour},
+			deleted: []string{"app-v2", "app-v3"},
+		},
+		"keep revisions referenced by resourcetrackers": {
+			cfg:       &gcConfig{appRevisionMaxAge: 36 * time.Hour},
+			historyRT: "app-v2",
+			deleted:   []string{"app-v3"},
+		},
+		"prune by total size": {
+			cfg:     &gcConfig{appRevisionMaxTotalSize: 1},
+			deleted: []string{"app-v2", "app-v3", "app-v4"},
+		},
+		"annotations override the global config": {
+			annotations: map[string]string{
+				oam.AnnotationAppRevisionMaxAge:       "60h",
+				oam.AnnotationAppRevisionMaxTotalSize: "0",
+			},
+			cfg:     &gcConfig{appRevisionMaxAge: time.Minute, appRevisionMaxTotalSize: 1},
+			deleted: []string{"app-v2"},
+		},
+		"invalid annotations are ignored": {
+			annotations: map[string]string{oam.AnnotationAppRevisionMaxAge: "invalid"},
+			cfg:         &gcConfig{appRevisionMaxAge: 60 * time.Hour},
+			deleted:     []string{"app-v2"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			h := &gcHandler{
+				resourceKeeper: &resourceKeeper{
+					Client: &test.MockClient{
+						MockDelete: func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+							deleted = append(deleted, obj.GetName())
+							return nil
+						},
+					},
+					app: &v1beta1.Application{
+						ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+						Status: apicommon.AppStatus{
+							LatestRevision: &apicommon.Revision{Name: "app-v5"},
+						},
+					},
+				},
+				cfg: tc.cfg,
+			}
+			if tc.historyRT != "" {
+				h._historyRTs = []*v1beta1.ResourceTracker{{ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{oam.LabelAppRevision: tc.historyRT},
+				}}}
+			}
+			require.NoError(t, pruneApplicationRevisions(context.Background(), h, revisions))
+			require.Equal(t, tc.deleted, deleted)
+		})
+	}
+}
+
 func Test_cleanUpWorkflowComponentRevision(t *testing.T) {
 	type args struct {
 		h *gcHandler
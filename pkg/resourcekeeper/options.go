@@ -17,6 +17,8 @@ limitations under the License.
 package resourcekeeper
 
 import (
+	"time"
+
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1alpha1"
 )
 
@@ -114,6 +116,23 @@ func (option AppRevisionLimitGCOption) ApplyToGCConfig(cfg *gcConfig) {
 	cfg.appRevisionLimit = int(option)
 }
 
+// AppRevisionMaxAgeGCOption is the maximum age of application revisions that will be maintained, 0 means no limit
+type AppRevisionMaxAgeGCOption time.Duration
+
+// ApplyToGCConfig apply change to gc config
+func (option AppRevisionMaxAgeGCOption) ApplyToGCConfig(cfg *gcConfig) {
+	cfg.appRevisionMaxAge = time.Duration(option)
+}
+
+// AppRevisionMaxTotalSizeGCOption is the maximum total size in bytes of application revisions that will be
+// maintained, 0 means no limit
+type AppRevisionMaxTotalSizeGCOption int64
+
+// ApplyToGCConfig apply change to gc config
+func (option AppRevisionMaxTotalSizeGCOption) ApplyToGCConfig(cfg *gcConfig) {
+	cfg.appRevisionMaxTotalSize = int64(option)
+}
+
 // GarbageCollectStrategyOption apply garbage collect strategy to resourcetracker recording
 type GarbageCollectStrategyOption v1alpha1.GarbageCollectStrategy
 
@@ -102,7 +102,13 @@ func (h *resourceKeeper) StateKeep(ctx context.Context) error {
 			if strategy := h.getUpdateStrategy(manifest); strategy != nil {
 				ao = append([]apply.ApplyOption{apply.WithUpdateStrategy(*strategy)}, ao...)
 			}
-			if err = h.applicator.Apply(applyCtx, manifest, ao...); err != nil {
+			release, err := GlobalResourceLimiter().Acquire(applyCtx)
+			if err != nil {
+				return err
+			}
+			err = h.applicator.Apply(applyCtx, manifest, ao...)
+			release()
+			if err != nil {
 				return errors.Wrapf(err, "failed to re-apply resource %s from resourcetracker %s", mr.ResourceKey(), rt.Name)
 			}
 		}
@@ -104,6 +104,7 @@ func createResourceTracker(ctx context.Context, cli client.Client, app *v1beta1.
 	if err := cli.Create(ctx, rt); err != nil {
 		return nil, err
 	}
+	RecordSizeMetrics(rt)
 	return rt, nil
 }
 
@@ -238,7 +239,7 @@ func RecordManifestsInResourceTracker(
 			updated = rt.AddManagedResource(manifest, metaOnly, skipGC, creator) || updated
 		}
 		if updated {
-			return cli.Update(ctx, rt)
+			return updateResourceTracker(ctx, cli, rt)
 		}
 	}
 	return nil
@@ -249,5 +250,13 @@ func DeletedManifestInResourceTracker(ctx context.Context, cli client.Client, rt
 	if updated := rt.DeleteManagedResource(manifest, remove); !updated {
 		return nil
 	}
-	return cli.Update(ctx, rt)
+	return updateResourceTracker(ctx, cli, rt)
+}
+
+func updateResourceTracker(ctx context.Context, cli client.Client, rt *v1beta1.ResourceTracker) error {
+	if err := cli.Update(ctx, rt); err != nil {
+		return err
+	}
+	RecordSizeMetrics(rt)
+	return nil
 }
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcetracker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubevela/pkg/util/compression"
+	"golang.org/x/time/rate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+)
+
+const (
+	// DefaultRecompressionQPS is the default number of objects rewritten per second by the Recompressor
+	DefaultRecompressionQPS = 5
+	// DefaultRecompressionPageSize is the default number of objects listed per page by the Recompressor
+	DefaultRecompressionPageSize = 100
+)
+
+// RecompressionProgress is the progress of the recompression of a kind
+type RecompressionProgress struct {
+	Kind      string
+	Type      compression.Type
+	Rewritten int
+	Skipped   int
+	Failed    int
+	Completed bool
+}
+
+// Recompressor rewrites the existing ResourceTrackers and ApplicationRevisions into the compression format
+// enabled by the feature gates. Objects are otherwise only compressed when the controller rewrites them, so
+// turning on the compression for a large cluster takes effect progressively. It implements the
+// controller-runtime Runnable interface and runs once after the controller started.
+type Recompressor struct {
+	// Reader lists the objects page by page, usually the API reader of the manager to bypass the cache
+	Reader client.Reader
+	// Client updates the objects
+	Client client.Client
+	// QPS bounds the number of objects rewritten per second
+	QPS float64
+	// PageSize is the number of objects listed per page
+	PageSize int64
+
+	mu       sync.Mutex
+	progress map[string]*RecompressionProgress
+}
+
+type recompressionTarget struct {
+	kind    string
+	desired compression.Type
+	newList func() client.ObjectList
+	items   func(client.ObjectList) []client.Object
+	typeOf  func(client.Object) compression.Type
+	setType func(client.Object, compression.Type)
+}
+
+// Start rewrites the objects until all of them are in the enabled compression format or ctx is done.
+// Failures are logged and reported in the progress, they never stop the controller.
+func (r *Recompressor) Start(ctx context.Context) error {
+	qps, pageSize := r.QPS, r.PageSize
+	if qps <= 0 {
+		qps = DefaultRecompressionQPS
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultRecompressionPageSize
+	}
+	limiter := rate.NewLimiter(rate.Limit(qps), 1)
+	for _, target := range recompressionTargets() {
+		if target.desired == compression.Uncompressed {
+			continue
+		}
+		klog.InfoS("Starting the background recompression", "kind", target.kind, "type", target.desired, "qps", qps)
+		if err := r.recompress(ctx, limiter, pageSize, target); err != nil {
+			klog.ErrorS(err, "Background recompression stopped", "kind", target.kind)
+			continue
+		}
+		p := r.Progress(target.kind)
+		klog.InfoS("Background recompression completed", "kind", target.kind, "rewritten", p.Rewritten, "skipped", p.Skipped, "failed", p.Failed)
+	}
+	return nil
+}
+
+// NeedLeaderElection makes the recompression run on the leader only.
+func (r *Recompressor) NeedLeaderElection() bool {
+	return true
+}
+
+// Progress returns the progress of the recompression of the kind
+func (r *Recompressor) Progress(kind string) RecompressionProgress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, found := r.progress[kind]; found {
+		return *p
+	}
+	return RecompressionProgress{Kind: kind}
+}
+
+func (r *Recompressor) recompress(ctx context.Context, limiter *rate.Limiter, pageSize int64, target recompressionTarget) error {
+	r.record(target.kind, func(p *RecompressionProgress) { *p = RecompressionProgress{Kind: target.kind, Type: target.desired} })
+	metrics.RecompressionCompletedGauge.WithLabelValues(target.kind).Set(0)
+	continueToken := ""
+	for {
+		list := target.newList()
+		if err := r.Reader.List(ctx, list, client.Limit(pageSize), client.Continue(continueToken)); err != nil {
+			return err
+		}
+		for _, obj := range target.items(list) {
+			result := r.rewrite(ctx, limiter, target, obj)
+			if result == "" {
+				return ctx.Err()
+			}
+			metrics.RecompressionObjectsCounter.WithLabelValues(target.kind, result).Inc()
+			r.record(target.kind, func(p *RecompressionProgress) {
+				switch result {
+				case "rewritten":
+					p.Rewritten++
+				case "skipped":
+					p.Skipped++
+				default:
+					p.Failed++
+				}
+			})
+		}
+		p := r.Progress(target.kind)
+		klog.InfoS("Background recompression progress", "kind", target.kind, "rewritten", p.Rewritten, "skipped", p.Skipped, "failed", p.Failed)
+		if continueToken = list.GetContinue(); continueToken == "" {
+			break
+		}
+	}
+	r.record(target.kind, func(p *RecompressionProgress) { p.Completed = true })
+	metrics.RecompressionCompletedGauge.WithLabelValues(target.kind).Set(1)
+	return nil
+}
+
+// rewrite rewrites the object and returns the result, or an empty result if ctx is done
+func (r *Recompressor) rewrite(ctx context.Context, limiter *rate.Limiter, target recompressionTarget, obj client.Object) string {
+	if target.typeOf(obj) == target.desired {
+		return "skipped"
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return ""
+	}
+	target.setType(obj, target.desired)
+	if err := r.Client.Update(ctx, obj); err != nil {
+		// the object is deleted or rewritten by the controller in the meantime
+		if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+			return "skipped"
+		}
+		klog.ErrorS(err, "Failed to recompress", "kind", target.kind, "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return "failed"
+	}
+	return "rewritten"
+}
+
+func (r *Recompressor) record(kind string, update func(p *RecompressionProgress)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.progress == nil {
+		r.progress = map[string]*RecompressionProgress{}
+	}
+	if _, found := r.progress[kind]; !found {
+		r.progress[kind] = &RecompressionProgress{Kind: kind}
+	}
+	update(r.progress[kind])
+}
+
+// enabledCompression returns the compression type enabled by the feature gates, zstd has higher priority
+// when both are enabled.
+func enabledCompression(gzip, zstd featuregate.Feature) compression.Type {
+	t := compression.Uncompressed
+	if utilfeature.DefaultMutableFeatureGate.Enabled(gzip) {
+		t = compression.Gzip
+	}
+	if utilfeature.DefaultMutableFeatureGate.Enabled(zstd) {
+		t = compression.Zstd
+	}
+	return t
+}
+
+func recompressionTargets() []recompressionTarget {
+	return []recompressionTarget{{
+		kind:    v1beta1.ResourceTrackerKind,
+		desired: enabledCompression(features.GzipResourceTracker, features.ZstdResourceTracker),
+		newList: func() client.ObjectList { return &v1beta1.ResourceTrackerList{} },
+		items: func(list client.ObjectList) []client.Object {
+			var objs []client.Object
+			for i := range list.(*v1beta1.ResourceTrackerList).Items {
+				objs = append(objs, &list.(*v1beta1.ResourceTrackerList).Items[i])
+			}
+			return objs
+		},
+		typeOf:  func(obj client.Object) compression.Type { return obj.(*v1beta1.ResourceTracker).Spec.Compression.Type },
+		setType: func(obj client.Object, t compression.Type) { obj.(*v1beta1.ResourceTracker).Spec.Compression.Type = t },
+	}, {
+		kind:    v1beta1.ApplicationRevisionKind,
+		desired: enabledCompression(features.GzipApplicationRevision, features.ZstdApplicationRevision),
+		newList: func() client.ObjectList { return &v1beta1.ApplicationRevisionList{} },
+		items: func(list client.ObjectList) []client.Object {
+			var objs []client.Object
+			for i := range list.(*v1beta1.ApplicationRevisionList).Items {
+				objs = append(objs, &list.(*v1beta1.ApplicationRevisionList).Items[i])
+			}
+			return objs
+		},
+		typeOf: func(obj client.Object) compression.Type {
+			return obj.(*v1beta1.ApplicationRevision).Spec.Compression.Type
+		},
+		setType: func(obj client.Object, t compression.Type) {
+			obj.(*v1beta1.ApplicationRevision).Spec.Compression.SetType(t)
+		},
+	}}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcetracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubevela/pkg/util/compression"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestRecompressor(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.ZstdResourceTracker, true)
+	r := require.New(t)
+	ctx := context.Background()
+	uncompressed := &v1beta1.ResourceTracker{ObjectMeta: v1.ObjectMeta{Name: "app-v1"}}
+	compressed := &v1beta1.ResourceTracker{ObjectMeta: v1.ObjectMeta{Name: "app-v2"}}
+	compressed.Spec.Compression.Type = compression.Zstd
+	appRev := &v1beta1.ApplicationRevision{ObjectMeta: v1.ObjectMeta{Name: "app-v1", Namespace: "default"}}
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(uncompressed, compressed, appRev).Build()
+
+	recompressor := &Recompressor{Reader: cli, Client: cli, QPS: 100}
+	r.True(recompressor.NeedLeaderElection())
+	r.NoError(recompressor.Start(ctx))
+
+	rt := &v1beta1.ResourceTracker{}
+	r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(uncompressed), rt))
+	r.Equal(compression.Zstd, rt.Spec.Compression.Type)
+	r.Equal(RecompressionProgress{Kind: v1beta1.ResourceTrackerKind, Type: compression.Zstd, Rewritten: 1, Skipped: 1, Completed: true},
+		recompressor.Progress(v1beta1.ResourceTrackerKind))
+
+	// the compression of ApplicationRevisions is not enabled
+	rev := &v1beta1.ApplicationRevision{}
+	r.NoError(cli.Get(ctx, client.ObjectKeyFromObject(appRev), rev))
+	r.Equal(compression.Uncompressed, rev.Spec.Compression.Type)
+	r.False(recompressor.Progress(v1beta1.ApplicationRevisionKind).Completed)
+}
+
+func TestRecompressorCanceled(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.GzipResourceTracker, true)
+	rt := &v1beta1.ResourceTracker{ObjectMeta: v1.ObjectMeta{Name: "app-v1"}}
+	cli := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(rt).Build()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recompressor := &Recompressor{Reader: cli, Client: cli}
+	require.NoError(t, recompressor.Start(ctx))
+	require.False(t, recompressor.Progress(v1beta1.ResourceTrackerKind).Completed)
+}
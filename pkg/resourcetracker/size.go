@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcetracker
+
+import (
+	"encoding/json"
+
+	"github.com/kubevela/pkg/util/compression"
+	"github.com/prometheus/client_golang/prometheus"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const (
+	// sizeFormatRaw is the format of the uncompressed size
+	sizeFormatRaw = "raw"
+	// sizeFormatStored is the format of the size stored in the cluster, compressed if the compression is enabled
+	sizeFormatStored = "stored"
+)
+
+// RecordSizeMetrics records the serialized size and the compression ratio of the written ResourceTracker or
+// ApplicationRevision if EnableCompressionMetrics is enabled.
+func RecordSizeMetrics(obj client.Object) {
+	if !utilfeature.DefaultMutableFeatureGate.Enabled(features.EnableCompressionMetrics) {
+		return
+	}
+	var kind, appName, appNamespace, trackerType string
+	var raw client.Object
+	switch o := obj.(type) {
+	case *v1beta1.ResourceTracker:
+		kind, trackerType = v1beta1.ResourceTrackerKind, string(o.Spec.Type)
+		appName, appNamespace = o.GetLabels()[oam.LabelAppName], o.GetLabels()[oam.LabelAppNamespace]
+		cpy := o.DeepCopy()
+		cpy.Spec.Compression.Type = compression.Uncompressed
+		raw = cpy
+	case *v1beta1.ApplicationRevision:
+		kind = v1beta1.ApplicationRevisionKind
+		appName, appNamespace = o.GetLabels()[oam.LabelAppName], o.GetNamespace()
+		cpy := o.DeepCopy()
+		cpy.Spec.Compression.SetType(compression.Uncompressed)
+		raw = cpy
+	default:
+		return
+	}
+	if appName == "" {
+		return
+	}
+	storedBytes, err := json.Marshal(obj)
+	if err != nil {
+		klog.ErrorS(err, "Failed to measure the stored size", "kind", kind, "name", obj.GetName())
+		return
+	}
+	rawBytes, err := json.Marshal(raw)
+	if err != nil {
+		klog.ErrorS(err, "Failed to measure the raw size", "kind", kind, "name", obj.GetName())
+		return
+	}
+	metrics.SerializedSizeGauge.WithLabelValues(kind, appName, appNamespace, trackerType, sizeFormatRaw).Set(float64(len(rawBytes)))
+	metrics.SerializedSizeGauge.WithLabelValues(kind, appName, appNamespace, trackerType, sizeFormatStored).Set(float64(len(storedBytes)))
+	metrics.CompressionRatioGauge.WithLabelValues(kind, appName, appNamespace, trackerType).Set(float64(len(rawBytes)) / float64(len(storedBytes)))
+}
+
+// DeleteSizeMetrics deletes the size metrics of the application
+func DeleteSizeMetrics(app *v1beta1.Application) {
+	labels := prometheus.Labels{"app_name": app.Name, "namespace": app.Namespace}
+	metrics.SerializedSizeGauge.DeletePartialMatch(labels)
+	metrics.CompressionRatioGauge.DeletePartialMatch(labels)
+}
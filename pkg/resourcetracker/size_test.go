@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcetracker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubevela/pkg/util/compression"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/monitor/metrics"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+func TestRecordSizeMetrics(t *testing.T) {
+	r := require.New(t)
+	app := &v1beta1.Application{ObjectMeta: v1.ObjectMeta{Name: "size-app", Namespace: "default"}}
+	rt := &v1beta1.ResourceTracker{ObjectMeta: v1.ObjectMeta{Name: "size-app-v1", Labels: map[string]string{
+		oam.LabelAppName:      app.Name,
+		oam.LabelAppNamespace: app.Namespace,
+	}}}
+	rt.Spec.Type = v1beta1.ResourceTrackerTypeVersioned
+	for i := 0; i < 50; i++ {
+		rt.Spec.ManagedResources = append(rt.Spec.ManagedResources, v1beta1.ManagedResource{
+			Data: &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d"}}`, i))},
+		})
+	}
+	rt.Spec.Compression.Type = compression.Zstd
+	raw := metrics.SerializedSizeGauge.WithLabelValues(v1beta1.ResourceTrackerKind, app.Name, app.Namespace, "versioned", sizeFormatRaw)
+	ratio := metrics.CompressionRatioGauge.WithLabelValues(v1beta1.ResourceTrackerKind, app.Name, app.Namespace, "versioned")
+
+	RecordSizeMetrics(rt)
+	r.Equal(float64(0), testutil.ToFloat64(raw))
+
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.EnableCompressionMetrics, true)
+	RecordSizeMetrics(rt)
+	r.Greater(testutil.ToFloat64(raw), float64(0))
+	r.Greater(testutil.ToFloat64(ratio), float64(1))
+	r.Equal(compression.Zstd, rt.Spec.Compression.Type)
+
+	DeleteSizeMetrics(app)
+	r.Equal(0, testutil.CollectAndCount(metrics.CompressionRatioGauge))
+}
@@ -21,22 +21,30 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	controller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/application"
 	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/componentdefinition"
+	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/definition"
 	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/policydefinition"
 	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/traitdefinition"
 	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/workflowstepdefinition"
 )
 
 // Register will be called in main and register all validation handlers
-func Register(mgr manager.Manager, args controller.Args) {
+func Register(mgr manager.Manager, args controller.Args) error {
+	verifier, err := signature.LoadVerifier(args.DefinitionSignaturePublicKeys)
+	if err != nil {
+		return err
+	}
 	application.RegisterValidatingHandler(mgr, args)
 	application.RegisterMutatingHandler(mgr)
 	componentdefinition.RegisterMutatingHandler(mgr, args)
-	componentdefinition.RegisterValidatingHandler(mgr)
-	traitdefinition.RegisterValidatingHandler(mgr, args)
-	policydefinition.RegisterValidatingHandler(mgr)
-	workflowstepdefinition.RegisterValidatingHandler(mgr)
+	componentdefinition.RegisterValidatingHandler(mgr, verifier)
+	definition.RegisterMutatingHandler(mgr)
+	traitdefinition.RegisterValidatingHandler(mgr, verifier)
+	policydefinition.RegisterValidatingHandler(mgr, verifier)
+	workflowstepdefinition.RegisterValidatingHandler(mgr, verifier)
 	server := mgr.GetWebhookServer()
 	server.Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
+	return nil
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// ValidateDefinitionRevisions rejects the component and trait types of the Application which cannot be resolved,
+// either because the definition does not exist or because the definition revision selected by the `@revision`
+// suffix does not exist or belongs to another definition. The error points to the exact field instead of
+// failing later while rendering the Application.
+func (h *ValidatingHandler) ValidateDefinitionRevisions(ctx context.Context, app *v1beta1.Application) field.ErrorList {
+	usage := collectDefinitionUsage(app)
+	autoUpdate := app.GetAnnotations()[oam.AnnotationAutoUpdate] == "true"
+	var errs field.ErrorList
+	check := func(kind string, defType common.DefinitionType, names []string, newDefs func() []client.Object, paths func(name string) []*field.Path) {
+		sort.Strings(names)
+		for _, name := range names {
+			msg, err := h.resolveDefinitionType(ctx, kind, defType, name, autoUpdate, newDefs)
+			if err != nil {
+				errs = append(errs, field.InternalError(paths(name)[0], err))
+				continue
+			}
+			if msg == "" {
+				continue
+			}
+			for _, path := range paths(name) {
+				errs = append(errs, field.Invalid(path, name, msg))
+			}
+		}
+	}
+
+	check(v1beta1.ComponentDefinitionKind, common.ComponentType, keysOf(usage.componentTypes), func() []client.Object {
+		return []client.Object{&v1beta1.ComponentDefinition{}, &v1beta1.WorkloadDefinition{}}
+	}, func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, idx := range usage.componentTypes[name] {
+			paths = append(paths, field.NewPath("spec", "components").Index(idx).Child("type"))
+		}
+		return paths
+	})
+	check(v1beta1.TraitDefinitionKind, common.TraitType, keysOf(usage.traitTypes), func() []client.Object {
+		return []client.Object{&v1beta1.TraitDefinition{}}
+	}, func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, loc := range usage.traitTypes[name] {
+			paths = append(paths, field.NewPath("spec", "components").Index(loc[0]).Child("traits").Index(loc[1]).Child("type"))
+		}
+		return paths
+	})
+	return errs
+}
+
+// resolveDefinitionType returns the reason why the type cannot be resolved, or an empty string if it can
+func (h *ValidatingHandler) resolveDefinitionType(ctx context.Context, kind string, defType common.DefinitionType, typ string, autoUpdate bool, newDefs func() []client.Object) (string, error) {
+	if !strings.Contains(typ, "@") {
+		for _, def := range newDefs() {
+			err := util.GetDefinition(ctx, h.Client, def, typ)
+			if err == nil {
+				return "", nil
+			}
+			if !errors.IsNotFound(err) {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("%s %q not found", kind, typ), nil
+	}
+
+	defName := strings.Split(typ, "@")[0]
+	defRevName, err := util.ConvertDefinitionRevName(typ)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if autoUpdate {
+		latest, err := util.GetLatestDefinitionRevisionName(ctx, h.Client, defName, defRevName, defType)
+		if err != nil {
+			return fmt.Sprintf("no revision of %s %q matches %q", kind, defName, typ), nil
+		}
+		defRevName = latest
+	}
+	defRev := &v1beta1.DefinitionRevision{}
+	if err := util.GetDefinition(ctx, h.Client, defRev, defRevName); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+		return fmt.Sprintf("revision %q of %s %q not found (DefinitionRevision %q)", strings.TrimPrefix(typ, defName+"@"), kind, defName, defRevName), nil
+	}
+	if defRev.Spec.DefinitionType != defType {
+		return fmt.Sprintf("DefinitionRevision %q is a revision of a %s definition, not of %s %q", defRevName, defRev.Spec.DefinitionType, kind, defName), nil
+	}
+	if owner := defRev.GetLabels()[util.DefinitionKindToNameLabel[defType]]; owner != "" && owner != defName {
+		return fmt.Sprintf("DefinitionRevision %q is a revision of %s %q, not of %q", defRevName, kind, owner, defName), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+func TestValidateDefinitionRevisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+
+	newDefRev := func(name, defName string, defType common.DefinitionType) *v1beta1.DefinitionRevision {
+		return &v1beta1.DefinitionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: oam.SystemDefinitionNamespace,
+				Labels:    map[string]string{util.DefinitionKindToNameLabel[defType]: defName},
+			},
+			Spec: v1beta1.DefinitionRevisionSpec{DefinitionType: defType},
+		}
+	}
+	webservice := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "webservice", Namespace: oam.SystemDefinitionNamespace}}
+	scaler := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "scaler", Namespace: oam.SystemDefinitionNamespace}}
+	handler := &ValidatingHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			webservice, scaler,
+			newDefRev("webservice-v1.2.0", "webservice", common.ComponentType),
+			newDefRev("scaler-v1", "scaler", common.TraitType),
+			newDefRev("worker-v1", "worker", common.TraitType),
+		).Build(),
+	}
+
+	testCases := map[string]struct {
+		annotations map[string]string
+		components  []common.ApplicationComponent
+		fields      []string
+	}{
+		"resolvable types": {
+			components: []common.ApplicationComponent{
+				{Name: "a", Type: "webservice", Traits: []common.ApplicationTrait{{Type: "scaler"}}},
+				{Name: "b", Type: "webservice@v1.2.0", Traits: []common.ApplicationTrait{{Type: "scaler@v1"}}},
+			},
+		},
+		"missing definitions": {
+			components: []common.ApplicationComponent{
+				{Name: "a", Type: "ledger", Traits: []common.ApplicationTrait{{Type: "audit"}}},
+			},
+			fields: []string{"spec.components[0].type", "spec.components[0].traits[0].type"},
+		},
+		"missing definition revisions": {
+			components: []common.ApplicationComponent{
+				{Name: "a", Type: "webservice@v2", Traits: []common.ApplicationTrait{{Type: "scaler@v1"}, {Type: "scaler@v3"}}},
+				{Name: "b", Type: "webservice@v2"},
+			},
+			fields: []string{"spec.components[0].type", "spec.components[1].type", "spec.components[0].traits[1].type"},
+		},
+		"mismatched definition revision": {
+			components: []common.ApplicationComponent{{Name: "a", Type: "worker@v1"}},
+			fields:     []string{"spec.components[0].type"},
+		},
+		"auto update resolves the latest matching revision": {
+			annotations: map[string]string{oam.AnnotationAutoUpdate: "true"},
+			components: []common.ApplicationComponent{
+				{Name: "a", Type: "webservice@v1"},
+				{Name: "b", Type: "webservice@v3"},
+			},
+			fields: []string{"spec.components[1].type"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			app := &v1beta1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Annotations: tc.annotations},
+				Spec:       v1beta1.ApplicationSpec{Components: tc.components},
+			}
+			ctx := util.SetNamespaceInCtx(context.Background(), app.Namespace)
+			errs := handler.ValidateDefinitionRevisions(ctx, app)
+			var fields []string
+			for _, err := range errs {
+				assert.Equal(t, field.ErrorTypeInvalid, err.Type)
+				assert.NotEmpty(t, err.Detail)
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, tc.fields, fields)
+		})
+	}
+}
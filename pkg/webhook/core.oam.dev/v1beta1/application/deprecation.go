@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// DeprecationWarnings returns an admission warning for each deprecated definition used by the Application.
+// The definitions are deprecated by the Deprecated condition set by the definition controllers. Definitions
+// which cannot be found are left to the other validations.
+func (h *ValidatingHandler) DeprecationWarnings(ctx context.Context, app *v1beta1.Application) []string {
+	usage := collectDefinitionUsage(app)
+	var warnings []string
+	check := func(kind string, names []string, newDef func() util.ConditionedObject) {
+		sort.Strings(names)
+		for _, name := range names {
+			def := newDef()
+			if err := util.GetDefinition(ctx, h.Client, def, name); err != nil {
+				if !errors.IsNotFound(err) {
+					klog.ErrorS(err, "Failed to get definition to check its deprecation", "kind", kind, "name", name)
+				}
+				continue
+			}
+			if cond := def.GetCondition(condition.TypeDeprecated); cond.Status == corev1.ConditionTrue {
+				warnings = append(warnings, fmt.Sprintf("%s %q is deprecated: %s", kind, name, cond.Message))
+			}
+		}
+	}
+	check(v1beta1.ComponentDefinitionKind, keysOf(usage.componentTypes), func() util.ConditionedObject {
+		return &v1beta1.ComponentDefinition{}
+	})
+	check(v1beta1.TraitDefinitionKind, keysOf(usage.traitTypes), func() util.ConditionedObject {
+		return &v1beta1.TraitDefinition{}
+	})
+	check(v1beta1.PolicyDefinitionKind, keysOf(usage.policyTypes), func() util.ConditionedObject {
+		return &v1beta1.PolicyDefinition{}
+	})
+	check(v1beta1.WorkflowStepDefinitionKind, keysOf(usage.workflowStepTypes), func() util.ConditionedObject {
+		return &v1beta1.WorkflowStepDefinition{}
+	})
+	return warnings
+}
+
+func keysOf[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
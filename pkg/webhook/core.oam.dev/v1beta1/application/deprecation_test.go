@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+
+	worker := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: oam.SystemDefinitionNamespace}}
+	worker.Status.SetConditions(condition.Deprecated("use webservice instead"))
+	webservice := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "webservice", Namespace: oam.SystemDefinitionNamespace}}
+	scaler := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "scaler", Namespace: "default"}}
+	scaler.Status.SetConditions(condition.Deprecated("use hpa instead"))
+	ingress := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: oam.SystemDefinitionNamespace}}
+	ingress.Status.SetConditions(condition.NotDeprecated())
+
+	handler := &ValidatingHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(worker, webservice, scaler, ingress).Build(),
+	}
+	app := &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Components: []common.ApplicationComponent{
+				{Name: "backend", Type: "worker", Traits: []common.ApplicationTrait{{Type: "scaler"}}},
+				{Name: "frontend", Type: "webservice", Traits: []common.ApplicationTrait{{Type: "ingress"}, {Type: "scaler"}}},
+				{Name: "unknown", Type: "not-installed"},
+			},
+		},
+	}
+	ctx := util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	assert.Equal(t, []string{
+		`ComponentDefinition "worker" is deprecated: use webservice instead`,
+		`TraitDefinition "scaler" is deprecated: use hpa instead`,
+	}, handler.DeprecationWarnings(ctx, app))
+
+	app.Spec.Components = app.Spec.Components[1:2]
+	app.Spec.Components[0].Traits = nil
+	assert.Empty(t, handler.DeprecationWarnings(ctx, app))
+}
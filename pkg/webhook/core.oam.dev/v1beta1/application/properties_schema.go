@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/controller/utils"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+// ValidatePropertiesSchema validates the properties of the components and traits of the Application against the
+// OpenAPI schema generated for their definition, or for the definition revision selected by the `@revision` suffix.
+// Unknown fields and type mismatches are rejected at admission instead of failing while rendering the Application.
+// Definitions without a stored schema, e.g. not reconciled yet or not defined in CUE, are not validated.
+func (h *ValidatingHandler) ValidatePropertiesSchema(ctx context.Context, app *v1beta1.Application) field.ErrorList {
+	ctx = util.SetNamespaceInCtx(ctx, app.Namespace)
+	usage := collectDefinitionUsage(app)
+	autoUpdate := app.GetAnnotations()[oam.AnnotationAutoUpdate] == "true"
+	var errs field.ErrorList
+
+	componentTypes := keysOf(usage.componentTypes)
+	sort.Strings(componentTypes)
+	for _, name := range componentTypes {
+		s, err := h.loadPropertiesSchema(ctx, common.ComponentType, name, autoUpdate)
+		for _, idx := range usage.componentTypes[name] {
+			path := field.NewPath("spec", "components").Index(idx).Child("properties")
+			if err != nil {
+				errs = append(errs, field.InternalError(path, err))
+				break
+			}
+			errs = append(errs, validateProperties(s, app.Spec.Components[idx].Properties, path)...)
+		}
+	}
+
+	traitTypes := keysOf(usage.traitTypes)
+	sort.Strings(traitTypes)
+	for _, name := range traitTypes {
+		s, err := h.loadPropertiesSchema(ctx, common.TraitType, name, autoUpdate)
+		for _, loc := range usage.traitTypes[name] {
+			path := field.NewPath("spec", "components").Index(loc[0]).Child("traits").Index(loc[1]).Child("properties")
+			if err != nil {
+				errs = append(errs, field.InternalError(path, err))
+				break
+			}
+			errs = append(errs, validateProperties(s, app.Spec.Components[loc[0]].Traits[loc[1]].Properties, path)...)
+		}
+	}
+	return errs
+}
+
+// loadPropertiesSchema returns the strict parameter schema of the component or trait type, or nil if the
+// definition has no stored schema.
+func (h *ValidatingHandler) loadPropertiesSchema(ctx context.Context, defType common.DefinitionType, typ string, autoUpdate bool) (*openapi3.Schema, error) {
+	var def client.Object
+	var cmPrefix string
+	switch defType {
+	case common.ComponentType:
+		def, cmPrefix = &v1beta1.ComponentDefinition{}, "component"
+	case common.TraitType:
+		def, cmPrefix = &v1beta1.TraitDefinition{}, "trait"
+	default:
+		return nil, fmt.Errorf("unsupported definition type %s", defType)
+	}
+
+	// the revision schemas are only stored in ConfigMaps, the schema of the definition may be in its status
+	cmName := typ
+	var status *common.DefinitionSchema
+	if strings.Contains(typ, "@") {
+		defRevName, err := util.ConvertDefinitionRevName(typ)
+		if err != nil {
+			return nil, nil
+		}
+		if autoUpdate {
+			if defRevName, err = util.GetLatestDefinitionRevisionName(ctx, h.Client, strings.Split(typ, "@")[0], defRevName, defType); err != nil {
+				return nil, nil
+			}
+		}
+		defRev := &v1beta1.DefinitionRevision{}
+		if err := util.GetDefinition(ctx, h.Client, defRev, defRevName); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if !isCUEDefinitionRevision(defRev) {
+			return nil, nil
+		}
+		def, cmName = defRev, defRevName
+	} else {
+		if err := util.GetDefinition(ctx, h.Client, def, typ); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		switch d := def.(type) {
+		case *v1beta1.ComponentDefinition:
+			if d.Spec.Schematic == nil || d.Spec.Schematic.CUE == nil {
+				return nil, nil
+			}
+			status = d.Status.Schema
+		case *v1beta1.TraitDefinition:
+			if d.Spec.Schematic == nil || d.Spec.Schematic.CUE == nil {
+				return nil, nil
+			}
+			status = d.Status.Schema
+		}
+	}
+
+	var data []byte
+	var err error
+	if status != nil && utilfeature.DefaultMutableFeatureGate.Enabled(features.StoreDefinitionSchemaInStatus) {
+		if data, err = utils.DecodeDefinitionSchema(status); err != nil {
+			return nil, fmt.Errorf("invalid schema in the status of %s: %w", typ, err)
+		}
+	} else {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: def.GetNamespace(), Name: fmt.Sprintf("%s-%s%s", cmPrefix, types.CapabilityConfigMapNamePrefix, cmName)}
+		err = h.Client.Get(ctx, key, cm)
+		switch {
+		case err == nil:
+			if data, err = utils.DecodeOpenAPISchema(cm); err != nil {
+				return nil, err
+			}
+		case !apierrors.IsNotFound(err):
+			return nil, err
+		default:
+			defRev, ok := def.(*v1beta1.DefinitionRevision)
+			if !ok {
+				klog.V(4).Infof("properties schema check: skipping %s %q, schema ConfigMap %s not found", defType, typ, key.Name)
+				return nil, nil
+			}
+			// the schema ConfigMaps of the revisions are not stored with the StoreDefinitionSchemaInStatus
+			// feature, the schema is generated from the revision instead
+			klog.V(4).Infof("properties schema check: generating the schema of %s %q, schema ConfigMap %s not found", defType, typ, key.Name)
+			if data, err = generateRevisionSchema(ctx, defRev, strings.Split(typ, "@")[0]); err != nil {
+				return nil, fmt.Errorf("cannot generate the schema of %s %q: %w", defType, typ, err)
+			}
+		}
+	}
+	s := &openapi3.Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid schema of %s %q: %w", defType, typ, err)
+	}
+	strictSchema(s)
+	return s, nil
+}
+
+func isCUEDefinitionRevision(defRev *v1beta1.DefinitionRevision) bool {
+	var schematic *common.Schematic
+	switch defRev.Spec.DefinitionType {
+	case common.ComponentType:
+		schematic = defRev.Spec.ComponentDefinition.Spec.Schematic
+	case common.TraitType:
+		schematic = defRev.Spec.TraitDefinition.Spec.Schematic
+	}
+	return schematic != nil && schematic.CUE != nil
+}
+
+// generateRevisionSchema generates the parameter schema of the CUE definition of the DefinitionRevision.
+func generateRevisionSchema(ctx context.Context, defRev *v1beta1.DefinitionRevision, name string) ([]byte, error) {
+	switch defRev.Spec.DefinitionType {
+	case common.ComponentType:
+		capability := utils.NewCapabilityComponentDef(&defRev.Spec.ComponentDefinition)
+		return capability.GetOpenAPISchema(ctx, name)
+	case common.TraitType:
+		capability := utils.NewCapabilityTraitDef(&defRev.Spec.TraitDefinition)
+		return capability.GetOpenAPISchema(ctx, name)
+	default:
+		return nil, fmt.Errorf("unsupported definition type %s", defRev.Spec.DefinitionType)
+	}
+}
+
+// isOpenSchema returns true if the object schema accepts properties it does not declare. The schemas generated
+// from CUE have additionalProperties set for the open structs, i.e. ending with `...`, and for the maps, e.g.
+// `[string]: string`.
+func isOpenSchema(s *openapi3.Schema) bool {
+	if s.AdditionalProperties.Has != nil || s.AdditionalProperties.Schema != nil {
+		return true
+	}
+	preserve, _ := s.Extensions["x-kubernetes-preserve-unknown-fields"].(bool)
+	return preserve
+}
+
+// strictSchema closes the objects of the schema which declare their properties and are not open, so that unknown
+// fields are rejected, and drops the fields with a default from the required fields, since CUE fills them in. The
+// branches of allOf are left open as each of them only declares a part of the properties.
+func strictSchema(s *openapi3.Schema) {
+	if s == nil {
+		return
+	}
+	if len(s.Properties) > 0 && !isOpenSchema(s) {
+		s.AdditionalProperties = openapi3.AdditionalProperties{Has: ptr.To(false)}
+	}
+	var required []string
+	for _, name := range s.Required {
+		if p := s.Properties[name]; p == nil || p.Value == nil || p.Value.Default == nil {
+			required = append(required, name)
+		}
+	}
+	s.Required = required
+
+	refs := []*openapi3.SchemaRef{s.Items, s.AdditionalProperties.Schema}
+	for _, p := range s.Properties {
+		refs = append(refs, p)
+	}
+	refs = append(refs, s.OneOf...)
+	refs = append(refs, s.AnyOf...)
+	for _, ref := range refs {
+		if ref != nil {
+			strictSchema(ref.Value)
+		}
+	}
+}
+
+// validateProperties validates the properties against the schema, the errors point to the invalid fields
+func validateProperties(s *openapi3.Schema, properties *runtime.RawExtension, path *field.Path) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+	var value interface{} = map[string]interface{}{}
+	if properties != nil && len(properties.Raw) > 0 {
+		if err := json.Unmarshal(properties.Raw, &value); err != nil {
+			return field.ErrorList{field.Invalid(path, string(properties.Raw), err.Error())}
+		}
+	}
+	err := s.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	for _, e := range flattenSchemaErrors(err) {
+		schemaErr, ok := e.(*openapi3.SchemaError)
+		if !ok {
+			errs = append(errs, field.Invalid(path, nil, e.Error()))
+			continue
+		}
+		fp := path
+		for _, elem := range schemaErr.JSONPointer() {
+			if i, err := strconv.Atoi(elem); err == nil {
+				fp = fp.Index(i)
+			} else {
+				fp = fp.Child(elem)
+			}
+		}
+		switch {
+		case schemaErr.SchemaField == "required":
+			errs = append(errs, field.Required(fp, schemaErr.Reason))
+		case strings.HasPrefix(schemaErr.Reason, "property ") && strings.HasSuffix(schemaErr.Reason, " is unsupported"):
+			// the path of unknown fields points to their object
+			quoted := strings.TrimSuffix(strings.TrimPrefix(schemaErr.Reason, "property "), " is unsupported")
+			if name, err := strconv.Unquote(quoted); err == nil {
+				fp = fp.Child(name)
+			}
+			errs = append(errs, field.Forbidden(fp, "unknown field, it is not declared in the parameter of the definition"))
+		default:
+			errs = append(errs, field.Invalid(fp, schemaErr.Value, schemaErr.Reason))
+		}
+	}
+	return errs
+}
+
+func flattenSchemaErrors(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi {
+		errs = append(errs, flattenSchemaErrors(e)...)
+	}
+	return errs
+}
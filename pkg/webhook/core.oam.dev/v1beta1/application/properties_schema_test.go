@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+func TestValidatePropertiesSchema(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	cueSchematic := &common.Schematic{CUE: &common.CUE{Template: "parameter: {}"}}
+	newSchemaCM := func(name, schema string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: oam.SystemDefinitionNamespace},
+			Data:       map[string]string{types.OpenapiV3JSONSchema: schema},
+		}
+	}
+	handler := &ValidatingHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1beta1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "webservice", Namespace: oam.SystemDefinitionNamespace},
+				Spec:       v1beta1.ComponentDefinitionSpec{Schematic: cueSchematic},
+			},
+			&v1beta1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: oam.SystemDefinitionNamespace},
+				Spec:       v1beta1.ComponentDefinitionSpec{Schematic: cueSchematic},
+			},
+			&v1beta1.TraitDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "scaler", Namespace: oam.SystemDefinitionNamespace},
+				Spec:       v1beta1.TraitDefinitionSpec{Schematic: cueSchematic},
+			},
+			&v1beta1.DefinitionRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "webservice-v1",
+					Namespace: oam.SystemDefinitionNamespace,
+					Labels:    map[string]string{oam.LabelComponentDefinitionName: "webservice"},
+				},
+				Spec: v1beta1.DefinitionRevisionSpec{
+					DefinitionType:      common.ComponentType,
+					ComponentDefinition: v1beta1.ComponentDefinition{Spec: v1beta1.ComponentDefinitionSpec{Schematic: cueSchematic}},
+				},
+			},
+			newSchemaCM("component-schema-webservice", `{"type":"object","required":["image","port"],"properties":{
+				"image":{"type":"string"},
+				"port":{"type":"integer","default":80},
+				"labels":{"type":"object"},
+				"env":{"type":"array","items":{"type":"object","required":["name"],"properties":{"name":{"type":"string"},"value":{"type":"string"}}}}}}`),
+			newSchemaCM("component-schema-webservice-v1", `{"type":"object","required":["image"],"properties":{"image":{"type":"string"}}}`),
+			newSchemaCM("trait-schema-scaler", `{"type":"object","required":["replicas"],"properties":{"replicas":{"type":"integer","default":1}}}`),
+			&v1beta1.TraitDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: oam.SystemDefinitionNamespace},
+				Spec:       v1beta1.TraitDefinitionSpec{Schematic: cueSchematic},
+			},
+			// generated from parameter: {app?: string, extra?: {a?: int, ...}, env?: [string]: string}
+			newSchemaCM("trait-schema-labels", `{"type":"object","properties":{
+				"app":{"type":"string"},
+				"extra":{"type":"object","properties":{"a":{"type":"integer"}},"additionalProperties":{}},
+				"env":{"type":"object","additionalProperties":{"type":"string"}}}}`),
+			// the schema ConfigMaps of the revisions are not stored with the StoreDefinitionSchemaInStatus feature
+			&v1beta1.DefinitionRevision{
+				ObjectMeta: metav1.ObjectMeta{Name: "scaler-v2", Namespace: oam.SystemDefinitionNamespace},
+				Spec: v1beta1.DefinitionRevisionSpec{
+					DefinitionType: common.TraitType,
+					TraitDefinition: v1beta1.TraitDefinition{Spec: v1beta1.TraitDefinitionSpec{Schematic: &common.Schematic{CUE: &common.CUE{
+						Template: "parameter: {\n\treplicas: *1 | int\n\textra?: {a?: int, ...}\n}\n",
+					}}}},
+				},
+			},
+		).Build(),
+	}
+
+	testCases := map[string]struct {
+		components []common.ApplicationComponent
+		errs       map[string]field.ErrorType
+	}{
+		"valid properties": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "webservice",
+				Properties: util.Object2RawExtension(map[string]interface{}{"image": "nginx", "labels": map[string]interface{}{"app": "a"}}),
+				Traits:     []common.ApplicationTrait{{Type: "scaler", Properties: util.Object2RawExtension(map[string]interface{}{"replicas": 2})}},
+			}},
+		},
+		"unknown fields": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "webservice",
+				Properties: util.Object2RawExtension(map[string]interface{}{"image": "nginx", "imge": "nginx"}),
+			}},
+			errs: map[string]field.ErrorType{"spec.components[0].properties.imge": field.ErrorTypeForbidden},
+		},
+		"type mismatches": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "webservice",
+				Properties: util.Object2RawExtension(map[string]interface{}{"image": "nginx", "port": "80"}),
+				Traits:     []common.ApplicationTrait{{Type: "scaler", Properties: util.Object2RawExtension(map[string]interface{}{"replicas": "two"})}},
+			}},
+			errs: map[string]field.ErrorType{
+				"spec.components[0].properties.port":               field.ErrorTypeInvalid,
+				"spec.components[0].traits[0].properties.replicas": field.ErrorTypeInvalid,
+			},
+		},
+		"missing required fields": {
+			components: []common.ApplicationComponent{
+				{Name: "a", Type: "webservice"},
+				{
+					Name: "b",
+					Type: "webservice",
+					Properties: util.Object2RawExtension(map[string]interface{}{
+						"image": "nginx",
+						"env":   []interface{}{map[string]interface{}{"value": "v"}},
+					}),
+				},
+			},
+			errs: map[string]field.ErrorType{
+				"spec.components[0].properties.image":       field.ErrorTypeRequired,
+				"spec.components[1].properties.env[0].name": field.ErrorTypeRequired,
+			},
+		},
+		"schema of the definition revision": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "webservice@v1",
+				Properties: util.Object2RawExtension(map[string]interface{}{"image": "nginx", "port": 80}),
+			}},
+			errs: map[string]field.ErrorType{"spec.components[0].properties.port": field.ErrorTypeForbidden},
+		},
+		"open structs and maps": {
+			components: []common.ApplicationComponent{{
+				Name: "a",
+				Type: "webservice",
+				Properties: util.Object2RawExtension(map[string]interface{}{
+					"image": "nginx",
+				}),
+				Traits: []common.ApplicationTrait{{Type: "labels", Properties: util.Object2RawExtension(map[string]interface{}{
+					"app":   "a",
+					"extra": map[string]interface{}{"a": 1, "b": "undeclared"},
+					"env":   map[string]interface{}{"HOME": "/home", "PORT": 80},
+					"other": "unknown",
+				})}},
+			}},
+			errs: map[string]field.ErrorType{
+				"spec.components[0].traits[0].properties.env.PORT": field.ErrorTypeInvalid,
+				"spec.components[0].traits[0].properties.other":    field.ErrorTypeForbidden,
+			},
+		},
+		"schema generated from the definition revision": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "webservice",
+				Properties: util.Object2RawExtension(map[string]interface{}{"image": "nginx"}),
+				Traits: []common.ApplicationTrait{{Type: "scaler@v2", Properties: util.Object2RawExtension(map[string]interface{}{
+					"extra":   map[string]interface{}{"b": "undeclared"},
+					"replica": 2,
+				})}},
+			}},
+			errs: map[string]field.ErrorType{"spec.components[0].traits[0].properties.replica": field.ErrorTypeForbidden},
+		},
+		"definitions without schema are skipped": {
+			components: []common.ApplicationComponent{{
+				Name:       "a",
+				Type:       "legacy",
+				Properties: util.Object2RawExtension(map[string]interface{}{"anything": 1}),
+				Traits:     []common.ApplicationTrait{{Type: "annotations", Properties: util.Object2RawExtension(map[string]interface{}{"a": "b"})}},
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			app := &v1beta1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       v1beta1.ApplicationSpec{Components: tc.components},
+			}
+			errs := handler.ValidatePropertiesSchema(context.Background(), app)
+			got := map[string]field.ErrorType{}
+			for _, err := range errs {
+				assert.NotEmpty(t, err.Detail)
+				got[err.Field] = err.Type
+			}
+			if len(tc.errs) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tc.errs, got)
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	controller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
+	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
 )
 
 var _ admission.Handler = &ValidatingHandler{}
@@ -96,16 +97,17 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		"workflowSteps", workflowSteps)
 
 	ctx = util.SetNamespaceInCtx(ctx, app.Namespace)
-
+	ctx, warnings := webhookutils.WithWarnings(ctx)
 	switch req.Operation {
 	case admissionv1.Create:
 		logger.WithStep("validate-create").Info("Validating Application creation - checking components, policies, and workflow configuration")
 		if allErrs := h.ValidateCreate(ctx, app, req); len(allErrs) > 0 {
 			mergedErr := mergeErrors(allErrs)
 			logger.WithStep("validate-create").WithError(mergedErr).Error(mergedErr, "Application creation validation failed - contains invalid components, policies, or workflow steps", "errorCount", len(allErrs), "applicationName", app.Name)
-			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%w (requestUID=%s)", mergedErr, req.UID))
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%w (requestUID=%s)", mergedErr, req.UID)).WithWarnings(warnings.List()...)
 		}
 		logger.WithStep("validate-create").WithSuccess(true).Info("Application creation validation completed successfully - all components, policies, and workflows are valid", "applicationName", app.Name)
+		h.SoftPolicyWarnings(ctx, app)
 
 	case admissionv1.Update:
 		logger.WithStep("validate-update").Info("Validating Application update - comparing new configuration with existing state")
@@ -121,9 +123,10 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			if allErrs := h.ValidateUpdate(ctx, app, oldApp, req); len(allErrs) > 0 {
 				mergedErr := mergeErrors(allErrs)
 				logger.WithStep("validate-update").WithError(mergedErr).Error(mergedErr, "Application update validation failed - new configuration contains invalid changes", "errorCount", len(allErrs), "applicationName", app.Name, "oldGeneration", oldApp.Generation, "newGeneration", app.Generation)
-				return admission.Errored(http.StatusBadRequest, fmt.Errorf("%w (requestUID=%s)", mergedErr, req.UID))
+				return admission.Errored(http.StatusBadRequest, fmt.Errorf("%w (requestUID=%s)", mergedErr, req.UID)).WithWarnings(warnings.List()...)
 			}
 			logger.WithStep("validate-update").WithSuccess(true).Info("Application update validation completed successfully - configuration changes are valid", "applicationName", app.Name, "generationChange", fmt.Sprintf("%d->%d", oldApp.Generation, app.Generation))
+			h.SoftPolicyWarnings(ctx, app)
 		} else {
 			logger.WithStep("skip-validation").Info("Skipping Application validation - resource is being deleted and validation is not required", "reason", "deletion-in-progress", "deletionTimestamp", app.DeletionTimestamp)
 		}
@@ -136,7 +139,7 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 	}
 
 	logger.WithStep("complete").WithSuccess(true, startTime).Info("Application admission validation completed successfully - resource will be admitted", "applicationName", req.Name, "operation", req.Operation, "namespace", req.Namespace)
-	return admission.ValidationResponse(true, "")
+	return admission.ValidationResponse(true, "").WithWarnings(warnings.List()...)
 }
 
 // RegisterValidatingHandler will register application validate handler to the webhook
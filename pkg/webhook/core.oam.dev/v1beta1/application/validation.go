@@ -88,11 +88,26 @@ func (in *appRevBypassCacheClient) Get(ctx context.Context, key client.ObjectKey
 	return in.Client.Get(ctx, key, obj)
 }
 
+// skipComponentValidation returns true if the components are not validated because the webhook runs with
+// sharding, where the definitions may not be cached by this shard.
+func skipComponentValidation() bool {
+	return sharding.EnableSharding && !utilfeature.DefaultMutableFeatureGate.Enabled(features.ValidateComponentWhenSharding)
+}
+
 // ValidateComponents validates the Application components
 func (h *ValidatingHandler) ValidateComponents(ctx context.Context, app *v1beta1.Application) field.ErrorList {
-	if sharding.EnableSharding && !utilfeature.DefaultMutableFeatureGate.Enabled(features.ValidateComponentWhenSharding) {
+	if skipComponentValidation() {
 		return nil
 	}
+	// reject the unresolvable definitions with precise errors before generating the app file
+	if errs := h.ValidateDefinitionRevisions(ctx, app); len(errs) > 0 {
+		return errs
+	}
+	if utilfeature.DefaultMutableFeatureGate.Enabled(features.ValidatePropertiesSchema) {
+		if errs := h.ValidatePropertiesSchema(ctx, app); len(errs) > 0 {
+			return errs
+		}
+	}
 	var componentErrs field.ErrorList
 	// try to generate an app file
 	cli := &appRevBypassCacheClient{Client: h.Client}
@@ -458,6 +473,9 @@ func (h *ValidatingHandler) ValidateCreate(ctx context.Context, app *v1beta1.App
 
 	errs = append(errs, h.ValidateAnnotations(ctx, app)...)
 	errs = append(errs, h.ValidateDefinitionPermissions(ctx, app, req)...)
+	if utilfeature.DefaultMutableFeatureGate.Enabled(features.DefinitionVisibility) && !skipComponentValidation() {
+		errs = append(errs, h.ValidateDefinitionVisibility(ctx, app)...)
+	}
 	errs = append(errs, h.ValidateWorkflow(ctx, app)...)
 	errs = append(errs, h.ValidateComponents(ctx, app)...)
 	return errs
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+)
+
+// ValidateDefinitionVisibility rejects the definitions used by the Application which are not visible to its
+// namespace, as restricted by the visible-namespaces annotation of the definitions. Definitions which cannot
+// be found are accepted, so that an Application can be applied before its definitions.
+func (h *ValidatingHandler) ValidateDefinitionVisibility(ctx context.Context, app *v1beta1.Application) field.ErrorList {
+	namespace := &corev1.Namespace{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: app.Namespace}, namespace); err != nil {
+		if !errors.IsNotFound(err) {
+			return field.ErrorList{field.InternalError(field.NewPath("metadata", "namespace"), err)}
+		}
+		namespace.Name = app.Namespace
+	}
+
+	usage := collectDefinitionUsage(app)
+	var errs field.ErrorList
+	check := func(kind string, names []string, paths func(name string) []*field.Path) {
+		sort.Strings(names)
+		for _, name := range names {
+			reason, err := coredef.CheckDefinitionVisibility(ctx, h.Client, kind, name, namespace)
+			if err != nil {
+				errs = append(errs, field.InternalError(paths(name)[0], err))
+				continue
+			}
+			if reason == "" {
+				continue
+			}
+			for _, path := range paths(name) {
+				errs = append(errs, field.Forbidden(path, reason))
+			}
+		}
+	}
+
+	check(v1beta1.ComponentDefinitionKind, keysOf(usage.componentTypes), func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, idx := range usage.componentTypes[name] {
+			paths = append(paths, field.NewPath("spec", "components").Index(idx).Child("type"))
+		}
+		return paths
+	})
+	check(v1beta1.TraitDefinitionKind, keysOf(usage.traitTypes), func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, loc := range usage.traitTypes[name] {
+			paths = append(paths, field.NewPath("spec", "components").Index(loc[0]).Child("traits").Index(loc[1]).Child("type"))
+		}
+		return paths
+	})
+	check(v1beta1.PolicyDefinitionKind, keysOf(usage.policyTypes), func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, idx := range usage.policyTypes[name] {
+			paths = append(paths, field.NewPath("spec", "policies").Index(idx).Child("type"))
+		}
+		return paths
+	})
+	check(v1beta1.WorkflowStepDefinitionKind, keysOf(usage.workflowStepTypes), func(name string) []*field.Path {
+		var paths []*field.Path
+		for _, loc := range usage.workflowStepTypes[name] {
+			paths = append(paths, getWorkflowStepFieldPath(loc))
+		}
+		return paths
+	})
+	return errs
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wfTypesv1alpha1 "github.com/kubevela/pkg/apis/oam/v1alpha1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+)
+
+func TestValidateDefinitionVisibility(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	payments := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}}
+	search := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search", Labels: map[string]string{"team": "search"}}}
+	webservice := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "webservice", Namespace: oam.SystemDefinitionNamespace}}
+	ledger := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "ledger",
+		Namespace:   oam.SystemDefinitionNamespace,
+		Annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=payments"},
+	}}
+	audit := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "audit",
+		Namespace:   oam.SystemDefinitionNamespace,
+		Annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team in (payments"},
+	}}
+	scaler := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "scaler", Namespace: "search"}}
+
+	handler := &ValidatingHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(payments, search, webservice, ledger, audit, scaler).Build(),
+	}
+	newApp := func(namespace string) *v1beta1.Application {
+		return &v1beta1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace},
+			Spec: v1beta1.ApplicationSpec{
+				Components: []common.ApplicationComponent{
+					{Name: "frontend", Type: "webservice", Traits: []common.ApplicationTrait{{Type: "scaler"}}},
+					{Name: "backend", Type: "ledger"},
+				},
+			},
+		}
+	}
+
+	app := newApp("payments")
+	ctx := util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	assert.Empty(t, handler.ValidateDefinitionVisibility(ctx, app))
+
+	app = newApp("search")
+	ctx = util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	errs := handler.ValidateDefinitionVisibility(ctx, app)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+	assert.Equal(t, "spec.components[1].type", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, `ComponentDefinition "ledger" is not visible to namespace search`)
+
+	app = newApp("payments")
+	app.Spec.Components[1].Traits = []common.ApplicationTrait{{Type: "audit"}}
+	ctx = util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	errs = handler.ValidateDefinitionVisibility(ctx, app)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.components[1].traits[0].type", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "invalid definition.oam.dev/visible-namespaces annotation")
+
+	app = newApp("search")
+	app.Spec.Components[1].Type = "ledger@v1"
+	ctx = util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	errs = handler.ValidateDefinitionVisibility(ctx, app)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.components[1].type", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, `ComponentDefinition "ledger@v1" is not visible to namespace search`)
+
+	app = newApp("payments")
+	app.Spec.Components[1].Type = "missing"
+	app.Spec.Workflow = &v1beta1.Workflow{Steps: []wfTypesv1alpha1.WorkflowStep{
+		{WorkflowStepBase: wfTypesv1alpha1.WorkflowStepBase{Name: "wait", Type: "suspend"}},
+	}}
+	ctx = util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	assert.Empty(t, handler.ValidateDefinitionVisibility(ctx, app))
+}
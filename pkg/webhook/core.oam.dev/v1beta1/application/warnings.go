@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
+)
+
+// PropertiesSizeWarningThreshold is the size in bytes of the properties of a component, trait, policy or
+// workflow step above which an admission warning is returned. The properties are rendered and stored in the
+// ApplicationRevision and the ResourceTrackers, so oversized properties slow down the reconciliation.
+var PropertiesSizeWarningThreshold = 64 * 1024
+
+// SoftPolicyWarnings adds the admission warnings of the Application to the warnings collector of the context.
+// Warnings never reject the Application.
+func (h *ValidatingHandler) SoftPolicyWarnings(ctx context.Context, app *v1beta1.Application) {
+	for _, warning := range h.DeprecationWarnings(ctx, app) {
+		webhookutils.AddWarning(ctx, nil, "%s", warning)
+	}
+	checkPropertiesSize(ctx, app)
+}
+
+// checkPropertiesSize warns about the properties larger than PropertiesSizeWarningThreshold
+func checkPropertiesSize(ctx context.Context, app *v1beta1.Application) {
+	check := func(path *field.Path, props *runtime.RawExtension) {
+		if PropertiesSizeWarningThreshold <= 0 || props == nil || len(props.Raw) <= PropertiesSizeWarningThreshold {
+			return
+		}
+		webhookutils.AddWarning(ctx, path, "properties of %d bytes exceed the recommended size of %d bytes, "+
+			"consider moving the large content into a ConfigMap or Secret", len(props.Raw), PropertiesSizeWarningThreshold)
+	}
+	for i, comp := range app.Spec.Components {
+		check(field.NewPath("spec", "components").Index(i).Child("properties"), comp.Properties)
+		for j, trait := range comp.Traits {
+			check(field.NewPath("spec", "components").Index(i).Child("traits").Index(j).Child("properties"), trait.Properties)
+		}
+	}
+	for i, policy := range app.Spec.Policies {
+		check(field.NewPath("spec", "policies").Index(i).Child("properties"), policy.Properties)
+	}
+	if app.Spec.Workflow != nil {
+		for i, step := range app.Spec.Workflow.Steps {
+			stepPath := field.NewPath("spec", "workflow", "steps").Index(i)
+			check(stepPath.Child("properties"), step.Properties)
+			for j, sub := range step.SubSteps {
+				check(stepPath.Child("subSteps").Index(j).Child("properties"), sub.Properties)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/oam/util"
+	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
+)
+
+func TestSoftPolicyWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	worker := &v1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: oam.SystemDefinitionNamespace}}
+	worker.Status.SetConditions(condition.Deprecated("use webservice instead"))
+	handler := &ValidatingHandler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(worker).Build()}
+
+	large := &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"content":%q}`, strings.Repeat("x", PropertiesSizeWarningThreshold)))}
+	app := &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: v1beta1.ApplicationSpec{
+			Components: []common.ApplicationComponent{
+				{Name: "a", Type: "worker", Properties: &runtime.RawExtension{Raw: []byte(`{"image":"nginx"}`)}},
+				{Name: "b", Type: "worker", Traits: []common.ApplicationTrait{{Type: "scaler", Properties: large}}},
+			},
+		},
+	}
+	ctx := util.SetNamespaceInCtx(context.Background(), app.Namespace)
+	ctx, warnings := webhookutils.WithWarnings(ctx)
+	handler.SoftPolicyWarnings(ctx, app)
+	list := warnings.List()
+	assert.Len(t, list, 2)
+	assert.Equal(t, `ComponentDefinition "worker" is deprecated: use webservice instead`, list[0])
+	assert.True(t, strings.HasPrefix(list[1], "spec.components[1].traits[0].properties: properties of"), list[1])
+}
@@ -31,6 +31,8 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
@@ -44,6 +46,8 @@ type ValidatingHandler struct {
 	// Decoder decodes object
 	Decoder admission.Decoder
 	Client  client.Client
+	// Verifier verifies the signature and the checksum of the definition
+	Verifier *signature.Verifier
 }
 
 var _ admission.Handler = &ValidatingHandler{}
@@ -67,6 +71,20 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
 	}
 
+	if req.Operation == admissionv1.Delete {
+		obj := &v1beta1.ComponentDefinition{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, obj); err != nil {
+			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode the deleted ComponentDefinition from admission request - malformed request")
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if err := coredef.ValidateDefinitionDeletion(ctx, h.Client, obj); err != nil {
+			logger.WithStep("validate-deletion").WithError(err).Error(err, "ComponentDefinition is still referenced by applications - rejecting deletion", "definitionName", obj.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		logger.WithStep("complete").WithSuccess(true, startTime).Info("ComponentDefinition deletion admitted - no application references it or deletion is forced", "definitionName", obj.Name)
+		return admission.ValidationResponse(true, "")
+	}
+
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
 		if err := h.Decoder.Decode(req, obj); err != nil {
 			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode admission request payload into ComponentDefinition object - malformed request")
@@ -83,6 +101,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			"workloadType", obj.Spec.Workload.Type,
 			"hasSchematic", obj.Spec.Schematic != nil)
 
+		if err := h.Verifier.Verify(obj); err != nil {
+			logger.WithStep("verify-signature").WithError(err).Error(err, "ComponentDefinition is unsigned or does not match its signature or checksum - rejecting request")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		// Validate workload
 		if err := ValidateWorkload(h.Client.RESTMapper(), obj); err != nil {
 			logger.WithStep("validate-workload").WithError(err).Error(err, "ComponentDefinition workload configuration is invalid - type and definition must be consistent")
@@ -106,6 +129,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			logger.WithStep("validate-cue").WithSuccess(true).Info("CUE template validation completed successfully - template is syntactically correct and all output resources exist")
 		}
 
+		if err := webhookutils.ValidateDefinitionVisibility(obj); err != nil {
+			logger.WithStep("validate-visibility").WithError(err).Error(err, "ComponentDefinition has an invalid visible-namespaces annotation")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		// Validate semantic version
 		if obj.Spec.Version != "" {
 			if err := webhookutils.ValidateSemanticVersion(obj.Spec.Version); err != nil {
@@ -141,11 +169,12 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 }
 
 // RegisterValidatingHandler will register ComponentDefinition validation to webhook
-func RegisterValidatingHandler(mgr manager.Manager) {
+func RegisterValidatingHandler(mgr manager.Manager, verifier *signature.Verifier) {
 	server := mgr.GetWebhookServer()
 	server.Register("/validating-core-oam-dev-v1beta1-componentdefinitions", &webhook.Admission{Handler: &ValidatingHandler{
-		Client:  mgr.GetClient(),
-		Decoder: admission.NewDecoder(mgr.GetScheme()),
+		Client:   mgr.GetClient(),
+		Decoder:  admission.NewDecoder(mgr.GetScheme()),
+		Verifier: verifier,
 	}})
 }
 
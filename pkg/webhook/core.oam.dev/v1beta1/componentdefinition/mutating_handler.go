@@ -34,6 +34,7 @@ import (
 	"github.com/oam-dev/kubevela/apis/types"
 	controller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
 	"github.com/oam-dev/kubevela/pkg/oam/util"
+	"github.com/oam-dev/kubevela/pkg/webhook/core.oam.dev/v1beta1/definition"
 )
 
 // MutatingHandler handles ComponentDefinition
@@ -55,6 +56,7 @@ func (h *MutatingHandler) Handle(_ context.Context, req admission.Request) admis
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
+	definition.SetRollbackRequester(req, obj)
 	// mutate the object
 	if err := h.Mutate(obj); err != nil {
 		klog.ErrorS(err, "failed to mutate the componentDefinition", "name", obj.Name)
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definition
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// MutatingHandler records the requester of the rollbacks of TraitDefinitions, PolicyDefinitions and
+// WorkflowStepDefinitions. ComponentDefinitions are handled by their own mutating handler.
+type MutatingHandler struct{}
+
+var _ admission.Handler = &MutatingHandler{}
+
+// Handle handles admission requests.
+func (h *MutatingHandler) Handle(_ context.Context, req admission.Request) admission.Response {
+	obj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !SetRollbackRequester(req, obj) {
+		return admission.Allowed("")
+	}
+	return admission.Patched("", annotationsPatch(obj.GetAnnotations()))
+}
+
+// SetRollbackRequester sets the rollback-by annotation of the definition to the user of the request
+// whenever the definition carries a rollback request, replacing any value set by the client or kept
+// from an earlier request the webhook did not see. The annotation is removed when no rollback is
+// requested. It returns true if the annotations of the definition are changed.
+func SetRollbackRequester(req admission.Request, def metav1.Object) bool {
+	annotations := def.GetAnnotations()
+	requester, found := annotations[oam.AnnotationDefinitionRollbackBy]
+	target, rollback := annotations[oam.AnnotationDefinitionRollbackToRevision]
+	if !rollback {
+		if !found {
+			return false
+		}
+		delete(annotations, oam.AnnotationDefinitionRollbackBy)
+		def.SetAnnotations(annotations)
+		return true
+	}
+
+	user := req.UserInfo.Username
+	if found && requester == user {
+		return false
+	}
+	if user == "" {
+		delete(annotations, oam.AnnotationDefinitionRollbackBy)
+	} else {
+		annotations[oam.AnnotationDefinitionRollbackBy] = user
+	}
+	def.SetAnnotations(annotations)
+	klog.InfoS("Recording the requester of the definition rollback", "definition", klog.KObj(def), "revision", target, "user", user)
+	return true
+}
+
+// annotationsPatch returns the JSON patch replacing the annotations of the object.
+func annotationsPatch(annotations map[string]string) jsonpatch.JsonPatchOperation {
+	return jsonpatch.NewOperation("add", "/metadata/annotations", annotations)
+}
+
+// RegisterMutatingHandler will register the definition mutation handler to the webhook
+func RegisterMutatingHandler(mgr manager.Manager) {
+	server := mgr.GetWebhookServer()
+	server.Register("/mutating-core-oam-dev-v1beta1-definitions", &webhook.Admission{Handler: &MutatingHandler{}})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package definition
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+func TestMutatingHandler(t *testing.T) {
+	request := func(obj, old string) admission.Request {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authv1.UserInfo{Username: "alice"},
+			Object:   runtime.RawExtension{Raw: []byte(obj)},
+		}}
+		if old != "" {
+			req.OldObject = runtime.RawExtension{Raw: []byte(old)}
+		}
+		return req
+	}
+	trait := func(annotations string) string {
+		return fmt.Sprintf(`{"apiVersion":"core.oam.dev/v1beta1","kind":"TraitDefinition","metadata":{"name":"scaler","annotations":{%s}}}`, annotations)
+	}
+	rollbackTo := func(rev string) string {
+		return fmt.Sprintf(`%q:%q`, oam.AnnotationDefinitionRollbackToRevision, rev)
+	}
+	rollbackBy := func(user string) string {
+		return fmt.Sprintf(`%q:%q`, oam.AnnotationDefinitionRollbackBy, user)
+	}
+	h := &MutatingHandler{}
+
+	testCases := map[string]struct {
+		obj         string
+		old         string
+		annotations map[string]string
+	}{
+		"no rollback": {
+			obj: trait(`"a":"b"`),
+		},
+		"rollback requested": {
+			obj:         trait(rollbackTo("1")),
+			annotations: map[string]string{oam.AnnotationDefinitionRollbackToRevision: "1", oam.AnnotationDefinitionRollbackBy: "alice"},
+		},
+		"forged requester": {
+			obj:         trait(rollbackTo("1") + "," + rollbackBy("bob")),
+			annotations: map[string]string{oam.AnnotationDefinitionRollbackToRevision: "1", oam.AnnotationDefinitionRollbackBy: "alice"},
+		},
+		"forged requester of a pending rollback": {
+			obj:         trait(rollbackTo("1") + "," + rollbackBy("bob")),
+			old:         trait(rollbackTo("1") + "," + rollbackBy("bob")),
+			annotations: map[string]string{oam.AnnotationDefinitionRollbackToRevision: "1", oam.AnnotationDefinitionRollbackBy: "alice"},
+		},
+		"requester of the request kept": {
+			obj: trait(rollbackTo("1") + "," + rollbackBy("alice")),
+			old: trait(rollbackTo("1") + "," + rollbackBy("alice")),
+		},
+		"requester replaced for a new rollback target": {
+			obj:         trait(rollbackTo("2") + "," + rollbackBy("bob")),
+			old:         trait(rollbackTo("1") + "," + rollbackBy("bob")),
+			annotations: map[string]string{oam.AnnotationDefinitionRollbackToRevision: "2", oam.AnnotationDefinitionRollbackBy: "alice"},
+		},
+		"requester without rollback": {
+			obj:         trait(rollbackBy("bob")),
+			annotations: map[string]string{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := h.Handle(context.Background(), request(tc.obj, tc.old))
+			require.True(t, resp.Allowed)
+			if tc.annotations == nil {
+				require.Empty(t, resp.Patches)
+				return
+			}
+			require.Len(t, resp.Patches, 1)
+			require.Equal(t, "/metadata/annotations", resp.Patches[0].Path)
+			require.Equal(t, tc.annotations, resp.Patches[0].Value)
+		})
+	}
+}
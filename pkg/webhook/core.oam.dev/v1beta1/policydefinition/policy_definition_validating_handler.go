@@ -30,6 +30,8 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	applicationcontroller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/application"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
@@ -42,6 +44,8 @@ type ValidatingHandler struct {
 	// Decoder decodes object
 	Decoder admission.Decoder
 	Client  client.Client
+	// Verifier verifies the signature and the checksum of the definition
+	Verifier *signature.Verifier
 }
 
 var _ admission.Handler = &ValidatingHandler{}
@@ -64,6 +68,20 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
 	}
 
+	if req.Operation == admissionv1.Delete {
+		obj := &v1beta1.PolicyDefinition{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, obj); err != nil {
+			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode the deleted PolicyDefinition from admission request - malformed request")
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if err := coredef.ValidateDefinitionDeletion(ctx, h.Client, obj); err != nil {
+			logger.WithStep("validate-deletion").WithError(err).Error(err, "PolicyDefinition is still referenced by applications - rejecting deletion", "definitionName", obj.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		logger.WithStep("complete").WithSuccess(true, startTime).Info("PolicyDefinition deletion admitted - no application references it or deletion is forced", "definitionName", obj.Name)
+		return admission.ValidationResponse(true, "")
+	}
+
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
 		if err := h.Decoder.Decode(req, obj); err != nil {
 			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode admission request payload into PolicyDefinition object - malformed request")
@@ -78,6 +96,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			"hasSchematic", obj.Spec.Schematic != nil,
 			"version", obj.Spec.Version)
 
+		if err := h.Verifier.Verify(obj); err != nil {
+			logger.WithStep("verify-signature").WithError(err).Error(err, "PolicyDefinition is unsigned or does not match its signature or checksum - rejecting request")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		if obj.Spec.Schematic != nil && obj.Spec.Schematic.CUE != nil {
 			logger.WithStep("validate-cue").Info("Validating CUE template syntax and semantics for PolicyDefinition schematic")
 			if err := webhookutils.ValidateCueTemplate(obj.Spec.Schematic.CUE.Template); err != nil {
@@ -91,6 +114,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			logger.WithStep("validate-cue").WithSuccess(true).Info("CUE template validation completed successfully - template is syntactically correct and all output resources exist")
 		}
 
+		if err := webhookutils.ValidateDefinitionVisibility(obj); err != nil {
+			logger.WithStep("validate-visibility").WithError(err).Error(err, "PolicyDefinition has an invalid visible-namespaces annotation")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		if obj.Spec.Version != "" {
 			if err := webhookutils.ValidateSemanticVersion(obj.Spec.Version); err != nil {
 				logger.WithStep("validate-version").WithError(err).Error(err, "PolicyDefinition version does not follow semantic versioning format (x.y.z)", "version", obj.Spec.Version, "expectedFormat", "x.y.z")
@@ -132,10 +160,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 }
 
 // RegisterValidatingHandler will register ComponentDefinition validation to webhook
-func RegisterValidatingHandler(mgr manager.Manager) {
+func RegisterValidatingHandler(mgr manager.Manager, verifier *signature.Verifier) {
 	server := mgr.GetWebhookServer()
 	server.Register("/validating-core-oam-dev-v1beta1-policydefinitions", &webhook.Admission{Handler: &ValidatingHandler{
-		Client:  mgr.GetClient(),
-		Decoder: admission.NewDecoder(mgr.GetScheme()),
+		Client:   mgr.GetClient(),
+		Decoder:  admission.NewDecoder(mgr.GetScheme()),
+		Verifier: verifier,
 	}})
 }
@@ -31,7 +31,8 @@ import (
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/appfile"
-	controller "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
@@ -53,6 +54,8 @@ type ValidatingHandler struct {
 	Decoder admission.Decoder
 	// Validators validate objects
 	Validators []TraitDefValidator
+	// Verifier verifies the signature and the checksum of the definition
+	Verifier *signature.Verifier
 }
 
 // TraitDefValidator validate trait definition
@@ -88,6 +91,20 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
 	}
 
+	if req.Operation == admissionv1.Delete {
+		obj := &v1beta1.TraitDefinition{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, obj); err != nil {
+			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode the deleted TraitDefinition from admission request - malformed request")
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if err := coredef.ValidateDefinitionDeletion(ctx, h.Client, obj); err != nil {
+			logger.WithStep("validate-deletion").WithError(err).Error(err, "TraitDefinition is still referenced by applications - rejecting deletion", "definitionName", obj.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		logger.WithStep("complete").WithSuccess(true, startTime).Info("TraitDefinition deletion admitted - no application references it or deletion is forced", "definitionName", obj.Name)
+		return admission.ValidationResponse(true, "")
+	}
+
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
 		if err := h.Decoder.Decode(req, obj); err != nil {
 			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode admission request payload into TraitDefinition object - malformed request")
@@ -102,6 +119,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			"hasReference", len(obj.Spec.Reference.Name) > 0,
 			"hasSchematic", obj.Spec.Schematic != nil)
 
+		if err := h.Verifier.Verify(obj); err != nil {
+			logger.WithStep("verify-signature").WithError(err).Error(err, "TraitDefinition is unsigned or does not match its signature or checksum - rejecting request")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		for i, validator := range h.Validators {
 			if err := validator.Validate(ctx, *obj); err != nil {
 				logger.WithStep(fmt.Sprintf("validator-%d", i)).WithError(err).Error(err, "TraitDefinition custom validator failed - definition does not meet validation requirements", "validatorIndex", i)
@@ -123,6 +145,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 			logger.WithStep("validate-cue").WithSuccess(true).Info("CUE template validation completed successfully - template is syntactically correct and all output resources exist")
 		}
 
+		if err := webhookutils.ValidateDefinitionVisibility(obj); err != nil {
+			logger.WithStep("validate-visibility").WithError(err).Error(err, "TraitDefinition has an invalid visible-namespaces annotation")
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+
 		if obj.Spec.Version != "" {
 			if err := webhookutils.ValidateSemanticVersion(obj.Spec.Version); err != nil {
 				logger.WithStep("validate-version").WithError(err).Error(err, "TraitDefinition version does not follow semantic versioning format (x.y.z)", "version", obj.Spec.Version, "expectedFormat", "x.y.z")
@@ -152,11 +179,12 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 }
 
 // RegisterValidatingHandler will register TraitDefinition validation to webhook
-func RegisterValidatingHandler(mgr manager.Manager, _ controller.Args) {
+func RegisterValidatingHandler(mgr manager.Manager, verifier *signature.Verifier) {
 	server := mgr.GetWebhookServer()
 	server.Register("/validating-core-oam-dev-v1beta1-traitdefinitions", &webhook.Admission{Handler: &ValidatingHandler{
-		Client:  mgr.GetClient(),
-		Decoder: admission.NewDecoder(mgr.GetScheme()),
+		Client:   mgr.GetClient(),
+		Decoder:  admission.NewDecoder(mgr.GetScheme()),
+		Verifier: verifier,
 		Validators: []TraitDefValidator{
 			TraitDefValidatorFn(ValidateDefinitionReference),
 			// add more validators here
@@ -33,11 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	velacommon "github.com/oam-dev/kubevela/pkg/utils/common"
 )
 
 var handler ValidatingHandler
@@ -260,6 +263,38 @@ var _ = Describe("Test TraitDefinition validating handler", func() {
 			Expect(string(resp.Result.Message)).Should(ContainSubstring("Not a valid version"))
 		})
 
+		It("Test TraitDefinition sharing its namespace with other namespaces", func() {
+			shared := v1beta1.TraitDefinition{}
+			shared.SetGroupVersionKind(v1beta1.TraitDefinitionGroupVersionKind)
+			shared.SetName("shared")
+			shared.SetNamespace("default")
+			shared.SetAnnotations(map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=a"})
+			shared.Spec = v1beta1.TraitDefinitionSpec{
+				Schematic: &common.Schematic{
+					CUE: &common.CUE{
+						Template: validCueTemplate,
+					},
+				},
+			}
+			sharedRaw, _ := json.Marshal(shared)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource:  reqResource,
+					Object:    runtime.RawExtension{Raw: sharedRaw},
+				},
+			}
+			resp := handler.Handle(context.TODO(), req)
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(string(resp.Result.Message)).Should(ContainSubstring("only supported on the definitions in namespace vela-system"))
+
+			shared.SetNamespace(oam.SystemDefinitionNamespace)
+			sharedRaw, _ = json.Marshal(shared)
+			req.Object = runtime.RawExtension{Raw: sharedRaw}
+			resp = handler.Handle(context.TODO(), req)
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+
 		It("Test TraitDefintion has both spec.version and revision name annotation", func() {
 			wrongtd := v1beta1.TraitDefinition{}
 			wrongtd.SetGroupVersionKind(v1beta1.TraitDefinitionGroupVersionKind)
@@ -342,4 +377,48 @@ var _ = Describe("Test TraitDefinition validating handler", func() {
 			Expect(resp.Allowed).Should(BeTrue())
 		})
 	})
+
+	Context("Test delete operation admission request", func() {
+		// the deletion is validated by a separate handler reading the applications from a fake client,
+		// leaving the shared handler untouched for the other specs
+		var deleteHandler ValidatingHandler
+		BeforeEach(func() {
+			app := &v1beta1.Application{}
+			app.SetName("app")
+			app.SetNamespace("default")
+			app.Spec.Components = []common.ApplicationComponent{{Name: "comp", Type: "webservice",
+				Traits: []common.ApplicationTrait{{Type: "scaler"}}}}
+			deleteHandler = ValidatingHandler{
+				Decoder: decoder,
+				Client:  fake.NewClientBuilder().WithScheme(velacommon.Scheme).WithObjects(app).Build(),
+			}
+		})
+
+		deleteRequest := func(annotations map[string]string) admission.Request {
+			td := v1beta1.TraitDefinition{}
+			td.SetGroupVersionKind(v1beta1.TraitDefinitionGroupVersionKind)
+			td.SetName("scaler")
+			td.SetNamespace("default")
+			td.SetAnnotations(annotations)
+			tdRaw, _ := json.Marshal(td)
+			return admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Delete,
+					Resource:  reqResource,
+					OldObject: runtime.RawExtension{Raw: tdRaw},
+				},
+			}
+		}
+
+		It("Test deleting a TraitDefinition referenced by applications", func() {
+			resp := deleteHandler.Handle(context.TODO(), deleteRequest(nil))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("still referenced by 1 application(s), e.g. default/app"))
+		})
+
+		It("Test force deleting a TraitDefinition referenced by applications", func() {
+			resp := deleteHandler.Handle(context.TODO(), deleteRequest(map[string]string{oam.AnnotationForceDelete: "true"}))
+			Expect(resp.Allowed).Should(BeTrue())
+		})
+	})
 })
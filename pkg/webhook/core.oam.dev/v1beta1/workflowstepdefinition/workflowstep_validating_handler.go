@@ -31,6 +31,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	coredef "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/definition/signature"
 	"github.com/oam-dev/kubevela/pkg/logging"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	webhookutils "github.com/oam-dev/kubevela/pkg/webhook/utils"
@@ -49,6 +51,8 @@ var (
 type ValidatingHandler struct {
 	Decoder admission.Decoder
 	Client  client.Client
+	// Verifier verifies the signature and the checksum of the definition
+	Verifier *signature.Verifier
 }
 
 // InjectClient injects the Kubernetes client into the handler.
@@ -81,6 +85,20 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
 	}
 
+	if req.Operation == admissionv1.Delete {
+		obj := &v1beta1.WorkflowStepDefinition{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, obj); err != nil {
+			logger.WithStep("decode").WithError(err).Error(err, "Unable to decode the deleted WorkflowStepDefinition from admission request - malformed request")
+			return admission.Errored(http.StatusBadRequest, fmt.Errorf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		if err := coredef.ValidateDefinitionDeletion(ctx, h.Client, obj); err != nil {
+			logger.WithStep("validate-deletion").WithError(err).Error(err, "WorkflowStepDefinition is still referenced by applications - rejecting deletion", "definitionName", obj.Name)
+			return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+		}
+		logger.WithStep("complete").WithSuccess(true, startTime).Info("WorkflowStepDefinition deletion admitted - no application references it or deletion is forced", "definitionName", obj.Name)
+		return admission.ValidationResponse(true, "")
+	}
+
 	// Only validate create and update operations
 	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
 		logger.WithStep("skip-validation").Info("Skipping WorkflowStepDefinition validation - operation does not require validation", "operation", req.Operation, "reason", "only CREATE and UPDATE operations are validated")
@@ -103,6 +121,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		"hasSchematic", obj.Spec.Schematic != nil,
 		"version", obj.Spec.Version)
 
+	if err := h.Verifier.Verify(obj); err != nil {
+		logger.WithStep("verify-signature").WithError(err).Error(err, "WorkflowStepDefinition is unsigned or does not match its signature or checksum - rejecting request")
+		return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+	}
+
 	// Validate output resources
 	if obj.Spec.Schematic != nil && obj.Spec.Schematic.CUE != nil {
 		logger.WithStep("validate-output-resources").Info("Validating output resources referenced in WorkflowStepDefinition CUE template")
@@ -113,6 +136,11 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 		logger.WithStep("validate-output-resources").WithSuccess(true).Info("Output resources validation completed successfully - all referenced resources exist in cluster")
 	}
 
+	if err := webhookutils.ValidateDefinitionVisibility(obj); err != nil {
+		logger.WithStep("validate-visibility").WithError(err).Error(err, "WorkflowStepDefinition has an invalid visible-namespaces annotation")
+		return admission.Denied(fmt.Sprintf("%s (requestUID=%s)", err.Error(), req.UID))
+	}
+
 	// Validate semantic version
 	if obj.Spec.Version != "" {
 		if err := webhookutils.ValidateSemanticVersion(obj.Spec.Version); err != nil {
@@ -134,15 +162,16 @@ func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) a
 }
 
 // RegisterValidatingHandler registers the WorkflowStepDefinition validation webhook with the manager.
-func RegisterValidatingHandler(mgr manager.Manager) {
+func RegisterValidatingHandler(mgr manager.Manager, verifier *signature.Verifier) {
 	logger := logging.New()
 	logger.Info("Registering WorkflowStepDefinition validation webhook", "path", ValidationWebhookPath)
 
 	server := mgr.GetWebhookServer()
 	server.Register(ValidationWebhookPath, &webhook.Admission{
 		Handler: &ValidatingHandler{
-			Client:  mgr.GetClient(),
-			Decoder: admission.NewDecoder(mgr.GetScheme()),
+			Client:   mgr.GetClient(),
+			Decoder:  admission.NewDecoder(mgr.GetScheme()),
+			Verifier: verifier,
 		},
 	})
 }
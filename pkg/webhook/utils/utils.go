@@ -63,6 +63,13 @@ func ValidateDefinitionRevision(ctx context.Context, cli client.Client, def runt
 	return nil
 }
 
+// ValidateDefinitionVisibility validates the visible-namespaces annotation of the definition, which is only
+// supported in vela-system.
+func ValidateDefinitionVisibility(def client.Object) error {
+	_, err := core.ParseDefinitionVisibility(def)
+	return err
+}
+
 // ValidateCueTemplate validate cueTemplate
 func ValidateCueTemplate(cueTemplate string) error {
 
@@ -38,6 +38,7 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/oam"
 )
 
 func TestValidateDefinitionRevision(t *testing.T) {
@@ -340,6 +341,45 @@ func TestValidateSemanticVersion(t *testing.T) {
 	}
 }
 
+func TestValidateDefinitionVisibility(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		namespace   string
+		annotations map[string]string
+		wantErr     string
+	}{
+		"noAnnotation": {
+			namespace: "team-a",
+		},
+		"systemDefinition": {
+			namespace:   oam.SystemDefinitionNamespace,
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=a"},
+		},
+		"invalidSelector": {
+			namespace:   oam.SystemDefinitionNamespace,
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team in (a"},
+			wantErr:     "invalid definition.oam.dev/visible-namespaces annotation",
+		},
+		"otherNamespace": {
+			namespace:   "team-b",
+			annotations: map[string]string{oam.AnnotationDefinitionVisibleNamespaces: "team=a"},
+			wantErr:     "only supported on the definitions in namespace vela-system",
+		},
+	}
+	for caseName, cs := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			t.Parallel()
+			def := &v1beta1.TraitDefinition{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: cs.namespace, Annotations: cs.annotations}}
+			err := ValidateDefinitionVisibility(def)
+			if cs.wantErr != "" {
+				assert.ErrorContains(t, err, cs.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateMultipleDefVersionsNotPresent(t *testing.T) {
 	t.Parallel()
 	cases := map[string]struct {
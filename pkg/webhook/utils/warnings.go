@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type warningsKey struct{}
+
+// Warnings collects the admission warnings of a request. Warnings are returned to the user by kubectl and the
+// vela CLI without rejecting the request, they are used for soft policy violations such as deprecated
+// definitions, oversized properties or ignored fields. Duplicated warnings are only returned once.
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []string
+	seen     map[string]bool
+}
+
+// WithWarnings returns a context carrying a new warnings collector, the validations add warnings to it with
+// AddWarning so that they do not need to return them through their signatures.
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, w), w
+}
+
+// WarningsFrom returns the warnings collector carried by the context, or nil if there is none
+func WarningsFrom(ctx context.Context) *Warnings {
+	w, _ := ctx.Value(warningsKey{}).(*Warnings)
+	return w
+}
+
+// AddWarning adds a warning to the collector carried by the context. It does nothing if there is no collector,
+// so the validations can be called outside an admission request.
+func AddWarning(ctx context.Context, path *field.Path, format string, args ...interface{}) {
+	WarningsFrom(ctx).Add(path, format, args...)
+}
+
+// Add adds a warning for the field, the path can be nil for warnings which are not about a specific field
+func (w *Warnings) Add(path *field.Path, format string, args ...interface{}) {
+	if w == nil {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if path != nil {
+		msg = fmt.Sprintf("%s: %s", path.String(), msg)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen == nil {
+		w.seen = map[string]bool{}
+	}
+	if w.seen[msg] {
+		return
+	}
+	w.seen[msg] = true
+	w.warnings = append(w.warnings, msg)
+}
+
+// List returns the collected warnings in the order they were added
+func (w *Warnings) List() []string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.warnings...)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestWarnings(t *testing.T) {
+	// no collector in the context
+	AddWarning(context.Background(), nil, "ignored")
+	assert.Nil(t, WarningsFrom(context.Background()).List())
+
+	ctx, warnings := WithWarnings(context.Background())
+	AddWarning(ctx, nil, "definition %q is deprecated", "worker")
+	AddWarning(ctx, field.NewPath("spec", "components").Index(0).Child("properties"), "too large")
+	AddWarning(ctx, nil, "definition %q is deprecated", "worker")
+	assert.Same(t, warnings, WarningsFrom(ctx))
+	assert.Equal(t, []string{
+		`definition "worker" is deprecated`,
+		`spec.components[0].properties: too large`,
+	}, warnings.List())
+}
@@ -19,16 +19,19 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	core "github.com/oam-dev/kubevela/apis/core.oam.dev"
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
 	common2 "github.com/oam-dev/kubevela/pkg/utils/common"
@@ -239,8 +242,17 @@ func PrintInstalledCompDef(c common2.Args, io cmdutil.IOStreams, filter filterFu
 		if filter != nil && !filter(capa) {
 			continue
 		}
-		table.AddRow(capa.Name, capa.CrdName, capa.Description)
+		table.AddRow(capa.Name, capa.CrdName, withDeprecation(capa.Description, cd.Status.ConditionedStatus))
 	}
 	io.Info(table.String())
 	return nil
 }
+
+// withDeprecation prefixes the description of a deprecated definition with its deprecation message
+func withDeprecation(description string, status condition.ConditionedStatus) string {
+	cond := status.GetCondition(condition.TypeDeprecated)
+	if cond.Status != corev1.ConditionTrue {
+		return description
+	}
+	return strings.TrimSpace(fmt.Sprintf("[DEPRECATED: %s] %s", cond.Message, description))
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/condition"
+)
+
+func TestWithDeprecation(t *testing.T) {
+	status := condition.ConditionedStatus{}
+	assert.Equal(t, "Describes long-running workloads", withDeprecation("Describes long-running workloads", status))
+
+	status.SetConditions(condition.Deprecated("use webservice instead"))
+	assert.Equal(t, "[DEPRECATED: use webservice instead] Describes long-running workloads",
+		withDeprecation("Describes long-running workloads", status))
+	assert.Equal(t, "[DEPRECATED: use webservice instead]", withDeprecation("", status))
+
+	status.SetConditions(condition.NotDeprecated())
+	assert.Equal(t, "Describes long-running workloads", withDeprecation("Describes long-running workloads", status))
+}
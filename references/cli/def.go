@@ -57,6 +57,7 @@ import (
 	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
 	"github.com/oam-dev/kubevela/pkg/definition/gen_sdk"
 	"github.com/oam-dev/kubevela/pkg/definition/goloader"
+	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/utils"
 	addonutil "github.com/oam-dev/kubevela/pkg/utils/addon"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
@@ -93,6 +94,9 @@ func DefinitionCommandGroup(c common.Args, order string, ioStreams util.IOStream
 		NewDefinitionRenderCommand(c),
 		NewDefinitionApplyCommand(c, ioStreams),
 		NewDefinitionDelCommand(c),
+		NewDefinitionRollbackCommand(c),
+		NewDefinitionDiffCommand(c, ioStreams),
+		NewDefinitionTestCommand(c, ioStreams),
 		NewDefinitionInitCommand(c),
 		NewDefinitionValidateCommand(c),
 		NewDefinitionUpgradeCommand(c, ioStreams),
@@ -1719,6 +1723,73 @@ func NewDefinitionDelCommand(c common.Args) *cobra.Command {
 	return cmd
 }
 
+// NewDefinitionRollbackCommand create the `vela def rollback` command to restore a definition from one of its revisions
+func NewDefinitionRollbackCommand(c common.Args) *cobra.Command {
+	var targetRevision string
+	cmd := &cobra.Command{
+		Use:   "rollback DEFINITION_NAME",
+		Short: "Rollback X-Definition to a revision.",
+		Long: "Rollback X-Definition to one of its DefinitionRevisions. The spec of the definition is restored by the " +
+			"definition controllers, which record an event with the user and the revisions of the rollback.",
+		Example: "# Command below will rollback TraitDefinition of annotations in default namespace to its revision 2\n" +
+			"> vela def rollback annotations -t trait -n default --revision 2",
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefManagement,
+			types.TagCommandOrder: "9",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			definitionType, err := cmd.Flags().GetString(FlagType)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", FlagType)
+			}
+			namespace, err := cmd.Flags().GetString(FlagNamespace)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", Namespace)
+			}
+			// "v1", "1", both need to work
+			ver, err := strconv.Atoi(strings.TrimPrefix(targetRevision, "v"))
+			if err != nil {
+				return fmt.Errorf("invalid revision %q: %w", targetRevision, err)
+			}
+			k8sClient, err := c.GetClient()
+			if err != nil {
+				return errors.Wrapf(err, "failed to get k8s client")
+			}
+			ctx := context.Background()
+			def, err := getSingleDefinition(cmd, args[0], k8sClient, definitionType, namespace)
+			if err != nil {
+				return err
+			}
+			revs, err := getDefRevs(ctx, k8sClient, def.GetNamespace(), pkgdef.DefinitionKindToType[def.GetKind()], def.GetName(), int64(ver))
+			if err != nil {
+				return err
+			}
+			if len(revs) == 0 {
+				return fmt.Errorf("no %s with revision %d found in namespace %s", def.GetName(), ver, def.GetNamespace())
+			}
+
+			patch := client.MergeFrom(def.Unstructured.DeepCopy())
+			annotations := def.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[oam.AnnotationDefinitionRollbackToRevision] = revs[0].Name
+			def.SetAnnotations(annotations)
+			if err := k8sClient.Patch(ctx, &def.Unstructured, patch); err != nil {
+				return errors.Wrapf(err, "failed to rollback %s %s in namespace %s", def.GetKind(), def.GetName(), def.GetNamespace())
+			}
+			cmd.Printf("%s %s in namespace %s is rolling back to revision %s.\n", def.GetKind(), def.GetName(), def.GetNamespace(), revs[0].Name)
+			return nil
+		},
+	}
+	cmd.Flags().StringP(FlagType, "t", "", "Specify the definition type of target. Valid types: "+strings.Join(pkgdef.ValidDefinitionTypes(), ", "))
+	cmd.Flags().StringP(Namespace, "n", types.DefaultKubeVelaNS, "Specify which namespace the definition locates.")
+	cmd.Flags().StringVarP(&targetRevision, "revision", "r", "", "The revision to rollback the definition to.")
+	_ = cmd.MarkFlagRequired("revision")
+	return cmd
+}
+
 // isCUEorGoDefinitionFile checks if a file is a CUE file or a Go definition file
 func isCUEorGoDefinitionFile(path string) bool {
 	if utils.IsCUEFile(path) {
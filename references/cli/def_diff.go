@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types2 "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+	"github.com/oam-dev/kubevela/pkg/definition/goloader"
+	"github.com/oam-dev/kubevela/pkg/utils"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+// NewDefinitionDiffCommand create the `vela def diff` command to compare local definitions with the ones in the cluster
+func NewDefinitionDiffCommand(c common.Args, streams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff DEFINITION.cue|DEFINITION.go|DEFINITION.yaml",
+		Short: "Diff X-Definition against the cluster.",
+		Long: "Render the local X-Definition and compare its spec with the definition in the cluster. " +
+			"The latest DefinitionRevision of the definition is checked as well, to tell whether applying the local definition would create a new revision.\n\n" +
+			"Supports CUE, YAML, and Go definition files. If a directory is used as input, all definitions in the directory will be compared.",
+		Example: "# Command below will compare the local my-webservice.cue file with the definition in the vela-system namespace\n" +
+			"> vela def diff my-webservice.cue\n" +
+			"# Compare a Go definition file with the definition in the default namespace\n" +
+			"> vela def diff my-webservice.go --namespace default\n" +
+			"# Compare all definitions in the local directory\n" +
+			"> vela def diff ./defs/",
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefManagement,
+			types.TagCommandOrder: "10",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString(Namespace)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get `%s`", Namespace)
+			}
+			return defDiffAll(context.Background(), c, streams, namespace, args[0])
+		},
+	}
+	cmd.Flags().StringP(Namespace, "n", types.DefaultKubeVelaNS, "Specify which namespace the definition locates.")
+	return cmd
+}
+
+func defDiffAll(ctx context.Context, c common.Args, streams util.IOStreams, namespace, path string) error {
+	config, err := c.GetConfig()
+	if err != nil {
+		return err
+	}
+	k8sClient, err := c.GetClient()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get k8s client")
+	}
+	files, err := utils.LoadDataFromPath(ctx, path, isDefinitionFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get from %s", path)
+	}
+	for _, f := range files {
+		defs, err := loadLocalDefinitions(config, f.Path, f.Data)
+		if err != nil {
+			return err
+		}
+		for _, def := range defs {
+			if def.GetNamespace() == "" {
+				def.SetNamespace(namespace)
+			}
+			if _, err := defDiffOne(ctx, k8sClient, streams, def); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadLocalDefinitions renders the definitions of a local CUE, YAML or Go definition file
+func loadLocalDefinitions(config *rest.Config, defpath string, defBytes []byte) ([]*pkgdef.Definition, error) {
+	switch {
+	case strings.HasSuffix(defpath, GoExtension):
+		results, err := goloader.LoadFromFile(defpath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load Go definition from %s", defpath)
+		}
+		var defs []*pkgdef.Definition
+		for _, result := range results {
+			if result.Error != nil {
+				return nil, errors.Wrapf(result.Error, "failed to generate CUE for %s", result.Definition.FunctionName)
+			}
+			def := &pkgdef.Definition{Unstructured: unstructured.Unstructured{}}
+			if err := def.FromCUEString(result.CUE, config); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse generated CUE for %s", result.Definition.FunctionName)
+			}
+			defs = append(defs, def)
+		}
+		return defs, nil
+	case strings.HasSuffix(defpath, YAMLExtension) || strings.HasSuffix(defpath, YMLExtension):
+		def := &pkgdef.Definition{Unstructured: unstructured.Unstructured{}}
+		if err := def.FromYAML(defBytes); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse YAML to definition")
+		}
+		return []*pkgdef.Definition{def}, nil
+	default:
+		def := &pkgdef.Definition{Unstructured: unstructured.Unstructured{}}
+		if err := def.FromCUEString(string(defBytes), config); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse CUE for definition")
+		}
+		return []*pkgdef.Definition{def}, nil
+	}
+}
+
+// defDiffOne prints the diff between the spec of the local definition and the one in the cluster, and whether
+// applying the local definition would create a new DefinitionRevision. It returns true if it would.
+func defDiffOne(ctx context.Context, k8sClient client.Client, streams util.IOStreams, def *pkgdef.Definition) (bool, error) {
+	ref := fmt.Sprintf("%s %s in namespace %s", def.GetKind(), def.GetName(), def.GetNamespace())
+	live := pkgdef.Definition{Unstructured: unstructured.Unstructured{}}
+	live.SetGroupVersionKind(def.GroupVersionKind())
+	err := k8sClient.Get(ctx, types2.NamespacedName{Namespace: def.GetNamespace(), Name: def.GetName()}, &live)
+	if err != nil && !errors2.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to get %s", ref)
+	}
+	found := err == nil
+
+	var before string
+	if found {
+		if before, err = specJSON(&live.Unstructured); err != nil {
+			return false, err
+		}
+	}
+	after, err := specJSON(&def.Unstructured)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(streams.Out, "--- %s (cluster)\n+++ %s (local)\n", ref, ref)
+	printSpecDiff(before, after, "", streams)
+
+	if !found {
+		fmt.Fprintf(streams.Out, "%s does not exist, applying would create it with revision 1.\n", ref)
+		return true, nil
+	}
+	newRevision, message, err := wouldCreateDefRevision(ctx, k8sClient, def)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintln(streams.Out, message)
+	return newRevision, nil
+}
+
+// wouldCreateDefRevision checks the local definition against the latest DefinitionRevision in the
+// cluster the way the definition controllers do: a new revision is created if the hash of the spec
+// differs, or if the specs differ despite the same hash.
+func wouldCreateDefRevision(ctx context.Context, k8sClient client.Client, def *pkgdef.Definition) (bool, string, error) {
+	obj, err := typedDefinition(def)
+	if err != nil {
+		return false, "", err
+	}
+	newDefRev, _, err := core.GatherRevisionInfo(obj)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to compute the revision of %s %s", def.GetKind(), def.GetName())
+	}
+	revs, err := getDefRevs(ctx, k8sClient, def.GetNamespace(), def.GetType(), def.GetName(), 0)
+	if err != nil {
+		return false, "", err
+	}
+	var latest *v1beta1.DefinitionRevision
+	for i := range revs {
+		if latest == nil || revs[i].Spec.Revision > latest.Spec.Revision {
+			latest = &revs[i]
+		}
+	}
+	if latest == nil {
+		return true, "No DefinitionRevision found, applying would create revision 1.", nil
+	}
+	if latest.Spec.RevisionHash == newDefRev.Spec.RevisionHash && core.DeepEqualDefRevision(latest, newDefRev) {
+		return false, fmt.Sprintf("Same as the latest revision %s (hash %s), applying would not create a new revision.",
+			latest.Name, latest.Spec.RevisionHash), nil
+	}
+	return true, fmt.Sprintf("Differs from the latest revision %s (hash %s), applying would create revision %d (hash %s).",
+		latest.Name, latest.Spec.RevisionHash, latest.Spec.Revision+1, newDefRev.Spec.RevisionHash), nil
+}
+
+// typedDefinition converts the definition into its typed object
+func typedDefinition(def *pkgdef.Definition) (runtime.Object, error) {
+	var obj runtime.Object
+	switch def.GetKind() {
+	case v1beta1.ComponentDefinitionKind:
+		obj = &v1beta1.ComponentDefinition{}
+	case v1beta1.TraitDefinitionKind:
+		obj = &v1beta1.TraitDefinition{}
+	case v1beta1.PolicyDefinitionKind:
+		obj = &v1beta1.PolicyDefinition{}
+	case v1beta1.WorkflowStepDefinitionKind:
+		obj = &v1beta1.WorkflowStepDefinition{}
+	default:
+		return nil, fmt.Errorf("%s has no DefinitionRevision", def.GetKind())
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(def.Object, obj); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s %s", def.GetKind(), def.GetName())
+	}
+	return obj, nil
+}
+
+func specJSON(obj *unstructured.Unstructured) (string, error) {
+	spec, _, err := unstructured.NestedFieldNoCopy(obj.Object, "spec")
+	if err != nil {
+		return "", err
+	}
+	bs, err := json.Marshal(spec)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal the spec of %s %s", obj.GetKind(), obj.GetName())
+	}
+	return string(bs), nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/v1beta1/core"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+func TestNewDefinitionDiffCommand(t *testing.T) {
+	ctx := context.Background()
+	c := initArgs()
+	k8sClient, err := c.GetClient()
+	require.NoError(t, err)
+	traitName, traitFilename := createLocalTrait(t)
+	defer removeFile(traitFilename, t)
+
+	diff := func() string {
+		out := bytes.NewBuffer(nil)
+		cmd := NewDefinitionDiffCommand(c, util.IOStreams{In: os.Stdin, Out: out, ErrOut: out})
+		initCommand(cmd)
+		cmd.SetArgs([]string{traitFilename, "-n", VelaTestNamespace})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	// the definition is not in the cluster yet
+	out := diff()
+	require.Contains(t, out, "does not exist, applying would create it with revision 1")
+	require.Contains(t, out, "schematic:")
+
+	applyCmd := NewDefinitionApplyCommand(c, util.IOStreams{In: os.Stdin, Out: bytes.NewBuffer(nil), ErrOut: bytes.NewBuffer(nil)})
+	initCommand(applyCmd)
+	applyCmd.SetArgs([]string{traitFilename, "-n", VelaTestNamespace})
+	require.NoError(t, applyCmd.Execute())
+
+	// the definition is in the cluster, but has no revision
+	out = diff()
+	require.Contains(t, out, "No DefinitionRevision found, applying would create revision 1")
+
+	// record the revision of the definition as the controller would
+	trait := &v1beta1.TraitDefinition{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: VelaTestNamespace, Name: traitName}, trait))
+	defRev, _, err := core.GatherRevisionInfo(trait)
+	require.NoError(t, err)
+	defRev.Name = traitName + "-v1"
+	defRev.Namespace = VelaTestNamespace
+	defRev.Labels = map[string]string{oam.LabelTraitDefinitionName: traitName}
+	defRev.Spec.Revision = 1
+	require.NoError(t, k8sClient.Create(ctx, defRev))
+
+	out = diff()
+	require.Contains(t, out, "Same as the latest revision "+traitName+"-v1")
+
+	// the local definition changes
+	data, err := os.ReadFile(traitFilename)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(traitFilename, bytes.ReplaceAll(data, []byte("*1 | int"), []byte("*2 | int")), 0600))
+	out = diff()
+	require.Contains(t, out, "Differs from the latest revision "+traitName+"-v1")
+	require.Contains(t, out, "applying would create revision 2")
+}
@@ -42,6 +42,7 @@ import (
 	common3 "github.com/oam-dev/kubevela/apis/core.oam.dev/common"
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	pkgdef "github.com/oam-dev/kubevela/pkg/definition"
+	"github.com/oam-dev/kubevela/pkg/oam"
 	addonutil "github.com/oam-dev/kubevela/pkg/utils/addon"
 	common2 "github.com/oam-dev/kubevela/pkg/utils/common"
 	"github.com/oam-dev/kubevela/pkg/utils/util"
@@ -627,6 +628,34 @@ func TestNewDefinitionDelCommand(t *testing.T) {
 	}
 }
 
+func TestNewDefinitionRollbackCommand(t *testing.T) {
+	c := initArgs()
+	traitName := createTrait(c, t)
+	k8sClient, err := c.GetClient()
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Create(context.Background(), &v1beta1.DefinitionRevision{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      traitName + "-v1",
+			Namespace: VelaTestNamespace,
+			Labels:    map[string]string{oam.LabelTraitDefinitionName: traitName},
+		},
+		Spec: v1beta1.DefinitionRevisionSpec{Revision: 1, DefinitionType: common3.TraitType},
+	}))
+
+	cmd := NewDefinitionRollbackCommand(c)
+	initCommand(cmd)
+	cmd.SetArgs([]string{traitName, "-n", VelaTestNamespace, "--revision", "2"})
+	require.Error(t, cmd.Execute())
+
+	cmd = NewDefinitionRollbackCommand(c)
+	initCommand(cmd)
+	cmd.SetArgs([]string{traitName, "-n", VelaTestNamespace, "--revision", "v1"})
+	require.NoError(t, cmd.Execute())
+	trait := &v1beta1.TraitDefinition{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: VelaTestNamespace, Name: traitName}, trait))
+	require.Equal(t, traitName+"-v1", trait.GetAnnotations()[oam.AnnotationDefinitionRollbackToRevision])
+}
+
 func TestNewDefinitionVetCommand(t *testing.T) {
 	c := initArgs()
 	cmd := NewDefinitionValidateCommand(c)
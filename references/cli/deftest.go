@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/klog/v2"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/definition/conformance"
+	"github.com/oam-dev/kubevela/pkg/utils"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+const (
+	// FlagValidate is the flag to validate the rendered manifests against the schemas of the cluster
+	FlagValidate = "validate"
+	// FlagJUnit is the flag for the path of the JUnit report
+	FlagJUnit = "junit"
+)
+
+type defTestOptions struct {
+	validate bool
+	output   string
+	junit    string
+}
+
+// NewDefinitionTestCommand create the `vela def test` command to render a definition with parameter fixtures
+func NewDefinitionTestCommand(c common.Args, streams util.IOStreams) *cobra.Command {
+	opts := defTestOptions{}
+	cmd := &cobra.Command{
+		Use:   "test DEFINITION.cue|DEFINITION.go|DEFINITION.yaml FIXTURES_DIR",
+		Short: "Test X-Definition with parameter fixtures.",
+		Long: "Render the outputs of the X-Definition for each parameter fixture in the fixtures directory. " +
+			"Each YAML or JSON file in the directory holds the parameters of one fixture, named after the file.\n\n" +
+			"The rendered manifests are printed, or saved under the output directory in a file per fixture. " +
+			"With --validate, the manifests are validated against the OpenAPI schemas served by the cluster. " +
+			"The command fails if a fixture cannot be rendered or its manifests are invalid.\n\n" +
+			"Only ComponentDefinitions and TraitDefinitions are supported, traits are rendered without a workload.",
+		Example: "# Render my-webservice.cue with each fixture in ./fixtures/ and print the manifests\n" +
+			"> vela def test my-webservice.cue ./fixtures/\n" +
+			"# Validate the manifests against the schemas of the cluster and save them in ./rendered/\n" +
+			"> vela def test my-webservice.go ./fixtures/ --validate -o ./rendered/\n" +
+			"# Write the results as a JUnit report for CI\n" +
+			"> vela def test my-webservice.cue ./fixtures/ --junit report.xml",
+		Args: cobra.ExactArgs(2),
+		Annotations: map[string]string{
+			types.TagCommandType:  types.TypeDefManagement,
+			types.TagCommandOrder: "11",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return defTest(context.Background(), c, streams, args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.validate, FlagValidate, false, "Validate the rendered manifests against the OpenAPI schemas of the cluster.")
+	cmd.Flags().StringVarP(&opts.output, FlagOutput, "o", "", "Specify the directory to save the rendered manifests in. If empty, the manifests will be printed in the console.")
+	cmd.Flags().StringVar(&opts.junit, FlagJUnit, "", "Specify the path to write the results as a JUnit XML report.")
+	return cmd
+}
+
+func defTest(ctx context.Context, c common.Args, streams util.IOStreams, defPath, fixturesDir string, opts defTestOptions) error {
+	samples, err := conformance.LoadSamples(fixturesDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load the fixtures from %s", fixturesDir)
+	}
+	if len(samples) == 0 {
+		return errors.Errorf("no fixture found in %s", fixturesDir)
+	}
+	config, err := c.GetConfig()
+	if err != nil {
+		klog.Infof("ignore kubernetes cluster, unable to get kubeconfig: %s", err.Error())
+	}
+	var conformanceOpts conformance.Options
+	if opts.validate {
+		dc, err := c.GetDiscoveryClient()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the discovery client to validate the manifests")
+		}
+		conformanceOpts.Validator = conformance.NewOpenAPIValidator(openapi3.NewRoot(dc.OpenAPIV3()))
+	}
+
+	files, err := utils.LoadDataFromPath(ctx, defPath, isDefinitionFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get from %s", defPath)
+	}
+	var reports []*conformance.Report
+	for _, f := range files {
+		defs, err := loadLocalDefinitions(config, f.Path, f.Data)
+		if err != nil {
+			return err
+		}
+		for _, def := range defs {
+			report, err := conformance.Run(ctx, &def.Unstructured, samples, conformanceOpts)
+			if err != nil {
+				return err
+			}
+			if err := writeDefTestManifests(streams, report, opts.output); err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+	}
+
+	if opts.junit != "" {
+		out, err := os.Create(filepath.Clean(opts.junit))
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the JUnit report %s", opts.junit)
+		}
+		defer func() { _ = out.Close() }()
+		if err := conformance.WriteJUnit(out, reports...); err != nil {
+			return errors.Wrapf(err, "failed to write the JUnit report %s", opts.junit)
+		}
+	}
+
+	var failures []string
+	for _, report := range reports {
+		for _, result := range report.Cases {
+			status := "PASS"
+			if len(result.Failures) > 0 {
+				status = "FAIL"
+			}
+			streams.Infof("%s %s/%s %s (%d outputs, %s)\n", status, report.Kind, report.Definition, result.Sample, result.Outputs, formatDuration(result.Duration))
+			for _, failure := range result.Failures {
+				streams.Infof("    %s\n", failure)
+			}
+		}
+		if err := report.Err(); err != nil {
+			failures = append(failures, fmt.Sprintf("%s/%s", report.Kind, report.Definition))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("%d definitions failed the tests: %s", len(failures), strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// writeDefTestManifests prints the manifests rendered for each fixture, or saves them in
+// <output>/<definition>/<fixture>.yaml if the output directory is set.
+func writeDefTestManifests(streams util.IOStreams, report *conformance.Report, output string) error {
+	for _, result := range report.Cases {
+		var docs []string
+		for _, obj := range result.Objects {
+			s, err := prettyYAMLMarshal(obj.Object)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal the manifests of fixture %s", result.Sample)
+			}
+			docs = append(docs, s)
+		}
+		if output == "" {
+			if len(docs) > 0 {
+				streams.Infof("# %s/%s with fixture %s\n%s\n", report.Kind, report.Definition, result.Sample, strings.Join(docs, "---\n"))
+			}
+			continue
+		}
+		dir := filepath.Join(output, report.Definition)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return errors.Wrapf(err, "failed to create the output directory %s", dir)
+		}
+		filename := filepath.Join(dir, result.Sample+YAMLExtension)
+		if err := os.WriteFile(filename, []byte(strings.Join(docs, "---\n")), 0600); err != nil {
+			return errors.Wrapf(err, "failed to save the manifests of fixture %s", result.Sample)
+		}
+	}
+	return nil
+}
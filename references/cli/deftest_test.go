@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+const fixtureWorkerCUE = `worker: {
+	type:        "component"
+	description: "A worker for the fixture tests"
+	attributes: workload: definition: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+	}
+}
+template: {
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		spec: {
+			replicas: parameter.replicas
+			template: spec: containers: [{name: context.name, image: parameter.image}]
+		}
+	}
+	parameter: {
+		image:     string
+		replicas: *1 | int
+	}
+}
+`
+
+func TestNewDefinitionTestCommand(t *testing.T) {
+	dir := t.TempDir()
+	defFilename := filepath.Join(dir, "worker.cue")
+	require.NoError(t, os.WriteFile(defFilename, []byte(fixtureWorkerCUE), 0600))
+	fixturesDir := filepath.Join(dir, "fixtures")
+	require.NoError(t, os.MkdirAll(fixturesDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(fixturesDir, "default.yaml"), []byte("image: nginx\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(fixturesDir, "scaled.json"), []byte(`{"image": "nginx", "replicas": 3}`), 0600))
+
+	// the manifests are printed
+	out := bytes.NewBuffer(nil)
+	cmd := NewDefinitionTestCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: out, ErrOut: out})
+	initCommand(cmd)
+	cmd.SetArgs([]string{defFilename, fixturesDir})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "# ComponentDefinition/worker with fixture scaled")
+	require.Contains(t, out.String(), "replicas: 3")
+	require.Contains(t, out.String(), "PASS ComponentDefinition/worker default")
+
+	// the manifests are saved along with a JUnit report
+	outputDir := filepath.Join(dir, "rendered")
+	junit := filepath.Join(dir, "report.xml")
+	cmd = NewDefinitionTestCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: bytes.NewBuffer(nil), ErrOut: bytes.NewBuffer(nil)})
+	initCommand(cmd)
+	cmd.SetArgs([]string{defFilename, fixturesDir, "-o", outputDir, "--junit", junit})
+	require.NoError(t, cmd.Execute())
+	data, err := os.ReadFile(filepath.Join(outputDir, "worker", "default.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "image: nginx")
+	data, err = os.ReadFile(junit)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `<testsuite name="ComponentDefinition/worker" tests="2" failures="0"`)
+
+	// a fixture which cannot be rendered fails the tests
+	require.NoError(t, os.WriteFile(filepath.Join(fixturesDir, "invalid.yaml"), []byte("image: nginx\nreplicas: two\n"), 0600))
+	out = bytes.NewBuffer(nil)
+	cmd = NewDefinitionTestCommand(common.Args{}, util.IOStreams{In: os.Stdin, Out: out, ErrOut: out})
+	initCommand(cmd)
+	cmd.SetArgs([]string{defFilename, fixturesDir})
+	require.ErrorContains(t, cmd.Execute(), "1 definitions failed the tests: ComponentDefinition/worker")
+	require.Contains(t, out.String(), "FAIL ComponentDefinition/worker invalid")
+}
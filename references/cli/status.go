@@ -18,6 +18,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -31,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	pkgtypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,6 +48,7 @@ import (
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
 	pkgappfile "github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/hooks"
 	"github.com/oam-dev/kubevela/pkg/multicluster"
 	"github.com/oam-dev/kubevela/pkg/policy"
 	"github.com/oam-dev/kubevela/pkg/resourcetracker"
@@ -99,8 +102,25 @@ func NewAppStatusCommand(c common.Args, order string, ioStreams cmdutil.IOStream
   vela status first-vela-app -o jsonpath='{.status}'
   
   # Get Application metrics status
-  vela status first-vela-app --metrics`,
+  vela status first-vela-app --metrics
+
+  # Show the outcome of the pre-start checks of vela-core
+  vela status --system`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if system, err := cmd.Flags().GetBool("system"); err == nil && system {
+				namespace, err := GetFlagNamespace(cmd, c)
+				if err != nil {
+					return err
+				}
+				if namespace == "" {
+					namespace = types.DefaultKubeVelaNS
+				}
+				newClient, err := c.GetClient()
+				if err != nil {
+					return err
+				}
+				return printSystemStatus(ctx, newClient, cmd.OutOrStdout(), namespace, outputFormat)
+			}
 			// check args
 			argsLength := len(args)
 			if argsLength == 0 {
@@ -181,10 +201,42 @@ func NewAppStatusCommand(c common.Args, order string, ioStreams cmdutil.IOStream
 	cmd.Flags().StringP("detail-format", "", "inline", "the format for displaying details, must be used with --detail. Can be one of inline, wide, list, table, raw.")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "raw Application output format. One of: (json, yaml, jsonpath)")
 	cmd.Flags().BoolP("metrics", "m", false, "show resource quota and consumption metrics of the application")
+	cmd.Flags().Bool("system", false, "show the outcome of the pre-start checks of vela-core published in its namespace, which defaults to vela-system")
 	addNamespaceAndEnvArg(cmd)
 	return cmd
 }
 
+// printSystemStatus prints the pre-start hook results vela-core published in the namespace
+func printSystemStatus(ctx context.Context, c client.Reader, out io.Writer, namespace string, format string) error {
+	summary, err := hooks.LoadResults(ctx, c, namespace, hooks.DefaultResultsConfigMap)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf("no pre-start check results found in the ConfigMap %s/%s",
+				namespace, hooks.DefaultResultsConfigMap)
+		}
+		return err
+	}
+	switch format {
+	case "":
+	case "json":
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	default:
+		return errors.Errorf("output format must be json if specified with --system")
+	}
+	status := "Passed"
+	if !summary.Passed {
+		status = "Failed"
+	}
+	fmt.Fprintf(out, "Pre-start checks of vela-core %s: %s (%s)\n\n", summary.Version, status, summary.Time.Format(time.RFC3339))
+	_, err = fmt.Fprintln(out, PrecheckSummaryPrinter(*summary).String())
+	return err
+}
+
 func printAppStatus(_ context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName string, namespace string, cmd *cobra.Command, velaC common.Args, detail bool) error {
 	app, err := appfile.LoadApplication(namespace, appName, velaC)
 	if err != nil {
@@ -19,6 +19,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/features"
 	"github.com/oam-dev/kubevela/pkg/multicluster"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
 )
@@ -51,6 +53,8 @@ const (
 	APIServiceName = "v1alpha1.cluster.core.oam.dev"
 	// UnknownMetric represent that we can't compute the metric data
 	UnknownMetric = "N/A"
+	// FeatureGatesConfigMap is the ConfigMap vela-core mirrors its feature gate status to
+	FeatureGatesConfigMap = "vela-core-feature-gates"
 )
 
 // NewSystemCommand print system detail info
@@ -64,7 +68,11 @@ func NewSystemCommand(c common.Args, order string) *cobra.Command {
 			"# Specify a deployment name with a namespace to check detail information:\n" +
 			"> vela system info -s kubevela-vela-core -n vela-system\n" +
 			"# Diagnose the system's health:\n" +
-			"> vela system diagnose\n",
+			"> vela system diagnose\n" +
+			"# Run the pre-start validations of vela-core before upgrading it:\n" +
+			"> vela system precheck\n" +
+			"# Replay the pre-start hooks of vela-core:\n" +
+			"> vela system hooks\n",
 		Annotations: map[string]string{
 			types.TagCommandType:  types.TypeSystem,
 			types.TagCommandOrder: order,
@@ -72,7 +80,9 @@ func NewSystemCommand(c common.Args, order string) *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewSystemInfoCommand(c),
-		NewSystemDiagnoseCommand(c))
+		NewSystemDiagnoseCommand(c),
+		NewSystemPrecheckCommand(c),
+		NewSystemHooksCommand(c))
 	return cmd
 }
 
@@ -142,6 +152,13 @@ func NewSystemInfoCommand(c common.Args) *cobra.Command {
 					if deployment.Name == deployName {
 						table := SpecifiedFormatPrinter(deployment)
 						cmd.Println(table.String())
+						if cm, err := clientset.CoreV1().ConfigMaps(deployment.Namespace).Get(ctx, FeatureGatesConfigMap, metav1.GetOptions{}); err == nil {
+							table, err := FeatureGatesPrinter(cm)
+							if err != nil {
+								return err
+							}
+							cmd.Println(table.String())
+						}
 						found = true
 						break
 					}
@@ -205,6 +222,25 @@ func SpecifiedFormatPrinter(deployment v1.Deployment) *uitable.Table {
 	return table
 }
 
+// FeatureGatesPrinter prints the feature gate status mirrored by vela-core to cm
+func FeatureGatesPrinter(cm *corev1.ConfigMap) (*uitable.Table, error) {
+	status := features.Status{}
+	if err := json.Unmarshal([]byte(cm.Data[features.StatusConfigMapKey]), &status); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode feature gate status of ConfigMap %s/%s", cm.Namespace, cm.Name)
+	}
+	table := newUITable().AddRow("FEATURE GATE", "ENABLED", "DEFAULT", "STAGE")
+	for _, gate := range status.Gates {
+		table.AddRow(gate.Name, gate.Enabled, gate.Default, gate.PreRelease)
+	}
+	for _, msg := range status.Errors {
+		table.AddRow("Error:", msg)
+	}
+	for _, msg := range status.Warnings {
+		table.AddRow("Warning:", msg)
+	}
+	return table, nil
+}
+
 // CPUMem returns the upsage of cpu and memory
 func CPUMem(resourceList corev1.ResourceList) string {
 	b := new(bytes.Buffer)
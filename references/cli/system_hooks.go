@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+// NewSystemHooksCommand replays the pre-start hooks of vela-core against the cluster. It runs the same
+// hooks from the same registry and with the same flags as vela system precheck.
+func NewSystemHooksCommand(c common.Args) *cobra.Command {
+	cmd := NewSystemPrecheckCommand(c)
+	cmd.Use = "hooks"
+	cmd.Short = "Replay the pre-start hooks of vela-core against the cluster."
+	cmd.Long = "Replay the pre-start hooks vela-core runs before starting its controllers against the cluster of the " +
+		"current kubeconfig, without restarting vela-core. It is the same as vela system precheck, see its help " +
+		"for the hooks writing to the cluster.\n\n" +
+		"The hooks are configured with the same precheck and prestart-hook flags as vela-core, pass the flags of " +
+		"the vela-core deployment to reproduce its validation. The hooks vela-core runs on its leader only are " +
+		"skipped unless --leader-only is set or they are selected with --hooks. Increase the log verbosity with -V " +
+		"to follow the checks of the hooks."
+	cmd.Example = "# List the pre-start hooks:\n" +
+		"> vela system hooks --list\n" +
+		"# Run all pre-start hooks with the default configuration of vela-core:\n" +
+		"> vela system hooks\n" +
+		"# Run the CRD validation with the flags of the vela-core deployment and print the failed checks:\n" +
+		"> vela system hooks --hooks CRDValidation --precheck-crd-schema-drift --verbose\n"
+	return cmd
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+func TestNewSystemHooksCommand(t *testing.T) {
+	cmd := NewSystemHooksCommand(common.Args{})
+	assert.Equal(t, "hooks", cmd.Use)
+	// the command shares the flags of vela system precheck
+	for _, flag := range []string{FlagHooks, FlagListHooks, FlagLeaderOnlyHooks, FlagVerbose, FlagFeatureGates, FlagNamespace} {
+		require.NotNil(t, cmd.Flags().Lookup(flag), flag)
+	}
+
+	var found bool
+	for _, sub := range NewSystemCommand(common.Args{}, "1").Commands() {
+		found = found || sub.Name() == "hooks"
+	}
+	assert.True(t, found)
+}
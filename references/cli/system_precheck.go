@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/oam-dev/kubevela/apis/types"
+	"github.com/oam-dev/kubevela/pkg/features"
+	"github.com/oam-dev/kubevela/pkg/hooks"
+	"github.com/oam-dev/kubevela/pkg/precheck"
+	"github.com/oam-dev/kubevela/pkg/utils/common"
+)
+
+const (
+	// FlagFeatureGates specifies the feature gates vela-core is going to run with
+	FlagFeatureGates = "feature-gates"
+	// FlagHooks selects the pre-start hooks to run
+	FlagHooks = "hooks"
+	// FlagListHooks lists the pre-start hooks instead of running them
+	FlagListHooks = "list"
+	// FlagLeaderOnlyHooks includes the pre-start hooks vela-core runs on its leader only
+	FlagLeaderOnlyHooks = "leader-only"
+	// FlagVerbose prints the checks and the remediations of the failed hooks
+	FlagVerbose = "verbose"
+)
+
+type systemPrecheckOptions struct {
+	precheck   precheck.Options
+	gates      string
+	hooks      []string
+	list       bool
+	leaderOnly bool
+	verbose    bool
+	output     string
+}
+
+// NewSystemPrecheckCommand runs the pre-start validations of vela-core against the cluster
+func NewSystemPrecheckCommand(c common.Args) *cobra.Command {
+	opts := systemPrecheckOptions{precheck: precheck.Options{Precheck: precheck.NewConfig()}}
+	opts.precheck.Precheck.Runner.Deadline = 5 * time.Minute
+	cmd := &cobra.Command{
+		Use:   "precheck",
+		Short: "Run the pre-start validations of vela-core against the cluster.",
+		Long: "Run the validations vela-core performs before starting, i.e. the feature gate dependencies and the " +
+			"pre-start hooks, against the cluster of the current kubeconfig without restarting vela-core. The hooks " +
+			"are built and configured exactly as vela-core does, with the same precheck and prestart-hook flags. Run " +
+			"it with the feature gates and the flags of the vela-core to upgrade to before upgrading, or with the " +
+			"flags of the running vela-core deployment to reproduce its validation.\n\n" +
+			"Like in vela-core, several hooks write to the cluster: the CRD validation creates and deletes test " +
+			"objects and records its fingerprint and version lease, it may migrate stored versions and submits " +
+			"SelfSubjectAccessReviews, and the orphan detection deletes orphaned objects if its garbage collection " +
+			"is enabled. The --namespace is the runtime namespace of vela-core holding these objects.\n\n" +
+			"The hooks vela-core runs on its leader only, i.e. the CRD round-trips with " +
+			"--precheck-round-trips-on-leader, are skipped unless --leader-only is set or they are selected with " +
+			"--hooks. Increase the log verbosity with -V to follow the checks of the hooks.",
+		Example: "# Check the cluster for the default feature gates:\n" +
+			"> vela system precheck\n" +
+			"# Check the cluster for the feature gates of the upgrade and print a JSON report:\n" +
+			"> vela system precheck --feature-gates=ZstdApplicationRevision=true -o json\n" +
+			"# List the pre-start hooks:\n" +
+			"> vela system precheck --list\n" +
+			"# Run the CRD validation with the flags of the vela-core deployment and print the failed checks:\n" +
+			"> vela system precheck --hooks CRDValidation --precheck-crd-schema-drift --verbose\n",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != "" && opts.output != "json" {
+				return errors.Errorf("output format must be json if specified")
+			}
+			if err := setCoreFeatureGates(opts.gates); err != nil {
+				return err
+			}
+			explicit, err := features.ParseGates(opts.gates)
+			if err != nil {
+				return errors.Wrapf(err, "invalid feature gates")
+			}
+			cfg, err := c.GetConfig()
+			if err != nil {
+				return err
+			}
+			cli, err := c.GetClient()
+			if err != nil {
+				return errors.Wrapf(err, "failed to get k8s client")
+			}
+			preStartHooks, err := precheck.PreStartHooks(cli, cfg, opts.precheck)
+			if err != nil {
+				return err
+			}
+			preStartHooks = append([]hooks.PreStartHook{featureGateHook{explicit: explicit}}, preStartHooks...)
+			if opts.list {
+				table := newUITable().AddRow("HOOK", "LEADER ONLY")
+				for _, hook := range preStartHooks {
+					_, leaderOnly := hook.(hooks.LeaderOnlyHook)
+					table.AddRow(hook.Name(), leaderOnly)
+				}
+				cmd.Println(table.String())
+				return nil
+			}
+			selected, err := selectHooks(preStartHooks, opts.hooks, opts.leaderOnly)
+			if err != nil {
+				return err
+			}
+			runner, err := precheck.NewRunner(selected, opts.precheck.Precheck)
+			if err != nil {
+				return err
+			}
+			_ = runner.Run(context.Background())
+			summary := hooks.Summarize(runner.Results(), time.Now())
+			if opts.output == "json" {
+				data, err := json.MarshalIndent(summary, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(data))
+			} else {
+				cmd.Println(PrecheckSummaryPrinter(summary).String())
+				if opts.verbose {
+					cmd.Print(hookDetails(summary))
+				}
+			}
+			if !summary.Passed {
+				return errors.New("vela-core prechecks failed")
+			}
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeSystem,
+		},
+	}
+	flags := cmd.Flags()
+	opts.precheck.Precheck.AddFlags(flags)
+	flags.StringVar(&opts.precheck.Namespace, FlagNamespace, types.DefaultKubeVelaNS,
+		"The runtime namespace of vela-core, holding the ConfigMaps and the test objects of the hooks.")
+	flags.IntVar(&opts.precheck.AppRevisionLimit, "application-revision-limit", precheck.DefaultAppRevisionLimit,
+		"The application-revision-limit of vela-core, used to detect orphaned ApplicationRevisions.")
+	flags.BoolVar(&opts.precheck.UseWebhook, "use-webhook", false, "Validate the admission webhooks of vela-core.")
+	flags.BoolVar(&opts.precheck.EnableClusterGateway, "enable-cluster-gateway", false, "Validate the cluster-gateway of vela-core.")
+	flags.StringVar(&opts.gates, FlagFeatureGates, "", "The feature gates vela-core will run with, e.g. ZstdApplicationRevision=true. Default to the vela-core defaults.")
+	flags.StringSliceVar(&opts.hooks, FlagHooks, nil, "The names of the hooks to run. Default to all hooks.")
+	flags.BoolVar(&opts.list, FlagListHooks, false, "List the hooks instead of running them.")
+	flags.BoolVar(&opts.leaderOnly, FlagLeaderOnlyHooks, false, "Also run the hooks vela-core runs on its leader only.")
+	flags.BoolVar(&opts.verbose, FlagVerbose, false, "Print the failed checks of the hooks and how to remediate them.")
+	flags.StringVarP(&opts.output, FlagOutputFormat, "o", "", "Specifies the output format. One of: (json)")
+	return cmd
+}
+
+// setCoreFeatureGates sets the feature gates vela-core is going to run with
+func setCoreFeatureGates(gates string) error {
+	// the vela CLI enables all alpha features for itself, reset them to
+	// the defaults of vela-core before applying the requested gates
+	if err := utilfeature.DefaultMutableFeatureGate.Set("AllAlpha=false"); err != nil {
+		return err
+	}
+	if gates != "" {
+		if err := utilfeature.DefaultMutableFeatureGate.Set(gates); err != nil {
+			return errors.Wrapf(err, "invalid feature gates")
+		}
+	}
+	return nil
+}
+
+// PrecheckSummaryPrinter prints the outcome of every precheck
+func PrecheckSummaryPrinter(summary hooks.Summary) *uitable.Table {
+	table := newUITable().AddRow("CHECK", "STATUS", "SEVERITY", "DURATION", "MESSAGE")
+	for _, h := range summary.Hooks {
+		table.AddRow(h.Name, h.Status, h.Severity, h.Duration, h.Message)
+	}
+	return table
+}
+
+// featureGateHook validates the dependencies of the enabled feature gates and
+// the lifecycle of the explicitly set ones.
+type featureGateHook struct {
+	explicit map[featuregate.Feature]bool
+}
+
+// Name returns the name of the check
+func (featureGateHook) Name() string {
+	return "FeatureGates"
+}
+
+// Run returns the violations of the feature gate dependencies. Violations that
+// vela-core only warns about are not reported.
+func (h featureGateHook) Run(_ context.Context) error {
+	validator := features.NewDependencyValidator()
+	validator.Explicit = h.explicit
+	violations := validator.Validate(utilfeature.DefaultMutableFeatureGate.Enabled)
+	if err := features.Err(violations); err != nil {
+		return fmt.Errorf("invalid feature gates: %w", err)
+	}
+	return nil
+}
+
+// selectHooks returns the hooks with the given names, or all hooks if no name is given. The LeaderOnlyHooks
+// are only returned if leaderOnly is set, unless they are selected by name.
+func selectHooks(all []hooks.PreStartHook, names []string, leaderOnly bool) ([]hooks.PreStartHook, error) {
+	var selected []hooks.PreStartHook
+	var available []string
+	for _, hook := range all {
+		available = append(available, hook.Name())
+		if len(names) > 0 {
+			if slices.Contains(names, hook.Name()) {
+				selected = append(selected, hook)
+			}
+			continue
+		}
+		if _, ok := hook.(hooks.LeaderOnlyHook); ok && !leaderOnly {
+			continue
+		}
+		selected = append(selected, hook)
+	}
+	for _, name := range names {
+		if !slices.Contains(available, name) {
+			return nil, errors.Errorf("unknown hook %s, available hooks are %s", name, strings.Join(available, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// hookDetails returns the message, the checks and the remediations of the hooks that did not pass
+func hookDetails(summary hooks.Summary) string {
+	var b strings.Builder
+	for _, h := range summary.Hooks {
+		if h.Status == hooks.StatusPassed || h.Status == hooks.StatusSkipped {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s (%s):\n  %s\n", h.Name, h.Status, h.Message)
+		for _, check := range h.Checks {
+			fmt.Fprintf(&b, "  - %s\n", check.Message)
+			if check.Remediation != "" {
+				fmt.Fprintf(&b, "    remediation: %s\n", check.Remediation)
+			}
+		}
+		if h.Remediation != "" {
+			fmt.Fprintf(&b, "  remediation: %s\n", h.Remediation)
+		}
+	}
+	return b.String()
+}
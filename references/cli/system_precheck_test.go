@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/pkg/hooks"
+)
+
+func TestPrecheckSummaryPrinter(t *testing.T) {
+	summary := hooks.Summary{Hooks: []hooks.HookStatus{
+		{Name: "FeatureGates", Status: hooks.StatusPassed, Severity: hooks.SeverityBlock, Duration: "0s"},
+		{Name: "CRDValidation", Status: hooks.StatusFailed, Severity: hooks.SeverityBlock, Duration: "1s", Message: "outdated CRDs"},
+	}}
+	lines := strings.Split(PrecheckSummaryPrinter(summary).String(), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "CHECK")
+	assert.Contains(t, lines[1], "FeatureGates")
+	assert.Contains(t, lines[2], "outdated CRDs")
+}
+
+func TestFeatureGateHook(t *testing.T) {
+	hook := featureGateHook{}
+	assert.Equal(t, "FeatureGates", hook.Name())
+	runner := &hooks.Runner{Hooks: []hooks.PreStartHook{hook}, Deadline: time.Minute}
+	// the feature gate dependencies must hold for the defaults of vela-core
+	require.NoError(t, runner.Run(context.Background()))
+	require.Len(t, runner.Results(), 1)
+	assert.Equal(t, hooks.SeverityBlock, runner.Results()[0].Severity)
+}
+
+func TestPrintSystemStatus(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	var out bytes.Buffer
+	err := printSystemStatus(ctx, cli, &out, "vela-system", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no pre-start check results found")
+
+	require.NoError(t, hooks.PublishResults(ctx, cli, "vela-system", hooks.DefaultResultsConfigMap, []hooks.Result{
+		{Name: "CRDValidation", Severity: hooks.SeverityBlock, Err: errors.New("outdated CRDs")},
+	}))
+	require.NoError(t, printSystemStatus(ctx, cli, &out, "vela-system", ""))
+	assert.Contains(t, out.String(), "Failed")
+	assert.Contains(t, out.String(), "outdated CRDs")
+
+	out.Reset()
+	require.NoError(t, printSystemStatus(ctx, cli, &out, "vela-system", "json"))
+	assert.Contains(t, out.String(), `"passed": false`)
+}
+
+type namedHook string
+
+func (h namedHook) Name() string { return string(h) }
+
+func (h namedHook) Run(_ context.Context) error { return nil }
+
+type leaderHook struct{ namedHook }
+
+func (leaderHook) LeaderOnly() {}
+
+func hookNames(hs []hooks.PreStartHook) []string {
+	var names []string
+	for _, h := range hs {
+		names = append(names, h.Name())
+	}
+	return names
+}
+
+func TestSelectHooks(t *testing.T) {
+	all := []hooks.PreStartHook{namedHook("CRDValidation"), leaderHook{namedHook("CRDRoundTrip")}, namedHook("RBACValidation")}
+
+	selected, err := selectHooks(all, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CRDValidation", "RBACValidation"}, hookNames(selected))
+
+	selected, err = selectHooks(all, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CRDValidation", "CRDRoundTrip", "RBACValidation"}, hookNames(selected))
+
+	// hooks selected by name run even if they are leader only
+	selected, err = selectHooks(all, []string{"RBACValidation", "CRDRoundTrip"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CRDRoundTrip", "RBACValidation"}, hookNames(selected))
+
+	_, err = selectHooks(all, []string{"Unknown"}, false)
+	require.ErrorContains(t, err, "unknown hook Unknown, available hooks are CRDValidation, CRDRoundTrip, RBACValidation")
+}
+
+func TestHookDetails(t *testing.T) {
+	summary := hooks.Summary{Hooks: []hooks.HookStatus{
+		{Name: "FeatureGates", Status: hooks.StatusPassed},
+		{Name: "CRDValidation", Status: hooks.StatusFailed, Message: "outdated CRDs", Remediation: "upgrade the CRDs",
+			Checks: []hooks.CheckStatus{{Message: "applications.core.oam.dev lacks spec.workflow", Remediation: "apply the CRD"}}},
+	}}
+	details := hookDetails(summary)
+	assert.NotContains(t, details, "FeatureGates")
+	assert.Contains(t, details, "CRDValidation (Failed):\n  outdated CRDs\n")
+	assert.Contains(t, details, "  - applications.core.oam.dev lacks spec.workflow\n    remediation: apply the CRD\n")
+	assert.Contains(t, details, "  remediation: upgrade the CRDs\n")
+}
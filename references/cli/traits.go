@@ -237,7 +237,7 @@ func PrintInstalledTraitDef(c common2.Args, io cmdutil.IOStreams, filter filterF
 		if filter != nil && !filter(capa) {
 			continue
 		}
-		table.AddRow(capa.Name, capa.AppliesTo, capa.Description)
+		table.AddRow(capa.Name, capa.AppliesTo, withDeprecation(capa.Description, td.Status.ConditionedStatus))
 	}
 	io.Info(table.String())
 	return nil